@@ -0,0 +1,82 @@
+package blogstore
+
+import "context"
+
+// Actions recorded in the audit log and passed to the authorizer.
+const ACTION_LIST = "list"
+const ACTION_CREATE = "create"
+const ACTION_UPDATE = "update"
+const ACTION_TRASH = "trash"
+const ACTION_UNTRASH = "untrash"
+const ACTION_SOFT_DELETE = "soft_delete"
+const ACTION_DELETE = "delete"
+const ACTION_UNARCHIVE = "unarchive"
+const ACTION_RESTORE = "restore"
+const ACTION_PREVIEW = "preview"
+const ACTION_SUBMIT_FOR_REVIEW = "submit_for_review"
+const ACTION_APPROVE = "approve"
+const ACTION_REJECT = "reject"
+const ACTION_READ = "read"
+const ACTION_LOCK = "lock"
+const ACTION_VIEW = "view"
+const ACTION_MANAGE_TAXONOMY = "manage_taxonomy"
+const ACTION_MANAGE_MEDIA = "manage_media"
+
+// actorContextKey is the context key holding the acting user.
+type actorContextKey struct{}
+
+// changeNoteContextKey is the context key holding the note describing a change.
+type changeNoteContextKey struct{}
+
+// actionContextKey is the context key overriding the action of the next
+// mutation, e.g. PostSoftDelete saving the post through PostUpdate.
+type actionContextKey struct{}
+
+// ContextWithActor returns a copy of the context carrying the ID of the user
+// performing the operations, recorded in the audit log and available to the
+// authorizer through ActorFromContext.
+func ContextWithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actorID)
+}
+
+// ActorFromContext returns the actor ID set by ContextWithActor, or an empty
+// string when there is none.
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actorID, _ := ctx.Value(actorContextKey{}).(string)
+	return actorID
+}
+
+// ContextWithChangeNote returns a copy of the context carrying a note on why
+// the next changes are made, e.g. "Fix typo in the title", stored with the
+// versions they record.
+func ContextWithChangeNote(ctx context.Context, note string) context.Context {
+	return context.WithValue(ctx, changeNoteContextKey{}, note)
+}
+
+// ChangeNoteFromContext returns the note set by ContextWithChangeNote, or an
+// empty string when there is none.
+func ChangeNoteFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	note, _ := ctx.Value(changeNoteContextKey{}).(string)
+	return note
+}
+
+// contextWithAction returns a context making the next mutation report the
+// given action instead of its own.
+func contextWithAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, actionContextKey{}, action)
+}
+
+// actionFromContext returns the action set by contextWithAction, or the
+// fallback when there is none.
+func actionFromContext(ctx context.Context, fallback string) string {
+	if action, ok := ctx.Value(actionContextKey{}).(string); ok {
+		return action
+	}
+	return fallback
+}
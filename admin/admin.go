@@ -0,0 +1,538 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dracory/blogstore"
+	"github.com/dracory/blogstore/pagination"
+	"github.com/dracory/blogstore/theme"
+)
+
+// postsPerPage is the number of posts shown per page of the post list and trash.
+const postsPerPage = 20
+
+// Admin is a minimal server-rendered admin UI for a blog store: a post list
+// with filters, an edit form, the version history with revert and the trash.
+//
+// The admin performs no authentication and no CSRF protection. Mount it
+// behind the application's own authentication and CSRF middleware.
+//
+// All links are relative to the mount path and select the page with the
+// "action" query parameter, so the handler can be mounted at any route.
+type Admin struct {
+	store            blogstore.StoreInterface
+	theme            theme.Theme
+	templates        *template.Template
+	actorFromRequest func(r *http.Request) string
+}
+
+// NewAdmin creates the admin UI for the store, rendered with DefaultTheme.
+func NewAdmin(store blogstore.StoreInterface) *Admin {
+	a := &Admin{store: store}
+	if err := a.SetTheme(DefaultTheme()); err != nil {
+		// the default theme is embedded, so it only fails to parse if it is broken
+		panic(err)
+	}
+	return a
+}
+
+// SetTheme renders the admin with another theme. The theme must provide
+// the templates of DefaultTheme; start from theme.Overlay to change a few.
+func (a *Admin) SetTheme(t theme.Theme) error {
+	templates, err := theme.Parse(t)
+	if err != nil {
+		return err
+	}
+
+	a.theme = t
+	a.templates = templates
+	return nil
+}
+
+// SetActorFromRequest sets how the acting user is identified, e.g. from an
+// authenticated session. The actor is passed to the store with
+// blogstore.ContextWithActor, for its audit log and authorizer.
+func (a *Admin) SetActorFromRequest(actorFromRequest func(r *http.Request) string) *Admin {
+	a.actorFromRequest = actorFromRequest
+	return a
+}
+
+// ServeHTTP dispatches to the page selected by the "action" query parameter.
+// Pages are shown on GET, changes are made on POST and redirect back.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.store == nil {
+		http.Error(w, "store is not initialized", http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	if a.actorFromRequest != nil {
+		ctx = blogstore.ContextWithActor(ctx, a.actorFromRequest(r))
+	}
+	r = r.WithContext(ctx)
+
+	action := r.URL.Query().Get("action")
+
+	if r.Method == http.MethodPost {
+		switch action {
+		case actionPostEdit:
+			a.postSave(w, r)
+		case actionPostTrash:
+			a.postTrash(w, r)
+		case actionPostRestore:
+			a.postRestore(w, r)
+		case actionPostDelete:
+			a.postDelete(w, r)
+		case actionPostRevert:
+			a.postRevert(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch action {
+	case "", actionPosts:
+		a.pagePosts(w, r)
+	case actionPostEdit:
+		a.pagePostEdit(w, r)
+	case actionPostVersions:
+		a.pagePostVersions(w, r)
+	case actionTrash:
+		a.pageTrash(w, r)
+	case actionAsset:
+		a.asset(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+const (
+	actionPosts        = "posts"
+	actionPostEdit     = "post-edit"
+	actionPostVersions = "post-versions"
+	actionTrash        = "trash"
+	actionPostTrash    = "post-trash"
+	actionPostRestore  = "post-restore"
+	actionPostDelete   = "post-delete"
+	actionPostRevert   = "post-revert"
+	actionAsset        = "asset"
+)
+
+// editors are the editors offered by the edit form, by content type.
+var editors = []editorOption{
+	{ContentType: blogstore.POST_CONTENT_TYPE_MARKDOWN, Editor: blogstore.POST_EDITOR_MARKDOWN, Label: "Markdown"},
+	{ContentType: blogstore.POST_CONTENT_TYPE_HTML, Editor: blogstore.POST_EDITOR_HTMLAREA, Label: "HTML"},
+	{ContentType: blogstore.POST_CONTENT_TYPE_PLAIN_TEXT, Editor: blogstore.POST_EDITOR_TEXTAREA, Label: "Plain text"},
+}
+
+type editorOption struct {
+	ContentType string
+	Editor      string
+	Label       string
+}
+
+// statuses returns the statuses offered by the filters and the edit form:
+// the statuses registered with the store, except the trash which has its
+// own page.
+func (a *Admin) statuses() []string {
+	return slices.DeleteFunc(a.store.PostStatuses(), func(status string) bool {
+		return status == blogstore.POST_STATUS_TRASH
+	})
+}
+
+// pagePosts lists the posts that are not in the trash, filtered by status and search.
+func (a *Admin) pagePosts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := query.Get("status")
+	search := query.Get("search")
+	page := pageNumber(query.Get("page"))
+
+	options := blogstore.PostQueryOptions{
+		Search:         search,
+		OrderBy:        blogstore.COLUMN_UPDATED_AT,
+		SortOrder:      blogstore.SORT_ORDER_DESC,
+		WithoutContent: true,
+	}
+	if status != "" {
+		options.Status = status
+	} else {
+		options.StatusIn = a.statuses()
+	}
+
+	posts, total, err := a.postPage(r.Context(), options, page)
+	if err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	a.render(w, http.StatusOK, "posts.html", map[string]any{
+		"Title":    "Posts",
+		"Posts":    posts,
+		"Status":   status,
+		"Search":   search,
+		"Statuses": a.statuses(),
+		"Pager":    newPager(page, total, query),
+	})
+}
+
+// pageTrash lists the posts in the trash.
+func (a *Admin) pageTrash(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page := pageNumber(query.Get("page"))
+
+	posts, total, err := a.postPage(r.Context(), blogstore.PostQueryOptions{
+		Status:         blogstore.POST_STATUS_TRASH,
+		OrderBy:        blogstore.COLUMN_UPDATED_AT,
+		SortOrder:      blogstore.SORT_ORDER_DESC,
+		WithoutContent: true,
+	}, page)
+	if err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	a.render(w, http.StatusOK, "trash.html", map[string]any{
+		"Title": "Trash",
+		"Posts": posts,
+		"Pager": newPager(page, total, query),
+	})
+}
+
+// pagePostEdit shows the edit form of a post, or an empty form for a new post.
+func (a *Admin) pagePostEdit(w http.ResponseWriter, r *http.Request) {
+	post := blogstore.NewPost().SetContentType(blogstore.POST_CONTENT_TYPE_MARKDOWN)
+
+	if id := r.URL.Query().Get("id"); id != "" {
+		found, err := a.store.PostFindByID(r.Context(), id)
+		if err != nil {
+			a.renderError(w, err)
+			return
+		}
+		if found == nil {
+			http.NotFound(w, r)
+			return
+		}
+		post = found
+	}
+
+	a.render(w, http.StatusOK, "post_edit.html", map[string]any{
+		"Title":    "Edit post",
+		"Post":     post,
+		"IsNew":    r.URL.Query().Get("id") == "",
+		"Statuses": a.statuses(),
+		"Editors":  editors,
+		"Saved":    r.URL.Query().Get("saved") != "",
+	})
+}
+
+// pagePostVersions shows the version history of a post.
+func (a *Admin) pagePostVersions(w http.ResponseWriter, r *http.Request) {
+	post, ok := a.findPost(w, r, r.URL.Query().Get("id"))
+	if !ok {
+		return
+	}
+
+	rows := []versionRow{}
+	if a.store.VersioningEnabled() {
+		versions, err := a.store.VersioningList(r.Context(), blogstore.NewVersioningQuery().
+			SetEntityType(blogstore.VERSIONING_TYPE_POST).
+			SetEntityID(post.GetID()).
+			SetOrderBy(blogstore.COLUMN_CREATED_AT).
+			SetSortOrder(blogstore.SORT_ORDER_DESC))
+		if err != nil {
+			a.renderError(w, err)
+			return
+		}
+
+		for _, version := range versions {
+			snapshot := blogstore.NewPost()
+			if err := snapshot.UnmarshalFromVersioning(version.Content()); err != nil {
+				a.renderError(w, err)
+				return
+			}
+			rows = append(rows, versionRow{
+				ID:         version.ID(),
+				CreatedAt:  version.GetCreatedAt(),
+				ChangedBy:  version.ChangedBy(),
+				ChangeNote: version.ChangeNote(),
+				Title:      snapshot.GetTitle(),
+				Status:     snapshot.GetStatus(),
+			})
+		}
+	}
+
+	a.render(w, http.StatusOK, "post_versions.html", map[string]any{
+		"Title":             "Versions",
+		"Post":              post,
+		"Versions":          rows,
+		"VersioningEnabled": a.store.VersioningEnabled(),
+	})
+}
+
+// versionRow is a version as shown in the version history.
+type versionRow struct {
+	ID         string
+	CreatedAt  string
+	ChangedBy  string
+	ChangeNote string
+	Title      string
+	Status     string
+}
+
+// postSave creates or updates a post from the edit form.
+func (a *Admin) postSave(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := r.PostForm.Get("id")
+	post := blogstore.NewPost()
+	if id != "" {
+		found, ok := a.findPost(w, r, id)
+		if !ok {
+			return
+		}
+		post = found
+	}
+
+	contentType := r.PostForm.Get("content_type")
+	editor := ""
+	for _, option := range editors {
+		if option.ContentType == contentType {
+			editor = option.Editor
+		}
+	}
+	if editor == "" {
+		http.Error(w, "unknown editor", http.StatusBadRequest)
+		return
+	}
+
+	featured := blogstore.NO
+	if r.PostForm.Get("featured") == blogstore.YES {
+		featured = blogstore.YES
+	}
+
+	post.SetTitle(r.PostForm.Get("title")).
+		SetSlug(r.PostForm.Get("slug")).
+		SetSummary(r.PostForm.Get("summary")).
+		SetContent(r.PostForm.Get("content")).
+		SetStatus(r.PostForm.Get("status")).
+		SetFeatured(featured).
+		SetContentType(contentType).
+		SetEditor(editor)
+
+	var err error
+	if id == "" {
+		err = a.store.PostCreate(r.Context(), post)
+	} else {
+		err = a.store.PostUpdate(r.Context(), post)
+	}
+	if err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	redirect(w, r, url.Values{"action": {actionPostEdit}, "id": {post.GetID()}, "saved": {"1"}})
+}
+
+// postTrash moves a post to the trash.
+func (a *Admin) postTrash(w http.ResponseWriter, r *http.Request) {
+	post, ok := a.findPost(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+
+	if err := a.store.PostTrash(r.Context(), post); err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	redirect(w, r, url.Values{"action": {actionPosts}})
+}
+
+// postRestore moves a post out of the trash, back to its previous status.
+func (a *Admin) postRestore(w http.ResponseWriter, r *http.Request) {
+	post, ok := a.findPost(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+
+	if err := a.store.PostUntrash(r.Context(), post); err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	redirect(w, r, url.Values{"action": {actionTrash}})
+}
+
+// postDelete permanently deletes a post in the trash.
+func (a *Admin) postDelete(w http.ResponseWriter, r *http.Request) {
+	post, ok := a.findPost(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+
+	if post.GetStatus() != blogstore.POST_STATUS_TRASH {
+		http.Error(w, "only posts in the trash can be deleted", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.store.PostDeleteByID(r.Context(), post.GetID()); err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	redirect(w, r, url.Values{"action": {actionTrash}})
+}
+
+// postRevert restores a post to the content of one of its versions.
+func (a *Admin) postRevert(w http.ResponseWriter, r *http.Request) {
+	post, ok := a.findPost(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+
+	version, err := a.store.VersioningFindByID(r.Context(), r.FormValue("version_id"))
+	if err != nil {
+		a.renderError(w, err)
+		return
+	}
+	if version == nil || version.EntityType() != blogstore.VERSIONING_TYPE_POST || version.EntityID() != post.GetID() {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := a.store.PostRevertToVersion(r.Context(), post.GetID(), version.ID()); err != nil {
+		a.renderError(w, err)
+		return
+	}
+
+	redirect(w, r, url.Values{"action": {actionPostVersions}, "id": {post.GetID()}})
+}
+
+// asset serves a static file of the theme.
+func (a *Admin) asset(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if !fs.ValidPath(name) || name == "." {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeFileFS(w, r, a.theme.Assets(), name)
+}
+
+// findPost loads a post, responding with not found if it does not exist.
+func (a *Admin) findPost(w http.ResponseWriter, r *http.Request, id string) (blogstore.PostInterface, bool) {
+	if strings.TrimSpace(id) == "" {
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return nil, false
+	}
+
+	post, err := a.store.PostFindByID(r.Context(), id)
+	if err != nil {
+		a.renderError(w, err)
+		return nil, false
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	return post, true
+}
+
+// postPage returns a page of posts and the total number of matching posts.
+func (a *Admin) postPage(ctx context.Context, options blogstore.PostQueryOptions, page int) ([]blogstore.PostInterface, int64, error) {
+	total, err := a.store.PostCount(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	options.Limit = postsPerPage
+	options.Offset = (page - 1) * postsPerPage
+
+	posts, err := a.store.PostList(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// render executes a page template, responding with an error if it fails.
+func (a *Admin) render(w http.ResponseWriter, status int, name string, data map[string]any) {
+	var out strings.Builder
+	if err := a.templates.ExecuteTemplate(&out, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(out.String()))
+}
+
+// renderError shows an error page, with status forbidden for refused actions.
+func (a *Admin) renderError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var orderErr *blogstore.OrderError
+	switch {
+	case errors.Is(err, blogstore.ErrNotAuthorized):
+		status = http.StatusForbidden
+	case errors.Is(err, blogstore.ErrSlugTaken), errors.As(err, &orderErr):
+		status = http.StatusBadRequest
+	}
+
+	a.render(w, status, "error.html", map[string]any{"Title": "Error", "Error": err.Error()})
+}
+
+// redirect sends the browser to another page of the admin after a change.
+func redirect(w http.ResponseWriter, r *http.Request, query url.Values) {
+	http.Redirect(w, r, "?"+query.Encode(), http.StatusSeeOther)
+}
+
+// pageNumber parses a 1-based page number, defaulting to the first page.
+func pageNumber(value string) int {
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+// pager holds the links to the neighbouring pages of a list.
+type pager struct {
+	Page     int
+	Pages    int
+	PrevLink string
+	NextLink string
+}
+
+// newPager computes the pages of a list, keeping the other query parameters
+// (action, filters) in the links.
+func newPager(page int, total int64, query url.Values) pager {
+	p := pagination.New(total, postsPerPage, (page-1)*postsPerPage)
+	links := p.Links(&url.URL{RawQuery: query.Encode()}, "page", 0)
+
+	return pager{
+		Page:     p.Page,
+		Pages:    p.Pages,
+		PrevLink: links.Prev,
+		NextLink: links.Next,
+	}
+}
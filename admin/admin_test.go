@@ -0,0 +1,256 @@
+package admin_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dracory/blogstore"
+	"github.com/dracory/blogstore/admin"
+	"github.com/dracory/blogstore/blogstoretest"
+	"github.com/dracory/blogstore/theme"
+)
+
+func initAdminServer(t *testing.T) (*httptest.Server, blogstore.StoreInterface) {
+	t.Helper()
+
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{VersioningEnabled: true})
+	server := httptest.NewServer(admin.NewAdmin(store))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+// noRedirectClient returns redirects to the test instead of following them.
+var noRedirectClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+func get(t *testing.T, target string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(target)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", target, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	return resp.StatusCode, string(body)
+}
+
+func post(t *testing.T, target string, form url.Values) *http.Response {
+	t.Helper()
+
+	resp, err := noRedirectClient.PostForm(target, form)
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", target, err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func TestAdminPostList(t *testing.T) {
+	server, store := initAdminServer(t)
+
+	posts := blogstoretest.SeedPosts(t, store, 3, blogstoretest.SeedOptions{Status: blogstore.POST_STATUS_PUBLISHED})
+
+	status, body := get(t, server.URL)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	for _, p := range posts {
+		if !strings.Contains(body, p.GetID()) {
+			t.Fatalf("expected the list to link post %s: %s", p.GetID(), body)
+		}
+	}
+
+	_, body = get(t, server.URL+"?action=posts&status=draft")
+	if strings.Contains(body, posts[0].GetID()) {
+		t.Fatal("expected the status filter to hide published posts")
+	}
+}
+
+func TestAdminPostListRegisteredStatuses(t *testing.T) {
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{PostStatuses: []string{"scheduled"}})
+	server := httptest.NewServer(admin.NewAdmin(store))
+	t.Cleanup(server.Close)
+
+	pending := blogstoretest.SeedPosts(t, store, 1, blogstoretest.SeedOptions{Status: blogstore.POST_STATUS_PENDING_REVIEW})[0]
+	scheduled := blogstoretest.SeedPosts(t, store, 1, blogstoretest.SeedOptions{Status: "scheduled"})[0]
+
+	_, body := get(t, server.URL)
+	for _, p := range []blogstore.PostInterface{pending, scheduled} {
+		if !strings.Contains(body, p.GetID()) {
+			t.Fatalf("expected the list to show the %s post: %s", p.GetStatus(), body)
+		}
+	}
+	for _, option := range []string{`<option value="pending_review"`, `<option value="scheduled"`} {
+		if !strings.Contains(body, option) {
+			t.Fatalf("expected the status filter to offer %s", option)
+		}
+	}
+	if strings.Contains(body, `<option value="trash"`) {
+		t.Fatal("expected the status filter not to offer the trash")
+	}
+
+	_, body = get(t, server.URL+"?action=post-edit&id="+scheduled.GetID())
+	if !strings.Contains(body, `<option value="scheduled" selected`) {
+		t.Fatalf("expected the edit form to select the custom status: %s", body)
+	}
+}
+
+func TestAdminCreateAndEditPost(t *testing.T) {
+	server, store := initAdminServer(t)
+
+	resp := post(t, server.URL+"?action=post-edit", url.Values{
+		"title":        {"Hello admin"},
+		"status":       {blogstore.POST_STATUS_DRAFT},
+		"content_type": {blogstore.POST_CONTENT_TYPE_HTML},
+		"content":      {"<p>Hi</p>"},
+	})
+	if resp.StatusCode != http.StatusSeeOther {
+		t.Fatalf("status = %d, want 303", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	id := location.Query().Get("id")
+
+	created, err := store.PostFindByID(context.Background(), id)
+	if err != nil || created == nil {
+		t.Fatalf("expected the post to be created, got %v, %v", created, err)
+	}
+	if created.GetEditor() != blogstore.POST_EDITOR_HTMLAREA {
+		t.Fatalf("editor = %q, want %q", created.GetEditor(), blogstore.POST_EDITOR_HTMLAREA)
+	}
+
+	status, body := get(t, server.URL+"?action=post-edit&id="+id)
+	if status != http.StatusOK || !strings.Contains(body, "Hello admin") {
+		t.Fatalf("expected the edit form to show the post, got %d: %s", status, body)
+	}
+
+	post(t, server.URL+"?action=post-edit", url.Values{
+		"id":           {id},
+		"title":        {"Hello again"},
+		"status":       {blogstore.POST_STATUS_PUBLISHED},
+		"content_type": {blogstore.POST_CONTENT_TYPE_MARKDOWN},
+	})
+
+	updated, _ := store.PostFindByID(context.Background(), id)
+	if updated.GetTitle() != "Hello again" || updated.GetStatus() != blogstore.POST_STATUS_PUBLISHED {
+		t.Fatalf("expected the post to be updated, got %q (%s)", updated.GetTitle(), updated.GetStatus())
+	}
+}
+
+func TestAdminVersionRevert(t *testing.T) {
+	server, store := initAdminServer(t)
+	ctx := context.Background()
+
+	p := blogstore.NewPost().SetTitle("Original")
+	if err := store.PostCreate(ctx, p); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	p.SetTitle("Changed")
+	if err := store.PostUpdate(ctx, p); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, blogstore.NewVersioningQuery().
+		SetEntityType(blogstore.VERSIONING_TYPE_POST).
+		SetEntityID(p.GetID()))
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d, %v", len(versions), err)
+	}
+
+	originalID := ""
+	for _, version := range versions {
+		if strings.Contains(version.Content(), `"Original"`) {
+			originalID = version.ID()
+		}
+	}
+
+	_, body := get(t, server.URL+"?action=post-versions&id="+p.GetID())
+	if !strings.Contains(body, "Original") || !strings.Contains(body, "Changed") {
+		t.Fatalf("expected the history to list both versions: %s", body)
+	}
+
+	post(t, server.URL+"?action=post-revert", url.Values{"id": {p.GetID()}, "version_id": {originalID}})
+
+	reverted, _ := store.PostFindByID(ctx, p.GetID())
+	if reverted.GetTitle() != "Original" {
+		t.Fatalf("title = %q, want the reverted %q", reverted.GetTitle(), "Original")
+	}
+}
+
+func TestAdminTrash(t *testing.T) {
+	server, store := initAdminServer(t)
+	ctx := context.Background()
+
+	p := blogstore.NewPost().SetTitle("Disposable")
+	if err := store.PostCreate(ctx, p); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post(t, server.URL+"?action=post-trash", url.Values{"id": {p.GetID()}})
+
+	_, body := get(t, server.URL+"?action=trash")
+	if !strings.Contains(body, "Disposable") {
+		t.Fatalf("expected the trash to list the post: %s", body)
+	}
+
+	post(t, server.URL+"?action=post-restore", url.Values{"id": {p.GetID()}})
+	restored, _ := store.PostFindByID(ctx, p.GetID())
+	if restored.GetStatus() != blogstore.POST_STATUS_DRAFT {
+		t.Fatalf("status = %q, want draft after restore", restored.GetStatus())
+	}
+
+	if resp := post(t, server.URL+"?action=post-delete", url.Values{"id": {p.GetID()}}); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400 for deleting a post outside the trash", resp.StatusCode)
+	}
+
+	post(t, server.URL+"?action=post-trash", url.Values{"id": {p.GetID()}})
+	post(t, server.URL+"?action=post-delete", url.Values{"id": {p.GetID()}})
+
+	deleted, _ := store.PostFindByID(ctx, p.GetID())
+	if deleted != nil {
+		t.Fatal("expected the post to be deleted permanently")
+	}
+}
+
+func TestAdminTheme(t *testing.T) {
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{})
+
+	h := admin.NewAdmin(store)
+	err := h.SetTheme(theme.Overlay(admin.DefaultTheme(), fstest.MapFS{
+		"posts.html": {Data: []byte(`{{template "header" .}}<p>{{len .Posts}} custom posts</p>{{template "footer" .}}`)},
+	}, fstest.MapFS{
+		"admin.css": {Data: []byte("body { background: pink; }")},
+	}, nil))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	if _, body := get(t, server.URL+"?action=asset&name=admin.css"); !strings.Contains(body, "pink") {
+		t.Fatalf("expected the overriding stylesheet, got: %s", body)
+	}
+
+	if _, body := get(t, server.URL+"?action=posts"); !strings.Contains(body, "0 custom posts") {
+		t.Fatalf("expected the overriding template, got: %s", body)
+	}
+
+	if _, body := get(t, server.URL+"?action=trash"); !strings.Contains(body, "The trash is empty.") {
+		t.Fatalf("expected the default templates to remain, got: %s", body)
+	}
+}
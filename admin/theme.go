@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+
+	"github.com/dracory/blogstore"
+	"github.com/dracory/blogstore/theme"
+)
+
+//go:embed templates/*.html assets/*
+var themeFS embed.FS
+
+// DefaultTheme returns the theme the admin is rendered with unless another
+// one is set with SetTheme. Use it as the base of theme.Overlay to replace
+// single templates or assets.
+//
+// Templates: layout.html (the "header", "footer" and "pager" blocks),
+// posts.html, post_edit.html, post_versions.html, trash.html and error.html.
+// Assets: admin.css.
+func DefaultTheme() theme.Theme {
+	templates, _ := fs.Sub(themeFS, "templates")
+	assets, _ := fs.Sub(themeFS, "assets")
+
+	return theme.New(templates, assets, template.FuncMap{
+		"postTitle": postTitle,
+	})
+}
+
+// postTitle returns the title of a post, or a placeholder for untitled posts.
+func postTitle(post blogstore.PostInterface) string {
+	if post.GetTitle() == "" {
+		return "(untitled)"
+	}
+	return post.GetTitle()
+}
@@ -0,0 +1,12 @@
+package blogstore
+
+// ArchiveMonth is the number of published posts of a month, as returned by
+// StoreInterface.PostArchiveMonths for blog archive sidebars.
+type ArchiveMonth struct {
+	// Year is the publication year, e.g. 2024.
+	Year int
+	// Month is the publication month, from 1 to 12.
+	Month int
+	// Count is the number of posts published in the month.
+	Count int64
+}
@@ -0,0 +1,41 @@
+package blogstore
+
+import "time"
+
+const AUDIT_ENTITY_POST = "post"
+const AUDIT_ENTITY_MEDIA = "media"
+const AUDIT_ENTITY_TAXONOMY = "taxonomy"
+const AUDIT_ENTITY_TERM = "term"
+
+// AuditEntry records a single mutation of a post, media, taxonomy or term.
+// The hashes are SHA-256 digests of the entity data before and after the
+// mutation, empty when the entity did not exist on that side.
+type AuditEntry struct {
+	ID         string
+	EntityType string
+	EntityID   string
+	Action     string
+	Actor      string
+	BeforeHash string
+	AfterHash  string
+	CreatedAt  time.Time
+}
+
+// AuditQueryOptions defines the filters for retrieving audit entries,
+// which are returned newest first.
+type AuditQueryOptions struct {
+	// EntityType filters by entity type (AUDIT_ENTITY_*).
+	EntityType string
+	// EntityID filters by entity ID.
+	EntityID string
+	// Action filters by action (ACTION_*).
+	Action string
+	// Actor filters by the actor ID set with ContextWithActor.
+	Actor string
+	// CreatedAtGreaterThan filters entries recorded after this time.
+	CreatedAtGreaterThan time.Time
+	// Offset is the number of entries to skip for pagination.
+	Offset int
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
@@ -0,0 +1,58 @@
+package blogstore
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Author is the public profile of a post author, matched to the posts by
+// their author ID.
+type Author struct {
+	ID          string
+	DisplayName string
+	Bio         string
+	AvatarURL   string
+	// SocialLinks maps network names to profile URLs,
+	// e.g. {"github": "https://github.com/jane"}.
+	SocialLinks map[string]string
+	// Status is AUTHOR_STATUS_ACTIVE or AUTHOR_STATUS_INACTIVE.
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AuthorQueryOptions defines query options for listing authors
+type AuthorQueryOptions struct {
+	// IDIn filters by multiple author IDs.
+	IDIn []string
+	// Status filters by author status.
+	Status string
+	// Search performs a case-insensitive search on the display name.
+	Search string
+	// Offset is the number of records to skip for pagination.
+	Offset int
+	// Limit is the maximum number of records to return.
+	Limit int
+}
+
+// MarshalToVersioning serializes the author profile for versioning storage.
+// Excludes the timestamp fields.
+func (author *Author) MarshalToVersioning() (string, error) {
+	socialLinks, err := json.Marshal(author.SocialLinks)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(map[string]string{
+		COLUMN_ID:           author.ID,
+		COLUMN_DISPLAY_NAME: author.DisplayName,
+		COLUMN_BIO:          author.Bio,
+		COLUMN_AVATAR_URL:   author.AvatarURL,
+		COLUMN_SOCIAL_LINKS: string(socialLinks),
+		COLUMN_STATUS:       author.Status,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
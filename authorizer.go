@@ -0,0 +1,36 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotAuthorized is a convenience error for authorizers to return when
+// the actor may not perform the action.
+var ErrNotAuthorized = errors.New("blogstore: not authorized")
+
+// AuthorizerInterface decides whether the actor in the context may perform an
+// action on a post, enabling role based editorial permissions. Set it with
+// NewStoreOptions.Authorizer.
+type AuthorizerInterface interface {
+	// Can returns nil if the action is allowed, or the error to return to the
+	// caller otherwise. The action is one of the ACTION_* constants. The post
+	// is the new post for ACTION_CREATE and the stored post for the other
+	// mutations, for ACTION_READ, which guards finding a single post, for
+	// ACTION_LOCK and for ACTION_VIEW, which guards counting its views.
+	// Changes to the terms and media of a post are authorized as
+	// ACTION_UPDATE of the post. The post is nil for ACTION_LIST, which
+	// guards listing, counting and the statistics, and for
+	// ACTION_MANAGE_TAXONOMY and ACTION_MANAGE_MEDIA, which guard changes to
+	// the taxonomies, terms and media. Use ActorFromContext to find out who
+	// is acting.
+	Can(ctx context.Context, action string, post PostInterface) error
+}
+
+// AuthorizerFunc adapts a function to AuthorizerInterface.
+type AuthorizerFunc func(ctx context.Context, action string, post PostInterface) error
+
+// Can calls f(ctx, action, post).
+func (f AuthorizerFunc) Can(ctx context.Context, action string, post PostInterface) error {
+	return f(ctx, action, post)
+}
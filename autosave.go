@@ -0,0 +1,12 @@
+package blogstore
+
+import "time"
+
+// Autosave holds the in-progress content of a post, saved by PostAutosave
+// apart from the post itself until it is restored or discarded.
+type Autosave struct {
+	PostID    string
+	Content   string
+	Actor     string
+	UpdatedAt time.Time
+}
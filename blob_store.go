@@ -0,0 +1,88 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BlobStoreInterface is a pluggable backend storing large post content outside
+// of the post table (see NewStoreOptions.BlobStore). Implementations for object
+// storage such as S3 only need to provide these three methods.
+type BlobStoreInterface interface {
+	// Put stores the data under the key, replacing any existing data.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under the key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the data stored under the key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFilesystemBlobStore creates a blob store keeping each blob as a file in the given directory.
+// The directory is created on the first write if it does not exist.
+func NewFilesystemBlobStore(dir string) BlobStoreInterface {
+	return &filesystemBlobStore{dir: dir}
+}
+
+// filesystemBlobStore implements BlobStoreInterface on the local filesystem.
+type filesystemBlobStore struct {
+	dir string
+}
+
+var _ BlobStoreInterface = (*filesystemBlobStore)(nil)
+
+// Put stores the data under the key, replacing any existing data.
+func (b *filesystemBlobStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so readers never see partial content
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Get returns the data stored under the key.
+func (b *filesystemBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+// Delete removes the data stored under the key.
+func (b *filesystemBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// path returns the file path of the key, rejecting keys that could escape the directory.
+func (b *filesystemBlobStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", errors.New("blogstore: invalid blob key: " + key)
+	}
+
+	return filepath.Join(b.dir, key), nil
+}
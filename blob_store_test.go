@@ -0,0 +1,39 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFilesystemBlobStore(t *testing.T) {
+	blobs := NewFilesystemBlobStore(t.TempDir() + "/blobs")
+	ctx := context.Background()
+
+	if err := blobs.Put(ctx, "post1", []byte("hello")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	data, err := blobs.Get(ctx, "post1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if string(data) != "hello" {
+		t.Fatal("expected hello, found:", string(data))
+	}
+
+	if err := blobs.Delete(ctx, "post1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := blobs.Get(ctx, "post1"); err == nil {
+		t.Fatal("expected error for deleted blob")
+	}
+	if err := blobs.Delete(ctx, "post1"); err != nil {
+		t.Fatal("expected deleting a missing blob to succeed, got:", err)
+	}
+
+	for _, key := range []string{"", "..", "../escape", `a\b`} {
+		if err := blobs.Put(ctx, key, []byte("x")); err == nil {
+			t.Fatalf("expected error for invalid key %q", key)
+		}
+	}
+}
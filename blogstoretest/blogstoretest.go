@@ -0,0 +1,173 @@
+// Package blogstoretest provides helpers for integration tests of code built
+// on blogstore: an in-memory SQLite database, a ready to use store, seeding
+// and factories producing posts and versions with realistic fake data.
+package blogstoretest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dracory/blogstore"
+	_ "modernc.org/sqlite"
+)
+
+// databaseCounter makes the name of each in-memory database unique.
+var databaseCounter atomic.Int64
+
+// NewSQLiteDB opens a new, empty in-memory SQLite database, closed when the
+// test ends. The connections of the pool share the same database.
+func NewSQLiteDB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	name := "blogstoretest_" + strconv.FormatInt(databaseCounter.Add(1), 10)
+	db, err := sql.Open("sqlite", "file:"+name+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("blogstoretest: failed to open sqlite db: %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// NewStore creates a migrated store on a new in-memory SQLite database.
+// The options are used as given, except that the DB defaults to a new
+// in-memory database, the post table name to "blog_posts", and automigration
+// is always enabled.
+func NewStore(t testing.TB, options blogstore.NewStoreOptions) blogstore.StoreInterface {
+	t.Helper()
+
+	if options.DB == nil {
+		options.DB = NewSQLiteDB(t)
+	}
+	if options.PostTableName == "" {
+		options.PostTableName = "blog_posts"
+	}
+	if options.VersioningEnabled && options.VersioningTableName == "" {
+		options.VersioningTableName = "blog_versions"
+	}
+	options.AutomigrateEnabled = true
+
+	store, err := blogstore.NewStore(options)
+	if err != nil {
+		t.Fatalf("blogstoretest: failed to create store: %v", err)
+	}
+
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+// SeedOptions configures Seed and SeedPosts.
+type SeedOptions struct {
+	// Status of the seeded posts. Defaults to a random mix of statuses,
+	// mostly published.
+	Status string
+	// AuthorIDs the seeded posts are assigned to in turn. Defaults to fake author IDs.
+	AuthorIDs []string
+	// Updates is the number of times each post is updated after creation,
+	// producing versions when versioning is enabled.
+	Updates int
+	// Tags assigns fake tags to the seeded posts, creating the tag taxonomy
+	// and its terms when missing. Requires taxonomy to be enabled.
+	Tags bool
+	// Seed makes the fake data reproducible. Zero uses a random seed.
+	Seed uint64
+}
+
+// SeedPosts creates n posts with fake data in the store and returns them,
+// failing the test on the first error.
+func SeedPosts(t testing.TB, store blogstore.StoreInterface, n int, options SeedOptions) []blogstore.PostInterface {
+	t.Helper()
+
+	posts, err := Seed(context.Background(), store, n, options)
+	if err != nil {
+		t.Fatalf("blogstoretest: %v", err)
+	}
+
+	return posts
+}
+
+// Seed creates n posts with fake data in the store and returns them,
+// stopping at the first error. It is the non-test counterpart of SeedPosts,
+// for benchmarks, tools and the blogstore seed command.
+func Seed(ctx context.Context, store blogstore.StoreInterface, n int, options SeedOptions) ([]blogstore.PostInterface, error) {
+	faker := NewFaker(options.Seed)
+	tags := map[string]string{}
+
+	posts := make([]blogstore.PostInterface, 0, n)
+	for i := range n {
+		post := faker.Post()
+		if options.Status != "" {
+			post.SetStatus(options.Status)
+		}
+		if len(options.AuthorIDs) > 0 {
+			post.SetAuthorID(options.AuthorIDs[i%len(options.AuthorIDs)])
+		}
+
+		if err := store.PostCreate(ctx, post); err != nil {
+			return posts, fmt.Errorf("failed to seed post %d: %w", i, err)
+		}
+
+		for u := range options.Updates {
+			post.SetTitle(faker.Title())
+			post.SetContent(faker.Content())
+			if err := store.PostUpdate(ctx, post); err != nil {
+				return posts, fmt.Errorf("failed to update seeded post %d (update %d): %w", i, u, err)
+			}
+		}
+
+		if options.Tags {
+			if err := seedTags(ctx, store, post, faker.Tags(), tags); err != nil {
+				return posts, fmt.Errorf("failed to tag seeded post %d: %w", i, err)
+			}
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
+
+// seedTags sets the named tags on the post, creating the tag taxonomy and
+// the missing terms. Known term IDs are kept in termIDs by name.
+func seedTags(ctx context.Context, store blogstore.StoreInterface, post blogstore.PostInterface, names []string, termIDs map[string]string) error {
+	taxonomy, err := store.TaxonomyFindBySlug(ctx, blogstore.TAXONOMY_TAG)
+	if err != nil {
+		return err
+	}
+	if taxonomy == nil {
+		taxonomy = blogstore.NewTaxonomy().SetName("Tags").SetSlug(blogstore.TAXONOMY_TAG)
+		if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		if id, ok := termIDs[name]; ok {
+			ids = append(ids, id)
+			continue
+		}
+
+		term, err := store.TermFindBySlug(ctx, blogstore.TAXONOMY_TAG, name)
+		if err != nil {
+			return err
+		}
+		if term == nil {
+			term = blogstore.NewTerm().SetTaxonomyID(taxonomy.GetID()).SetName(name).SetSlug(name)
+			if err := store.TermCreate(ctx, term); err != nil {
+				return err
+			}
+		}
+
+		termIDs[name] = term.GetID()
+		ids = append(ids, term.GetID())
+	}
+
+	return store.PostSetTerms(ctx, post.GetID(), blogstore.TAXONOMY_TAG, ids)
+}
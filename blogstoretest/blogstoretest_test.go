@@ -0,0 +1,146 @@
+package blogstoretest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dracory/blogstore"
+	"github.com/dracory/blogstore/blogstoretest"
+)
+
+func TestSeedPosts(t *testing.T) {
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{VersioningEnabled: true})
+
+	posts := blogstoretest.SeedPosts(t, store, 5, blogstoretest.SeedOptions{
+		Status:    blogstore.POST_STATUS_PUBLISHED,
+		AuthorIDs: []string{"alice", "bob"},
+		Updates:   2,
+	})
+	if len(posts) != 5 {
+		t.Fatalf("SeedPosts() returned %d posts, want 5", len(posts))
+	}
+
+	ctx := context.Background()
+
+	count, err := store.PostCount(ctx, blogstore.PostQueryOptions{Status: blogstore.POST_STATUS_PUBLISHED})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 5 {
+		t.Fatalf("PostCount() = %d, want 5", count)
+	}
+
+	if posts[1].GetAuthorID() != "bob" {
+		t.Fatalf("AuthorID = %q, want authors assigned in turn", posts[1].GetAuthorID())
+	}
+
+	versions, err := store.VersioningList(ctx, blogstore.NewVersioningQuery().
+		SetEntityType(blogstore.VERSIONING_TYPE_POST).
+		SetEntityID(posts[0].GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("got %d versions, want 3 (create and 2 updates)", len(versions))
+	}
+}
+
+func TestNewStoreDatabasesAreIsolated(t *testing.T) {
+	first := blogstoretest.NewStore(t, blogstore.NewStoreOptions{})
+	second := blogstoretest.NewStore(t, blogstore.NewStoreOptions{})
+
+	blogstoretest.SeedPosts(t, first, 3, blogstoretest.SeedOptions{})
+
+	count, err := second.PostCount(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatalf("PostCount() = %d, want 0 in a separate database", count)
+	}
+}
+
+func TestFakerIsReproducible(t *testing.T) {
+	first := blogstoretest.NewFaker(42).Post()
+	second := blogstoretest.NewFaker(42).Post()
+
+	if first.GetTitle() != second.GetTitle() || first.GetContent() != second.GetContent() {
+		t.Fatal("expected the same seed to produce the same post")
+	}
+	if first.GetTitle() == "" || first.GetContent() == "" || first.GetSummary() == "" {
+		t.Fatal("expected the fake post to be filled")
+	}
+}
+
+func TestFakerVersion(t *testing.T) {
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{VersioningEnabled: true})
+	faker := blogstoretest.NewFaker(1)
+
+	post := faker.Post()
+	if err := store.PostCreate(context.Background(), post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	version := faker.Version(post)
+	if err := store.VersioningCreate(context.Background(), version); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	restored := blogstore.NewPost()
+	if err := restored.UnmarshalFromVersioning(version.Content()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if restored.GetID() != post.GetID() {
+		t.Fatalf("version holds post %q, want %q", restored.GetID(), post.GetID())
+	}
+}
+
+func TestSeedPostsWithTags(t *testing.T) {
+	store := blogstoretest.NewStore(t, blogstore.NewStoreOptions{TaxonomyEnabled: true})
+
+	posts := blogstoretest.SeedPosts(t, store, 20, blogstoretest.SeedOptions{Tags: true, Seed: 7})
+
+	ctx := context.Background()
+	tagged := 0
+	for _, post := range posts {
+		terms, err := store.TermListByPostID(ctx, post.GetID(), blogstore.TAXONOMY_TAG)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if len(terms) > 0 {
+			tagged++
+		}
+	}
+	if tagged == 0 {
+		t.Fatal("expected some seeded posts to be tagged")
+	}
+}
+
+func TestFakerVariety(t *testing.T) {
+	faker := blogstoretest.NewFaker(3)
+
+	statuses := map[string]bool{}
+	shortest, longest := -1, 0
+	years := map[string]bool{}
+	for range 300 {
+		post := faker.Post()
+		statuses[post.GetStatus()] = true
+		years[post.GetPublishedAt()[:4]] = true
+
+		length := len(post.GetContent())
+		if shortest < 0 || length < shortest {
+			shortest = length
+		}
+		longest = max(longest, length)
+	}
+
+	if len(statuses) < 4 {
+		t.Fatalf("got statuses %v, want a mix", statuses)
+	}
+	if len(years) < 3 {
+		t.Fatalf("got publication years %v, want dates spread over years", years)
+	}
+	if longest < 5*shortest {
+		t.Fatalf("content lengths range from %d to %d, want varied lengths", shortest, longest)
+	}
+}
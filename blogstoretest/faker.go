@@ -0,0 +1,243 @@
+package blogstoretest
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dracory/blogstore"
+)
+
+var fakeAdjectives = []string{
+	"practical", "modern", "simple", "hidden", "complete", "quick", "honest",
+	"essential", "surprising", "gentle", "reliable", "curious", "forgotten",
+}
+
+var fakeNouns = []string{
+	"guide", "garden", "kitchen", "database", "journey", "habit", "recipe",
+	"workflow", "city", "library", "season", "toolkit", "notebook", "bicycle",
+}
+
+var fakeTopics = []string{
+	"remote work", "sourdough baking", "small business", "home networking",
+	"trail running", "urban cycling", "personal finance", "photography",
+	"open source", "language learning", "composting", "public speaking",
+}
+
+var fakeTags = []string{
+	"go", "sql", "testing", "travel", "food", "health", "design", "books",
+	"music", "productivity", "tutorial", "opinion", "news", "interview",
+}
+
+var fakeCode = []string{
+	"package main\n\nfunc main() {\n\tprintln(\"hello\")\n}",
+	"SELECT id, title FROM posts WHERE status = 'published' ORDER BY published_at DESC;",
+	"git commit -am \"Update notes\"\ngit push",
+}
+
+var fakeWords = []string{
+	"the", "a", "we", "it", "is", "was", "with", "for", "and", "but", "when",
+	"every", "often", "never", "about", "after", "before", "during", "simple",
+	"people", "time", "work", "idea", "change", "week", "morning", "result",
+	"started", "noticed", "learned", "tried", "found", "built", "wrote",
+	"small", "better", "first", "last", "important", "easy", "slow", "careful",
+}
+
+// Faker produces realistic looking fake data for posts and versions.
+// A Faker is not safe for concurrent use.
+type Faker struct {
+	random *rand.Rand
+}
+
+// NewFaker creates a Faker. The same non-zero seed produces the same data;
+// zero uses a random seed.
+func NewFaker(seed uint64) *Faker {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Faker{random: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Title returns a fake post title, e.g. "A practical guide to composting".
+func (f *Faker) Title() string {
+	templates := []func() string{
+		func() string {
+			return "A " + f.pick(fakeAdjectives) + " " + f.pick(fakeNouns) + " to " + f.pick(fakeTopics)
+		},
+		func() string {
+			return strconv.Itoa(3+f.random.IntN(8)) + " " + f.pick(fakeAdjectives) + " lessons from " + f.pick(fakeTopics)
+		},
+		func() string {
+			return "What I learned about " + f.pick(fakeTopics)
+		},
+	}
+	return templates[f.random.IntN(len(templates))]()
+}
+
+// Sentence returns a fake sentence of 6 to 15 words.
+func (f *Faker) Sentence() string {
+	words := make([]string, 6+f.random.IntN(10))
+	for i := range words {
+		words[i] = f.pick(fakeWords)
+	}
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// Paragraph returns a fake paragraph of 3 to 6 sentences.
+func (f *Faker) Paragraph() string {
+	sentences := make([]string, 3+f.random.IntN(4))
+	for i := range sentences {
+		sentences[i] = f.Sentence()
+	}
+	return strings.Join(sentences, " ")
+}
+
+// Summary returns a fake one or two sentence summary.
+func (f *Faker) Summary() string {
+	if f.random.IntN(2) == 0 {
+		return f.Sentence()
+	}
+	return f.Sentence() + " " + f.Sentence()
+}
+
+// Content returns fake Markdown content of varied length, from a short note
+// to a long article, mixing paragraphs with headings, lists, quotes, code
+// blocks, links and emphasis.
+func (f *Faker) Content() string {
+	blocks := 0
+	switch f.random.IntN(10) {
+	case 0, 1, 2:
+		blocks = 1 + f.random.IntN(3) // short note
+	case 9:
+		blocks = 15 + f.random.IntN(16) // long article
+	default:
+		blocks = 4 + f.random.IntN(7)
+	}
+
+	parts := []string{"## " + f.Title()}
+	for range blocks {
+		parts = append(parts, f.markdownBlock())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// markdownBlock returns a Markdown block, most often a paragraph.
+func (f *Faker) markdownBlock() string {
+	switch f.random.IntN(12) {
+	case 0:
+		return "### " + strings.TrimSuffix(f.Sentence(), ".")
+	case 1:
+		items := make([]string, 2+f.random.IntN(4))
+		for i := range items {
+			items[i] = "- " + f.Sentence()
+		}
+		return strings.Join(items, "\n")
+	case 2:
+		items := make([]string, 2+f.random.IntN(4))
+		for i := range items {
+			items[i] = strconv.Itoa(i+1) + ". " + f.Sentence()
+		}
+		return strings.Join(items, "\n")
+	case 3:
+		return "> " + f.Sentence()
+	case 4:
+		return "```\n" + f.pick(fakeCode) + "\n```"
+	case 5:
+		return f.Sentence() + " See [" + f.pick(fakeTopics) + "](https://example.com/" + f.pick(fakeNouns) + ") for **" + f.pick(fakeAdjectives) + "** details."
+	default:
+		return f.Paragraph()
+	}
+}
+
+// AuthorID returns one of a small set of fake author IDs, so several posts
+// share an author.
+func (f *Faker) AuthorID() string {
+	return "author-" + strconv.Itoa(1+f.random.IntN(5))
+}
+
+// PublishedAt returns a fake publication time within the last five years,
+// in the UTC datetime format the store expects.
+func (f *Faker) PublishedAt() string {
+	ago := time.Duration(f.random.IntN(5*365*24)) * time.Hour
+	return time.Now().UTC().Add(-ago).Format("2006-01-02 15:04:05")
+}
+
+// Status returns a fake post status, mostly published, with drafts and a
+// few unpublished, pending review and trashed posts.
+func (f *Faker) Status() string {
+	switch n := f.random.IntN(20); {
+	case n < 12:
+		return blogstore.POST_STATUS_PUBLISHED
+	case n < 16:
+		return blogstore.POST_STATUS_DRAFT
+	case n < 17:
+		return blogstore.POST_STATUS_UNPUBLISHED
+	case n < 18:
+		return blogstore.POST_STATUS_PENDING_REVIEW
+	default:
+		return blogstore.POST_STATUS_TRASH
+	}
+}
+
+// Tags returns zero to four distinct fake tag names.
+func (f *Faker) Tags() []string {
+	tags := []string{}
+	for range f.random.IntN(5) {
+		tag := f.pick(fakeTags)
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Post returns a new, unsaved post filled with fake data, in any of the
+// statuses returned by Status.
+func (f *Faker) Post() blogstore.PostInterface {
+	status := f.Status()
+
+	featured := blogstore.NO
+	if f.random.IntN(5) == 0 {
+		featured = blogstore.YES
+	}
+
+	post := blogstore.NewPost().
+		SetTitle(f.Title()).
+		SetSummary(f.Summary()).
+		SetContent(f.Content()).
+		SetContentType(blogstore.POST_CONTENT_TYPE_MARKDOWN).
+		SetAuthorID(f.AuthorID()).
+		SetStatus(status).
+		SetFeatured(featured).
+		SetMetaDescription(f.Sentence()).
+		SetPublishedAt(f.PublishedAt())
+
+	return post
+}
+
+// Version returns a new, unsaved version of the post, holding the post with
+// a fake title and content.
+func (f *Faker) Version(post blogstore.PostInterface) blogstore.VersioningInterface {
+	changed := blogstore.NewPostFromExistingData(post.GetData()).
+		SetTitle(f.Title()).
+		SetContent(f.Content())
+
+	content, err := changed.MarshalToVersioning()
+	if err != nil {
+		// the post data is a map of strings, which always marshals
+		panic(err)
+	}
+
+	return blogstore.NewVersioning().
+		SetEntityType(blogstore.VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()).
+		SetContent(content)
+}
+
+// pick returns a random element of the list.
+func (f *Faker) pick(list []string) string {
+	return list[f.random.IntN(len(list))]
+}
@@ -0,0 +1,95 @@
+// Command blogstore runs maintenance tasks against a blog store database.
+//
+// Usage:
+//
+//	blogstore seed --count 10000 --db blog.db [--table blog_posts] [--tags] [--status published] [--seed 42]
+//
+// The seed command fills a SQLite database with realistic fake posts for
+// load and UI testing.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dracory/blogstore"
+	"github.com/dracory/blogstore/blogstoretest"
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "blogstore:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the command line to its command.
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: blogstore seed [flags]")
+	}
+
+	switch args[0] {
+	case "seed":
+		return runSeed(args[1:], stdout)
+	default:
+		return errors.New("unknown command: " + args[0])
+	}
+}
+
+// runSeed creates fake posts in a SQLite database, migrating it first.
+func runSeed(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	count := flags.Int("count", 100, "number of posts to create")
+	dsn := flags.String("db", "blog.db", "SQLite database file")
+	table := flags.String("table", "blog_posts", "post table name")
+	tags := flags.Bool("tags", false, "assign fake tags to the posts (enables taxonomy)")
+	status := flags.String("status", "", "status of the posts (default: a random mix)")
+	seed := flags.Uint64("seed", 0, "seed for reproducible data (default: random)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *count <= 0 {
+		return errors.New("count must be positive")
+	}
+
+	db, err := sql.Open("sqlite", *dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      *table,
+		TaxonomyEnabled:    *tags,
+		DB:                 db,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	start := time.Now()
+	posts, err := blogstoretest.Seed(context.Background(), store, *count, blogstoretest.SeedOptions{
+		Status: *status,
+		Tags:   *tags,
+		Seed:   *seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "seeded %d posts into %s in %s\n", len(posts), *dsn, time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunSeed(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "blog.db")
+
+	var out bytes.Buffer
+	if err := run([]string{"seed", "--count", "5", "--db", dsn, "--tags", "--seed", "1"}, &out); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !strings.HasPrefix(out.String(), "seeded 5 posts into ") {
+		t.Fatalf("output = %q", out.String())
+	}
+
+	if err := run([]string{"unknown"}, &out); err == nil {
+		t.Fatal("expected an error for an unknown command")
+	}
+}
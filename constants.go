@@ -7,6 +7,7 @@ const POST_STATUS_DRAFT = "draft"
 const POST_STATUS_PUBLISHED = "published"
 const POST_STATUS_UNPUBLISHED = "unpublished"
 const POST_STATUS_TRASH = "trash"
+const POST_STATUS_PENDING_REVIEW = "pending_review"
 
 const POST_EDITOR_BLOCKAREA = "BlockArea"
 const POST_EDITOR_BLOCKEDITOR = "BlockEditor"
@@ -19,7 +20,16 @@ const POST_CONTENT_TYPE_HTML = "html"
 const POST_CONTENT_TYPE_PLAIN_TEXT = "plain_text"
 const POST_CONTENT_TYPE_BLOCKS = "blocks"
 
+// Entity types of the versions, sharing the versioning table
 const VERSIONING_TYPE_POST = "post"
+const VERSIONING_TYPE_TERM = "term"
+const VERSIONING_TYPE_AUTHOR = "author"
+const VERSIONING_TYPE_MENU = "menu"
+const VERSIONING_TYPE_MENU_ITEM = "menu_item"
+const VERSIONING_TYPE_MEDIA = "media"
+
+const SORT_ORDER_ASC = "asc"
+const SORT_ORDER_DESC = "desc"
 
 const NULL_DATETIME = "0000-00-00 00:00:00"
 const MAX_DATETIME = "9999-12-31 23:59:59"
@@ -30,9 +40,13 @@ const COLUMN_CONTENT = "content"
 const COLUMN_CREATED_AT = "created_at"
 const COLUMN_ENTITY_ID = "entity_id"
 const COLUMN_ENTITY_TYPE = "entity_type"
+const COLUMN_CHANGED_BY = "changed_by"
+const COLUMN_CHANGE_NOTE = "change_note"
 const COLUMN_SOFT_DELETED_AT = "soft_deleted_at"
 const COLUMN_ID = "id"
+const COLUMN_IDEMPOTENCY_KEY = "idempotency_key"
 const COLUMN_IMAGE_URL = "image_url"
+const COLUMN_LOCALE = "locale"
 const COLUMN_FEATURED = "featured"
 const COLUMN_MEMO = "memo"
 const COLUMN_META_KEYWORDS = "meta_keywords"
@@ -43,6 +57,7 @@ const COLUMN_PUBLISHED_AT = "published_at"
 const COLUMN_STATUS = "status"
 const COLUMN_SUMMARY = "summary"
 const COLUMN_TITLE = "title"
+const COLUMN_TRANSLATION_GROUP_ID = "translation_group_id"
 const COLUMN_UPDATED_AT = "updated_at"
 const COLUMN_COUNT = "count"
 const COLUMN_DESCRIPTION = "description"
@@ -58,12 +73,71 @@ const COLUMN_SEQUENCE = "sequence"
 const TAXONOMY_CATEGORY = "category"
 const TAXONOMY_TAG = "tag"
 
+// Post relations that can be eager loaded via PostQueryOptions.With
+const POST_WITH_CATEGORIES = "categories"
+const POST_WITH_TAGS = "tags"
+const POST_WITH_MEDIA = "media"
+const POST_WITH_AUTHOR = "author"
+
+// Audit columns
+const COLUMN_ACTION = "action"
+const COLUMN_ACTOR = "actor"
+const COLUMN_BEFORE_HASH = "before_hash"
+const COLUMN_AFTER_HASH = "after_hash"
+
+// Status history columns
+const COLUMN_FROM_STATUS = "from_status"
+const COLUMN_TO_STATUS = "to_status"
+
+// Schema migration columns
+const COLUMN_VERSION = "version"
+const COLUMN_APPLIED_AT = "applied_at"
+
+// Preview token, lock and post expiry columns
+const COLUMN_EXPIRES_AT = "expires_at"
+
+// COLUMN_TRASHED_AT holds when a post was moved to the trash, the start of
+// its trash retention. It is managed by the store and not part of the post.
+const COLUMN_TRASHED_AT = "trashed_at"
+const COLUMN_OWNER = "owner"
+
+// Views columns, COLUMN_VIEWS also holds the total views of a post
+const COLUMN_DAY = "day"
+const COLUMN_VIEWS = "views"
+
+// Reaction columns, the count is kept in COLUMN_COUNT
+const COLUMN_REACTION = "reaction"
+
+// Variant columns
+const COLUMN_WEIGHT = "weight"
+const COLUMN_IMPRESSIONS = "impressions"
+const COLUMN_CLICKS = "clicks"
+
+// Author columns
+const COLUMN_DISPLAY_NAME = "display_name"
+const COLUMN_BIO = "bio"
+const COLUMN_AVATAR_URL = "avatar_url"
+const COLUMN_SOCIAL_LINKS = "social_links"
+
+// Author status constants
+const AUTHOR_STATUS_ACTIVE = "active"
+const AUTHOR_STATUS_INACTIVE = "inactive"
+
+// Menu columns, menu items are ordered by COLUMN_SEQUENCE
+const COLUMN_MENU_ID = "menu_id"
+const COLUMN_LABEL = "label"
+const COLUMN_URL = "url"
+
 // Media columns
 const COLUMN_MEDIA_URL = "media_url"
 const COLUMN_MEDIA_TYPE = "media_type"
 const COLUMN_FILE_SIZE = "file_size"
 const COLUMN_FILE_EXTENSION = "file_extension"
 
+// MEDIA_META_ALT_TEXT holds the alternative text of a media, for the alt
+// attribute of images.
+const MEDIA_META_ALT_TEXT = "alt_text"
+
 // Media status constants
 const MEDIA_STATUS_DRAFT = "draft"
 const MEDIA_STATUS_ACTIVE = "active"
@@ -71,3 +145,24 @@ const MEDIA_STATUS_INACTIVE = "inactive"
 
 // Meta key names
 const META_KEY_OLD_SLUGS = "_old_slugs"
+
+// META_KEY_TRASHED_FROM_STATUS holds the status of a post before it was
+// trashed, restored by PostUntrash.
+const META_KEY_TRASHED_FROM_STATUS = "_trashed_from_status"
+
+// META_KEY_DELETED_AT holds the time a post was permanently deleted, in the
+// final version recorded before the deletion.
+const META_KEY_DELETED_AT = "_deleted_at"
+
+// Meta keys of the review workflow
+const META_KEY_REVIEW_STATUS = "_review_status"
+const META_KEY_REVIEW_SUBMITTED_BY = "_review_submitted_by"
+const META_KEY_REVIEW_SUBMITTED_AT = "_review_submitted_at"
+const META_KEY_REVIEWED_BY = "_reviewed_by"
+const META_KEY_REVIEWED_AT = "_reviewed_at"
+const META_KEY_REVIEW_REASON = "_review_reason"
+
+// Review decisions, stored in the META_KEY_REVIEW_STATUS meta
+const REVIEW_STATUS_PENDING = "pending"
+const REVIEW_STATUS_APPROVED = "approved"
+const REVIEW_STATUS_REJECTED = "rejected"
@@ -0,0 +1,289 @@
+package blogstore
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// ContentRendererInterface converts post content of one content type to
+// HTML, e.g. an adapter for a Markdown library.
+type ContentRendererInterface interface {
+	// Render returns the content as HTML. The HTML is output as is, so
+	// renderers of untrusted content must return safe HTML.
+	Render(content string) (string, error)
+}
+
+// ContentRendererFunc adapts a function to ContentRendererInterface.
+type ContentRendererFunc func(content string) (string, error)
+
+// Render calls f(content).
+func (f ContentRendererFunc) Render(content string) (string, error) {
+	return f(content)
+}
+
+// ContentRenderers maps content types to the renderers converting the
+// content of posts of that type to HTML.
+//
+// Example, rendering Markdown with another library:
+//
+//	renderers := blogstore.NewContentRenderers()
+//	renderers[blogstore.POST_CONTENT_TYPE_MARKDOWN] = blogstore.ContentRendererFunc(myMarkdownToHTML)
+//	html, err := post.ContentHtmlWith(renderers)
+type ContentRenderers map[string]ContentRendererInterface
+
+// ContentRendererError is returned when no renderer is registered for the
+// content type of a post.
+type ContentRendererError struct {
+	// ContentType is the content type without renderer.
+	ContentType string
+}
+
+// Error implements the error interface.
+func (e *ContentRendererError) Error() string {
+	return "blogstore: no content renderer for content type " + e.ContentType
+}
+
+// NewContentRenderers returns the built-in renderers, all producing HTML
+// sanitized with NewHTMLSanitizer:
+//   - markdown: a CommonMark subset with headings, paragraphs, lists, quotes,
+//     fenced code, rules, emphasis, code spans, links and images; raw HTML
+//     is escaped
+//   - plain_text: escaped paragraphs, with line breaks kept
+//   - html: the content, sanitized
+//
+// Blocks content has no built-in renderer.
+func NewContentRenderers() ContentRenderers {
+	sanitizer := NewHTMLSanitizer()
+	return ContentRenderers{
+		POST_CONTENT_TYPE_MARKDOWN: ContentRendererFunc(func(content string) (string, error) {
+			return sanitizer.Sanitize(renderMarkdown(content)), nil
+		}),
+		POST_CONTENT_TYPE_PLAIN_TEXT: ContentRendererFunc(func(content string) (string, error) {
+			return renderPlainText(content), nil
+		}),
+		POST_CONTENT_TYPE_HTML: ContentRendererFunc(func(content string) (string, error) {
+			return sanitizer.Sanitize(content), nil
+		}),
+	}
+}
+
+// Render converts the content to HTML with the renderer of the content type.
+// Content without a content type is rendered as plain text. It returns a
+// ContentRendererError if no renderer is registered for the content type.
+func (r ContentRenderers) Render(contentType string, content string) (string, error) {
+	if contentType == "" {
+		contentType = POST_CONTENT_TYPE_PLAIN_TEXT
+	}
+
+	renderer, ok := r[contentType]
+	if !ok || renderer == nil {
+		return "", &ContentRendererError{ContentType: contentType}
+	}
+
+	return renderer.Render(content)
+}
+
+// renderPlainText escapes the text into paragraphs separated by blank lines,
+// with the other line breaks kept as <br>.
+func renderPlainText(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	var b strings.Builder
+	for paragraph := range strings.SplitSeq(content, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>\n")
+	}
+
+	return b.String()
+}
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	markdownRule        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	markdownUnordered   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^\s*\d{1,9}[.)]\s+(.*)$`)
+	markdownFence       = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+-]*)")
+	markdownQuoteMarker = regexp.MustCompile(`^\s*>\s?`)
+)
+
+// renderMarkdown converts the supported Markdown subset to HTML.
+func renderMarkdown(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case markdownFence.MatchString(line):
+			match := markdownFence.FindStringSubmatch(line)
+			code := []string{}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+
+			b.WriteString("<pre><code")
+			if match[2] != "" {
+				b.WriteString(` class="language-` + html.EscapeString(match[2]) + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case markdownHeading.MatchString(line):
+			match := markdownHeading.FindStringSubmatch(line)
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + renderMarkdownInline(match[2]) + "</h" + level + ">\n")
+			i++
+
+		case markdownRule.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case markdownQuoteMarker.MatchString(line):
+			quote := []string{}
+			for ; i < len(lines) && markdownQuoteMarker.MatchString(lines[i]); i++ {
+				quote = append(quote, markdownQuoteMarker.ReplaceAllString(lines[i], ""))
+			}
+			b.WriteString("<blockquote>\n" + renderMarkdown(strings.Join(quote, "\n")) + "</blockquote>\n")
+
+		case markdownUnordered.MatchString(line), markdownOrdered.MatchString(line):
+			item, tag := markdownUnordered, "ul"
+			if !markdownUnordered.MatchString(line) {
+				item, tag = markdownOrdered, "ol"
+			}
+
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && item.MatchString(lines[i]); i++ {
+				b.WriteString("<li>" + renderMarkdownInline(item.FindStringSubmatch(lines[i])[1]) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph := []string{}
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(paragraph) == 0 || !markdownBlockStart(lines[i])); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			b.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+		}
+	}
+
+	return b.String()
+}
+
+// markdownBlockStart returns true if the line starts a block other than a
+// paragraph, ending the paragraph before it.
+func markdownBlockStart(line string) bool {
+	return markdownFence.MatchString(line) ||
+		markdownHeading.MatchString(line) ||
+		markdownRule.MatchString(line) ||
+		markdownQuoteMarker.MatchString(line) ||
+		markdownUnordered.MatchString(line) ||
+		markdownOrdered.MatchString(line)
+}
+
+// renderMarkdownInline converts the code spans, emphasis, links and images
+// of the text to HTML, escaping everything else.
+func renderMarkdownInline(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_[]()#+-.!>", rune(rest[1])):
+			b.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "!["):
+			if label, url, n, ok := markdownLink(rest[1:]); ok {
+				b.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(label) + `">`)
+				i += n + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if label, url, n, ok := markdownLink(rest); ok {
+				b.WriteString(`<a href="` + html.EscapeString(url) + `">` + renderMarkdownInline(label) + "</a>")
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 {
+				b.WriteString("<strong>" + renderMarkdownInline(rest[2:2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_' && (i == 0 || !markdownIsWordByte(text[i-1])):
+			if end := strings.IndexByte(rest[1:], rest[0]); end > 0 && rest[1] != ' ' {
+				b.WriteString("<em>" + renderMarkdownInline(rest[1:1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case rest[0] == '\n':
+			b.WriteString("\n")
+			i++
+			continue
+		}
+
+		b.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+
+	return b.String()
+}
+
+// markdownLink parses a "[label](url)" link at the start of the text,
+// returning the label, the URL and the length of the link.
+func markdownLink(text string) (string, string, int, bool) {
+	closing := strings.Index(text, "](")
+	if !strings.HasPrefix(text, "[") || closing < 0 {
+		return "", "", 0, false
+	}
+
+	// the URL may contain balanced parentheses
+	end, depth := -1, 0
+	for j, c := range text[closing+2:] {
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			if depth == 0 {
+				end = j
+				break
+			}
+			depth--
+		}
+	}
+	if end < 0 {
+		return "", "", 0, false
+	}
+
+	url := strings.TrimSpace(text[closing+2 : closing+2+end])
+	// drop an optional link title
+	if space := strings.IndexByte(url, ' '); space >= 0 {
+		url = url[:space]
+	}
+
+	return text[1:closing], url, closing + 2 + end + 1, true
+}
+
+func markdownIsWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,122 @@
+package blogstore
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestContentRenderersMarkdown(t *testing.T) {
+	content := strings.Join([]string{
+		"# Title #",
+		"",
+		"Some **bold**, *em* and `<code>` with a [link](https://example.com \"Example\").",
+		"Second line with snake_case_name.",
+		"",
+		"- one",
+		"- two",
+		"",
+		"1. first",
+		"2) second",
+		"",
+		"> quoted *text*",
+		"",
+		"```go",
+		"fmt.Println(\"<hi>\")",
+		"```",
+		"",
+		"---",
+		"",
+		"![alt](/image.png) <script>alert(1)</script> [bad](javascript:alert(1))",
+	}, "\n")
+
+	want := strings.Join([]string{
+		"<h1>Title</h1>",
+		"<p>Some <strong>bold</strong>, <em>em</em> and <code>&lt;code&gt;</code> with a <a href=\"https://example.com\">link</a>.",
+		"Second line with snake_case_name.</p>",
+		"<ul>",
+		"<li>one</li>",
+		"<li>two</li>",
+		"</ul>",
+		"<ol>",
+		"<li>first</li>",
+		"<li>second</li>",
+		"</ol>",
+		"<blockquote>",
+		"<p>quoted <em>text</em></p>",
+		"</blockquote>",
+		"<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)</code></pre>",
+		"<hr>",
+		"<p><img src=\"/image.png\" alt=\"alt\"> &lt;script&gt;alert(1)&lt;/script&gt; <a>bad</a></p>",
+		"",
+	}, "\n")
+
+	got, err := NewContentRenderers().Render(POST_CONTENT_TYPE_MARKDOWN, content)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != want {
+		t.Fatalf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestContentRenderersPlainTextAndHTML(t *testing.T) {
+	renderers := NewContentRenderers()
+
+	got, err := renderers.Render(POST_CONTENT_TYPE_PLAIN_TEXT, "Tom & Jerry\nsay <hi>\n\nBye")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "<p>Tom &amp; Jerry<br>say &lt;hi&gt;</p>\n<p>Bye</p>\n" {
+		t.Fatal("unexpected plain text HTML:", got)
+	}
+
+	got, err = renderers.Render("", "<b>raw</b>")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "<p>&lt;b&gt;raw&lt;/b&gt;</p>\n" {
+		t.Fatal("Expected content without type to render as plain text, got:", got)
+	}
+
+	got, err = renderers.Render(POST_CONTENT_TYPE_HTML, `<p onclick="x()">Hi</p><script>x()</script>`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "<p>Hi</p>" {
+		t.Fatal("Expected sanitized HTML, got:", got)
+	}
+
+	_, err = renderers.Render(POST_CONTENT_TYPE_BLOCKS, "[]")
+	var rendererErr *ContentRendererError
+	if !errors.As(err, &rendererErr) || rendererErr.ContentType != POST_CONTENT_TYPE_BLOCKS {
+		t.Fatal("Expected a ContentRendererError for blocks, got:", err)
+	}
+}
+
+func TestPostContentHtml(t *testing.T) {
+	post := NewPost().
+		SetContentType(POST_CONTENT_TYPE_MARKDOWN).
+		SetContent("Hello **world**")
+
+	got, err := post.ContentHtml()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "<p>Hello <strong>world</strong></p>\n" {
+		t.Fatal("unexpected HTML:", got)
+	}
+
+	renderers := NewContentRenderers()
+	renderers[POST_CONTENT_TYPE_MARKDOWN] = ContentRendererFunc(func(content string) (string, error) {
+		return "<div>" + content + "</div>", nil
+	})
+
+	got, err = post.ContentHtmlWith(renderers)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "<div>Hello **world**</div>" {
+		t.Fatal("Expected the custom renderer to be used, got:", got)
+	}
+}
@@ -0,0 +1,45 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// EncryptionKeyProviderInterface supplies the AES keys used to encrypt the
+// columns listed in NewStoreOptions.EncryptedColumns. Keys are identified by
+// an ID stored with every value, so keys can be rotated: new values use the
+// current key, while older values are still decrypted with the key they name.
+type EncryptionKeyProviderInterface interface {
+	// CurrentKey returns the ID and the key to encrypt new values with.
+	// The key must be 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+	CurrentKey(ctx context.Context) (keyID string, key []byte, err error)
+	// Key returns the key with the given ID, to decrypt values encrypted with it.
+	Key(ctx context.Context, keyID string) ([]byte, error)
+}
+
+// NewStaticKeyProvider creates a key provider with a single key, e.g. loaded
+// from an environment variable.
+func NewStaticKeyProvider(keyID string, key []byte) EncryptionKeyProviderInterface {
+	return &staticKeyProvider{keyID: keyID, key: key}
+}
+
+// staticKeyProvider implements EncryptionKeyProviderInterface with a single key.
+type staticKeyProvider struct {
+	keyID string
+	key   []byte
+}
+
+var _ EncryptionKeyProviderInterface = (*staticKeyProvider)(nil)
+
+// CurrentKey returns the only key.
+func (p *staticKeyProvider) CurrentKey(ctx context.Context) (string, []byte, error) {
+	return p.keyID, p.key, nil
+}
+
+// Key returns the only key, or an error for any other key ID.
+func (p *staticKeyProvider) Key(ctx context.Context, keyID string) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, errors.New("blogstore: unknown encryption key: " + keyID)
+	}
+	return p.key, nil
+}
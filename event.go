@@ -0,0 +1,48 @@
+package blogstore
+
+import (
+	"context"
+	"time"
+)
+
+// Post lifecycle event types, published after the change is saved.
+const (
+	EVENT_POST_CREATED   = "post.created"
+	EVENT_POST_UPDATED   = "post.updated"
+	EVENT_POST_PUBLISHED = "post.published"
+	EVENT_POST_TRASHED   = "post.trashed"
+	EVENT_POST_DELETED   = "post.deleted"
+)
+
+// Event describes a change to a post.
+type Event struct {
+	// Type is one of the EVENT_* constants.
+	Type string
+	// PostID is the ID of the changed post.
+	PostID string
+	// Post is the post as saved, or as it was before it was deleted.
+	Post PostInterface
+	// ActorID is the actor from the context of the change, if any.
+	ActorID string
+	// OccurredAt is when the change was saved.
+	OccurredAt time.Time
+}
+
+// EventPublisherInterface delivers post lifecycle events to downstream
+// systems such as caches, search indexes or notifications. Set it with
+// NewStoreOptions.EventPublisher.
+type EventPublisherInterface interface {
+	// Publish is called after each saved change. Every update publishes
+	// EVENT_POST_UPDATED, followed by EVENT_POST_PUBLISHED or
+	// EVENT_POST_TRASHED when the status changed to published or trash.
+	// An error is returned to the caller, but the change is already saved.
+	Publish(ctx context.Context, event Event) error
+}
+
+// EventPublisherFunc adapts a function to EventPublisherInterface.
+type EventPublisherFunc func(ctx context.Context, event Event) error
+
+// Publish calls f(ctx, event).
+func (f EventPublisherFunc) Publish(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
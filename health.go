@@ -0,0 +1,16 @@
+package blogstore
+
+// HealthReport describes the health of a store, as returned by StoreInterface.Healthy.
+type HealthReport struct {
+	// Healthy is true when all checks passed.
+	Healthy bool
+	// DatabaseReachable is true when the database answered a ping.
+	DatabaseReachable bool
+	// PostTableExists is true when the post table exists.
+	PostTableExists bool
+	// VersioningTableExists is true when the versioning table exists.
+	// Always false when versioning is disabled, in which case it is not checked.
+	VersioningTableExists bool
+	// Errors lists the reasons of the failed checks.
+	Errors []string
+}
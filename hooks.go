@@ -0,0 +1,76 @@
+package blogstore
+
+import "context"
+
+const (
+	hookBeforePostCreate = "before_post_create"
+	hookAfterPostCreate  = "after_post_create"
+	hookBeforePostUpdate = "before_post_update"
+	hookAfterPostUpdate  = "after_post_update"
+	hookBeforePostDelete = "before_post_delete"
+	hookAfterPostDelete  = "after_post_delete"
+)
+
+// PostHookFunc is a function called around a post mutation. An error from a
+// before hook aborts the mutation and is returned to the caller. An error from
+// an after hook is returned to the caller too, but the mutation is already
+// saved.
+type PostHookFunc func(ctx context.Context, post PostInterface) error
+
+// Hooks holds the functions called around post mutations, so applications can
+// validate or enrich posts and trigger side effects. Register the functions
+// with the On* methods, then set it with NewStoreOptions.Hooks. The functions
+// of an event are called in the order they were registered. Registering is
+// not safe while the store is in use.
+//
+// The create hooks get the new post, the update hooks the post being saved,
+// and the delete hooks the stored post. Changes made by the before hooks are
+// saved with the post. Trashing and soft deleting are updates.
+type Hooks struct {
+	funcs map[string][]PostHookFunc
+}
+
+// NewHooks creates an empty set of hooks.
+func NewHooks() *Hooks {
+	return &Hooks{funcs: map[string][]PostHookFunc{}}
+}
+
+// OnBeforePostCreate registers a function called before a post is created.
+func (h *Hooks) OnBeforePostCreate(fn PostHookFunc) *Hooks {
+	return h.on(hookBeforePostCreate, fn)
+}
+
+// OnAfterPostCreate registers a function called after a post is created.
+func (h *Hooks) OnAfterPostCreate(fn PostHookFunc) *Hooks {
+	return h.on(hookAfterPostCreate, fn)
+}
+
+// OnBeforePostUpdate registers a function called before a post is updated.
+func (h *Hooks) OnBeforePostUpdate(fn PostHookFunc) *Hooks {
+	return h.on(hookBeforePostUpdate, fn)
+}
+
+// OnAfterPostUpdate registers a function called after a post is updated.
+func (h *Hooks) OnAfterPostUpdate(fn PostHookFunc) *Hooks {
+	return h.on(hookAfterPostUpdate, fn)
+}
+
+// OnBeforePostDelete registers a function called before a post is
+// permanently deleted.
+func (h *Hooks) OnBeforePostDelete(fn PostHookFunc) *Hooks {
+	return h.on(hookBeforePostDelete, fn)
+}
+
+// OnAfterPostDelete registers a function called after a post is permanently
+// deleted.
+func (h *Hooks) OnAfterPostDelete(fn PostHookFunc) *Hooks {
+	return h.on(hookAfterPostDelete, fn)
+}
+
+func (h *Hooks) on(event string, fn PostHookFunc) *Hooks {
+	if h.funcs == nil {
+		h.funcs = map[string][]PostHookFunc{}
+	}
+	h.funcs[event] = append(h.funcs[event], fn)
+	return h
+}
@@ -0,0 +1,12 @@
+package blogstore
+
+import "time"
+
+// Lock records who is editing a post, taken with PostLock until it
+// expires or is released with PostUnlock.
+type Lock struct {
+	PostID    string
+	Owner     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
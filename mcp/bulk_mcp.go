@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ============================ BULK TOOLS ============================
+
+// bulkUpsertMaxPosts is the largest number of posts post_bulk_upsert
+// accepts in one call.
+const bulkUpsertMaxPosts = 100
+
+func (m *MCP) bulkTools() []map[string]any {
+	return []map[string]any{
+		{
+			"name":        "post_bulk_upsert",
+			"description": "Create or update several blog posts in one call. Each post takes the arguments of post_upsert. Posts are saved one by one: a failing post does not stop the others, and the result lists the outcome of each post by index",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"posts"},
+				"properties": map[string]any{
+					"posts": map[string]any{
+						"type":        "array",
+						"maxItems":    bulkUpsertMaxPosts,
+						"items":       map[string]any{"type": "object"},
+						"description": "Posts to create or update, with the same fields as post_upsert; include id to update an existing post",
+					},
+				},
+			},
+		},
+	}
+}
+
+// toolPostBulkUpsert upserts each post and reports the outcome per post
+func (m *MCP) toolPostBulkUpsert(ctx context.Context, args map[string]any) (string, error) {
+	posts, ok := args["posts"].([]any)
+	if !ok || len(posts) == 0 {
+		return "", errors.New("posts is required")
+	}
+	if len(posts) > bulkUpsertMaxPosts {
+		return "", errors.New("posts cannot have more than " + strconv.Itoa(bulkUpsertMaxPosts) + " items")
+	}
+
+	results := make([]map[string]any, 0, len(posts))
+	failed := 0
+	for i, item := range posts {
+		result := map[string]any{"index": i}
+		results = append(results, result)
+
+		postArgs, ok := item.(map[string]any)
+		if !ok {
+			result["error"] = "post must be an object"
+			failed++
+			continue
+		}
+
+		post, isUpdate, err := m.upsertPost(ctx, postArgs)
+		if err != nil {
+			result["error"] = err.Error()
+			failed++
+			continue
+		}
+
+		result["id"] = post.GetID()
+		result["title"] = post.GetTitle()
+		result["action"] = "created"
+		if isUpdate {
+			result["action"] = "updated"
+		}
+	}
+
+	b, _ := json.Marshal(map[string]any{
+		"results":   results,
+		"succeeded": len(posts) - failed,
+		"failed":    failed,
+	})
+	return string(b), nil
+}
@@ -1,28 +1,91 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dracory/blogstore"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
+// batchMaxRequests is the largest number of requests accepted in a
+// JSON-RPC batch.
+const batchMaxRequests = 50
+
 type MCP struct {
-	store blogstore.StoreInterface
+	store            blogstore.StoreInterface
+	tracer           trace.Tracer
+	actorFromRequest func(r *http.Request) string
+	rateLimiter      *rateLimiter
+	options          Options
+}
+
+// Options describes the server to the clients in the initialize response.
+type Options struct {
+	// Name and Version identify the server in serverInfo.
+	// They default to "blogstore" and "0.1.0".
+	Name    string
+	Version string
+	// Instructions tell agents how to use the server, sent as the
+	// instructions field of the initialize response when set.
+	Instructions string
+	// Capabilities are the protocol capabilities advertised to the clients.
+	// Defaults to the tools capability.
+	Capabilities map[string]any
 }
 
-func NewMCP(store blogstore.StoreInterface) *MCP {
-	return &MCP{store: store}
+// NewMCP creates the MCP handler for the store. The optional options brand
+// the server; without them it introduces itself as blogstore.
+func NewMCP(store blogstore.StoreInterface, options ...Options) *MCP {
+	m := &MCP{store: store, tracer: noop.NewTracerProvider().Tracer("")}
+	if len(options) > 0 {
+		m.options = options[0]
+	}
+	if m.options.Name == "" {
+		m.options.Name = "blogstore"
+	}
+	if m.options.Version == "" {
+		m.options.Version = "0.1.0"
+	}
+	if m.options.Capabilities == nil {
+		m.options.Capabilities = map[string]any{"tools": map[string]any{}}
+	}
+	return m
+}
+
+// SetTracerProvider enables OpenTelemetry tracing of tool calls.
+// Every tools/call request is recorded as a span named after the tool.
+func (m *MCP) SetTracerProvider(provider trace.TracerProvider) *MCP {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	m.tracer = provider.Tracer("github.com/dracory/blogstore/mcp")
+	return m
+}
+
+// SetActorFromRequest sets how the acting user is identified, e.g. from an
+// authenticated session. The actor is passed to the store with
+// blogstore.ContextWithActor, for its audit log and authorizer.
+func (m *MCP) SetActorFromRequest(actorFromRequest func(r *http.Request) string) *MCP {
+	m.actorFromRequest = actorFromRequest
+	return m
 }
 
 // Handler is an HTTP handler intended to be mounted at a dedicated route.
 //
-// The protocol is JSON-RPC 2.0 compatible and currently supports:
+// The protocol is JSON-RPC 2.0 compatible, including batches, and currently supports:
 // - MCP standard methods: initialize, notifications/initialized, tools/list, tools/call
 // - legacy aliases: list_tools, call_tool
 func (m *MCP) Handler(w http.ResponseWriter, r *http.Request) {
@@ -43,41 +106,108 @@ func (m *MCP) Handler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
+	ctx := r.Context()
+	if m.actorFromRequest != nil {
+		ctx = blogstore.ContextWithActor(ctx, m.actorFromRequest(r))
+	}
+
+	client := ""
+	if m.rateLimiter != nil {
+		client = m.rateLimiter.client(r)
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		m.handleBatch(w, ctx, trimmed, client)
+		return
+	}
+
 	var req jsonRPCRequest
 	if err := json.Unmarshal(body, &req); err != nil {
 		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(nil, -32700, "parse error"))
 		return
 	}
 
-	if strings.TrimSpace(req.JSONRPC) == "" {
-		req.JSONRPC = "2.0"
+	resp, ok := m.handleRequest(w.Header(), ctx, req, client)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
 	}
+	writeJSON(w, http.StatusOK, resp)
+}
 
+// handleBatch answers a JSON-RPC batch with the array of the responses to
+// its requests, in order. Notifications, the requests without an id, get
+// no response; when the batch holds only notifications nothing is returned.
+func (m *MCP) handleBatch(w http.ResponseWriter, ctx context.Context, body []byte, client string) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(nil, -32700, "parse error"))
+		return
+	}
+	if len(items) == 0 {
+		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(nil, -32600, "invalid request: empty batch"))
+		return
+	}
+	if len(items) > batchMaxRequests {
+		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(nil, -32600, fmt.Sprintf("invalid request: a batch cannot have more than %d requests", batchMaxRequests)))
+		return
+	}
+
+	responses := make([]jsonRPCResponse, 0, len(items))
+	for _, item := range items {
+		var fields map[string]json.RawMessage
+		var req jsonRPCRequest
+		if json.Unmarshal(item, &fields) != nil || json.Unmarshal(item, &req) != nil {
+			responses = append(responses, jsonRPCErrorResponse(nil, -32600, "invalid request"))
+			continue
+		}
+
+		resp, ok := m.handleRequest(w.Header(), ctx, req, client)
+		if _, hasID := fields["id"]; ok && hasID {
+			responses = append(responses, resp)
+		}
+	}
+
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, http.StatusOK, responses)
+}
+
+// handleRequest runs a single JSON-RPC request. It returns false when the
+// method has no response, e.g. notifications/initialized. Headers of the
+// HTTP response, e.g. Retry-After, are set on header.
+func (m *MCP) handleRequest(header http.Header, ctx context.Context, req jsonRPCRequest, client string) (jsonRPCResponse, bool) {
 	switch req.Method {
 	case "initialize":
-		m.handleInitialize(w, r.Context(), req.ID, req.Params)
-		return
+		return m.handleInitialize(ctx, req.ID, req.Params), true
 	case "notifications/initialized":
-		m.handleInitialized(w, r.Context())
-		return
+		return jsonRPCResponse{}, false
 	case "tools/list":
-		m.handleToolsList(w, r.Context(), req.ID)
-		return
+		return m.handleToolsList(ctx, req.ID), true
 	case "tools/call":
-		m.handleToolsCall(w, r.Context(), req.ID, req.Params)
-		return
+		return m.handleToolsCall(header, ctx, req.ID, req.Params, client), true
 	case "list_tools":
-		m.handleToolsList(w, r.Context(), req.ID)
-		return
+		return m.handleToolsList(ctx, req.ID), true
 	case "call_tool":
-		m.handleToolsCall(w, r.Context(), req.ID, req.Params)
-		return
+		return m.handleToolsCall(header, ctx, req.ID, req.Params, client), true
 	default:
-		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(req.ID, -32601, "method not found"))
-		return
+		return jsonRPCErrorResponse(req.ID, -32601, "method not found"), true
 	}
 }
 
+// argError is a tool argument of the wrong type or value. It is the
+// caller's mistake, reported as invalid params.
+type argError struct {
+	message string
+}
+
+func (e *argError) Error() string {
+	return e.message
+}
+
 func argString(args map[string]any, key string) string {
 	v, ok := args[key]
 	if !ok || v == nil {
@@ -149,6 +279,28 @@ func argBool(args map[string]any, key string) (bool, bool) {
 	}
 }
 
+// argStrings returns the non-empty strings of an array argument. A single
+// string is accepted as an array of one.
+func argStrings(args map[string]any, key string) []string {
+	switch t := args[key].(type) {
+	case string:
+		if strings.TrimSpace(t) == "" {
+			return nil
+		}
+		return []string{t}
+	case []any:
+		values := []string{}
+		for _, item := range t {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
 type jsonRPCRequest struct {
 	JSONRPC string          `json:"jsonrpc"`
 	ID      any             `json:"id"`
@@ -166,6 +318,7 @@ type jsonRPCResponse struct {
 type jsonRPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
 }
 
 func jsonRPCErrorResponse(id any, code int, message string) jsonRPCResponse {
@@ -179,6 +332,18 @@ func jsonRPCErrorResponse(id any, code int, message string) jsonRPCResponse {
 	}
 }
 
+func jsonRPCErrorDataResponse(id any, code int, message string, data any) jsonRPCResponse {
+	return jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: jsonRPCError{
+			Code:    code,
+			Message: message,
+			Data:    data,
+		},
+	}
+}
+
 func jsonRPCResultResponse(id any, result any) jsonRPCResponse {
 	return jsonRPCResponse{
 		JSONRPC: "2.0",
@@ -204,7 +369,7 @@ func toolTextResult(text string) map[string]any {
 	}
 }
 
-func (m *MCP) handleInitialize(w http.ResponseWriter, _ context.Context, id any, params json.RawMessage) {
+func (m *MCP) handleInitialize(_ context.Context, id any, params json.RawMessage) jsonRPCResponse {
 	var p struct {
 		ProtocolVersion string `json:"protocolVersion"`
 		ClientInfo      any    `json:"clientInfo"`
@@ -215,36 +380,48 @@ func (m *MCP) handleInitialize(w http.ResponseWriter, _ context.Context, id any,
 	result := map[string]any{
 		"protocolVersion": "2025-06-18",
 		"serverInfo": map[string]any{
-			"name":    "blogstore",
-			"version": "0.1.0",
-		},
-		"capabilities": map[string]any{
-			"tools": map[string]any{},
+			"name":    m.options.Name,
+			"version": m.options.Version,
 		},
+		"capabilities": m.options.Capabilities,
 		"echo": map[string]any{
 			"clientProtocolVersion": p.ProtocolVersion,
 			"clientInfo":            p.ClientInfo,
 			"clientCapabilities":    p.Capabilities,
 		},
 	}
+	if m.options.Instructions != "" {
+		result["instructions"] = m.options.Instructions
+	}
+
+	return jsonRPCResultResponse(id, result)
+}
 
-	writeJSON(w, http.StatusOK, jsonRPCResultResponse(id, result))
+func (m *MCP) handleToolsList(_ context.Context, id any) jsonRPCResponse {
+	result := map[string]any{"tools": m.tools()}
+	return jsonRPCResultResponse(id, result)
 }
 
-func (m *MCP) handleInitialized(w http.ResponseWriter, _ context.Context) {
-	w.WriteHeader(http.StatusOK)
+// readOnlyAnnotations marks a tool as not changing the store, with the
+// readOnlyHint annotation of the MCP specification. The rate limit counts
+// the calls of these tools as reads.
+func readOnlyAnnotations() map[string]any {
+	return map[string]any{"readOnlyHint": true}
 }
 
-func (m *MCP) handleToolsList(w http.ResponseWriter, _ context.Context, id any) {
+// tools returns the definitions of all the tools, as listed by tools/list.
+func (m *MCP) tools() []map[string]any {
 	baseTools := []map[string]any{
 		{
 			"name":        "blog_schema",
 			"description": "Get schema information about blog entities and their field constraints",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{"type": "object"},
 		},
 		{
 			"name":        "post_list",
 			"description": "List blog posts",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -254,14 +431,15 @@ func (m *MCP) handleToolsList(w http.ResponseWriter, _ context.Context, id any)
 					"status":       map[string]any{"type": "string"},
 					"search":       map[string]any{"type": "string"},
 					"with_deleted": map[string]any{"type": "boolean"},
-					"order_by":     map[string]any{"type": "string"},
-					"sort_order":   map[string]any{"type": "string"},
+					"order_by":     map[string]any{"type": "string", "description": "Column to order by, e.g. created_at, published_at, title"},
+					"sort_order":   map[string]any{"type": "string", "enum": []string{"asc", "desc"}, "description": "Sort order (default: desc)"},
 				},
 			},
 		},
 		{
 			"name":        "post_get",
-			"description": "Get a blog post by ID",
+			"description": "Get a blog post by ID. Includes locked_by and locked_until while the post is locked for editing",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type":     "object",
 				"required": []string{"id"},
@@ -282,22 +460,26 @@ func (m *MCP) handleToolsList(w http.ResponseWriter, _ context.Context, id any)
 					"content":          map[string]any{"type": "string", "description": "Post content"},
 					"content_type":     map[string]any{"type": "string", "enum": []string{"markdown", "html", "plain_text"}, "default": "plain_text", "description": "Content format type for proper rendering"},
 					"summary":          map[string]any{"type": "string"},
-					"status":           map[string]any{"type": "string", "enum": []string{"draft", "published", "unpublished", "trash"}},
+					"status":           map[string]any{"type": "string", "enum": m.store.PostStatuses()},
 					"author_id":        map[string]any{"type": "string"},
 					"canonical_url":    map[string]any{"type": "string"},
 					"image_url":        map[string]any{"type": "string"},
 					"featured":         map[string]any{"type": "string", "enum": []string{"yes", "no"}, "description": "Whether the post is featured (use 'yes' or 'no')"},
 					"published_at":     map[string]any{"type": "string"},
+					"expires_at":       map[string]any{"type": "string", "description": "When the post is unpublished automatically; empty for never"},
 					"meta_description": map[string]any{"type": "string"},
 					"meta_keywords":    map[string]any{"type": "string"},
 					"meta_robots":      map[string]any{"type": "string"},
 					"memo":             map[string]any{"type": "string"},
+					"idempotency_key":  map[string]any{"type": "string", "description": "Client generated key; retrying a create with the same key returns the post created the first time"},
+					"metas":            map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}, "description": "Meta values to set, validated against the meta schema of the store; an empty value removes the key"},
 				},
 			},
 		},
 		{
 			"name":        "post_versions",
 			"description": "Get version history for a blog post",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type":     "object",
 				"required": []string{"id"},
@@ -309,6 +491,18 @@ func (m *MCP) handleToolsList(w http.ResponseWriter, _ context.Context, id any)
 				},
 			},
 		},
+		{
+			"name":        "post_revert",
+			"description": "Revert a blog post to one of its versions, as listed by post_versions. Returns the restored post",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id", "version_id"},
+				"properties": map[string]any{
+					"id":         map[string]any{"type": "string", "description": "Post ID"},
+					"version_id": map[string]any{"type": "string", "description": "ID of the version to restore"},
+				},
+			},
+		},
 		{
 			"name":        "post_delete",
 			"description": "Delete a blog post",
@@ -320,17 +514,42 @@ func (m *MCP) handleToolsList(w http.ResponseWriter, _ context.Context, id any)
 				},
 			},
 		},
+		{
+			"name":        "post_duplicate",
+			"description": "Create a draft copy of a blog post",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id"},
+				"properties": map[string]any{
+					"id": map[string]any{"type": "string"},
+				},
+			},
+		},
+		{
+			"name":        "post_restore",
+			"description": "Restore a soft deleted blog post",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id"},
+				"properties": map[string]any{
+					"id": map[string]any{"type": "string"},
+				},
+			},
+		},
 	}
 
-	// Add taxonomy tools
+	// Add taxonomy, search, bulk, stats and review tools
 	taxonomyTools := m.taxonomyTools()
 	tools := append(baseTools, taxonomyTools...)
+	tools = append(tools, m.searchTools()...)
+	tools = append(tools, m.bulkTools()...)
+	tools = append(tools, m.statsTools()...)
+	tools = append(tools, m.reviewTools()...)
 
-	result := map[string]any{"tools": tools}
-	writeJSON(w, http.StatusOK, jsonRPCResultResponse(id, result))
+	return tools
 }
 
-func (m *MCP) handleToolsCall(w http.ResponseWriter, ctx context.Context, id any, params json.RawMessage) {
+func (m *MCP) handleToolsCall(header http.Header, ctx context.Context, id any, params json.RawMessage, client string) jsonRPCResponse {
 	var p struct {
 		Name      string          `json:"name"`
 		ToolName  string          `json:"tool_name"`
@@ -354,18 +573,51 @@ func (m *MCP) handleToolsCall(w http.ResponseWriter, ctx context.Context, id any
 		dec := json.NewDecoder(strings.NewReader(string(argsRaw)))
 		dec.UseNumber()
 		if err := dec.Decode(&args); err != nil {
-			writeJSON(w, http.StatusOK, jsonRPCErrorResponse(id, -32602, "invalid tool arguments"))
-			return
+			return jsonRPCErrorResponse(id, -32602, "invalid tool arguments")
+		}
+	}
+
+	if m.rateLimiter != nil {
+		ok, retryAfter, err := m.rateLimiter.allow(client, toolName, toolCallWeight(toolName, args))
+		if err != nil {
+			return jsonRPCErrorResponse(id, -32602, err.Error())
+		}
+		if !ok {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			header.Set("Retry-After", strconv.Itoa(seconds))
+			return jsonRPCErrorDataResponse(id, rateLimitErrorCode,
+				fmt.Sprintf("rate limit exceeded, retry after %d seconds", seconds),
+				map[string]any{"retry_after_seconds": seconds})
 		}
 	}
 
+	tracer := m.tracer
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	ctx, span := tracer.Start(ctx, "mcp.tools/call "+toolName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("mcp.tool.name", toolName)))
 	text, err := m.dispatchTool(ctx, toolName, args)
 	if err != nil {
-		writeJSON(w, http.StatusOK, jsonRPCErrorResponse(id, -32603, err.Error()))
-		return
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	if err != nil {
+		// Rejected arguments, ordering and metas are the caller's mistake, not a server failure
+		var orderErr *blogstore.OrderError
+		var metaErr *blogstore.MetaError
+		var argErr *argError
+		if errors.As(err, &orderErr) || errors.As(err, &metaErr) || errors.As(err, &argErr) {
+			return jsonRPCErrorResponse(id, -32602, err.Error())
+		}
+		return jsonRPCErrorResponse(id, -32603, err.Error())
 	}
 
-	writeJSON(w, http.StatusOK, jsonRPCResultResponse(id, toolTextResult(text)))
+	return jsonRPCResultResponse(id, toolTextResult(text))
 }
 
 func (m *MCP) dispatchTool(ctx context.Context, toolName string, args map[string]any) (string, error) {
@@ -374,17 +626,31 @@ func (m *MCP) dispatchTool(ctx context.Context, toolName string, args map[string
 		return m.toolBlogSchema(ctx, args)
 	case "post_list":
 		return m.toolPostList(ctx, args)
+	case "post_search":
+		return m.toolPostSearch(ctx, args)
 	case "post_get":
 		return m.toolPostGet(ctx, args)
 	case "post_upsert":
 		return m.toolPostUpsert(ctx, args)
+	case "post_bulk_upsert":
+		return m.toolPostBulkUpsert(ctx, args)
 	case "post_versions":
 		return m.toolPostVersions(ctx, args)
+	case "post_revert":
+		return m.toolPostRevert(ctx, args)
 	case "post_delete":
 		return m.toolPostDelete(ctx, args)
+	case "post_duplicate":
+		return m.toolPostDuplicate(ctx, args)
+	case "post_restore":
+		return m.toolPostRestore(ctx, args)
 	case "taxonomy_list", "taxonomy_create", "term_list", "term_create",
 		"post_set_terms", "post_add_term", "post_get_terms":
 		return m.taxonomyToolDispatch(ctx, toolName, args)
+	case "post_stats", "blog_stats":
+		return m.statsToolDispatch(ctx, toolName, args)
+	case "post_submit_for_review", "post_approve", "post_reject":
+		return m.reviewToolDispatch(ctx, toolName, args)
 	default:
 		return "", errors.New("unknown tool")
 	}
@@ -429,12 +695,13 @@ func (m *MCP) toolBlogSchema(_ context.Context, _ map[string]any) (string, error
 					{"name": "content", "type": "string", "description": "Post content"},
 					{"name": "content_type", "type": "string", "enum": []string{"markdown", "html", "plain_text"}, "description": "Content format type for proper rendering"},
 					{"name": "summary", "type": "string", "description": "Brief summary of the post"},
-					{"name": "status", "type": "string", "enum": []string{"draft", "published", "unpublished", "trash"}, "description": "Publication status"},
+					{"name": "status", "type": "string", "enum": m.store.PostStatuses(), "description": "Publication status"},
 					{"name": "author_id", "type": "string", "description": "ID of the post author"},
 					{"name": "canonical_url", "type": "string", "description": "Canonical URL for SEO"},
 					{"name": "image_url", "type": "string", "description": "URL to featured image"},
 					{"name": "featured", "type": "string", "enum": []string{"yes", "no"}, "description": "Whether the post is featured (use 'yes' or 'no')"},
 					{"name": "published_at", "type": "string", "description": "Publication timestamp"},
+					{"name": "expires_at", "type": "string", "description": "Timestamp after which the post is unpublished, empty if it does not expire"},
 					{"name": "meta_description", "type": "string", "description": "SEO meta description"},
 					{"name": "meta_keywords", "type": "string", "description": "SEO meta keywords"},
 					{"name": "meta_robots", "type": "string", "description": "SEO meta robots tag"},
@@ -450,7 +717,7 @@ func (m *MCP) toolBlogSchema(_ context.Context, _ map[string]any) (string, error
 						"description":    "Must be exactly 'yes' or 'no' (not boolean true/false)",
 					},
 					"status": map[string]any{
-						"allowed_values": []string{"draft", "published", "unpublished", "trash"},
+						"allowed_values": m.store.PostStatuses(),
 						"default":        "draft",
 						"description":    "Publication status of the post",
 					},
@@ -480,12 +747,13 @@ func (m *MCP) toolBlogSchema(_ context.Context, _ map[string]any) (string, error
 				"description":        "Create or update a blog post (single operation for both create and update)",
 				"required_arguments": []string{"title"},
 				"arguments": map[string]any{
-					"id":           map[string]any{"type": "string", "description": "Post ID (required for updates, optional for creates)"},
-					"title":        map[string]any{"type": "string", "required": true, "description": "Post title"},
-					"content":      map[string]any{"type": "string", "description": "Post content"},
-					"content_type": map[string]any{"type": "string", "enum": []string{"markdown", "html", "plain_text"}, "default": "plain_text", "description": "Content format type for proper rendering"},
-					"featured":     map[string]any{"type": "string", "enum": []string{"yes", "no"}, "default": "no", "description": "Use 'yes' or 'no' only"},
-					"status":       map[string]any{"type": "string", "enum": []string{"draft", "published", "unpublished", "trash"}, "default": "draft"},
+					"id":              map[string]any{"type": "string", "description": "Post ID (required for updates, optional for creates)"},
+					"title":           map[string]any{"type": "string", "required": true, "description": "Post title"},
+					"content":         map[string]any{"type": "string", "description": "Post content"},
+					"content_type":    map[string]any{"type": "string", "enum": []string{"markdown", "html", "plain_text"}, "default": "plain_text", "description": "Content format type for proper rendering"},
+					"featured":        map[string]any{"type": "string", "enum": []string{"yes", "no"}, "default": "no", "description": "Use 'yes' or 'no' only"},
+					"status":          map[string]any{"type": "string", "enum": m.store.PostStatuses(), "default": "draft"},
+					"idempotency_key": map[string]any{"type": "string", "description": "Client generated key; retrying a create with the same key returns the post created the first time"},
 				},
 			},
 			"post_versions": map[string]any{
@@ -498,6 +766,14 @@ func (m *MCP) toolBlogSchema(_ context.Context, _ map[string]any) (string, error
 					"sort_order": map[string]any{"type": "string", "enum": []string{"asc", "desc"}, "description": "Sort order (default: desc)"},
 				},
 			},
+			"post_revert": map[string]any{
+				"description":        "Revert a blog post to one of its versions (requires versioning to be enabled)",
+				"required_arguments": []string{"id", "version_id"},
+				"arguments": map[string]any{
+					"id":         map[string]any{"type": "string", "required": true, "description": "Post ID"},
+					"version_id": map[string]any{"type": "string", "required": true, "description": "Version ID from post_versions"},
+				},
+			},
 		},
 		"usage_notes": []string{
 			"The 'featured' field requires string values 'yes' or 'no', not boolean true/false",
@@ -506,8 +782,13 @@ func (m *MCP) toolBlogSchema(_ context.Context, _ map[string]any) (string, error
 			"Technical posts should have featured='yes' and include meta keywords",
 			"Set content_type='markdown' for markdown content to enable proper rendering",
 			"Use 'post_upsert' for simplified create/update operations - single method handles both cases",
+			"Pass an 'idempotency_key' to 'post_upsert' when creating, so a retried call does not create a duplicate post",
+			"Use 'post_bulk_upsert' to save many posts in one call; check the per-post results, as a failing post does not stop the others",
 			"Post updates automatically create version entries when versioning is enabled",
-			"Use 'post_versions' to view and revert to previous versions of a post",
+			"Use 'post_versions' to view the previous versions of a post and 'post_revert' to restore one of them",
+			"Teams requiring approval use 'post_submit_for_review', then a reviewer calls 'post_approve' or 'post_reject'",
+			"Use 'post_search' to locate posts by status, author, dates, category or tags without loading their content",
+			"Use 'blog_stats' for post counts and top posts, and 'post_stats' for the daily views of one post",
 		},
 	}
 
@@ -566,7 +847,21 @@ func (m *MCP) toolPostGet(ctx context.Context, args map[string]any) (string, err
 		return "", errors.New("post not found")
 	}
 
-	b, _ := json.Marshal(postToMap(post))
+	result := postToMap(post)
+
+	// Tell the agent when someone else is editing the post
+	if m.store.LockEnabled() {
+		lock, err := m.store.PostLockStatus(ctx, post.GetID())
+		if err != nil {
+			return "", err
+		}
+		if lock != nil {
+			result["locked_by"] = lock.Owner
+			result["locked_until"] = lock.ExpiresAt.UTC().Format(time.DateTime)
+		}
+	}
+
+	b, _ := json.Marshal(result)
 	return string(b), nil
 }
 
@@ -584,6 +879,36 @@ func (m *MCP) toolPostDelete(ctx context.Context, args map[string]any) (string,
 	return string(b), nil
 }
 
+func (m *MCP) toolPostDuplicate(ctx context.Context, args map[string]any) (string, error) {
+	id := argString(args, "id")
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id is required")
+	}
+
+	post, err := m.store.PostDuplicate(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	b, _ := json.Marshal(postToMap(post))
+	return string(b), nil
+}
+
+func (m *MCP) toolPostRestore(ctx context.Context, args map[string]any) (string, error) {
+	id := argString(args, "id")
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id is required")
+	}
+
+	post, err := m.store.PostRestoreByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	b, _ := json.Marshal(postToMap(post))
+	return string(b), nil
+}
+
 func (m *MCP) toolPostVersions(ctx context.Context, args map[string]any) (string, error) {
 	id := argString(args, "id")
 	if strings.TrimSpace(id) == "" {
@@ -643,7 +968,53 @@ func (m *MCP) toolPostVersions(ctx context.Context, args map[string]any) (string
 	return string(b), nil
 }
 
+func (m *MCP) toolPostRevert(ctx context.Context, args map[string]any) (string, error) {
+	id := argString(args, "id")
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id is required")
+	}
+	versionID := argString(args, "version_id")
+	if strings.TrimSpace(versionID) == "" {
+		return "", errors.New("version_id is required")
+	}
+
+	if !m.store.VersioningEnabled() {
+		return "", errors.New("versioning is not enabled")
+	}
+
+	if err := m.store.PostRevertToVersion(ctx, id, versionID); err != nil {
+		return "", err
+	}
+
+	post, err := m.store.PostFindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if post == nil {
+		return "", errors.New("post not found")
+	}
+
+	b, _ := json.Marshal(postToMap(post))
+	return string(b), nil
+}
+
 func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string, error) {
+	post, _, err := m.upsertPost(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	b, _ := json.Marshal(map[string]any{
+		"id":     post.GetID(),
+		"title":  post.GetTitle(),
+		"action": "upserted",
+	})
+	return string(b), nil
+}
+
+// upsertPost creates or updates the post described by the arguments of
+// post_upsert, and reports whether an existing post was updated
+func (m *MCP) upsertPost(ctx context.Context, args map[string]any) (blogstore.PostInterface, bool, error) {
 	id := argString(args, "id")
 	var post blogstore.PostInterface
 	var err error
@@ -653,7 +1024,7 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 	if strings.TrimSpace(id) != "" {
 		post, err = m.store.PostFindByID(ctx, id)
 		if err != nil {
-			return "", err
+			return nil, false, err
 		}
 		if post != nil {
 			isUpdate = true
@@ -664,7 +1035,7 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 	if post == nil {
 		title := argString(args, "title")
 		if strings.TrimSpace(title) == "" {
-			return "", errors.New("title is required for new posts")
+			return nil, false, errors.New("title is required for new posts")
 		}
 
 		post = blogstore.NewPost()
@@ -672,6 +1043,7 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 			post.SetID(id)
 		}
 		post.SetTitle(title)
+		post.SetIdempotencyKey(argString(args, "idempotency_key"))
 	}
 
 	// Set/update all fields (only if provided)
@@ -698,7 +1070,7 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 	}
 	if v := argString(args, "featured"); v != "" {
 		if v != "yes" && v != "no" {
-			return "", errors.New("featured field must be 'yes' or 'no', not boolean true/false")
+			return nil, false, errors.New("featured field must be 'yes' or 'no', not boolean true/false")
 		}
 		post.SetFeatured(v)
 	}
@@ -724,6 +1096,16 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 	if v := argString(args, "published_at"); v != "" {
 		post.SetPublishedAt(v)
 	}
+	if v, ok := args["expires_at"]; ok {
+		expiresAt, ok := v.(string)
+		if !ok {
+			return nil, false, &argError{"expires_at must be a timestamp string, or empty for no expiry"}
+		}
+		post.SetExpiresAt(expiresAt)
+		if expiresAt != "" && post.GetExpiresAtTime().IsZero() {
+			return nil, false, &argError{"expires_at is not a valid timestamp: " + expiresAt}
+		}
+	}
 	if v := argString(args, "meta_description"); v != "" {
 		post.SetMetaDescription(v)
 	}
@@ -736,24 +1118,43 @@ func (m *MCP) toolPostUpsert(ctx context.Context, args map[string]any) (string,
 	if v := argString(args, "memo"); v != "" {
 		post.SetMemo(v)
 	}
+	if v, ok := args["metas"]; ok {
+		values, ok := v.(map[string]any)
+		if !ok {
+			return nil, false, errors.New("metas must be an object of strings")
+		}
+		metas, err := post.GetMetas()
+		if err != nil {
+			return nil, false, err
+		}
+		for key, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, false, errors.New("meta " + key + " must be a string")
+			}
+			if s == "" {
+				delete(metas, key)
+			} else {
+				metas[key] = s
+			}
+		}
+		if err := post.SetMetas(metas); err != nil {
+			return nil, false, err
+		}
+	}
 
 	// Create or update based on whether we found an existing post
 	if isUpdate {
 		// Update existing post
 		if err := m.store.PostUpdate(ctx, post); err != nil {
-			return "", err
+			return nil, false, err
 		}
 	} else {
 		// Create new post
 		if err := m.store.PostCreate(ctx, post); err != nil {
-			return "", err
+			return nil, false, err
 		}
 	}
 
-	b, _ := json.Marshal(map[string]any{
-		"id":     post.GetID(),
-		"title":  post.GetTitle(),
-		"action": "upserted",
-	})
-	return string(b), nil
+	return post, isUpdate, nil
 }
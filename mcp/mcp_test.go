@@ -11,9 +11,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dracory/blogstore"
 	"github.com/dracory/blogstore/mcp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	_ "modernc.org/sqlite"
 )
 
@@ -123,6 +126,55 @@ func Test_MCP_Initialize(t *testing.T) {
 	}
 }
 
+func Test_MCP_Initialize_Options(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	h := mcp.NewMCP(store, mcp.Options{
+		Name:         "acme-blog",
+		Version:      "2.1.0",
+		Instructions: "Create posts as drafts.",
+		Capabilities: map[string]any{"tools": map[string]any{"listChanged": false}},
+	})
+	server := httptest.NewServer(http.HandlerFunc(h.Handler))
+	defer server.Close()
+
+	reqBody, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": "1", "method": "initialize", "params": map[string]any{}})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Result struct {
+			ServerInfo   map[string]any `json:"serverInfo"`
+			Instructions string         `json:"instructions"`
+			Capabilities map[string]any `json:"capabilities"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if out.Result.ServerInfo["name"] != "acme-blog" || out.Result.ServerInfo["version"] != "2.1.0" {
+		t.Fatalf("Unexpected serverInfo: %v", out.Result.ServerInfo)
+	}
+	if out.Result.Instructions != "Create posts as drafts." {
+		t.Fatalf("Unexpected instructions: %q", out.Result.Instructions)
+	}
+	tools, _ := out.Result.Capabilities["tools"].(map[string]any)
+	if tools == nil || tools["listChanged"] != false {
+		t.Fatalf("Unexpected capabilities: %v", out.Result.Capabilities)
+	}
+}
+
 func Test_MCP_ToolsList_StandardMethod(t *testing.T) {
 	server, _, cleanup := initMCPServerWithStore(t)
 	defer cleanup()
@@ -166,6 +218,39 @@ func Test_MCP_ToolsList_StandardMethod(t *testing.T) {
 	}
 }
 
+func Test_MCP_ToolsList_CustomStatuses(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		PostStatuses:       []string{"archived"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	for _, method := range []string{"tools/list", "tools/call"} {
+		params := map[string]any{}
+		if method == "tools/call" {
+			params = map[string]any{"name": "blog_schema", "arguments": map[string]any{}}
+		}
+		body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": "1", "method": method, "params": params})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		respBytes, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if !strings.Contains(string(respBytes), "archived") {
+			t.Fatalf("Expected %s to offer the custom status, got: %s", method, string(respBytes))
+		}
+	}
+}
+
 func Test_MCP_ToolsCall_StandardMethod_PostCRUD(t *testing.T) {
 	server, store, cleanup := initMCPServerWithStore(t)
 	defer cleanup()
@@ -768,3 +853,1097 @@ func Test_MCP_PostVersions(t *testing.T) {
 
 	t.Logf("Successfully validated post_versions tool for post %s", postID)
 }
+
+func Test_MCP_Tracing_ToolsCall(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		TracerProvider:     provider,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	h := mcp.NewMCP(store).SetTracerProvider(provider)
+	server := httptest.NewServer(http.HandlerFunc(h.Handler))
+	defer server.Close()
+
+	reqBody, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      "post_list",
+			"arguments": map[string]any{},
+		},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+
+	var toolSpan sdktrace.ReadOnlySpan
+	var listSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "mcp.tools/call post_list":
+			toolSpan = span
+		case "blogstore.PostList":
+			listSpan = span
+		}
+	}
+
+	if toolSpan == nil {
+		t.Fatalf("Expected tool call span to be recorded")
+	}
+	if listSpan == nil {
+		t.Fatalf("Expected store span to be recorded")
+	}
+	if listSpan.Parent().SpanID() != toolSpan.SpanContext().SpanID() {
+		t.Fatalf("Expected store span to be a child of the tool call span")
+	}
+}
+
+func Test_MCP_PostList_RejectsUnknownOrder(t *testing.T) {
+	server, _, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	req := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name": "post_list",
+			"arguments": map[string]any{
+				"order_by": "(SELECT 1)",
+			},
+		},
+	}
+	body, _ := json.Marshal(req)
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	respBytes, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	var rpcResp map[string]any
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	rpcErr, ok := rpcResp["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected error response, got: %s", string(respBytes))
+	}
+	if code, _ := rpcErr["code"].(float64); code != -32602 {
+		t.Fatalf("Expected invalid params code -32602, got: %s", string(respBytes))
+	}
+	if !strings.Contains(rpcErr["message"].(string), "order_by") {
+		t.Fatalf("Expected error to mention order_by, got: %s", string(respBytes))
+	}
+}
+
+func Test_MCP_ActorFromRequest(t *testing.T) {
+	authorizer := blogstore.AuthorizerFunc(func(ctx context.Context, action string, post blogstore.PostInterface) error {
+		if action != blogstore.ACTION_LIST && blogstore.ActorFromContext(ctx) != "editor" {
+			return blogstore.ErrNotAuthorized
+		}
+		return nil
+	})
+
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		Authorizer:         authorizer,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	h := mcp.NewMCP(store).SetActorFromRequest(func(r *http.Request) string {
+		return r.Header.Get("X-Actor")
+	})
+	server := httptest.NewServer(http.HandlerFunc(h.Handler))
+	defer server.Close()
+
+	upsert := func(actor string) []byte {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name":      "post_upsert",
+				"arguments": map[string]any{"title": "Hello"},
+			},
+		})
+		req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBuffer(body))
+		req.Header.Set("X-Actor", actor)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return respBytes
+	}
+
+	if resp := upsert("reader"); !strings.Contains(string(resp), "not authorized") {
+		t.Fatalf("Expected reader to be refused, got: %s", string(resp))
+	}
+
+	if text := rpcResultText(t, upsert("editor")); !strings.Contains(text, "Hello") {
+		t.Fatalf("Expected editor to create the post, got: %s", text)
+	}
+}
+
+func Test_MCP_PostUpsert_IdempotencyKey(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	upsert := func() string {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name":      "post_upsert",
+				"arguments": map[string]any{"title": "Retried", "idempotency_key": "req-1"},
+			},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+
+		var result map[string]any
+		if err := json.Unmarshal([]byte(rpcResultText(t, respBytes)), &result); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		id, _ := result["id"].(string)
+		return id
+	}
+
+	firstID := upsert()
+	if secondID := upsert(); secondID != firstID {
+		t.Fatalf("Expected retried call to return post %q, got %q", firstID, secondID)
+	}
+
+	count, err := store.PostCount(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("Failed to count posts: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 post, got %d", count)
+	}
+}
+
+func Test_MCP_PostGet_LockState(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		LockEnabled:        true,
+		LockTableName:      "blog_locks",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("Being edited")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	get := func() map[string]any {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name":      "post_get",
+				"arguments": map[string]any{"id": post.GetID()},
+			},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+
+		var result map[string]any
+		if err := json.Unmarshal([]byte(rpcResultText(t, respBytes)), &result); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		return result
+	}
+
+	if result := get(); result["locked_by"] != nil {
+		t.Fatalf("Expected no lock state for an unlocked post, got %v", result["locked_by"])
+	}
+
+	if err := store.PostLock(ctx, post.GetID(), "editor-1", time.Minute); err != nil {
+		t.Fatalf("Failed to lock post: %v", err)
+	}
+
+	result := get()
+	if result["locked_by"] != "editor-1" {
+		t.Fatalf("Expected locked_by editor-1, got %v", result["locked_by"])
+	}
+	if until, _ := result["locked_until"].(string); until == "" {
+		t.Fatal("Expected locked_until to be set")
+	}
+}
+
+func Test_MCP_StatsTools(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		ViewsEnabled:       true,
+		ViewsTableName:     "blog_post_views",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("Popular").SetStatus(blogstore.POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	for range 3 {
+		if err := store.PostRecordView(ctx, post.GetID(), time.Now().UTC()); err != nil {
+			t.Fatalf("Failed to record view: %v", err)
+		}
+	}
+
+	call := func(name string, args map[string]any) map[string]any {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": name, "arguments": args},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+
+		var result map[string]any
+		if err := json.Unmarshal([]byte(rpcResultText(t, respBytes)), &result); err != nil {
+			t.Fatalf("Failed to parse result: %v", err)
+		}
+		return result
+	}
+
+	postStats := call("post_stats", map[string]any{"id": post.GetID(), "days": 7})
+	if postStats["views_total"] != float64(3) {
+		t.Fatalf("Expected 3 views, got %v", postStats["views_total"])
+	}
+
+	blogStats := call("blog_stats", map[string]any{})
+	if blogStats["total_posts"] != float64(1) {
+		t.Fatalf("Expected 1 post, got %v", blogStats["total_posts"])
+	}
+	top, _ := blogStats["top_posts"].([]any)
+	if len(top) != 1 {
+		t.Fatalf("Expected 1 top post, got %v", blogStats["top_posts"])
+	}
+	if entry, _ := top[0].(map[string]any); entry["title"] != "Popular" || entry["views"] != float64(3) {
+		t.Fatalf("Expected the popular post with 3 views, got %v", entry)
+	}
+}
+
+func Test_MCP_ReviewTools(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("For review")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	call := func(name string, args map[string]any) string {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": name, "arguments": args},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return rpcResultText(t, respBytes)
+	}
+
+	if text := call("post_submit_for_review", map[string]any{"id": post.GetID()}); !strings.Contains(text, `"status":"pending_review"`) {
+		t.Fatalf("Expected the post to be pending review. Got: %s", text)
+	}
+
+	if text := call("post_approve", map[string]any{"id": post.GetID(), "reviewer_id": "editor-1"}); !strings.Contains(text, `"status":"published"`) {
+		t.Fatalf("Expected the post to be published. Got: %s", text)
+	}
+
+	approved, _ := store.PostFindByID(ctx, post.GetID())
+	if approved.GetMeta(blogstore.META_KEY_REVIEWED_BY) != "editor-1" {
+		t.Fatalf("Expected the reviewer to be recorded, got %q", approved.GetMeta(blogstore.META_KEY_REVIEWED_BY))
+	}
+}
+
+func Test_MCP_PostApprove_ActorWins(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("For review").SetStatus(blogstore.POST_STATUS_PENDING_REVIEW)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	h := mcp.NewMCP(store).SetActorFromRequest(func(r *http.Request) string {
+		return "editor-2"
+	})
+	server := httptest.NewServer(http.HandlerFunc(h.Handler))
+	defer server.Close()
+
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      "post_approve",
+			"arguments": map[string]any{"id": post.GetID(), "reviewer_id": "someone-else"},
+		},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	if text := rpcResultText(t, respBytes); !strings.Contains(text, `"status":"published"`) {
+		t.Fatalf("Expected the post to be published. Got: %s", text)
+	}
+
+	approved, _ := store.PostFindByID(ctx, post.GetID())
+	if approved.GetMeta(blogstore.META_KEY_REVIEWED_BY) != "editor-2" {
+		t.Fatalf("Expected the acting user as the reviewer, got %q", approved.GetMeta(blogstore.META_KEY_REVIEWED_BY))
+	}
+}
+
+func Test_MCP_PostRestore(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("Deleted by mistake")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatalf("Failed to soft delete post: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "post_restore", "arguments": map[string]any{"id": post.GetID()}},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	if text := rpcResultText(t, respBytes); !strings.Contains(text, "Deleted by mistake") {
+		t.Fatalf("Expected the restored post. Got: %s", text)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatalf("PostFindByID() error: %v", err)
+	}
+	if found == nil {
+		t.Fatal("Expected the post to be active again")
+	}
+}
+
+func Test_MCP_PostDuplicate(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("Template").SetStatus(blogstore.POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "post_duplicate", "arguments": map[string]any{"id": post.GetID()}},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	text := rpcResultText(t, respBytes)
+	if !strings.Contains(text, "Copy of Template") || !strings.Contains(text, `"status":"draft"`) {
+		t.Fatalf("Expected a draft copy. Got: %s", text)
+	}
+}
+
+func Test_MCP_PostUpsertMetas(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		MetaSchema: blogstore.NewMetaSchema(
+			blogstore.MetaField{Key: "layout", AllowedValues: []string{"wide", "narrow"}},
+		),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	upsert := func(layout string) []byte {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name":      "post_upsert",
+				"arguments": map[string]any{"title": "Layout " + layout, "metas": map[string]any{"layout": layout}},
+			},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return respBytes
+	}
+
+	respBytes := upsert("tall")
+	var rpcResp map[string]any
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	rpcErr, ok := rpcResp["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected error response, got: %s", string(respBytes))
+	}
+	if code, _ := rpcErr["code"].(float64); code != -32602 {
+		t.Fatalf("Expected invalid params code -32602, got: %s", string(respBytes))
+	}
+	if !strings.Contains(rpcErr["message"].(string), "layout") {
+		t.Fatalf("Expected error to mention layout, got: %s", string(respBytes))
+	}
+
+	upsert("wide")
+	posts, err := store.PostList(context.Background(), blogstore.PostQueryOptions{
+		MetaEquals: map[string]string{"layout": "wide"},
+	})
+	if err != nil {
+		t.Fatalf("PostList() error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("Expected the post with the wide layout, got %d posts", len(posts))
+	}
+}
+
+func Test_MCP_PostUpsertExpiresAt(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	upsert := func(expiresAt any) map[string]any {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name":      "post_upsert",
+				"arguments": map[string]any{"title": "Sale", "expires_at": expiresAt},
+			},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		var rpcResp map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return rpcResp
+	}
+
+	for _, expiresAt := range []any{true, 1767225600, "next tuesday"} {
+		rpcResp := upsert(expiresAt)
+		rpcErr, ok := rpcResp["error"].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected error response for expires_at %v, got: %v", expiresAt, rpcResp)
+		}
+		if code, _ := rpcErr["code"].(float64); code != -32602 {
+			t.Fatalf("Expected invalid params code -32602 for expires_at %v, got: %v", expiresAt, rpcErr)
+		}
+	}
+
+	count, err := store.PostCount(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("PostCount() error: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("Expected no post to be created with an invalid expiry, got %d", count)
+	}
+
+	if rpcResp := upsert("2026-12-31 23:59:59"); rpcResp["error"] != nil {
+		t.Fatalf("Expected a valid expiry to be accepted, got: %v", rpcResp)
+	}
+}
+
+func Test_MCP_PostUpsertSanitizesHTML(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		Sanitizer:          blogstore.NewHTMLSanitizer(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name": "post_upsert",
+			"arguments": map[string]any{
+				"title":        "Generated",
+				"content":      `<p>Summary</p><img src="x" onerror="alert(1)">`,
+				"content_type": "html",
+			},
+		},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	if text := rpcResultText(t, respBytes); strings.Contains(text, "onerror") {
+		t.Fatalf("Expected the content to be sanitized. Got: %s", text)
+	}
+
+	posts, err := store.PostList(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("PostList() error: %v", err)
+	}
+	if len(posts) != 1 || posts[0].GetContent() != `<p>Summary</p><img src="x">` {
+		t.Fatalf("Expected the sanitized content to be stored, got: %v", posts)
+	}
+}
+
+func Test_MCP_PostSearch(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+		TaxonomyEnabled:    true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	ctx := context.Background()
+
+	category := blogstore.NewTaxonomy().SetName("Categories").SetSlug(blogstore.TAXONOMY_CATEGORY)
+	tag := blogstore.NewTaxonomy().SetName("Tags").SetSlug(blogstore.TAXONOMY_TAG)
+	for _, taxonomy := range []blogstore.TaxonomyInterface{category, tag} {
+		if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+			t.Fatalf("TaxonomyCreate() error: %v", err)
+		}
+	}
+	news := blogstore.NewTerm().SetTaxonomyID(category.GetID()).SetName("News").SetSlug("news")
+	golang := blogstore.NewTerm().SetTaxonomyID(tag.GetID()).SetName("Go").SetSlug("go")
+	for _, term := range []blogstore.TermInterface{news, golang} {
+		if err := store.TermCreate(ctx, term); err != nil {
+			t.Fatalf("TermCreate() error: %v", err)
+		}
+	}
+
+	draft := blogstore.NewPost().SetTitle("Draft").SetAuthorID("alice").SetFeatured("yes").SetStatus(blogstore.POST_STATUS_DRAFT).SetContent("Long content")
+	aliceLive := blogstore.NewPost().SetTitle("Alice live").SetAuthorID("alice").SetStatus(blogstore.POST_STATUS_PUBLISHED).SetSummary("By Alice")
+	bobLive := blogstore.NewPost().SetTitle("Bob live").SetAuthorID("bob").SetFeatured("yes").SetStatus(blogstore.POST_STATUS_PUBLISHED)
+	for _, post := range []blogstore.PostInterface{draft, aliceLive, bobLive} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatalf("PostCreate() error: %v", err)
+		}
+	}
+	for _, relation := range [][2]string{{draft.GetID(), news.GetID()}, {draft.GetID(), golang.GetID()}, {bobLive.GetID(), golang.GetID()}} {
+		if err := store.PostAddTerm(ctx, relation[0], relation[1]); err != nil {
+			t.Fatalf("PostAddTerm() error: %v", err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	search := func(arguments map[string]any) []byte {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": "post_search", "arguments": arguments},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return respBytes
+	}
+
+	titles := func(arguments map[string]any) ([]string, float64) {
+		var result struct {
+			Items []map[string]string `json:"items"`
+			Total float64             `json:"total"`
+		}
+		text := rpcResultText(t, search(arguments))
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			t.Fatalf("Failed to parse result: %v. Text=%s", err, text)
+		}
+		titles := []string{}
+		for _, item := range result.Items {
+			if _, ok := item["content"]; ok || len(item) != 4 {
+				t.Fatalf("Expected only id, title, summary and status, got: %v", item)
+			}
+			titles = append(titles, item["title"])
+		}
+		return titles, result.Total
+	}
+
+	got, total := titles(map[string]any{"status_in": []string{"published"}, "author_id": "alice"})
+	if fmt.Sprint(got) != "[Alice live]" || total != 1 {
+		t.Fatalf("status_in and author_id = %v (total %v), want [Alice live]", got, total)
+	}
+
+	got, _ = titles(map[string]any{"featured": "yes", "order_by": "title", "sort_order": "asc"})
+	if fmt.Sprint(got) != "[Bob live Draft]" {
+		t.Fatalf("featured = %v, want [Bob live Draft]", got)
+	}
+
+	got, _ = titles(map[string]any{"category": "news"})
+	if fmt.Sprint(got) != "[Draft]" {
+		t.Fatalf("category = %v, want [Draft]", got)
+	}
+
+	got, total = titles(map[string]any{"tags": []string{"go"}, "order_by": "title", "sort_order": "asc", "limit": 1})
+	if fmt.Sprint(got) != "[Bob live]" || total != 2 {
+		t.Fatalf("tags = %v (total %v), want [Bob live] of 2", got, total)
+	}
+
+	respBytes := search(map[string]any{"tags": []string{"rust"}})
+	if !strings.Contains(string(respBytes), "tag not found: rust") {
+		t.Fatalf("Expected an unknown tag error, got: %s", string(respBytes))
+	}
+
+	got, _ = titles(map[string]any{"created_after": "2000-01-01 00:00:00", "published_before": "2999-01-01"})
+	if len(got) != 3 {
+		t.Fatalf("created_after and published_before = %v, want the 3 posts", got)
+	}
+
+	for _, key := range []string{"created_after", "created_before", "published_after", "published_before"} {
+		var rpcResp map[string]any
+		if err := json.Unmarshal(search(map[string]any{key: "2024' OR '1'='1"}), &rpcResp); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		rpcErr, ok := rpcResp["error"].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected an error for an invalid %s, got: %v", key, rpcResp)
+		}
+		if code, _ := rpcErr["code"].(float64); code != -32602 {
+			t.Fatalf("Expected invalid params code -32602 for %s, got: %v", key, rpcErr)
+		}
+	}
+
+	for i := 0; i < 101; i++ {
+		if err := store.PostCreate(ctx, blogstore.NewPost().SetTitle("Filler")); err != nil {
+			t.Fatalf("PostCreate() error: %v", err)
+		}
+	}
+	got, total = titles(map[string]any{"limit": 1000})
+	if len(got) != 100 || total != 104 {
+		t.Fatalf("limit 1000 returned %d posts (total %v), want 100 of 104", len(got), total)
+	}
+}
+
+func Test_MCP_PostRevert(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:       "blog_posts",
+		DB:                  initDB(t),
+		AutomigrateEnabled:  true,
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	ctx := context.Background()
+	post := blogstore.NewPost().SetTitle("Good title").SetContent("Good content")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	versions, err := store.VersioningList(ctx, blogstore.NewVersioningQuery().SetEntityID(post.GetID()))
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("Expected the version of the created post, got %d, %v", len(versions), err)
+	}
+	post.SetTitle("Bad title").SetContent("Bad edit")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatalf("Failed to update post: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(mcp.NewMCP(store).Handler))
+	defer server.Close()
+
+	revert := func(arguments map[string]any) []byte {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": "post_revert", "arguments": arguments},
+		})
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return respBytes
+	}
+
+	if resp := revert(map[string]any{"id": post.GetID()}); !strings.Contains(string(resp), "version_id is required") {
+		t.Fatalf("Expected a missing version_id error, got: %s", string(resp))
+	}
+
+	text := rpcResultText(t, revert(map[string]any{"id": post.GetID(), "version_id": versions[0].ID()}))
+	var restored map[string]string
+	if err := json.Unmarshal([]byte(text), &restored); err != nil {
+		t.Fatalf("Failed to parse result: %v. Text=%s", err, text)
+	}
+	if restored["title"] != "Good title" || restored["content"] != "Good content" {
+		t.Fatalf("Expected the restored fields, got: %s", text)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatalf("PostFindByID() error: %v", err)
+	}
+	if found == nil || found.GetTitle() != "Good title" {
+		t.Fatalf("Expected the post to be reverted, got: %v", found)
+	}
+}
+
+func Test_MCP_PostBulkUpsert(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	existing := blogstore.NewPost().SetTitle("Old title")
+	if err := store.PostCreate(ctx, existing); err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name": "post_bulk_upsert",
+			"arguments": map[string]any{
+				"posts": []any{
+					map[string]any{"title": "Migrated 1", "content": "One"},
+					map[string]any{"id": existing.GetID(), "title": "New title"},
+					map[string]any{"title": "Bad", "featured": "maybe"},
+					"not a post",
+					map[string]any{"title": "Migrated 2"},
+				},
+			},
+		},
+	})
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	text := rpcResultText(t, respBytes)
+	var result struct {
+		Results []struct {
+			Index  int    `json:"index"`
+			ID     string `json:"id"`
+			Action string `json:"action"`
+			Error  string `json:"error"`
+		} `json:"results"`
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		t.Fatalf("Failed to parse result: %v. Text=%s", err, text)
+	}
+	if len(result.Results) != 5 || result.Succeeded != 3 || result.Failed != 2 {
+		t.Fatalf("Expected 3 saved and 2 failed posts, got: %s", text)
+	}
+
+	actions := []string{}
+	for i, r := range result.Results {
+		if r.Index != i {
+			t.Fatalf("Expected results in order, got: %s", text)
+		}
+		if r.Error != "" {
+			actions = append(actions, "error")
+			continue
+		}
+		actions = append(actions, r.Action)
+	}
+	if fmt.Sprint(actions) != "[created updated error error created]" {
+		t.Fatalf("actions = %v, want [created updated error error created]", actions)
+	}
+	if !strings.Contains(result.Results[2].Error, "featured") {
+		t.Fatalf("Expected the featured error for the third post, got: %q", result.Results[2].Error)
+	}
+
+	count, err := store.PostCount(ctx, blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("PostCount() error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("PostCount() = %d, want 3", count)
+	}
+	updated, err := store.PostFindByID(ctx, existing.GetID())
+	if err != nil || updated == nil || updated.GetTitle() != "New title" {
+		t.Fatalf("Expected the existing post to be updated, got: %v, %v", updated, err)
+	}
+}
+
+func Test_MCP_RateLimit(t *testing.T) {
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(t),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	h := mcp.NewMCP(store).SetRateLimit(mcp.RateLimit{
+		Writes: 1,
+		Window: time.Hour,
+		ClientFromRequest: func(r *http.Request) string {
+			return r.Header.Get("X-Token")
+		},
+	})
+	server := httptest.NewServer(http.HandlerFunc(h.Handler))
+	defer server.Close()
+
+	call := func(token string, tool string, arguments map[string]any) (*http.Response, []byte) {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "1",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool, "arguments": arguments},
+		})
+		req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBuffer(body))
+		req.Header.Set("X-Token", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return resp, respBytes
+	}
+
+	_, respBytes := call("agent", "post_upsert", map[string]any{"title": "First"})
+	rpcResultText(t, respBytes)
+
+	resp, respBytes := call("agent", "post_upsert", map[string]any{"title": "Second"})
+	var rpcResp struct {
+		Error struct {
+			Code    int            `json:"code"`
+			Message string         `json:"message"`
+			Data    map[string]int `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if rpcResp.Error.Code != -32029 || !strings.Contains(rpcResp.Error.Message, "rate limit exceeded") {
+		t.Fatalf("Expected a rate limit error, got: %s", string(respBytes))
+	}
+	if seconds := rpcResp.Error.Data["retry_after_seconds"]; seconds < 3590 || seconds > 3600 {
+		t.Fatalf("retry_after_seconds = %d, want about an hour", seconds)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Fatal("Expected a Retry-After header")
+	}
+
+	_, respBytes = call("agent", "post_list", map[string]any{})
+	if text := rpcResultText(t, respBytes); !strings.Contains(text, "First") {
+		t.Fatalf("Expected reads to stay allowed, got: %s", text)
+	}
+
+	_, respBytes = call("other-agent", "post_upsert", map[string]any{"title": "Other"})
+	rpcResultText(t, respBytes)
+
+	_, respBytes = call("bulk-agent", "post_bulk_upsert", map[string]any{"posts": []any{
+		map[string]any{"title": "Bulk 1"},
+		map[string]any{"title": "Bulk 2"},
+	}})
+	rpcResp.Error.Code = 0
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if rpcResp.Error.Code != -32602 {
+		t.Fatalf("Expected a bulk upsert over the write limit to be refused, got: %s", string(respBytes))
+	}
+
+	count, err := store.PostCount(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("PostCount() error: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("PostCount() = %d, want 2", count)
+	}
+}
+
+func Test_MCP_BatchRequest(t *testing.T) {
+	server, store, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	body := `[
+		{"jsonrpc": "2.0", "id": 1, "method": "tools/list"},
+		{"jsonrpc": "2.0", "id": "create", "method": "tools/call", "params": {"name": "post_upsert", "arguments": {"title": "Batched"}}},
+		{"jsonrpc": "2.0", "method": "notifications/initialized"},
+		{"jsonrpc": "2.0", "method": "tools/call", "params": {"name": "post_upsert", "arguments": {"title": "Notified"}}},
+		{"jsonrpc": "2.0", "id": 3, "method": "unknown"},
+		42
+	]`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(resp.Body)
+
+	var responses []map[string]any
+	if err := json.Unmarshal(respBytes, &responses); err != nil {
+		t.Fatalf("Expected an array of responses: %v. Body=%s", err, string(respBytes))
+	}
+	if len(responses) != 4 {
+		t.Fatalf("Expected 4 responses, notifications excluded, got: %s", string(respBytes))
+	}
+
+	if responses[0]["id"] != float64(1) || responses[0]["result"] == nil {
+		t.Fatalf("Expected the tools/list result with id 1, got: %v", responses[0])
+	}
+	if responses[1]["id"] != "create" || !strings.Contains(fmt.Sprint(responses[1]["result"]), "Batched") {
+		t.Fatalf("Expected the post_upsert result with id create, got: %v", responses[1])
+	}
+	if rpcErr, _ := responses[2]["error"].(map[string]any); responses[2]["id"] != float64(3) || rpcErr["code"] != float64(-32601) {
+		t.Fatalf("Expected method not found with id 3, got: %v", responses[2])
+	}
+	if rpcErr, _ := responses[3]["error"].(map[string]any); responses[3]["id"] != nil || rpcErr["code"] != float64(-32600) {
+		t.Fatalf("Expected an invalid request error for the number, got: %v", responses[3])
+	}
+
+	count, err := store.PostCount(context.Background(), blogstore.PostQueryOptions{})
+	if err != nil {
+		t.Fatalf("PostCount() error: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("PostCount() = %d, want 2: notifications are run too", count)
+	}
+}
+
+func Test_MCP_BatchRequest_EdgeCases(t *testing.T) {
+	server, _, cleanup := initMCPServerWithStore(t)
+	defer cleanup()
+
+	post := func(body string) string {
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		respBytes, _ := io.ReadAll(resp.Body)
+		return string(respBytes)
+	}
+
+	if resp := post(`[]`); !strings.Contains(resp, "-32600") {
+		t.Fatalf("Expected an invalid request error for an empty batch, got: %s", resp)
+	}
+	if resp := post(`[{"jsonrpc": "2.0", "method": "notifications/initialized"}]`); resp != "" {
+		t.Fatalf("Expected no content for a batch of notifications, got: %s", resp)
+	}
+	if resp := post(`[{"jsonrpc": "2.0", "id": 1`); !strings.Contains(resp, "-32700") {
+		t.Fatalf("Expected a parse error, got: %s", resp)
+	}
+}
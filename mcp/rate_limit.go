@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitDefaultWindow is the window of the limits when
+// RateLimit.Window is not set.
+const rateLimitDefaultWindow = time.Minute
+
+// rateLimitErrorCode is the JSON-RPC error code of a refused tool call.
+const rateLimitErrorCode = -32029
+
+// RateLimit limits the tool calls each client can make, to protect the
+// database from runaway agent loops. Reads and writes are counted
+// separately, so a client stuck saving posts can still look them up.
+type RateLimit struct {
+	// Reads is the number of calls to read tools, e.g. post_list and
+	// post_get, a client can make per window. Zero means unlimited.
+	Reads int
+	// Writes is the number of calls to all other tools, e.g. post_upsert
+	// and post_delete, a client can make per window. A post_bulk_upsert call
+	// counts once per post. Zero means unlimited.
+	Writes int
+	// Window is the period the limits apply to. Defaults to a minute.
+	Window time.Duration
+	// ClientFromRequest identifies the client, e.g. by its API token.
+	// Defaults to the remote IP address; set it when the server is behind
+	// a proxy.
+	ClientFromRequest func(r *http.Request) string
+}
+
+// SetRateLimit limits the tool calls of each client. Calls over the limit
+// are refused with a JSON-RPC error telling when to retry.
+func (m *MCP) SetRateLimit(limit RateLimit) *MCP {
+	if limit.Window <= 0 {
+		limit.Window = rateLimitDefaultWindow
+	}
+	m.rateLimiter = &rateLimiter{limit: limit, readTools: m.readOnlyTools(), windows: map[string]*rateWindow{}, now: time.Now}
+	return m
+}
+
+// readOnlyTools returns the names of the tools annotated as read only. Any
+// other tool counts as a write.
+func (m *MCP) readOnlyTools() map[string]bool {
+	names := map[string]bool{}
+	for _, tool := range m.tools() {
+		annotations, _ := tool["annotations"].(map[string]any)
+		if readOnly, _ := annotations["readOnlyHint"].(bool); readOnly {
+			names[tool["name"].(string)] = true
+		}
+	}
+	return names
+}
+
+// toolCallWeight returns the number of calls a tool call counts as: one per
+// post of post_bulk_upsert, so bulk calls cannot get around the write limit,
+// and one for any other call.
+func toolCallWeight(toolName string, args map[string]any) int {
+	if toolName == "post_bulk_upsert" {
+		if posts, ok := args["posts"].([]any); ok && len(posts) > 1 {
+			return len(posts)
+		}
+	}
+	return 1
+}
+
+// rateLimiter counts the tool calls of each client in fixed windows.
+type rateLimiter struct {
+	limit     RateLimit
+	readTools map[string]bool
+	now       func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// rateWindow is the number of calls made since start.
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// client returns the key identifying the client of the request.
+func (l *rateLimiter) client(r *http.Request) string {
+	if l.limit.ClientFromRequest != nil {
+		return l.limit.ClientFromRequest(r)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// allow counts a call to the tool by the client, as the given number of
+// calls. When the client would go over its limit the call is not counted,
+// and the time until the window ends is returned. A call counting as more
+// than the whole limit is refused with an error, as it can never be allowed.
+func (l *rateLimiter) allow(client string, toolName string, calls int) (bool, time.Duration, error) {
+	class, limit := "write", l.limit.Writes
+	if l.readTools[toolName] {
+		class, limit = "read", l.limit.Reads
+	}
+	if limit <= 0 {
+		return true, 0, nil
+	}
+	if calls > limit {
+		return false, 0, errors.New(toolName + " counts as " + strconv.Itoa(calls) + " calls, over the limit of " + strconv.Itoa(limit) + " " + class + "s per window")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	key := class + " " + client
+	window, ok := l.windows[key]
+	if !ok || now.Sub(window.start) >= l.limit.Window {
+		window = &rateWindow{start: now}
+		l.windows[key] = window
+	}
+
+	if window.count+calls > limit {
+		return false, window.start.Add(l.limit.Window).Sub(now), nil
+	}
+	window.count += calls
+	return true, 0, nil
+}
+
+// sweep forgets the windows that ended, at most once per window.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limit.Window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, window := range l.windows {
+		if now.Sub(window.start) >= l.limit.Window {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dracory/blogstore"
+	_ "modernc.org/sqlite"
+)
+
+// newTestMCP returns an MCP over an in memory store; the tool schemas read
+// the post statuses from the store.
+func newTestMCP(t *testing.T) *MCP {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:?parseTime=true")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := blogstore.NewStore(blogstore.NewStoreOptions{
+		PostTableName:      "test_posts",
+		DB:                 db,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return NewMCP(store)
+}
+
+func TestRateLimiterWindows(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestMCP(t).SetRateLimit(RateLimit{Reads: 2, Writes: 1, Window: time.Minute})
+	limiter := m.rateLimiter
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _, _ := limiter.allow("agent", "post_get", 1); !ok {
+			t.Fatalf("read %d refused, want allowed", i+1)
+		}
+	}
+	if ok, _, _ := limiter.allow("agent", "post_list", 1); ok {
+		t.Fatal("third read allowed, want refused")
+	}
+
+	if ok, _, _ := limiter.allow("agent", "post_upsert", 1); !ok {
+		t.Fatal("first write refused, want allowed: reads and writes are counted separately")
+	}
+	now = now.Add(20 * time.Second)
+	ok, retryAfter, _ := limiter.allow("agent", "post_delete", 1)
+	if ok {
+		t.Fatal("second write allowed, want refused")
+	}
+	if retryAfter != 40*time.Second {
+		t.Fatalf("retryAfter = %v, want 40s", retryAfter)
+	}
+
+	if ok, _, _ := limiter.allow("other", "post_upsert", 1); !ok {
+		t.Fatal("write of another client refused, want allowed")
+	}
+
+	now = now.Add(40 * time.Second)
+	if ok, _, _ := limiter.allow("agent", "post_delete", 1); !ok {
+		t.Fatal("write in the next window refused, want allowed")
+	}
+
+	now = now.Add(time.Minute)
+	limiter.allow("agent", "post_get", 1)
+	if _, ok := limiter.windows["write other"]; ok {
+		t.Fatal("expected the ended window of the other client to be forgotten")
+	}
+}
+
+func TestRateLimiterUnlimitedClass(t *testing.T) {
+	limiter := newTestMCP(t).SetRateLimit(RateLimit{Writes: 1}).rateLimiter
+	if limiter.limit.Window != time.Minute {
+		t.Fatalf("Window = %v, want the default of a minute", limiter.limit.Window)
+	}
+
+	for i := 0; i < 10; i++ {
+		if ok, _, _ := limiter.allow("agent", "post_search", 1); !ok {
+			t.Fatal("read refused, want reads unlimited")
+		}
+	}
+}
+
+func TestRateLimiterBulkUpsertWeight(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newTestMCP(t).SetRateLimit(RateLimit{Writes: 5}).rateLimiter
+	limiter.now = func() time.Time { return now }
+
+	posts := map[string]any{"posts": []any{map[string]any{}, map[string]any{}, map[string]any{}}}
+	if weight := toolCallWeight("post_bulk_upsert", posts); weight != 3 {
+		t.Fatalf("toolCallWeight() = %d, want one per post", weight)
+	}
+	if weight := toolCallWeight("post_upsert", posts); weight != 1 {
+		t.Fatalf("toolCallWeight() = %d, want 1 for other tools", weight)
+	}
+
+	if ok, _, err := limiter.allow("agent", "post_bulk_upsert", 3); !ok || err != nil {
+		t.Fatalf("bulk upsert of 3 posts refused (%v), want allowed", err)
+	}
+	if ok, _, _ := limiter.allow("agent", "post_bulk_upsert", 3); ok {
+		t.Fatal("bulk upsert going over the limit allowed, want refused")
+	}
+	if ok, _, _ := limiter.allow("agent", "post_upsert", 1); !ok {
+		t.Fatal("write within the limit refused, want allowed")
+	}
+
+	if _, _, err := limiter.allow("other", "post_bulk_upsert", 6); err == nil {
+		t.Fatal("bulk upsert over the whole limit accepted, want an error")
+	}
+}
+
+func TestRateLimiterReadOnlyTools(t *testing.T) {
+	readTools := newTestMCP(t).readOnlyTools()
+
+	for _, name := range []string{"blog_schema", "post_list", "post_search", "post_get", "post_versions", "taxonomy_list", "term_list", "post_get_terms", "post_stats", "blog_stats"} {
+		if !readTools[name] {
+			t.Errorf("%s is not annotated as read only", name)
+		}
+	}
+	for _, name := range []string{"post_upsert", "post_bulk_upsert", "post_delete", "post_revert", "term_create", "post_approve"} {
+		if readTools[name] {
+			t.Errorf("%s is annotated as read only, want a write", name)
+		}
+	}
+}
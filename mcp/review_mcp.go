@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/dracory/blogstore"
+)
+
+// ============================ REVIEW TOOLS ============================
+
+func (m *MCP) reviewTools() []map[string]any {
+	return []map[string]any{
+		{
+			"name":        "post_submit_for_review",
+			"description": "Submit a blog post for editorial review (status pending_review)",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id"},
+				"properties": map[string]any{
+					"id": map[string]any{"type": "string"},
+				},
+			},
+		},
+		{
+			"name":        "post_approve",
+			"description": "Approve and publish a blog post pending review",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id"},
+				"properties": map[string]any{
+					"id":          map[string]any{"type": "string"},
+					"reviewer_id": map[string]any{"type": "string", "description": "Reviewer ID, used only when the request has no acting user"},
+				},
+			},
+		},
+		{
+			"name":        "post_reject",
+			"description": "Reject a blog post pending review, returning it to draft",
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id", "reason"},
+				"properties": map[string]any{
+					"id":     map[string]any{"type": "string"},
+					"reason": map[string]any{"type": "string", "description": "Why the post was rejected, shown to the author"},
+				},
+			},
+		},
+	}
+}
+
+// reviewToolDispatch routes review tool calls to their handlers
+func (m *MCP) reviewToolDispatch(ctx context.Context, toolName string, args map[string]any) (string, error) {
+	id := argString(args, "id")
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id is required")
+	}
+
+	var err error
+	switch toolName {
+	case "post_submit_for_review":
+		err = m.store.PostSubmitForReview(ctx, id)
+	case "post_approve":
+		// The authenticated actor always wins, so a caller cannot record
+		// someone else as the reviewer.
+		reviewerID := blogstore.ActorFromContext(ctx)
+		if reviewerID == "" {
+			reviewerID = argString(args, "reviewer_id")
+		}
+		if reviewerID == "" {
+			return "", errors.New("reviewer_id is required")
+		}
+		err = m.store.PostApprove(ctx, id, reviewerID)
+	case "post_reject":
+		reason := argString(args, "reason")
+		if strings.TrimSpace(reason) == "" {
+			return "", errors.New("reason is required")
+		}
+		err = m.store.PostReject(ctx, id, reason)
+	default:
+		return "", errors.New("unknown review tool")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	post, err := m.store.PostFindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	b, _ := json.Marshal(postToMap(post))
+	return string(b), nil
+}
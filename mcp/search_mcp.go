@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/dracory/blogstore"
+	"github.com/dromara/carbon/v2"
+)
+
+// ============================ SEARCH TOOLS ============================
+
+// searchDefaultLimit is the number of posts returned by post_search when
+// the limit argument is not given.
+const searchDefaultLimit = 20
+
+// searchMaxLimit is the largest number of posts post_search returns in one
+// call; larger limits are lowered to it.
+const searchMaxLimit = 100
+
+func (m *MCP) searchTools() []map[string]any {
+	return []map[string]any{
+		{
+			"name":        "post_search",
+			"description": "Find blog posts by status, author, featured flag, dates, category and tags. Returns only the id, title, summary and status of each post; use post_get for the full post",
+			"annotations": readOnlyAnnotations(),
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"search":           map[string]any{"type": "string", "description": "Search term for title/content"},
+					"status_in":        map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Statuses to include, e.g. [\"draft\", \"pending_review\"]"},
+					"author_id":        map[string]any{"type": "string"},
+					"featured":         map[string]any{"type": "string", "enum": []string{"yes", "no"}},
+					"created_after":    map[string]any{"type": "string", "description": "Only posts created after this timestamp"},
+					"created_before":   map[string]any{"type": "string", "description": "Only posts created before this timestamp"},
+					"published_after":  map[string]any{"type": "string", "description": "Only posts published after this timestamp"},
+					"published_before": map[string]any{"type": "string", "description": "Only posts published before this timestamp"},
+					"category":         map[string]any{"type": "string", "description": "Slug of a category term (requires taxonomy)"},
+					"tags":             map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Slugs of tag terms; posts with any of the tags match (requires taxonomy)"},
+					"order_by":         map[string]any{"type": "string", "description": "Column to order by, e.g. created_at, published_at, title"},
+					"sort_order":       map[string]any{"type": "string", "enum": []string{"asc", "desc"}, "description": "Sort order (default: desc)"},
+					"limit":            map[string]any{"type": "integer", "description": "Maximum number of posts to return (default: 20, at most 100)"},
+					"offset":           map[string]any{"type": "integer"},
+				},
+			},
+		},
+	}
+}
+
+// toolPostSearch lists the posts matching the filters, trimmed to the
+// fields needed to locate a post
+func (m *MCP) toolPostSearch(ctx context.Context, args map[string]any) (string, error) {
+	for _, key := range []string{"created_after", "created_before", "published_after", "published_before"} {
+		if v := argString(args, key); v != "" && carbon.Parse(v, carbon.UTC).IsInvalid() {
+			return "", &argError{key + " is not a valid timestamp: " + v}
+		}
+	}
+
+	opts := blogstore.PostQueryOptions{
+		Search:                 argString(args, "search"),
+		StatusIn:               argStrings(args, "status_in"),
+		AuthorID:               argString(args, "author_id"),
+		CreatedAtGreaterThan:   argString(args, "created_after"),
+		CreatedAtLessThan:      argString(args, "created_before"),
+		PublishedAtGreaterThan: argString(args, "published_after"),
+		PublishedAtLessThan:    argString(args, "published_before"),
+		OrderBy:                argString(args, "order_by"),
+		SortOrder:              argString(args, "sort_order"),
+		Columns:                []string{blogstore.COLUMN_TITLE, blogstore.COLUMN_SUMMARY, blogstore.COLUMN_STATUS},
+	}
+
+	if v := argString(args, "featured"); v != "" {
+		if v != blogstore.YES && v != blogstore.NO {
+			return "", errors.New("featured field must be 'yes' or 'no', not boolean true/false")
+		}
+		opts.Featured = v
+	}
+
+	category := argString(args, "category")
+	tags := argStrings(args, "tags")
+	if strings.TrimSpace(category) != "" || len(tags) > 0 {
+		if !m.store.TaxonomyEnabled() {
+			return "", errors.New("taxonomy is not enabled")
+		}
+	}
+	if strings.TrimSpace(category) != "" {
+		term, err := m.findTerm(ctx, blogstore.TAXONOMY_CATEGORY, category)
+		if err != nil {
+			return "", err
+		}
+		opts.TermID = term.GetID()
+	}
+	for _, tag := range tags {
+		term, err := m.findTerm(ctx, blogstore.TAXONOMY_TAG, tag)
+		if err != nil {
+			return "", err
+		}
+		opts.TermIDIn = append(opts.TermIDIn, term.GetID())
+	}
+
+	total, err := m.store.PostCount(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+
+	opts.Limit = searchDefaultLimit
+	if v, ok := argInt(args, "limit"); ok && v > 0 {
+		opts.Limit = min(v, searchMaxLimit)
+	}
+	if v, ok := argInt(args, "offset"); ok {
+		opts.Offset = v
+	}
+
+	list, err := m.store.PostList(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+
+	items := make([]map[string]string, 0, len(list))
+	for _, post := range list {
+		items = append(items, map[string]string{
+			"id":      blogstore.ShortenID(post.GetID()),
+			"title":   post.GetTitle(),
+			"summary": post.GetSummary(),
+			"status":  post.GetStatus(),
+		})
+	}
+
+	b, _ := json.Marshal(map[string]any{"items": items, "total": total})
+	return string(b), nil
+}
+
+// findTerm returns the term with the slug in the taxonomy
+func (m *MCP) findTerm(ctx context.Context, taxonomySlug string, termSlug string) (blogstore.TermInterface, error) {
+	term, err := m.store.TermFindBySlug(ctx, taxonomySlug, termSlug)
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, errors.New(taxonomySlug + " not found: " + termSlug)
+	}
+	return term, nil
+}
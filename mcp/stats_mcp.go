@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dracory/blogstore"
+)
+
+// ============================ STATS TOOLS ============================
+
+// statsDefaultDays is the number of days covered by the view numbers when
+// the days argument is not given.
+const statsDefaultDays = 30
+
+// statsDefaultTop is the number of top posts returned by blog_stats when
+// the top argument is not given.
+const statsDefaultTop = 10
+
+func (m *MCP) statsTools() []map[string]any {
+	return []map[string]any{
+		{
+			"name":        "post_stats",
+			"description": "Get the views of a blog post per day over the last days (requires view counting)",
+			"annotations": readOnlyAnnotations(),
+			"inputSchema": map[string]any{
+				"type":     "object",
+				"required": []string{"id"},
+				"properties": map[string]any{
+					"id":   map[string]any{"type": "string"},
+					"days": map[string]any{"type": "integer", "description": "Number of days up to today (default: 30)"},
+				},
+			},
+		},
+		{
+			"name":        "blog_stats",
+			"description": "Get blog statistics: post counts by status, author and month, and, when views are counted, daily views and the top posts over the last days",
+			"annotations": readOnlyAnnotations(),
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"days": map[string]any{"type": "integer", "description": "Number of days up to today (default: 30)"},
+					"top":  map[string]any{"type": "integer", "description": "Number of top posts (default: 10)"},
+				},
+			},
+		},
+	}
+}
+
+// statsToolDispatch routes stats tool calls to their handlers
+func (m *MCP) statsToolDispatch(ctx context.Context, toolName string, args map[string]any) (string, error) {
+	switch toolName {
+	case "post_stats":
+		return m.toolPostStats(ctx, args)
+	case "blog_stats":
+		return m.toolBlogStats(ctx, args)
+	default:
+		return "", errors.New("unknown stats tool")
+	}
+}
+
+// toolPostStats returns the daily views of a post
+func (m *MCP) toolPostStats(ctx context.Context, args map[string]any) (string, error) {
+	id := argString(args, "id")
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id is required")
+	}
+
+	if !m.store.ViewsEnabled() {
+		return "", errors.New("views are not enabled")
+	}
+
+	post, err := m.store.PostFindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if post == nil {
+		return "", errors.New("post not found")
+	}
+
+	from, to := statsRange(args)
+	days, err := m.store.PostViewsByDay(ctx, post.GetID(), from, to)
+	if err != nil {
+		return "", err
+	}
+
+	b, _ := json.Marshal(map[string]any{
+		"id":           post.GetID(),
+		"title":        post.GetTitle(),
+		"status":       post.GetStatus(),
+		"from":         from.Format(time.DateOnly),
+		"to":           to.Format(time.DateOnly),
+		"views_total":  viewsTotal(days),
+		"views_by_day": viewsByDay(days),
+	})
+	return string(b), nil
+}
+
+// toolBlogStats returns the post counts and, when views are counted,
+// the daily views and top posts
+func (m *MCP) toolBlogStats(ctx context.Context, args map[string]any) (string, error) {
+	stats, err := m.store.Stats(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]any{
+		"total_posts":     stats.TotalPosts,
+		"posts_by_status": stats.PostsByStatus,
+		"posts_by_author": stats.PostsByAuthor,
+		"posts_by_month":  stats.PostsByMonth,
+		"total_versions":  stats.TotalVersions,
+		"generated_at":    stats.GeneratedAt.UTC().Format(time.DateTime),
+		"views_enabled":   m.store.ViewsEnabled(),
+	}
+
+	if m.store.ViewsEnabled() {
+		from, to := statsRange(args)
+
+		days, err := m.store.PostViewsByDay(ctx, "", from, to)
+		if err != nil {
+			return "", err
+		}
+
+		limit, ok := argInt(args, "top")
+		if !ok || limit <= 0 {
+			limit = statsDefaultTop
+		}
+		totals, err := m.store.PostViewsTop(ctx, from, to, limit)
+		if err != nil {
+			return "", err
+		}
+
+		top := make([]map[string]any, 0, len(totals))
+		for _, total := range totals {
+			entry := map[string]any{"id": total.PostID, "views": total.Views}
+			if post, err := m.store.PostFindByID(ctx, total.PostID); err == nil && post != nil {
+				entry["title"] = post.GetTitle()
+				entry["status"] = post.GetStatus()
+			}
+			top = append(top, entry)
+		}
+
+		result["from"] = from.Format(time.DateOnly)
+		result["to"] = to.Format(time.DateOnly)
+		result["views_total"] = viewsTotal(days)
+		result["views_by_day"] = viewsByDay(days)
+		result["top_posts"] = top
+	}
+
+	b, _ := json.Marshal(result)
+	return string(b), nil
+}
+
+// statsRange returns the first and last day covered by the days argument,
+// ending today.
+func statsRange(args map[string]any) (time.Time, time.Time) {
+	days, ok := argInt(args, "days")
+	if !ok || days <= 0 {
+		days = statsDefaultDays
+	}
+
+	to := time.Now().UTC()
+	return to.AddDate(0, 0, -(days - 1)), to
+}
+
+func viewsTotal(days []blogstore.PostViewsDay) int64 {
+	total := int64(0)
+	for _, day := range days {
+		total += day.Views
+	}
+	return total
+}
+
+func viewsByDay(days []blogstore.PostViewsDay) map[string]int64 {
+	result := map[string]int64{}
+	for _, day := range days {
+		result[day.Day] = day.Views
+	}
+	return result
+}
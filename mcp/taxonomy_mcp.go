@@ -16,6 +16,7 @@ func (m *MCP) taxonomyTools() []map[string]any {
 		{
 			"name":        "taxonomy_list",
 			"description": "List available taxonomy types (category, tag, etc.)",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -43,6 +44,7 @@ func (m *MCP) taxonomyTools() []map[string]any {
 		{
 			"name":        "term_list",
 			"description": "List terms within a taxonomy",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -98,6 +100,7 @@ func (m *MCP) taxonomyTools() []map[string]any {
 		{
 			"name":        "post_get_terms",
 			"description": "Get terms assigned to a post",
+			"annotations": readOnlyAnnotations(),
 			"inputSchema": map[string]any{
 				"type":     "object",
 				"required": []string{"post_id"},
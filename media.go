@@ -67,6 +67,11 @@ type MediaInterface interface {
 	// SetSequence sets the display sequence/order of the file.
 	SetSequence(sequence int) MediaInterface
 
+	// GetAltText returns the alternative text describing the media.
+	GetAltText() string
+	// SetAltText sets the alternative text describing the media.
+	SetAltText(altText string) MediaInterface
+
 	// GetStatus returns the current status.
 	GetStatus() string
 	// SetStatus sets the current status.
@@ -294,6 +299,23 @@ func (o *mediaImplementation) SetSequence(sequence int) MediaInterface {
 	return o
 }
 
+// GetAltText returns the alternative text describing the media, kept in
+// the MEDIA_META_ALT_TEXT meta.
+func (o *mediaImplementation) GetAltText() string {
+	return o.GetMeta(MEDIA_META_ALT_TEXT)
+}
+
+// SetAltText sets the alternative text describing the media. An empty text
+// removes it.
+func (o *mediaImplementation) SetAltText(altText string) MediaInterface {
+	if altText == "" {
+		_ = o.MetaRemove(MEDIA_META_ALT_TEXT)
+	} else {
+		_ = o.SetMeta(MEDIA_META_ALT_TEXT, altText)
+	}
+	return o
+}
+
 // GetStatus returns the current status.
 func (o *mediaImplementation) GetStatus() string {
 	return o.Status
@@ -78,6 +78,7 @@ func TestMediaSettersAndGetters(t *testing.T) {
 		SetSize("1024").
 		SetExtension("jpg").
 		SetSequence(5).
+		SetAltText("A test image").
 		SetStatus(MEDIA_STATUS_ACTIVE)
 
 	if got := m.GetID(); got != "media-123" {
@@ -113,6 +114,12 @@ func TestMediaSettersAndGetters(t *testing.T) {
 	if got := m.GetStatus(); got != MEDIA_STATUS_ACTIVE {
 		t.Errorf("GetStatus() = %q, want %q", got, MEDIA_STATUS_ACTIVE)
 	}
+	if got := m.GetAltText(); got != "A test image" {
+		t.Errorf("GetAltText() = %q, want %q", got, "A test image")
+	}
+	if got := m.SetAltText("").GetMeta(MEDIA_META_ALT_TEXT); got != "" {
+		t.Errorf("SetAltText(\"\") kept the meta %q", got)
+	}
 }
 
 // TestMediaTimestamps tests timestamp setters and getters.
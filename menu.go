@@ -0,0 +1,80 @@
+package blogstore
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Menu is a navigation menu of the blog, e.g. the header or the footer
+// navigation, holding the menu items.
+type Menu struct {
+	ID string
+	// Name identifies the menu to the templates, e.g. "header".
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MenuItem is a link of a menu, either to a URL or to a post. Items with a
+// parent are nested under it, e.g. in a dropdown.
+type MenuItem struct {
+	ID     string
+	MenuID string
+	// ParentID is the ID of the parent item, empty for top level items.
+	ParentID string
+	Label    string
+	// URL is the link target, used when PostID is empty.
+	URL string
+	// PostID is the post linked to, resolved to its URL by the application.
+	PostID string
+	// Sequence is the position of the item among its siblings, lowest first.
+	Sequence  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MenuItemNode is a menu item with its sub-items, as returned by MenuTree.
+type MenuItemNode struct {
+	MenuItem
+	Children []MenuItemNode
+}
+
+// MenuQueryOptions defines query options for listing menus
+type MenuQueryOptions struct {
+	// IDIn filters by multiple menu IDs.
+	IDIn []string
+	// Name filters by the menu name.
+	Name string
+}
+
+// MarshalToVersioning serializes the menu for versioning storage.
+// Excludes the timestamp fields.
+func (menu *Menu) MarshalToVersioning() (string, error) {
+	b, err := json.Marshal(map[string]string{
+		COLUMN_ID:   menu.ID,
+		COLUMN_NAME: menu.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// MarshalToVersioning serializes the menu item for versioning storage.
+// Excludes the timestamp fields.
+func (item *MenuItem) MarshalToVersioning() (string, error) {
+	b, err := json.Marshal(map[string]string{
+		COLUMN_ID:        item.ID,
+		COLUMN_MENU_ID:   item.MenuID,
+		COLUMN_PARENT_ID: item.ParentID,
+		COLUMN_LABEL:     item.Label,
+		COLUMN_URL:       item.URL,
+		COLUMN_POST_ID:   item.PostID,
+		COLUMN_SEQUENCE:  strconv.Itoa(item.Sequence),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
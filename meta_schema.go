@@ -0,0 +1,147 @@
+package blogstore
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// Meta value types of a MetaField.
+const (
+	META_TYPE_STRING   = "string"
+	META_TYPE_INT      = "int"
+	META_TYPE_FLOAT    = "float"
+	META_TYPE_BOOL     = "bool"
+	META_TYPE_DATETIME = "datetime"
+	META_TYPE_JSON     = "json"
+)
+
+// MetaField describes a meta key accepted by a MetaSchema.
+type MetaField struct {
+	// Key is the meta key.
+	Key string
+	// Type is one of the META_TYPE_* constants. Defaults to META_TYPE_STRING.
+	Type string
+	// AllowedValues restricts the value to this list, if not empty.
+	AllowedValues []string
+	// Required rejects posts without a non-empty value for the key.
+	Required bool
+}
+
+// MetaError is returned by PostCreate and PostUpdate when the metas of a post
+// do not match the MetaSchema of the store.
+type MetaError struct {
+	// Key is the offending meta key.
+	Key string
+	// Reason describes what is wrong with the key or its value.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *MetaError) Error() string {
+	return "blogstore: invalid meta " + strconv.Quote(e.Key) + ": " + e.Reason
+}
+
+// MetaSchema lists the meta keys applications may set on posts, with their
+// types and allowed values. Set it with NewStoreOptions.MetaSchema. The metas
+// maintained by the store itself, the keys starting with an underscore,
+// "editor", "content_type" and the "term_ids_" keys, are always accepted.
+type MetaSchema struct {
+	fields map[string]MetaField
+}
+
+// NewMetaSchema creates a schema accepting the given fields.
+func NewMetaSchema(fields ...MetaField) *MetaSchema {
+	schema := &MetaSchema{fields: map[string]MetaField{}}
+	for _, field := range fields {
+		if field.Type == "" {
+			field.Type = META_TYPE_STRING
+		}
+		schema.fields[field.Key] = field
+	}
+	return schema
+}
+
+// Validate returns a MetaError for the first unknown key, malformed value or
+// missing required key of the metas, checked in key order.
+func (s *MetaSchema) Validate(metas map[string]string) error {
+	keys := make([]string, 0, len(metas))
+	for key := range metas {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if metaKeyBuiltIn(key) {
+			continue
+		}
+
+		field, ok := s.fields[key]
+		if !ok {
+			return &MetaError{Key: key, Reason: "unknown key"}
+		}
+
+		if err := field.validate(metas[key]); err != nil {
+			return err
+		}
+	}
+
+	required := []string{}
+	for key, field := range s.fields {
+		if field.Required && metas[key] == "" {
+			required = append(required, key)
+		}
+	}
+	if len(required) > 0 {
+		slices.Sort(required)
+		return &MetaError{Key: required[0], Reason: "required"}
+	}
+
+	return nil
+}
+
+// validate checks a value against the type and allowed values of the field.
+// Empty values are accepted, Required is checked by MetaSchema.Validate.
+func (f MetaField) validate(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	valid := true
+	switch f.Type {
+	case META_TYPE_INT:
+		_, err := strconv.ParseInt(value, 10, 64)
+		valid = err == nil
+	case META_TYPE_FLOAT:
+		_, err := strconv.ParseFloat(value, 64)
+		valid = err == nil
+	case META_TYPE_BOOL:
+		_, err := strconv.ParseBool(value)
+		valid = err == nil
+	case META_TYPE_DATETIME:
+		parsed := carbon.Parse(value, carbon.UTC)
+		valid = parsed.Error == nil && !parsed.IsInvalid()
+	case META_TYPE_JSON:
+		valid = json.Valid([]byte(value))
+	}
+	if !valid {
+		return &MetaError{Key: f.Key, Reason: "not a valid " + f.Type + ": " + strconv.Quote(value)}
+	}
+
+	if len(f.AllowedValues) > 0 && !slices.Contains(f.AllowedValues, value) {
+		return &MetaError{Key: f.Key, Reason: "must be one of " + strings.Join(f.AllowedValues, ", ")}
+	}
+
+	return nil
+}
+
+// metaKeyBuiltIn returns true for the meta keys maintained by the store.
+func metaKeyBuiltIn(key string) bool {
+	return strings.HasPrefix(key, "_") ||
+		strings.HasPrefix(key, "term_ids_") ||
+		key == "editor" ||
+		key == "content_type"
+}
@@ -0,0 +1,119 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMetaSchemaValidate(t *testing.T) {
+	schema := NewMetaSchema(
+		MetaField{Key: "reading_time", Type: META_TYPE_INT},
+		MetaField{Key: "rating", Type: META_TYPE_FLOAT},
+		MetaField{Key: "sponsored", Type: META_TYPE_BOOL},
+		MetaField{Key: "event_date", Type: META_TYPE_DATETIME},
+		MetaField{Key: "layout", AllowedValues: []string{"wide", "narrow"}},
+		MetaField{Key: "extra", Type: META_TYPE_JSON},
+		MetaField{Key: "audience", Required: true},
+	)
+
+	valid := map[string]string{
+		"audience":     "developers",
+		"reading_time": "5",
+		"rating":       "4.5",
+		"sponsored":    "true",
+		"event_date":   "2026-03-01 10:00:00",
+		"layout":       "wide",
+		"extra":        `{"a":1}`,
+		"editor":       "markdown",
+		"_old_slugs":   `["old"]`,
+	}
+	if err := schema.Validate(valid); err != nil {
+		t.Fatal("Expected the metas to be valid, got:", err)
+	}
+
+	tests := map[string]struct {
+		key   string
+		value string
+	}{
+		"unknown key":     {"colour", "red"},
+		"invalid int":     {"reading_time", "five"},
+		"invalid float":   {"rating", "good"},
+		"invalid bool":    {"sponsored", "maybe"},
+		"invalid date":    {"event_date", "someday"},
+		"not allowed":     {"layout", "tall"},
+		"invalid json":    {"extra", "{"},
+		"missing require": {"audience", ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metas := map[string]string{"audience": "developers", tt.key: tt.value}
+
+			err := schema.Validate(metas)
+
+			var metaErr *MetaError
+			if !errors.As(err, &metaErr) {
+				t.Fatal("Expected a MetaError, got:", err)
+			}
+			if metaErr.Key != tt.key {
+				t.Fatal("Expected the error for", tt.key, "got:", metaErr.Key)
+			}
+			if !strings.Contains(err.Error(), tt.key) {
+				t.Fatal("Expected the error to name the key, got:", err.Error())
+			}
+		})
+	}
+}
+
+func TestStoreMetaSchema(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		MetaSchema: NewMetaSchema(
+			MetaField{Key: "reading_time", Type: META_TYPE_INT},
+		),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Invalid").SetContentType(POST_CONTENT_TYPE_MARKDOWN)
+	if err := post.SetMeta("reading_time", "long"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	var metaErr *MetaError
+	if err := store.PostCreate(ctx, post); !errors.As(err, &metaErr) {
+		t.Fatal("Expected PostCreate to reject the metas, got:", err)
+	}
+
+	post = NewPost().SetTitle("Valid").SetContentType(POST_CONTENT_TYPE_MARKDOWN)
+	if err := post.SetMeta("reading_time", "7"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("Expected PostCreate to accept the metas, got:", err)
+	}
+
+	if err := post.SetMeta("colour", "red"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, post); !errors.As(err, &metaErr) {
+		t.Fatal("Expected PostUpdate to reject the unknown key, got:", err)
+	}
+	if metaErr.Key != "colour" {
+		t.Fatal("Expected the error for colour, got:", metaErr.Key)
+	}
+
+	many := NewPost().SetTitle("Many").SetContentType(POST_CONTENT_TYPE_MARKDOWN)
+	if err := many.SetMeta("reading_time", "long"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostCreateMany(ctx, []PostInterface{many}); !errors.As(err, &metaErr) {
+		t.Fatal("Expected PostCreateMany to reject the metas, got:", err)
+	}
+}
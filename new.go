@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/dracory/neat"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NewStoreOptions defines the configuration options for creating a new blog store.
@@ -20,10 +24,202 @@ type NewStoreOptions struct {
 	AutomigrateEnabled    bool
 	DebugEnabled          bool
 
+	// TablePrefix is prepended to the names of all the tables of the store,
+	// including the defaulted ones and those of the optional features, e.g.
+	// "app1_" turns "blog_posts" into "app1_blog_posts". This lets several
+	// applications share one database schema.
+	TablePrefix string
+
+	// MigrationsTableName is the table recording the schema migrations applied
+	// to the post tables by MigrateUp. Defaults to the post table name with a
+	// "_migrations" suffix.
+	MigrationsTableName string
+
+	// DefaultTimeout bounds every operation called with a context that has no
+	// deadline, such as context.Background(). Zero leaves such calls unbounded.
+	DefaultTimeout time.Duration
+
+	// Now replaces the clock used for the created, updated and soft deleted
+	// timestamps and for telling soft-deleted records apart, so tests can
+	// control time. Defaults to time.Now.
+	Now func() time.Time
+
+	// Timezone is the IANA name of the location (e.g. "Europe/Berlin") that
+	// FormatDateTime converts stored timestamps to, and ParseDateTime converts
+	// from. Timestamps are always stored in UTC. Defaults to UTC.
+	Timezone string
+	// DateTimeFormat is the Go time layout used by FormatDateTime and
+	// ParseDateTime. Defaults to "2006-01-02 15:04:05".
+	DateTimeFormat string
+
+	// Sanitizer cleans the content of posts with the html content type on
+	// PostCreate and PostUpdate, e.g. NewHTMLSanitizer(). Nil stores the
+	// content as given.
+	Sanitizer SanitizerInterface
+
+	// MetaSchema restricts the metas of the posts to the registered keys,
+	// types and values. PostCreate and PostUpdate return a MetaError for posts
+	// that do not match it. Nil accepts any metas.
+	MetaSchema *MetaSchema
+
+	// PostStatuses registers custom post statuses, accepted by PostCreate and
+	// PostUpdate in addition to the built-in POST_STATUS_* constants.
+	PostStatuses []string
+
+	// SlugUniqueEnabled declares a unique index on the post slug, so PostCreate
+	// and PostUpdate return ErrSlugTaken instead of storing a duplicate slug.
+	// Posts without a slug are stored with their ID as the slug. Duplicate slugs
+	// of existing posts must be resolved before enabling it.
+	SlugUniqueEnabled bool
+
 	VersioningEnabled   bool
 	VersioningTableName string
+	// VersioningAsync writes version entries in the background instead of as part
+	// of each create/update. Pending writes are flushed by Close, which must be
+	// called before the program exits. Write errors are logged, not returned.
+	VersioningAsync bool
+	// VersioningQueueSize is the number of pending version writes buffered in
+	// async mode before writers block. Defaults to 1000.
+	VersioningQueueSize int
+	// VersioningSkipDeleteSnapshot stops PostDeleteByID and PostDeleteByIDs
+	// from recording a final version of each post before deleting it. The
+	// snapshot, marked with META_KEY_DELETED_AT, lets PostRevertToVersion
+	// recreate a post deleted by accident.
+	VersioningSkipDeleteSnapshot bool
 
 	TaxonomyEnabled bool
+
+	// ArchiveEnabled moves soft-deleted posts out of the post table into the
+	// archive table, keeping the post table and its indexes small.
+	// Archived posts can be listed with PostArchiveList and restored with PostUnarchive.
+	ArchiveEnabled   bool
+	ArchiveTableName string
+
+	// AuditEnabled records every mutation of posts, media, taxonomies and terms
+	// in the audit table: the action, the actor set with ContextWithActor and
+	// hashes of the entity before and after. Query it with AuditList.
+	AuditEnabled   bool
+	AuditTableName string
+
+	// PreviewEnabled allows creating preview tokens with PostPreviewTokenCreate,
+	// which grant access to a single post, published or not, until they expire
+	// or are revoked. The tokens are kept in the preview table.
+	PreviewEnabled   bool
+	PreviewTableName string
+
+	// AutosaveEnabled keeps in-progress edits saved with PostAutosave in the
+	// autosave table, apart from the post, so they neither change the live post
+	// nor record versions until restored with PostAutosaveRestore. Autosaved
+	// content is stored unencrypted.
+	AutosaveEnabled   bool
+	AutosaveTableName string
+
+	// LockEnabled allows editors to take turns on a post with PostLock,
+	// PostUnlock and PostLockStatus. The locks are kept in the lock table.
+	LockEnabled   bool
+	LockTableName string
+
+	// Authorizer is asked before each post operation whether the actor in the
+	// context may perform it. Nil allows everything.
+	Authorizer AuthorizerInterface
+
+	// Hooks are called before and after posts are created, updated and
+	// deleted, including by the bulk methods. Nil calls nothing.
+	Hooks *Hooks
+
+	// EventPublisher receives an event after each saved post change, including
+	// those of the bulk methods. Nil publishes nothing.
+	EventPublisher EventPublisherInterface
+
+	// BlobStore keeps post content larger than BlobThreshold bytes outside of
+	// the post table, leaving only a reference in the row. The content is loaded
+	// back transparently when posts are read. Such content is not matched by Search.
+	BlobStore     BlobStoreInterface
+	BlobThreshold int
+
+	// EncryptedColumns lists the post columns encrypted at rest with AES-GCM,
+	// using keys from EncryptionKeyProvider. Supported are COLUMN_MEMO and
+	// COLUMN_CONTENT. Values are decrypted transparently when posts are read,
+	// but encrypted content is not matched by Search. Version snapshots are
+	// encrypted as well, and autosaves when the content is encrypted, with
+	// the same keys. An encrypted memo needs about twice the space, so
+	// saving a post with a memo over about 140 characters returns an error.
+	EncryptedColumns      []string
+	EncryptionKeyProvider EncryptionKeyProviderInterface
+
+	// ReadDB is a read replica of DB. PostList, PostCount and PostFindByID
+	// read from it, unless called with ContextWithForcePrimary, while writes
+	// and the reads made by the mutations go to DB. Nil reads from DB.
+	ReadDB *sql.DB
+
+	// Connection pool tuning. Zero values leave the *sql.DB settings untouched.
+	// The pool belongs to the provided DB, so these settings affect every user of it.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// TracerProvider enables OpenTelemetry tracing of store operations when set.
+	// Each operation is recorded as a span carrying the table name, row count and error.
+	TracerProvider trace.TracerProvider
+
+	// Logger receives the store's diagnostic output. Defaults to slog.Default().
+	Logger *slog.Logger
+
+	// SlowQueryThreshold logs a warning with the SQL and its parameters for every
+	// query that takes at least this long. Zero disables slow query logging.
+	SlowQueryThreshold time.Duration
+
+	// ExplainEnabled logs the query plan of every query while debug mode is on,
+	// using EXPLAIN QUERY PLAN on SQLite and EXPLAIN on Postgres and MySQL.
+	// EXPLAIN only plans the query, on the same database the query runs on, but
+	// it adds a round-trip per query, so it is meant for diagnosing missing
+	// indexes only.
+	ExplainEnabled bool
+
+	// ViewsEnabled counts post views per day in the views table with
+	// PostRecordView, for PostViewsByDay and PostViewsTop.
+	ViewsEnabled   bool
+	ViewsTableName string
+
+	// ReactionsEnabled counts reactions to the posts, such as likes or claps,
+	// with PostReactionIncrement, for PostReactionsSummary.
+	ReactionsEnabled   bool
+	ReactionsTableName string
+
+	// VariantsEnabled stores alternative titles and summaries of the posts
+	// with their impressions and clicks, for PostVariantPick.
+	VariantsEnabled   bool
+	VariantsTableName string
+
+	// AuthorsEnabled stores author profiles in the authors table, matched to
+	// the posts by author ID and loaded with POST_WITH_AUTHOR.
+	AuthorsEnabled   bool
+	AuthorsTableName string
+
+	// MenusEnabled stores the navigation menus of the blog, e.g. the header
+	// and footer navigation, with their items in the menu items table.
+	MenusEnabled       bool
+	MenusTableName     string
+	MenuItemsTableName string
+
+	// SlugHistoryEnabled records the previous slug of a post on every slug
+	// change, so PostFindBySlugOrHistory keeps resolving old URLs.
+	SlugHistoryEnabled   bool
+	SlugHistoryTableName string
+
+	// StatusHistoryEnabled records every status change of a post with the
+	// actor and time, returned by PostStatusHistory.
+	StatusHistoryEnabled   bool
+	StatusHistoryTableName string
+
+	// TrashRetentionDays is how many days posts stay in the trash before
+	// PostTrashPurge deletes them permanently. Zero keeps them until deleted.
+	TrashRetentionDays int
+
+	// StatsCacheTTL is how long the numbers returned by Stats are cached.
+	// Defaults to 5 minutes.
+	StatsCacheTTL time.Duration
 }
 
 // NewStore creates a new blog store with the provided options.
@@ -50,40 +246,226 @@ func NewStore(opts NewStoreOptions) (StoreInterface, error) {
 		opts.MediaTableName = "blog_media"
 	}
 
+	if opts.MigrationsTableName == "" {
+		opts.MigrationsTableName = opts.PostTableName + "_migrations"
+	}
+
 	if opts.DB == nil {
 		return nil, errors.New("blog store: DB is required")
 	}
 
+	if opts.MaxOpenConns > 0 {
+		opts.DB.SetMaxOpenConns(opts.MaxOpenConns)
+	}
+
+	if opts.MaxIdleConns > 0 {
+		opts.DB.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+
+	if opts.ConnMaxLifetime > 0 {
+		opts.DB.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+
+	if opts.ConnMaxIdleTime > 0 {
+		opts.DB.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	}
+
 	neatDB, err := neat.NewFromSQLDB(opts.DB)
 	if err != nil {
 		return nil, err
 	}
 
+	var readDB *neat.Database
+	if opts.ReadDB != nil {
+		readDB, err = neat.NewFromSQLDB(opts.ReadDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if opts.VersioningEnabled && opts.VersioningTableName == "" {
 		return nil, errors.New("blog store: VersioningTableName is required")
 	}
 
+	if opts.ArchiveEnabled && opts.ArchiveTableName == "" {
+		return nil, errors.New("blog store: ArchiveTableName is required")
+	}
+
+	if opts.AuditEnabled && opts.AuditTableName == "" {
+		return nil, errors.New("blog store: AuditTableName is required")
+	}
+
+	if opts.PreviewEnabled && opts.PreviewTableName == "" {
+		return nil, errors.New("blog store: PreviewTableName is required")
+	}
+
+	if opts.AutosaveEnabled && opts.AutosaveTableName == "" {
+		return nil, errors.New("blog store: AutosaveTableName is required")
+	}
+
+	if opts.ViewsEnabled && opts.ViewsTableName == "" {
+		return nil, errors.New("blog store: ViewsTableName is required")
+	}
+
+	if opts.ReactionsEnabled && opts.ReactionsTableName == "" {
+		return nil, errors.New("blog store: ReactionsTableName is required")
+	}
+
+	if opts.LockEnabled && opts.LockTableName == "" {
+		return nil, errors.New("blog store: LockTableName is required")
+	}
+
+	if opts.VariantsEnabled && opts.VariantsTableName == "" {
+		return nil, errors.New("blog store: VariantsTableName is required")
+	}
+
+	if opts.AuthorsEnabled && opts.AuthorsTableName == "" {
+		return nil, errors.New("blog store: AuthorsTableName is required")
+	}
+
+	if opts.MenusEnabled && opts.MenusTableName == "" {
+		return nil, errors.New("blog store: MenusTableName is required")
+	}
+
+	if opts.MenusEnabled && opts.MenuItemsTableName == "" {
+		return nil, errors.New("blog store: MenuItemsTableName is required")
+	}
+
+	if opts.SlugHistoryEnabled && opts.SlugHistoryTableName == "" {
+		return nil, errors.New("blog store: SlugHistoryTableName is required")
+	}
+
+	if opts.StatusHistoryEnabled && opts.StatusHistoryTableName == "" {
+		return nil, errors.New("blog store: StatusHistoryTableName is required")
+	}
+
+	if opts.TablePrefix != "" {
+		tableNames := []*string{
+			&opts.PostTableName,
+			&opts.TaxonomyTableName,
+			&opts.TermTableName,
+			&opts.TermRelationTableName,
+			&opts.MediaTableName,
+			&opts.MigrationsTableName,
+			&opts.VersioningTableName,
+			&opts.ArchiveTableName,
+			&opts.AuditTableName,
+			&opts.PreviewTableName,
+			&opts.AutosaveTableName,
+			&opts.LockTableName,
+			&opts.ViewsTableName,
+			&opts.ReactionsTableName,
+			&opts.VariantsTableName,
+			&opts.AuthorsTableName,
+			&opts.MenusTableName,
+			&opts.MenuItemsTableName,
+			&opts.SlugHistoryTableName,
+			&opts.StatusHistoryTableName,
+		}
+		for _, tableName := range tableNames {
+			if *tableName != "" {
+				*tableName = opts.TablePrefix + *tableName
+			}
+		}
+	}
+
+	if len(opts.EncryptedColumns) > 0 && opts.EncryptionKeyProvider == nil {
+		return nil, errors.New("blog store: EncryptionKeyProvider is required")
+	}
+
+	for _, column := range opts.EncryptedColumns {
+		if !slices.Contains(encryptableColumns, column) {
+			return nil, errors.New("blog store: column cannot be encrypted: " + column)
+		}
+	}
+
+	timezone := time.UTC
+	if opts.Timezone != "" {
+		location, err := time.LoadLocation(opts.Timezone)
+		if err != nil {
+			return nil, errors.New("blog store: invalid Timezone: " + opts.Timezone)
+		}
+		timezone = location
+	}
+
 	store := &storeImplementation{
-		postTableName:         opts.PostTableName,
-		taxonomyTableName:     opts.TaxonomyTableName,
-		termTableName:         opts.TermTableName,
-		termRelationTableName: opts.TermRelationTableName,
-		mediaTableName:        opts.MediaTableName,
-		automigrateEnabled:    opts.AutomigrateEnabled,
-		db:                    neatDB,
-		debugEnabled:          opts.DebugEnabled,
-		versioningEnabled:     opts.VersioningEnabled,
-		versioningTableName:   opts.VersioningTableName,
-		taxonomyEnabled:       opts.TaxonomyEnabled,
+		postTableName:                opts.PostTableName,
+		taxonomyTableName:            opts.TaxonomyTableName,
+		termTableName:                opts.TermTableName,
+		termRelationTableName:        opts.TermRelationTableName,
+		mediaTableName:               opts.MediaTableName,
+		automigrateEnabled:           opts.AutomigrateEnabled,
+		migrationsTableName:          opts.MigrationsTableName,
+		db:                           neatDB,
+		readDB:                       readDB,
+		versioningEnabled:            opts.VersioningEnabled,
+		versioningSkipDeleteSnapshot: opts.VersioningSkipDeleteSnapshot,
+		versioningTableName:          opts.VersioningTableName,
+		taxonomyEnabled:              opts.TaxonomyEnabled,
+		logger:                       opts.Logger,
+		slowQueryThreshold:           opts.SlowQueryThreshold,
+		explainEnabled:               opts.ExplainEnabled,
+		statsCacheTTL:                opts.StatsCacheTTL,
+		trashRetention:               time.Duration(opts.TrashRetentionDays) * 24 * time.Hour,
+		archiveEnabled:               opts.ArchiveEnabled,
+		archiveTableName:             opts.ArchiveTableName,
+		auditEnabled:                 opts.AuditEnabled,
+		auditTableName:               opts.AuditTableName,
+		previewEnabled:               opts.PreviewEnabled,
+		previewTableName:             opts.PreviewTableName,
+		autosaveEnabled:              opts.AutosaveEnabled,
+		autosaveTableName:            opts.AutosaveTableName,
+		lockEnabled:                  opts.LockEnabled,
+		lockTableName:                opts.LockTableName,
+		viewsEnabled:                 opts.ViewsEnabled,
+		viewsTableName:               opts.ViewsTableName,
+		reactionsEnabled:             opts.ReactionsEnabled,
+		reactionsTableName:           opts.ReactionsTableName,
+		variantsEnabled:              opts.VariantsEnabled,
+		variantsTableName:            opts.VariantsTableName,
+		authorsEnabled:               opts.AuthorsEnabled,
+		authorsTableName:             opts.AuthorsTableName,
+		menusEnabled:                 opts.MenusEnabled,
+		menusTableName:               opts.MenusTableName,
+		menuItemsTableName:           opts.MenuItemsTableName,
+		slugHistoryEnabled:           opts.SlugHistoryEnabled,
+		slugHistoryTableName:         opts.SlugHistoryTableName,
+		statusHistoryEnabled:         opts.StatusHistoryEnabled,
+		statusHistoryTableName:       opts.StatusHistoryTableName,
+		authorizer:                   opts.Authorizer,
+		hooks:                        opts.Hooks,
+		eventPublisher:               opts.EventPublisher,
+		metaSchema:                   opts.MetaSchema,
+		sanitizer:                    opts.Sanitizer,
+		encryptedColumns:             opts.EncryptedColumns,
+		encryptionKeyProvider:        opts.EncryptionKeyProvider,
+		blobStore:                    opts.BlobStore,
+		blobThreshold:                opts.BlobThreshold,
+		postStatuses:                 append(postStatusesBuiltIn(), opts.PostStatuses...),
+		slugUniqueEnabled:            opts.SlugUniqueEnabled,
+		defaultTimeout:               opts.DefaultTimeout,
+		timezone:                     timezone,
+		nowFunc:                      opts.Now,
+		dateTimeFormat:               opts.DateTimeFormat,
 	}
 
 	store.timeoutSeconds = 2 * 60 * 60 // 2 hours
+	store.debugEnabled.Store(opts.DebugEnabled)
+
+	var result StoreInterface = store
+	if opts.TracerProvider != nil {
+		result = newTracingStore(store, opts.TracerProvider)
+	}
 
 	if store.automigrateEnabled {
-		if err := store.MigrateUp(context.Background()); err != nil {
+		if err := result.MigrateUp(context.Background()); err != nil {
 			return nil, err
 		}
 	}
 
-	return store, nil
+	if store.versioningEnabled && opts.VersioningAsync {
+		store.versioningAsync = newVersioningAsyncWriter(store, opts.VersioningQueueSize)
+	}
+
+	return result, nil
 }
@@ -0,0 +1,112 @@
+package blogstore
+
+import (
+	"slices"
+	"strings"
+)
+
+// OrderError is returned by list queries when OrderBy is not a known column or
+// SortOrder is not asc or desc. Ordering values are written into the SQL, so
+// they are checked against an allowlist rather than escaped.
+type OrderError struct {
+	// Field is the offending option, "order_by" or "sort_order".
+	Field string
+	// Value is the rejected value.
+	Value string
+}
+
+// Error implements the error interface.
+func (e *OrderError) Error() string {
+	return "blogstore: invalid " + e.Field + ": " + e.Value
+}
+
+// OrderSpec is one column of a multi-column ordering.
+type OrderSpec struct {
+	// Column is the column to sort by.
+	Column string
+	// Direction is the sort direction (asc or desc). Defaults to desc.
+	Direction string
+}
+
+// mediaTableColumns lists the columns of the media table that can be ordered by.
+var mediaTableColumns = []string{
+	COLUMN_ID,
+	COLUMN_ENTITY_ID,
+	COLUMN_TITLE,
+	COLUMN_DESCRIPTION,
+	COLUMN_MEMO,
+	COLUMN_MEDIA_URL,
+	COLUMN_MEDIA_TYPE,
+	COLUMN_FILE_SIZE,
+	COLUMN_FILE_EXTENSION,
+	COLUMN_SEQUENCE,
+	COLUMN_STATUS,
+	COLUMN_METAS,
+	COLUMN_CREATED_AT,
+	COLUMN_UPDATED_AT,
+	COLUMN_SOFT_DELETED_AT,
+}
+
+// termTableColumns lists the columns of the term table that can be ordered by.
+var termTableColumns = []string{
+	COLUMN_ID,
+	COLUMN_TAXONOMY_ID,
+	COLUMN_PARENT_ID,
+	COLUMN_SEQUENCE,
+	COLUMN_NAME,
+	COLUMN_SLUG,
+	COLUMN_DESCRIPTION,
+	COLUMN_COUNT,
+	COLUMN_CREATED_AT,
+	COLUMN_UPDATED_AT,
+}
+
+// versioningTableColumns lists the columns of the versioning table that can be ordered by.
+var versioningTableColumns = []string{
+	COLUMN_ID,
+	COLUMN_ENTITY_TYPE,
+	COLUMN_ENTITY_ID,
+	COLUMN_CONTENT,
+	COLUMN_CREATED_AT,
+	COLUMN_SOFT_DELETED_AT,
+}
+
+// validateOrderBy returns an OrderError unless orderBy is one of the columns.
+func validateOrderBy(orderBy string, columns []string) error {
+	if !slices.Contains(columns, orderBy) {
+		return &OrderError{Field: "order_by", Value: orderBy}
+	}
+	return nil
+}
+
+// validateOrderSpecs checks every spec against the columns and returns the
+// specs with their directions normalized.
+func validateOrderSpecs(specs []OrderSpec, columns []string) ([]OrderSpec, error) {
+	normalized := make([]OrderSpec, 0, len(specs))
+	for _, spec := range specs {
+		if err := validateOrderBy(spec.Column, columns); err != nil {
+			return nil, err
+		}
+		direction, err := normalizeSortOrder(spec.Direction)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, OrderSpec{Column: spec.Column, Direction: direction})
+	}
+	return normalized, nil
+}
+
+// normalizeSortOrder returns the sort order in lower case, defaulting to
+// descending when empty, or an OrderError when it is neither asc nor desc.
+func normalizeSortOrder(sortOrder string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(sortOrder)) {
+	case "":
+		return SORT_ORDER_DESC, nil
+	case SORT_ORDER_ASC:
+		return SORT_ORDER_ASC, nil
+	case SORT_ORDER_DESC:
+		return SORT_ORDER_DESC, nil
+	default:
+		return "", &OrderError{Field: "sort_order", Value: sortOrder}
+	}
+}
@@ -0,0 +1,142 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeSortOrder(t *testing.T) {
+	cases := map[string]string{
+		"":      SORT_ORDER_DESC,
+		"asc":   SORT_ORDER_ASC,
+		"ASC":   SORT_ORDER_ASC,
+		"desc":  SORT_ORDER_DESC,
+		" Desc": SORT_ORDER_DESC,
+	}
+
+	for input, expected := range cases {
+		order, err := normalizeSortOrder(input)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", input, err)
+		}
+		if order != expected {
+			t.Fatalf("expected %q for %q, found %q", expected, input, order)
+		}
+	}
+
+	_, err := normalizeSortOrder("desc; DROP TABLE blog_posts")
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Field != "sort_order" {
+		t.Fatal("expected sort_order OrderError, found:", err)
+	}
+}
+
+func TestPostListRejectsUnknownOrder(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("B")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostCreate(ctx, NewPost().SetTitle("A")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{OrderBy: COLUMN_TITLE, SortOrder: "ASC"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 2 || list[0].GetTitle() != "A" {
+		t.Fatal("expected posts ordered by title ascending")
+	}
+
+	_, err = store.PostList(ctx, PostQueryOptions{OrderBy: "title; DROP TABLE blog_posts"})
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Field != "order_by" {
+		t.Fatal("expected order_by OrderError, found:", err)
+	}
+
+	_, err = store.PostList(ctx, PostQueryOptions{OrderBy: COLUMN_TITLE, SortOrder: "sideways"})
+	if !errors.As(err, &orderErr) || orderErr.Field != "sort_order" {
+		t.Fatal("expected sort_order OrderError, found:", err)
+	}
+}
+
+func TestVersioningListRejectsUnknownOrder(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.VersioningList(context.Background(), NewVersioningQuery().SetOrderBy("1=1"))
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) {
+		t.Fatal("expected OrderError, found:", err)
+	}
+}
+
+func TestPostListOrderByMulti(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	posts := []PostInterface{
+		NewPost().SetTitle("B").SetFeatured(NO),
+		NewPost().SetTitle("D").SetFeatured(YES),
+		NewPost().SetTitle("A").SetFeatured(NO),
+		NewPost().SetTitle("C").SetFeatured(YES),
+	}
+	for _, post := range posts {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{
+		OrderByMulti: []OrderSpec{
+			{Column: COLUMN_FEATURED, Direction: "desc"},
+			{Column: COLUMN_TITLE, Direction: "asc"},
+		},
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	titles := ""
+	for _, post := range list {
+		titles += post.GetTitle()
+	}
+	if titles != "CDAB" {
+		t.Fatal("expected order CDAB, found:", titles)
+	}
+
+	_, err = store.PostList(ctx, PostQueryOptions{
+		OrderByMulti: []OrderSpec{{Column: COLUMN_TITLE}, {Column: "unknown"}},
+	})
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Value != "unknown" {
+		t.Fatal("expected OrderError for unknown column, found:", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package pagination computes the pages of a post listing from the total
+// count (StoreInterface.PostCount) and the Limit/Offset of the query: the
+// page window, the previous/next links and the rel tags for the page head.
+package pagination
+
+import (
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination describes the current page of a listing.
+type Pagination struct {
+	// Total is the number of items in the listing.
+	Total int64
+	// PerPage is the number of items per page.
+	PerPage int
+	// Page is the current page, starting at 1.
+	Page int
+	// Pages is the number of pages, at least 1.
+	Pages int
+}
+
+// New computes the pagination of a listing of total items, queried with the
+// given limit and offset. A limit below 1 means a single page.
+func New(total int64, limit int, offset int) Pagination {
+	if total < 0 {
+		total = 0
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 1 {
+		return Pagination{Total: total, PerPage: int(total), Page: 1, Pages: 1}
+	}
+
+	pages := int((total + int64(limit) - 1) / int64(limit))
+
+	return Pagination{
+		Total:   total,
+		PerPage: limit,
+		Page:    offset/limit + 1,
+		Pages:   max(pages, 1),
+	}
+}
+
+// FromPage computes the pagination of a listing of total items, for a page
+// number as found in a request. Out of range pages are clamped.
+// Query the posts with the Limit and Offset of the result.
+func FromPage(total int64, perPage int, page int) Pagination {
+	p := New(total, perPage, 0)
+	p.Page = min(max(page, 1), p.Pages)
+	return p
+}
+
+// Limit returns the PostQueryOptions.Limit of the current page.
+func (p Pagination) Limit() int {
+	return p.PerPage
+}
+
+// Offset returns the PostQueryOptions.Offset of the current page.
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// HasPrev returns true if there is a page before the current one.
+func (p Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext returns true if there is a page after the current one.
+func (p Pagination) HasNext() bool {
+	return p.Page < p.Pages
+}
+
+// Window returns up to size page numbers around the current page, e.g. for
+// size 5 on page 7 of 20: 5, 6, 7, 8, 9.
+func (p Pagination) Window(size int) []int {
+	if size < 1 {
+		return []int{}
+	}
+
+	first := max(p.Page-size/2, 1)
+	last := min(first+size-1, p.Pages)
+	first = max(last-size+1, 1)
+
+	window := make([]int, 0, last-first+1)
+	for page := first; page <= last; page++ {
+		window = append(window, page)
+	}
+	return window
+}
+
+// PageLink is a link to a page of the listing.
+type PageLink struct {
+	Page    int
+	URL     string
+	Current bool
+}
+
+// Links holds the URLs of the pages around the current one.
+// Empty URLs mean there is no such page.
+type Links struct {
+	First     string
+	Prev      string
+	Next      string
+	Last      string
+	Canonical string
+	// Window lists the pages around the current one, see Pagination.Window.
+	Window []PageLink
+}
+
+// Links builds the links of the listing from its base URL, setting the page
+// number in the query parameter param (e.g. "page") and keeping all other
+// parameters. The first page has no page parameter, so it has one canonical URL.
+func (p Pagination) Links(base *url.URL, param string, windowSize int) Links {
+	links := Links{
+		First:     p.PageURL(base, param, 1),
+		Last:      p.PageURL(base, param, p.Pages),
+		Canonical: p.PageURL(base, param, p.Page),
+	}
+	if p.HasPrev() {
+		links.Prev = p.PageURL(base, param, p.Page-1)
+	}
+	if p.HasNext() {
+		links.Next = p.PageURL(base, param, p.Page+1)
+	}
+
+	for _, page := range p.Window(windowSize) {
+		links.Window = append(links.Window, PageLink{
+			Page:    page,
+			URL:     p.PageURL(base, param, page),
+			Current: page == p.Page,
+		})
+	}
+
+	return links
+}
+
+// CursorLinks builds the links of a listing paged by cursors rather than
+// offsets, setting the cursor in the query parameter param. Empty cursors
+// mean there is no previous or next page; the canonical URL is the base.
+func CursorLinks(base *url.URL, param string, prevCursor string, nextCursor string) Links {
+	withCursor := func(cursor string) string {
+		u := *base
+		query := u.Query()
+		query.Set(param, cursor)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	links := Links{Canonical: base.String()}
+	if prevCursor != "" {
+		links.Prev = withCursor(prevCursor)
+	}
+	if nextCursor != "" {
+		links.Next = withCursor(nextCursor)
+	}
+	return links
+}
+
+// PageURL returns the URL of a page of the listing, see Links.
+func (p Pagination) PageURL(base *url.URL, param string, page int) string {
+	u := *base
+	query := u.Query()
+	if page <= 1 {
+		query.Del(param)
+	} else {
+		query.Set(param, strconv.Itoa(page))
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// RelTags returns the canonical, prev and next link tags of the current
+// page, for the head of the page.
+func (l Links) RelTags() template.HTML {
+	var tags strings.Builder
+	write := func(rel string, href string) {
+		if href == "" {
+			return
+		}
+		tags.WriteString(`<link rel="` + rel + `" href="` + template.HTMLEscapeString(href) + `">`)
+		tags.WriteString("\n")
+	}
+
+	write("canonical", l.Canonical)
+	write("prev", l.Prev)
+	write("next", l.Next)
+
+	return template.HTML(tags.String())
+}
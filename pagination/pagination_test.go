@@ -0,0 +1,115 @@
+package pagination_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dracory/blogstore/pagination"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int64
+		limit  int
+		offset int
+		want   pagination.Pagination
+	}{
+		{"empty", 0, 10, 0, pagination.Pagination{Total: 0, PerPage: 10, Page: 1, Pages: 1}},
+		{"first page", 25, 10, 0, pagination.Pagination{Total: 25, PerPage: 10, Page: 1, Pages: 3}},
+		{"last page", 25, 10, 20, pagination.Pagination{Total: 25, PerPage: 10, Page: 3, Pages: 3}},
+		{"exact pages", 20, 10, 10, pagination.Pagination{Total: 20, PerPage: 10, Page: 2, Pages: 2}},
+		{"no limit", 25, 0, 0, pagination.Pagination{Total: 25, PerPage: 25, Page: 1, Pages: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pagination.New(tt.total, tt.limit, tt.offset); got != tt.want {
+				t.Fatalf("New() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromPage(t *testing.T) {
+	p := pagination.FromPage(25, 10, 7)
+	if p.Page != 3 {
+		t.Fatalf("Page = %d, want out of range pages clamped to 3", p.Page)
+	}
+	if p.Limit() != 10 || p.Offset() != 20 {
+		t.Fatalf("Limit/Offset = %d/%d, want 10/20", p.Limit(), p.Offset())
+	}
+	if !p.HasPrev() || p.HasNext() {
+		t.Fatal("expected the last page to have a previous page only")
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		page int
+		want []int
+	}{
+		{1, []int{1, 2, 3, 4, 5}},
+		{7, []int{5, 6, 7, 8, 9}},
+		{20, []int{16, 17, 18, 19, 20}},
+	}
+
+	for _, tt := range tests {
+		p := pagination.FromPage(200, 10, tt.page)
+		if got := p.Window(5); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("Window(5) on page %d = %v, want %v", tt.page, got, tt.want)
+		}
+	}
+
+	if got := pagination.FromPage(20, 10, 1).Window(5); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("Window(5) of 2 pages = %v, want [1 2]", got)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog?tag=go&page=2")
+
+	links := pagination.FromPage(50, 10, 2).Links(base, "page", 3)
+
+	if links.Canonical != "https://example.com/blog?page=2&tag=go" {
+		t.Fatalf("Canonical = %q", links.Canonical)
+	}
+	if links.Prev != "https://example.com/blog?tag=go" {
+		t.Fatalf("Prev = %q, want the first page without a page parameter", links.Prev)
+	}
+	if links.Next != "https://example.com/blog?page=3&tag=go" {
+		t.Fatalf("Next = %q", links.Next)
+	}
+	if links.Last != "https://example.com/blog?page=5&tag=go" {
+		t.Fatalf("Last = %q", links.Last)
+	}
+	if len(links.Window) != 3 || !links.Window[1].Current || links.Window[1].Page != 2 {
+		t.Fatalf("Window = %+v, want pages 1-3 with 2 current", links.Window)
+	}
+
+	tags := string(links.RelTags())
+	for _, rel := range []string{`rel="canonical"`, `rel="prev"`, `rel="next"`} {
+		if !strings.Contains(tags, rel) {
+			t.Fatalf("expected %s in %s", rel, tags)
+		}
+	}
+	if !strings.Contains(tags, "page=3&amp;tag=go") {
+		t.Fatalf("expected escaped hrefs in %s", tags)
+	}
+
+	first := pagination.FromPage(50, 10, 1).Links(base, "page", 3)
+	if first.Prev != "" || strings.Contains(string(first.RelTags()), `rel="prev"`) {
+		t.Fatal("expected no previous link on the first page")
+	}
+}
+
+func TestCursorLinks(t *testing.T) {
+	base, _ := url.Parse("/blog")
+
+	links := pagination.CursorLinks(base, "after", "", "abc")
+	if links.Prev != "" || links.Next != "/blog?after=abc" || links.Canonical != "/blog" {
+		t.Fatalf("CursorLinks() = %+v", links)
+	}
+}
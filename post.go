@@ -2,11 +2,13 @@ package blogstore
 
 import (
 	"encoding/json"
+	"github.com/dracory/neat"
 	"github.com/dracory/neat/database/orm"
 	"github.com/dracory/neat/database/soft_delete"
 	"github.com/dracory/str"
 	"github.com/dromara/carbon/v2"
 	"github.com/samber/lo"
+	"strconv"
 	"time"
 )
 
@@ -26,6 +28,24 @@ type PostInterface interface {
 	// SetAuthorID sets the ID of the post author.
 	SetAuthorID(authorID string) PostInterface
 
+	// Idempotency
+	// GetIdempotencyKey returns the key identifying the request that created the post.
+	GetIdempotencyKey() string
+	// SetIdempotencyKey sets the key identifying the request that creates the post,
+	// so a retried PostCreate returns the existing post instead of a duplicate.
+	SetIdempotencyKey(idempotencyKey string) PostInterface
+
+	// Translations
+	// GetLocale returns the language of the post, e.g. "en" or "pt-BR".
+	GetLocale() string
+	// SetLocale sets the language of the post, e.g. "en" or "pt-BR".
+	SetLocale(locale string) PostInterface
+	// GetTranslationGroupID returns the ID shared by the language versions of the post.
+	GetTranslationGroupID() string
+	// SetTranslationGroupID sets the ID shared by the language versions of the post,
+	// usually the ID of the original post, so PostTranslations finds them.
+	SetTranslationGroupID(translationGroupID string) PostInterface
+
 	// Content
 	// GetTitle returns the post title.
 	GetTitle() string
@@ -64,6 +84,11 @@ type PostInterface interface {
 	IsContentPlainText() bool
 	// IsContentBlocks returns true if the post content type is blocks.
 	IsContentBlocks() bool
+	// ContentHtml renders the content to safe HTML with the built-in
+	// renderers of NewContentRenderers, based on the content type.
+	ContentHtml() (string, error)
+	// ContentHtmlWith renders the content to HTML with the given renderers.
+	ContentHtmlWith(renderers ContentRenderers) (string, error)
 
 	// SEO and Meta
 	// GetCanonicalURL returns the canonical URL for SEO purposes.
@@ -118,6 +143,23 @@ type PostInterface interface {
 	GetPublishedAtCarbon() *carbon.Carbon
 	// GetPublishedAtTime returns the publication timestamp as a time.Time instance.
 	GetPublishedAtTime() time.Time
+	// GetExpiresAt returns the timestamp after which the post is unpublished,
+	// or an empty string if the post does not expire.
+	GetExpiresAt() string
+	// SetExpiresAt sets the timestamp after which the post is unpublished by
+	// PostExpireDue. An empty string means the post does not expire.
+	SetExpiresAt(expiresAt string) PostInterface
+	// GetExpiresAtTime returns the expiry timestamp as a time.Time instance,
+	// the zero time if the post does not expire.
+	GetExpiresAtTime() time.Time
+	// IsExpired returns true if the post expires at or before the given time.
+	IsExpired(at time.Time) bool
+
+	// GetViewCount returns the number of views counted by PostIncrementViewCount.
+	GetViewCount() int64
+	// SetViewCount sets the number of views. It is not saved by PostCreate
+	// or PostUpdate, the count only changes with PostIncrementViewCount.
+	SetViewCount(views int64) PostInterface
 
 	// Timestamps
 	// GetCreatedAt returns the creation timestamp as a string.
@@ -189,6 +231,25 @@ type PostInterface interface {
 	// SetTagIDs sets the tag IDs for this post.
 	SetTagIDs(ids []string) PostInterface
 
+	// Eager loaded relations (see PostQueryOptions.With)
+	// Terms returns the loaded terms of a taxonomy, or nil if they were not loaded.
+	Terms(taxonomySlug string) []TermInterface
+	// SetTerms sets the loaded terms of a taxonomy.
+	SetTerms(taxonomySlug string, terms []TermInterface) PostInterface
+	// Categories returns the loaded category terms, or nil if they were not loaded.
+	Categories() []TermInterface
+	// Tags returns the loaded tag terms, or nil if they were not loaded.
+	Tags() []TermInterface
+	// Media returns the loaded media, or nil if they were not loaded.
+	Media() []MediaInterface
+	// SetMedia sets the loaded media.
+	SetMedia(media []MediaInterface) PostInterface
+	// Author returns the loaded author profile, or nil if it was not loaded
+	// or the author has no profile.
+	Author() *Author
+	// SetAuthor sets the loaded author profile.
+	SetAuthor(author *Author) PostInterface
+
 	// Old Slug methods (WordPress-style slug history for redirects)
 	// GetOldSlugs retrieves the array of historical slugs for redirect purposes.
 	GetOldSlugs() []string
@@ -233,7 +294,9 @@ func NewPost() PostInterface {
 		SetCanonicalURL("").
 		SetContent("").
 		SetFeatured(NO).
+		SetIdempotencyKey("").
 		SetImageUrl("").
+		SetLocale("").
 		SetMemo("").
 		SetMetaDescription("").
 		SetMetaKeywords("").
@@ -242,6 +305,7 @@ func NewPost() PostInterface {
 		SetStatus(POST_STATUS_DRAFT).
 		SetSummary("").
 		SetTitle("").
+		SetTranslationGroupID("").
 		SetPublishedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC)).
 		SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC)).
 		SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC)).
@@ -264,25 +328,40 @@ func NewPostFromExistingData(data map[string]string) PostInterface {
 type postImplementation struct {
 	orm.ShortID
 
-	AuthorIDField        string    `db:"author_id"`
-	CanonicalURLField    string    `db:"canonical_url"`
-	ContentField         string    `db:"content"`
-	FeaturedField        string    `db:"featured"`
-	ImageURLField        string    `db:"image_url"`
-	MemoField            string    `db:"memo"`
-	MetaDescriptionField string    `db:"meta_description"`
-	MetaKeywordsField    string    `db:"meta_keywords"`
-	MetaRobotsField      string    `db:"meta_robots"`
-	MetasField           string    `db:"metas"`
-	PublishedAtField     time.Time `db:"published_at"`
-	StatusField          string    `db:"status"`
-	SlugField            string    `db:"slug"`
-	SummaryField         string    `db:"summary"`
-	TitleField           string    `db:"title"`
+	AuthorIDField           string    `db:"author_id"`
+	CanonicalURLField       string    `db:"canonical_url"`
+	ContentField            string    `db:"content"`
+	ExpiresAtField          time.Time `db:"expires_at"`
+	FeaturedField           string    `db:"featured"`
+	IdempotencyKeyField     string    `db:"idempotency_key"`
+	ImageURLField           string    `db:"image_url"`
+	LocaleField             string    `db:"locale"`
+	MemoField               string    `db:"memo"`
+	MetaDescriptionField    string    `db:"meta_description"`
+	MetaKeywordsField       string    `db:"meta_keywords"`
+	MetaRobotsField         string    `db:"meta_robots"`
+	MetasField              string    `db:"metas"`
+	PublishedAtField        time.Time `db:"published_at"`
+	StatusField             string    `db:"status"`
+	SlugField               string    `db:"slug"`
+	SummaryField            string    `db:"summary"`
+	TitleField              string    `db:"title"`
+	TranslationGroupIDField string    `db:"translation_group_id"`
+	ViewsField              int64     `db:"views"`
 
 	CreatedAtField orm.CreatedAt
 	UpdatedAtField orm.UpdatedAt
 	soft_delete.SoftDeletesMaxDate
+
+	// relations loaded on demand, not persisted
+	terms  map[string][]TermInterface
+	media  []MediaInterface
+	author *Author
+
+	// unloaded holds the columns left out of a listing with
+	// PostQueryOptions.Columns or WithoutContent, with the empty value they
+	// were listed with
+	unloaded map[string]string
 }
 
 // ================================== METHODS ==================================
@@ -355,6 +434,19 @@ func (o *postImplementation) IsContentBlocks() bool {
 	return o.GetContentType() == POST_CONTENT_TYPE_BLOCKS
 }
 
+// ContentHtml renders the content to safe HTML with the built-in renderers
+// of NewContentRenderers: Markdown is converted, plain text escaped into
+// paragraphs and HTML sanitized.
+func (o *postImplementation) ContentHtml() (string, error) {
+	return o.ContentHtmlWith(NewContentRenderers())
+}
+
+// ContentHtmlWith renders the content to HTML with the renderer registered
+// for the content type of the post.
+func (o *postImplementation) ContentHtmlWith(renderers ContentRenderers) (string, error) {
+	return renderers.Render(o.GetContentType(), o.GetContent())
+}
+
 // IsTrashed returns true if the post status is POST_STATUS_TRASH.
 func (o *postImplementation) IsTrashed() bool {
 	return o.GetStatus() == POST_STATUS_TRASH
@@ -438,6 +530,39 @@ func (o *postImplementation) SetAuthorID(authorID string) PostInterface {
 	return o
 }
 
+// GetIdempotencyKey returns the key identifying the request that created the post.
+func (o *postImplementation) GetIdempotencyKey() string {
+	return o.Get(COLUMN_IDEMPOTENCY_KEY)
+}
+
+// SetIdempotencyKey sets the key identifying the request that creates the post.
+func (o *postImplementation) SetIdempotencyKey(idempotencyKey string) PostInterface {
+	o.Set(COLUMN_IDEMPOTENCY_KEY, idempotencyKey)
+	return o
+}
+
+// GetLocale returns the language of the post, e.g. "en" or "pt-BR".
+func (o *postImplementation) GetLocale() string {
+	return o.Get(COLUMN_LOCALE)
+}
+
+// SetLocale sets the language of the post, e.g. "en" or "pt-BR".
+func (o *postImplementation) SetLocale(locale string) PostInterface {
+	o.Set(COLUMN_LOCALE, locale)
+	return o
+}
+
+// GetTranslationGroupID returns the ID shared by the language versions of the post.
+func (o *postImplementation) GetTranslationGroupID() string {
+	return o.Get(COLUMN_TRANSLATION_GROUP_ID)
+}
+
+// SetTranslationGroupID sets the ID shared by the language versions of the post.
+func (o *postImplementation) SetTranslationGroupID(translationGroupID string) PostInterface {
+	o.Set(COLUMN_TRANSLATION_GROUP_ID, translationGroupID)
+	return o
+}
+
 // GetCanonicalURL returns the canonical URL for SEO purposes.
 func (o *postImplementation) GetCanonicalURL() string {
 	return o.Get(COLUMN_CANONICAL_URL)
@@ -678,6 +803,40 @@ func (o *postImplementation) GetPublishedAtTime() time.Time {
 	return carbon.Parse(publishedAt).StdTime()
 }
 
+// GetExpiresAt returns the expiry timestamp as a string.
+// Returns an empty string if the post does not expire.
+func (o *postImplementation) GetExpiresAt() string {
+	return o.Get(COLUMN_EXPIRES_AT)
+}
+
+// SetExpiresAt sets the expiry timestamp. An empty string clears it.
+func (o *postImplementation) SetExpiresAt(expiresAt string) PostInterface {
+	o.Set(COLUMN_EXPIRES_AT, expiresAt)
+	return o
+}
+
+// GetExpiresAtTime returns the expiry timestamp as a time.Time instance.
+// Returns zero time if the post does not expire.
+func (o *postImplementation) GetExpiresAtTime() time.Time {
+	return o.ExpiresAtField
+}
+
+// IsExpired returns true if the post has an expiry timestamp at or before at.
+func (o *postImplementation) IsExpired(at time.Time) bool {
+	return !o.ExpiresAtField.IsZero() && !o.ExpiresAtField.After(at)
+}
+
+// GetViewCount returns the number of views of the post.
+func (o *postImplementation) GetViewCount() int64 {
+	return o.ViewsField
+}
+
+// SetViewCount sets the number of views of the post.
+func (o *postImplementation) SetViewCount(views int64) PostInterface {
+	o.ViewsField = views
+	return o
+}
+
 // GetStatus returns the post status (draft, published, trash, etc.).
 func (o *postImplementation) GetStatus() string {
 	return o.Get(COLUMN_STATUS)
@@ -750,25 +909,30 @@ func (o *postImplementation) GetData() map[string]string {
 	}
 
 	return map[string]string{
-		COLUMN_ID:               o.ShortID.ID,
-		COLUMN_AUTHOR_ID:        o.AuthorIDField,
-		COLUMN_CANONICAL_URL:    o.CanonicalURLField,
-		COLUMN_CONTENT:          o.ContentField,
-		COLUMN_FEATURED:         o.FeaturedField,
-		COLUMN_IMAGE_URL:        o.ImageURLField,
-		COLUMN_MEMO:             o.MemoField,
-		COLUMN_META_DESCRIPTION: o.MetaDescriptionField,
-		COLUMN_META_KEYWORDS:    o.MetaKeywordsField,
-		COLUMN_META_ROBOTS:      o.MetaRobotsField,
-		COLUMN_METAS:            o.MetasField,
-		COLUMN_PUBLISHED_AT:     carbon.CreateFromStdTime(o.PublishedAtField).ToDateTimeString(carbon.UTC),
-		COLUMN_STATUS:           o.StatusField,
-		COLUMN_SLUG:             o.SlugField,
-		COLUMN_SUMMARY:          o.SummaryField,
-		COLUMN_TITLE:            o.TitleField,
-		COLUMN_CREATED_AT:       createdAt,
-		COLUMN_UPDATED_AT:       updatedAt,
-		COLUMN_SOFT_DELETED_AT:  softDeletedAt,
+		COLUMN_ID:                   o.ShortID.ID,
+		COLUMN_AUTHOR_ID:            o.AuthorIDField,
+		COLUMN_CANONICAL_URL:        o.CanonicalURLField,
+		COLUMN_CONTENT:              o.ContentField,
+		COLUMN_EXPIRES_AT:           o.GetExpiresAt(),
+		COLUMN_FEATURED:             o.FeaturedField,
+		COLUMN_IDEMPOTENCY_KEY:      o.IdempotencyKeyField,
+		COLUMN_IMAGE_URL:            o.ImageURLField,
+		COLUMN_LOCALE:               o.LocaleField,
+		COLUMN_MEMO:                 o.MemoField,
+		COLUMN_META_DESCRIPTION:     o.MetaDescriptionField,
+		COLUMN_META_KEYWORDS:        o.MetaKeywordsField,
+		COLUMN_META_ROBOTS:          o.MetaRobotsField,
+		COLUMN_METAS:                o.MetasField,
+		COLUMN_PUBLISHED_AT:         carbon.CreateFromStdTime(o.PublishedAtField).ToDateTimeString(carbon.UTC),
+		COLUMN_STATUS:               o.StatusField,
+		COLUMN_SLUG:                 o.SlugField,
+		COLUMN_SUMMARY:              o.SummaryField,
+		COLUMN_TITLE:                o.TitleField,
+		COLUMN_TRANSLATION_GROUP_ID: o.TranslationGroupIDField,
+		COLUMN_VIEWS:                strconv.FormatInt(o.ViewsField, 10),
+		COLUMN_CREATED_AT:           createdAt,
+		COLUMN_UPDATED_AT:           updatedAt,
+		COLUMN_SOFT_DELETED_AT:      softDeletedAt,
 	}
 }
 
@@ -799,10 +963,19 @@ func (o *postImplementation) Get(key string) string {
 		return o.CanonicalURLField
 	case COLUMN_CONTENT:
 		return o.ContentField
+	case COLUMN_EXPIRES_AT:
+		if o.ExpiresAtField.IsZero() {
+			return ""
+		}
+		return carbon.CreateFromStdTime(o.ExpiresAtField).ToDateTimeString(carbon.UTC)
 	case COLUMN_FEATURED:
 		return o.FeaturedField
+	case COLUMN_IDEMPOTENCY_KEY:
+		return o.IdempotencyKeyField
 	case COLUMN_IMAGE_URL:
 		return o.ImageURLField
+	case COLUMN_LOCALE:
+		return o.LocaleField
 	case COLUMN_MEMO:
 		return o.MemoField
 	case COLUMN_META_DESCRIPTION:
@@ -823,6 +996,10 @@ func (o *postImplementation) Get(key string) string {
 		return o.SummaryField
 	case COLUMN_TITLE:
 		return o.TitleField
+	case COLUMN_TRANSLATION_GROUP_ID:
+		return o.TranslationGroupIDField
+	case COLUMN_VIEWS:
+		return strconv.FormatInt(o.ViewsField, 10)
 	case COLUMN_CREATED_AT:
 		if o.CreatedAtField.CreatedAt.IsZero() {
 			return ""
@@ -851,10 +1028,16 @@ func (o *postImplementation) Set(key string, value string) {
 		o.CanonicalURLField = value
 	case COLUMN_CONTENT:
 		o.ContentField = value
+	case COLUMN_EXPIRES_AT:
+		o.ExpiresAtField = postExpiresAtParse(value)
 	case COLUMN_FEATURED:
 		o.FeaturedField = value
+	case COLUMN_IDEMPOTENCY_KEY:
+		o.IdempotencyKeyField = value
 	case COLUMN_IMAGE_URL:
 		o.ImageURLField = value
+	case COLUMN_LOCALE:
+		o.LocaleField = value
 	case COLUMN_MEMO:
 		o.MemoField = value
 	case COLUMN_META_DESCRIPTION:
@@ -875,6 +1058,10 @@ func (o *postImplementation) Set(key string, value string) {
 		o.SummaryField = value
 	case COLUMN_TITLE:
 		o.TitleField = value
+	case COLUMN_TRANSLATION_GROUP_ID:
+		o.TranslationGroupIDField = value
+	case COLUMN_VIEWS:
+		o.ViewsField, _ = strconv.ParseInt(value, 10, 64)
 	case COLUMN_CREATED_AT:
 		if value != "" {
 			o.CreatedAtField.CreatedAt = carbon.Parse(value, carbon.UTC).StdTime()
@@ -905,6 +1092,21 @@ func (o *postImplementation) IsDirty() bool {
 	return false
 }
 
+// postExpiresAtParse parses a stored expiry timestamp. Empty, invalid and
+// null datetimes, as stored for posts without expiry, give the zero time.
+func postExpiresAtParse(value string) time.Time {
+	if value == "" || value == NULL_DATETIME || value == neat.NullDateTime {
+		return time.Time{}
+	}
+
+	parsed := carbon.Parse(value, carbon.UTC)
+	if parsed.Error != nil || parsed.IsInvalid() {
+		return time.Time{}
+	}
+
+	return parsed.StdTime()
+}
+
 // ============================ TAXONOMY METHODS ============================
 
 // TermIDs retrieves the term IDs for a specific taxonomy from the post metadata.
@@ -970,6 +1172,75 @@ func (o *postImplementation) SetTagIDs(ids []string) PostInterface {
 	return o.SetTermIDs(TAXONOMY_TAG, ids)
 }
 
+// ============================ RELATION METHODS ============================
+
+// Terms returns the loaded terms of a taxonomy, or nil if they were not loaded.
+func (o *postImplementation) Terms(taxonomySlug string) []TermInterface {
+	if o.terms == nil {
+		return nil
+	}
+	return o.terms[taxonomySlug]
+}
+
+// SetTerms sets the loaded terms of a taxonomy.
+func (o *postImplementation) SetTerms(taxonomySlug string, terms []TermInterface) PostInterface {
+	if o.terms == nil {
+		o.terms = map[string][]TermInterface{}
+	}
+	o.terms[taxonomySlug] = terms
+	return o
+}
+
+// Categories returns the loaded category terms, or nil if they were not loaded.
+func (o *postImplementation) Categories() []TermInterface {
+	return o.Terms(TAXONOMY_CATEGORY)
+}
+
+// Tags returns the loaded tag terms, or nil if they were not loaded.
+func (o *postImplementation) Tags() []TermInterface {
+	return o.Terms(TAXONOMY_TAG)
+}
+
+// Media returns the loaded media, or nil if they were not loaded.
+func (o *postImplementation) Media() []MediaInterface {
+	return o.media
+}
+
+// SetMedia sets the loaded media.
+func (o *postImplementation) SetMedia(media []MediaInterface) PostInterface {
+	o.media = media
+	return o
+}
+
+// Author returns the loaded author profile, or nil if it was not loaded.
+func (o *postImplementation) Author() *Author {
+	return o.author
+}
+
+// SetAuthor sets the loaded author profile.
+func (o *postImplementation) SetAuthor(author *Author) PostInterface {
+	o.author = author
+	return o
+}
+
+// ============================ PARTIAL LOAD ============================
+
+// postUnloadedColumns returns the columns the post was listed without and
+// the values they were listed with, or nil for a fully loaded post.
+func postUnloadedColumns(post PostInterface) map[string]string {
+	if o, ok := post.(*postImplementation); ok {
+		return o.unloaded
+	}
+	return nil
+}
+
+// postSetUnloadedColumns records the columns the post was listed without.
+func postSetUnloadedColumns(post PostInterface, unloaded map[string]string) {
+	if o, ok := post.(*postImplementation); ok {
+		o.unloaded = unloaded
+	}
+}
+
 // ============================ OLD SLUG METHODS ============================
 
 // GetOldSlugs retrieves the array of historical slugs for redirect purposes.
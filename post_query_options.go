@@ -11,8 +11,29 @@ type PostQueryOptions struct {
 	Status string
 	// StatusIn filters by multiple post statuses.
 	StatusIn []string
+	// AuthorID filters by the author of the post.
+	AuthorID string
+	// Featured filters by the featured flag, "yes" or "no".
+	Featured string
+	// IdempotencyKey filters by the key of the request that created the post.
+	IdempotencyKey string
 	// Slug filters by the post slug.
 	Slug string
+	// CanonicalURL filters by the canonical URL, e.g. of an imported or
+	// syndicated article.
+	CanonicalURL string
+	// Locale filters by the language of the post, e.g. "en".
+	Locale string
+	// TranslationGroupID filters the language versions of the same article.
+	TranslationGroupID string
+	// TermID filters posts associated with the term, e.g. a term of the
+	// TAXONOMY_CATEGORY taxonomy to list the posts of a category. Requires
+	// TaxonomyEnabled; without taxonomy support no post matches.
+	TermID string
+	// TermIDIn filters posts associated with any of the terms, e.g. the
+	// terms of the TAXONOMY_TAG taxonomy to list the posts with some tags.
+	// Requires TaxonomyEnabled; without taxonomy support no post matches.
+	TermIDIn []string
 	// OldSlug filters posts where the old slugs array contains this value.
 	OldSlug string
 	// Search performs a case-insensitive search on title and content.
@@ -21,6 +42,24 @@ type PostQueryOptions struct {
 	CreatedAtLessThan string
 	// CreatedAtGreaterThan filters posts created after this timestamp.
 	CreatedAtGreaterThan string
+	// UpdatedAtLessThan filters posts last updated before this timestamp.
+	UpdatedAtLessThan string
+	// TrashedAtLessThan filters posts moved to the trash before this
+	// timestamp, e.g. to purge them. Posts not in the trash never match.
+	TrashedAtLessThan string
+	// PublishedAtLessThan filters posts published before this timestamp.
+	PublishedAtLessThan string
+	// PublishedAtGreaterThan filters posts published after this timestamp.
+	PublishedAtGreaterThan string
+	// PublishedBeforeNow filters out posts with a publication date in the
+	// future, e.g. scheduled posts, for public listings.
+	PublishedBeforeNow bool
+	// ExpiredBeforeNow filters posts with an expiry timestamp that has
+	// passed, e.g. to unpublish them. Posts without an expiry never match.
+	ExpiredBeforeNow bool
+	// ViewsGreaterThan filters posts with more views than this, as counted by
+	// PostIncrementViewCount. Order by COLUMN_VIEWS for "most read" listings.
+	ViewsGreaterThan int64
 	// Offset is the number of records to skip for pagination.
 	Offset int
 	// Limit is the maximum number of records to return.
@@ -29,6 +68,15 @@ type PostQueryOptions struct {
 	SortOrder string
 	// OrderBy is the field to sort by.
 	OrderBy string
+	// OrderByMulti sorts by several columns, each with its own direction,
+	// applied after OrderBy when both are set.
+	// Example: OrderByMulti: []OrderSpec{{Column: COLUMN_FEATURED, Direction: "desc"}, {Column: COLUMN_PUBLISHED_AT, Direction: "desc"}}
+	OrderByMulti []OrderSpec
+	// Random returns the posts in random order, e.g. for "random post" and
+	// "you may also like" widgets. It applies after OrderBy and OrderByMulti,
+	// so it only shuffles posts sorting equally by those.
+	// Example: Status: POST_STATUS_PUBLISHED, Random: true, Limit: 3
+	Random bool
 	// CountOnly returns only the count, not the actual records.
 	CountOnly bool
 	// WithDeleted includes soft-deleted posts in the results.
@@ -39,4 +87,20 @@ type PostQueryOptions struct {
 	// MetaArrayContains filters posts where the meta JSON column's array field contains the specified value.
 	// Example: MetaArrayContains: map[string]string{"_old_slugs": "11"}
 	MetaArrayContains map[string]string
+	// WithoutContent omits the content column from the query, reducing memory
+	// for listings. Load it on demand with PostLoadContent. PostUpdate keeps
+	// the stored content of such a post unless it was set since.
+	WithoutContent bool
+	// Columns selects only these columns of the post table, for lightweight
+	// listings such as index pages. The ID is always selected; the fields of
+	// the other columns are left empty on the listed posts. PostUpdate fills
+	// the columns that were not selected, and not set since, with their
+	// stored values before saving, so a listed post can be saved back
+	// without losing them.
+	// Example: Columns: []string{COLUMN_TITLE, COLUMN_SUMMARY, COLUMN_PUBLISHED_AT}
+	Columns []string
+	// With lists the relations to eager load with each post, using one batched
+	// query per relation instead of one query per post.
+	// Example: With: []string{POST_WITH_CATEGORIES, POST_WITH_TAGS, POST_WITH_MEDIA, POST_WITH_AUTHOR}
+	With []string
 }
@@ -3,6 +3,7 @@ package blogstore
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/dracory/sb"
 )
@@ -167,6 +168,31 @@ func TestPostStatusHelpers(t *testing.T) {
 	}
 }
 
+func TestPostExpiresAt(t *testing.T) {
+	p := NewPost()
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if p.GetExpiresAt() != "" || p.IsExpired(at) {
+		t.Errorf("a new post should not expire, got ExpiresAt %q", p.GetExpiresAt())
+	}
+
+	p.SetExpiresAt("2026-05-01 12:00:00")
+	if p.GetExpiresAt() != "2026-05-01 12:00:00" {
+		t.Errorf("GetExpiresAt() = %q, want the set expiry", p.GetExpiresAt())
+	}
+	if !p.IsExpired(at) {
+		t.Errorf("IsExpired() = false, want true at the expiry time")
+	}
+	if p.IsExpired(at.Add(-time.Second)) {
+		t.Errorf("IsExpired() = true, want false before the expiry time")
+	}
+
+	p.SetExpiresAt("")
+	if !p.GetExpiresAtTime().IsZero() {
+		t.Errorf("GetExpiresAtTime() = %v, want zero after clearing", p.GetExpiresAtTime())
+	}
+}
+
 func TestPostSlugAndImageUrlOrDefault(t *testing.T) {
 	p := NewPost()
 
@@ -0,0 +1,22 @@
+package blogstore
+
+import "context"
+
+// forcePrimaryContextKey is the context key making reads use the primary
+// database instead of the read replica.
+type forcePrimaryContextKey struct{}
+
+// ContextWithForcePrimary returns a copy of the context making PostList,
+// PostCount and PostFindByID read from the primary database even when a
+// NewStoreOptions.ReadDB replica is configured, e.g. to read a post right
+// after writing it, before the replica caught up.
+func ContextWithForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcePrimaryContextKey{}, true)
+}
+
+// forcePrimaryFromContext returns true if the context was made by
+// ContextWithForcePrimary.
+func forcePrimaryFromContext(ctx context.Context) bool {
+	forcePrimary, _ := ctx.Value(forcePrimaryContextKey{}).(bool)
+	return forcePrimary
+}
@@ -0,0 +1,280 @@
+package blogstore
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// SanitizerInterface cleans the content of HTML posts before it is stored,
+// preventing stored XSS from untrusted or AI generated content. Set it with
+// NewStoreOptions.Sanitizer.
+type SanitizerInterface interface {
+	// Sanitize returns the HTML with the dangerous tags and attributes removed.
+	Sanitize(content string) string
+}
+
+// SanitizerFunc adapts a function to SanitizerInterface.
+type SanitizerFunc func(content string) string
+
+// Sanitize calls f(content).
+func (f SanitizerFunc) Sanitize(content string) string {
+	return f(content)
+}
+
+// sanitizerTags lists the tags kept by the HTML sanitizer, with the
+// attributes they may carry besides sanitizerGlobalAttributes.
+var sanitizerTags = map[string][]string{
+	"a": {"href", "rel", "target", "name"}, "abbr": {}, "b": {}, "blockquote": {"cite"},
+	"br": {}, "caption": {}, "cite": {}, "code": {}, "dd": {}, "del": {}, "div": {},
+	"dl": {}, "dt": {}, "em": {}, "figcaption": {}, "figure": {}, "h1": {}, "h2": {},
+	"h3": {}, "h4": {}, "h5": {}, "h6": {}, "hr": {}, "i": {},
+	"img": {"src", "alt", "width", "height"}, "ins": {}, "kbd": {}, "li": {},
+	"mark": {}, "ol": {"start"}, "p": {}, "pre": {}, "q": {"cite"}, "s": {},
+	"small": {}, "span": {}, "strong": {}, "sub": {}, "sup": {}, "table": {},
+	"tbody": {}, "td": {"colspan", "rowspan"}, "tfoot": {}, "th": {"colspan", "rowspan", "scope"},
+	"thead": {}, "time": {"datetime"}, "tr": {}, "u": {}, "ul": {},
+}
+
+// sanitizerGlobalAttributes lists the attributes kept on every allowed tag.
+var sanitizerGlobalAttributes = []string{"class", "id", "title", "lang", "dir"}
+
+// sanitizerDroppedTags lists the tags removed together with their content.
+// Other unknown tags are removed but their content is kept.
+var sanitizerDroppedTags = []string{"script", "style", "iframe", "frame", "frameset", "object", "embed", "applet", "noscript", "template", "svg", "math", "textarea", "select"}
+
+// sanitizerURLAttributes lists the attributes holding URLs, which must be
+// relative or use one of sanitizerURLSchemes.
+var sanitizerURLAttributes = []string{"href", "src", "cite"}
+
+var sanitizerURLSchemes = []string{"http", "https", "mailto", "tel"}
+
+// sanitizerVoidTags lists the allowed tags without a closing tag.
+var sanitizerVoidTags = []string{"br", "hr", "img"}
+
+// NewHTMLSanitizer returns the built-in sanitizer. It keeps a conservative
+// allowlist of formatting tags and attributes, removes scripts, styles,
+// frames and embedded objects with their content, drops event handler and
+// style attributes, and only keeps links and images with http, https,
+// mailto, tel or relative URLs. Comments are removed and a '<' not starting
+// a tag is escaped.
+func NewHTMLSanitizer() SanitizerInterface {
+	return SanitizerFunc(sanitizeHTML)
+}
+
+// sanitizeHTML implements the built-in sanitizer.
+func sanitizeHTML(content string) string {
+	var b strings.Builder
+	b.Grow(len(content))
+
+	for i := 0; i < len(content); {
+		if content[i] != '<' {
+			j := strings.IndexByte(content[i:], '<')
+			if j < 0 {
+				j = len(content) - i
+			}
+			b.WriteString(content[i : i+j])
+			i += j
+			continue
+		}
+
+		// comments, doctypes and processing instructions
+		if strings.HasPrefix(content[i:], "<!--") {
+			end := strings.Index(content[i+4:], "-->")
+			if end < 0 {
+				break
+			}
+			i += 4 + end + 3
+			continue
+		}
+		if strings.HasPrefix(content[i:], "<!") || strings.HasPrefix(content[i:], "<?") {
+			end := strings.IndexByte(content[i:], '>')
+			if end < 0 {
+				break
+			}
+			i += end + 1
+			continue
+		}
+
+		tag, next, ok := sanitizerParseTag(content, i)
+		if !ok {
+			b.WriteString("&lt;")
+			i++
+			continue
+		}
+		i = next
+
+		if slices.Contains(sanitizerDroppedTags, tag.name) {
+			if !tag.closing && !tag.selfClosing {
+				i = sanitizerSkipElement(content, i, tag.name)
+			}
+			continue
+		}
+
+		attributes, allowed := sanitizerTags[tag.name]
+		if !allowed {
+			continue
+		}
+
+		if tag.closing {
+			if !slices.Contains(sanitizerVoidTags, tag.name) {
+				b.WriteString("</" + tag.name + ">")
+			}
+			continue
+		}
+
+		hasTarget := tag.name == "a" && slices.ContainsFunc(tag.attributes, func(a sanitizerAttribute) bool { return a.name == "target" })
+
+		b.WriteString("<" + tag.name)
+		for _, attribute := range tag.attributes {
+			if hasTarget && attribute.name == "rel" {
+				continue
+			}
+			if !slices.Contains(attributes, attribute.name) && !slices.Contains(sanitizerGlobalAttributes, attribute.name) {
+				continue
+			}
+			value := html.UnescapeString(attribute.value)
+			if slices.Contains(sanitizerURLAttributes, attribute.name) && !sanitizerURLAllowed(value) {
+				continue
+			}
+			b.WriteString(" " + attribute.name + `="` + html.EscapeString(value) + `"`)
+		}
+		if hasTarget {
+			// keep the opened page from controlling the opener
+			b.WriteString(` rel="noopener noreferrer"`)
+		}
+		b.WriteString(">")
+	}
+
+	return b.String()
+}
+
+type sanitizerTag struct {
+	name        string
+	closing     bool
+	selfClosing bool
+	attributes  []sanitizerAttribute
+}
+
+type sanitizerAttribute struct {
+	name  string
+	value string
+}
+
+// sanitizerParseTag parses the tag starting at content[start], which is a
+// '<', returning the tag and the index after it. It returns false if no tag
+// starts there.
+func sanitizerParseTag(content string, start int) (sanitizerTag, int, bool) {
+	tag := sanitizerTag{}
+	i := start + 1
+
+	if i < len(content) && content[i] == '/' {
+		tag.closing = true
+		i++
+	}
+
+	nameStart := i
+	for i < len(content) && sanitizerIsNameByte(content[i], i == nameStart) {
+		i++
+	}
+	if i == nameStart {
+		return tag, start, false
+	}
+	tag.name = strings.ToLower(content[nameStart:i])
+
+	for i < len(content) {
+		switch c := content[i]; {
+		case c == '>':
+			return tag, i + 1, true
+		case c == '/':
+			tag.selfClosing = true
+			i++
+		case sanitizerIsSpace(c):
+			i++
+		default:
+			attributeStart := i
+			for i < len(content) && !sanitizerIsSpace(content[i]) && content[i] != '=' && content[i] != '>' && content[i] != '/' {
+				i++
+			}
+			attribute := sanitizerAttribute{name: strings.ToLower(content[attributeStart:i])}
+
+			for i < len(content) && sanitizerIsSpace(content[i]) {
+				i++
+			}
+			if i < len(content) && content[i] == '=' {
+				i++
+				for i < len(content) && sanitizerIsSpace(content[i]) {
+					i++
+				}
+				if i < len(content) && (content[i] == '"' || content[i] == '\'') {
+					quote := content[i]
+					end := strings.IndexByte(content[i+1:], quote)
+					if end < 0 {
+						return tag, start, false
+					}
+					attribute.value = content[i+1 : i+1+end]
+					i += end + 2
+				} else {
+					valueStart := i
+					for i < len(content) && !sanitizerIsSpace(content[i]) && content[i] != '>' {
+						i++
+					}
+					attribute.value = content[valueStart:i]
+				}
+			}
+
+			tag.attributes = append(tag.attributes, attribute)
+		}
+	}
+
+	// unterminated tag
+	return tag, start, false
+}
+
+// sanitizerSkipElement returns the index after the closing tag of the
+// element named name, or the end of the content if it is not closed.
+func sanitizerSkipElement(content string, start int, name string) int {
+	for i := start; i < len(content)-1; i++ {
+		if content[i] != '<' || content[i+1] != '/' {
+			continue
+		}
+		if tag, next, ok := sanitizerParseTag(content, i); ok && tag.name == name {
+			return next
+		}
+	}
+	return len(content)
+}
+
+// sanitizerURLAllowed returns true for relative URLs and URLs with one of
+// the allowed schemes.
+func sanitizerURLAllowed(value string) bool {
+	// browsers ignore whitespace and control characters in the scheme
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, value)
+
+	colon := strings.IndexByte(cleaned, ':')
+	if colon < 0 {
+		return true
+	}
+	// a colon after a path, query or fragment character is not a scheme
+	if slash := strings.IndexAny(cleaned, "/?#"); slash >= 0 && slash < colon {
+		return true
+	}
+
+	return slices.Contains(sanitizerURLSchemes, strings.ToLower(cleaned[:colon]))
+}
+
+func sanitizerIsNameByte(c byte, first bool) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+		return true
+	}
+	return !first && (c >= '0' && c <= '9' || c == '-')
+}
+
+func sanitizerIsSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,64 @@
+package blogstore
+
+import "testing"
+
+func TestHTMLSanitizer(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"formatting kept": {
+			`<p class="lead">Hello <strong>world</strong><br/></p>`,
+			`<p class="lead">Hello <strong>world</strong><br></p>`,
+		},
+		"script removed with content": {
+			`<p>Hi</p><script>alert(1)</script><SCRIPT src="x.js"></SCRIPT>`,
+			`<p>Hi</p>`,
+		},
+		"event handlers and styles removed": {
+			`<img src="/a.png" onerror="alert(1)" style="x" alt="A">`,
+			`<img src="/a.png" alt="A">`,
+		},
+		"javascript urls removed": {
+			`<a href=" jav&#x09;ascript:alert(1)">x</a><a href="JavaScript:alert(1)">y</a>`,
+			`<a>x</a><a>y</a>`,
+		},
+		"safe urls kept": {
+			`<a href="https://example.com/?a=1&amp;b=2">x</a><a href="/about">y</a><a href="mailto:me@example.com">z</a>`,
+			`<a href="https://example.com/?a=1&amp;b=2">x</a><a href="/about">y</a><a href="mailto:me@example.com">z</a>`,
+		},
+		"target adds rel": {
+			`<a href="https://example.com" target="_blank" rel="opener">x</a>`,
+			`<a href="https://example.com" target="_blank" rel="noopener noreferrer">x</a>`,
+		},
+		"unknown tags unwrapped": {
+			`<section><p>Text</p></section><form action="/x"><input name="a">Go</form>`,
+			`<p>Text</p>Go`,
+		},
+		"comments removed": {
+			`a<!-- <script>alert(1)</script> -->b`,
+			`ab`,
+		},
+		"frames removed": {
+			`<iframe src="https://evil.example"></iframe><svg><script>alert(1)</script></svg>ok`,
+			`ok`,
+		},
+		"stray brackets escaped": {
+			`1 < 2 and <3 >`,
+			`1 &lt; 2 and &lt;3 >`,
+		},
+		"attribute values escaped": {
+			`<span title='a "quoted" <b>'>x</span>`,
+			`<span title="a &#34;quoted&#34; &lt;b&gt;">x</span>`,
+		},
+	}
+
+	sanitizer := NewHTMLSanitizer()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := sanitizer.Sanitize(tt.content); got != tt.want {
+				t.Fatalf("Sanitize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
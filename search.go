@@ -0,0 +1,175 @@
+package blogstore
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Default highlight tags and snippet length of PostSearchRanked
+const SEARCH_HIGHLIGHT_OPEN = "<mark>"
+const SEARCH_HIGHLIGHT_CLOSE = "</mark>"
+const SEARCH_SNIPPET_LENGTH = 160
+
+// A term in the title counts as much as this many in the content
+const searchTitleWeight = 3.0
+
+// Bonus for the whole query found as a phrase in the title or the content
+const searchPhraseTitleBonus = 5.0
+const searchPhraseContentBonus = 2.0
+
+// SearchOptions configures PostSearchRanked.
+type SearchOptions struct {
+	// Status filters by post status. Empty searches posts of any status.
+	Status string
+	// Offset is the number of ranked results to skip.
+	Offset int
+	// Limit is the maximum number of results. Zero returns all.
+	Limit int
+	// HighlightOpen and HighlightClose wrap the matched terms in the
+	// snippets. They default to "<mark>" and "</mark>".
+	HighlightOpen  string
+	HighlightClose string
+	// SnippetLength is the length of the content snippet in characters.
+	// Defaults to 160.
+	SnippetLength int
+}
+
+// SearchResult is a post matching a search, with its relevance score and
+// the title and a content excerpt highlighted. The snippets are HTML: the
+// text is escaped and the matches are wrapped in the highlight tags.
+type SearchResult struct {
+	Post           PostInterface
+	Score          float64
+	TitleSnippet   string
+	ContentSnippet string
+}
+
+var searchTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// searchTerms splits a query into its distinct lower case terms.
+func searchTerms(query string) []string {
+	terms := []string{}
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// searchPlainText returns the text of post content, with the markup
+// removed and the whitespace collapsed.
+func searchPlainText(content string) string {
+	text := html.UnescapeString(searchTagPattern.ReplaceAllString(content, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// searchScore scores a post by the occurrences of the terms, a title match
+// weighing more than a content match, and the whole query found as a phrase
+// adding a bonus.
+func searchScore(title string, content string, query string, terms []string) float64 {
+	title = strings.ToLower(title)
+	content = strings.ToLower(content)
+
+	score := 0.0
+	for _, term := range terms {
+		score += searchTitleWeight * float64(strings.Count(title, term))
+		score += float64(strings.Count(content, term))
+	}
+
+	if len(terms) > 1 {
+		phrase := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+		if strings.Contains(title, phrase) {
+			score += searchPhraseTitleBonus
+		}
+		if strings.Contains(content, phrase) {
+			score += searchPhraseContentBonus
+		}
+	}
+
+	return score
+}
+
+// searchMatches marks the characters of the text covered by a term,
+// ignoring case.
+func searchMatches(text []rune, terms []string) []bool {
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	matched := make([]bool, len(text))
+	for _, term := range terms {
+		needle := []rune(term)
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == term {
+				for j := i; j < i+len(needle); j++ {
+					matched[j] = true
+				}
+			}
+		}
+	}
+
+	return matched
+}
+
+// searchHighlight escapes the text and wraps the runs of matched characters
+// in the highlight tags.
+func searchHighlight(text []rune, matched []bool, open string, close string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && matched[j] == matched[i] {
+			j++
+		}
+		if matched[i] {
+			b.WriteString(open)
+			b.WriteString(html.EscapeString(string(text[i:j])))
+			b.WriteString(close)
+		} else {
+			b.WriteString(html.EscapeString(string(text[i:j])))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// searchSnippet returns an excerpt of the text of about the given length
+// around the first match, highlighted, with an ellipsis where it is cut.
+func searchSnippet(text string, terms []string, length int, open string, close string) string {
+	runes := []rune(text)
+	matched := searchMatches(runes, terms)
+
+	first := 0
+	for i, m := range matched {
+		if m {
+			first = i
+			break
+		}
+	}
+
+	start := 0
+	if len(runes) > length {
+		start = max(0, min(first-length/4, len(runes)-length))
+		// start on a word
+		for start > 0 && start < first && !unicode.IsSpace(runes[start-1]) {
+			start++
+		}
+	}
+	end := min(len(runes), start+length)
+
+	snippet := searchHighlight(runes[start:end], matched[start:end], open, close)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
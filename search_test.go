@@ -0,0 +1,42 @@
+package blogstore
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchHighlight(t *testing.T) {
+	text := []rune("Go & GOlang <tips>")
+	got := searchHighlight(text, searchMatches(text, []string{"go"}), "<b>", "</b>")
+	want := "<b>Go</b> &amp; <b>GO</b>lang &lt;tips&gt;"
+	if got != want {
+		t.Fatalf("searchHighlight() = %q, want %q", got, want)
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	text := strings.Repeat("filler ", 40) + "the needle is here " + strings.Repeat("padding ", 40)
+
+	snippet := searchSnippet(text, []string{"needle"}, 60, "[", "]")
+	if !strings.Contains(snippet, "[needle]") {
+		t.Fatalf("snippet %q does not highlight the match", snippet)
+	}
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Fatalf("snippet %q is not marked as cut on both ends", snippet)
+	}
+	if strings.HasPrefix(snippet, "…iller") || strings.HasPrefix(snippet, "…ller") {
+		t.Fatalf("snippet %q does not start on a word", snippet)
+	}
+
+	short := searchSnippet("a short needle", []string{"needle"}, 60, "[", "]")
+	if short != "a short [needle]" {
+		t.Fatalf("searchSnippet() of a short text = %q, want it whole", short)
+	}
+}
+
+func TestSearchPlainText(t *testing.T) {
+	got := searchPlainText("<p>Fish &amp; chips</p>\n<p>  today</p>")
+	if got != "Fish & chips today" {
+		t.Fatalf("searchPlainText() = %q", got)
+	}
+}
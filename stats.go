@@ -0,0 +1,20 @@
+package blogstore
+
+import "time"
+
+// Stats holds aggregate numbers about the blog, as returned by StoreInterface.Stats.
+// Soft-deleted posts are not counted.
+type Stats struct {
+	// TotalPosts is the number of posts, regardless of status.
+	TotalPosts int64
+	// PostsByStatus is the number of posts per status.
+	PostsByStatus map[string]int64
+	// PostsByAuthor is the number of posts per author ID.
+	PostsByAuthor map[string]int64
+	// PostsByMonth is the number of posts per creation month, keyed as "YYYY-MM".
+	PostsByMonth map[string]int64
+	// TotalVersions is the number of version entries. Zero if versioning is disabled.
+	TotalVersions int64
+	// GeneratedAt is the time the numbers were computed.
+	GeneratedAt time.Time
+}
@@ -0,0 +1,15 @@
+package blogstore
+
+import "time"
+
+// StatusChange records a change of the status of a post.
+type StatusChange struct {
+	ID     string
+	PostID string
+	// FromStatus is the status before the change, empty when the post was created.
+	FromStatus string
+	ToStatus   string
+	// Actor is the actor from the context of the change, if any.
+	Actor     string
+	CreatedAt time.Time
+}
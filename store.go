@@ -6,17 +6,27 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dracory/neat"
+	contractsdatabase "github.com/dracory/neat/contracts/database"
 	contractsorm "github.com/dracory/neat/contracts/database/orm"
 	contractsschema "github.com/dracory/neat/contracts/database/schema"
 	"github.com/dracory/neat/database/schema/constants"
 	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
 )
 
 // StoreInterface defines the complete interface for blog post storage operations,
 // including post management, taxonomy/term handling, and optional versioning support.
+//
+// A store is safe for concurrent use by multiple goroutines, so a single instance
+// can be shared by all request handlers. The Set*TableName methods are part of
+// the configuration and must be called before the store is shared.
 type StoreInterface interface {
 	// GetPostTableName returns the post table name
 	GetPostTableName() string
@@ -47,25 +57,244 @@ type StoreInterface interface {
 	MigrateDown(ctx context.Context, tx ...*sql.Tx) error
 	// MigrateUp creates the blog store tables
 	MigrateUp(ctx context.Context, tx ...*sql.Tx) error
+	// MigrateDownTo reverts the schema migrations of the post tables applied
+	// after the version, newest first
+	MigrateDownTo(ctx context.Context, version int) error
+	// SchemaVersion returns the version of the latest schema migration applied
+	// to the post tables
+	SchemaVersion(ctx context.Context) (int, error)
+	// GetMigrationsTableName returns the table recording the applied schema migrations
+	GetMigrationsTableName() string
 
 	// EnableDebug toggles debug mode logging for database operations.
 	// Returns the StoreInterface to allow method chaining.
 	EnableDebug(debug bool) StoreInterface
 
+	// DBStats returns a snapshot of the connection pool statistics of the underlying database.
+	DBStats() sql.DBStats
+	// Ping verifies the database connection is alive.
+	Ping(ctx context.Context) error
+	// Healthy checks the database is reachable and the required tables exist.
+	Healthy(ctx context.Context) HealthReport
+
+	// Close flushes pending asynchronous version writes and stops the background
+	// worker. It does not close the underlying database, which is owned by the caller.
+	Close() error
+
 	// VersioningEnabled returns true if versioning support is enabled for this store.
 	VersioningEnabled() bool
 
+	// Timezone returns the location used by FormatDateTime and ParseDateTime.
+	Timezone() *time.Location
+	// FormatDateTime converts a stored UTC datetime string to the store timezone and format.
+	FormatDateTime(value string) string
+	// ParseDateTime converts a datetime string in the store timezone and format
+	// to the UTC datetime string the store expects.
+	ParseDateTime(value string) (string, error)
+
+	// Stats returns cached aggregate statistics (posts per status, author and month,
+	// total versions), recomputed once older than the configured cache TTL.
+	Stats(ctx context.Context) (Stats, error)
+	// StatsRefresh recomputes the statistics, bypassing and updating the cache.
+	StatsRefresh(ctx context.Context) (Stats, error)
+	// PostArchiveMonths returns the number of published posts per
+	// publication month, newest first, for blog archive sidebars.
+	PostArchiveMonths(ctx context.Context, options PostQueryOptions) ([]ArchiveMonth, error)
+
+	// ArchiveEnabled returns true if soft-deleted posts are moved to the archive table.
+	ArchiveEnabled() bool
+	// GetArchiveTableName returns the archive table name
+	GetArchiveTableName() string
+	// PostArchiveList retrieves soft-deleted posts from the archive table.
+	PostArchiveList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error)
+	// PostUnarchive moves an archived post back to the post table as an active post.
+	PostUnarchive(ctx context.Context, id string) error
+
+	// AuditEnabled returns true if mutations are recorded in the audit table.
+	AuditEnabled() bool
+	// GetAuditTableName returns the audit table name
+	GetAuditTableName() string
+	// AuditList retrieves audit entries matching the given options, newest first.
+	AuditList(ctx context.Context, options AuditQueryOptions) ([]AuditEntry, error)
+
+	// PreviewEnabled returns true if preview tokens can be created.
+	PreviewEnabled() bool
+	// GetPreviewTableName returns the preview token table name
+	GetPreviewTableName() string
+	// PostPreviewTokenCreate creates a token granting read access to the post,
+	// whatever its status, until the TTL elapses.
+	PostPreviewTokenCreate(ctx context.Context, postID string, ttl time.Duration) (string, error)
+	// PostPreviewTokenValidate returns the post the token grants access to,
+	// or ErrPreviewTokenInvalid if the token is unknown, revoked or expired.
+	PostPreviewTokenValidate(ctx context.Context, token string) (PostInterface, error)
+	// PostPreviewTokenRevoke invalidates a preview token.
+	PostPreviewTokenRevoke(ctx context.Context, token string) error
+	// PostPreviewTokenRevokeAll invalidates all preview tokens of a post.
+	PostPreviewTokenRevokeAll(ctx context.Context, postID string) error
+
+	// AutosaveEnabled returns true if in-progress edits can be autosaved.
+	AutosaveEnabled() bool
+	// GetAutosaveTableName returns the autosave table name
+	GetAutosaveTableName() string
+	// PostAutosave saves the in-progress content of a post apart from the post,
+	// replacing its previous autosave.
+	PostAutosave(ctx context.Context, postID string, content string) error
+	// PostAutosaveFind returns the autosave of a post, or nil if there is none.
+	PostAutosaveFind(ctx context.Context, postID string) (*Autosave, error)
+	// PostAutosaveRestore saves the autosaved content into the post and removes the autosave.
+	PostAutosaveRestore(ctx context.Context, postID string) error
+	// PostAutosaveDiscard removes the autosave of a post.
+	PostAutosaveDiscard(ctx context.Context, postID string) error
+
+	// LockEnabled returns true if posts can be locked for editing.
+	LockEnabled() bool
+	// GetLockTableName returns the lock table name
+	GetLockTableName() string
+	// PostLock locks a post for editing by the owner until the TTL elapses,
+	// or returns ErrPostLocked if another owner holds it.
+	PostLock(ctx context.Context, postID string, owner string, ttl time.Duration) error
+	// PostUnlock releases the owner's lock on a post.
+	PostUnlock(ctx context.Context, postID string, owner string) error
+	// PostLockStatus returns the lock on a post, or nil if it is not locked.
+	PostLockStatus(ctx context.Context, postID string) (*Lock, error)
+
+	// ViewsEnabled returns true if post views are counted in the views table.
+	ViewsEnabled() bool
+	// GetViewsTableName returns the views table name
+	GetViewsTableName() string
+	// PostRecordView counts a view of the post on the calendar day of the given time.
+	PostRecordView(ctx context.Context, postID string, day time.Time) error
+	// PostViewsByDay returns the daily views of a post, or of all posts for an
+	// empty ID, between two days inclusive.
+	PostViewsByDay(ctx context.Context, postID string, from time.Time, to time.Time) ([]PostViewsDay, error)
+	// PostViewsTop returns the most viewed posts between two days inclusive.
+	PostViewsTop(ctx context.Context, from time.Time, to time.Time, limit int) ([]PostViewsTotal, error)
+
+	// PostListPopular returns the published posts with the most views since
+	// the given day, most viewed first.
+	PostListPopular(ctx context.Context, since time.Time, limit int) ([]PostInterface, error)
+
+	// ReactionsEnabled returns true if post reactions are counted in the reactions table.
+	ReactionsEnabled() bool
+	// GetReactionsTableName returns the reactions table name
+	GetReactionsTableName() string
+	// PostReactionIncrement counts a reaction of the given type to a post.
+	PostReactionIncrement(ctx context.Context, postID string, reaction string) error
+	// PostReactionsSummary returns the number of reactions to a post per type.
+	PostReactionsSummary(ctx context.Context, postID string) (map[string]int64, error)
+
+	// VariantsEnabled returns true if posts can have title and summary variants.
+	VariantsEnabled() bool
+	// GetVariantsTableName returns the variants table name
+	GetVariantsTableName() string
+	// PostVariantCreate adds a title and summary variant to a post.
+	PostVariantCreate(ctx context.Context, variant *Variant) error
+	// PostVariantList returns the variants of a post, oldest first.
+	PostVariantList(ctx context.Context, postID string) ([]Variant, error)
+	// PostVariantPick picks a variant of a post in proportion to the weights,
+	// or returns nil if the post has no variants.
+	PostVariantPick(ctx context.Context, postID string) (*Variant, error)
+	// PostVariantDelete removes a variant with its counters.
+	PostVariantDelete(ctx context.Context, id string) error
+	// PostVariantRecordImpression counts one showing of a variant.
+	PostVariantRecordImpression(ctx context.Context, id string) error
+	// PostVariantRecordClick counts one click on a variant.
+	PostVariantRecordClick(ctx context.Context, id string) error
+
+	// AuthorsEnabled returns true if author profiles are stored in the authors table.
+	AuthorsEnabled() bool
+	// GetAuthorsTableName returns the authors table name
+	GetAuthorsTableName() string
+	// AuthorCreate inserts an author profile.
+	AuthorCreate(ctx context.Context, author *Author) error
+	// AuthorUpdate saves the changes of an author profile.
+	AuthorUpdate(ctx context.Context, author *Author) error
+	// AuthorDelete removes an author profile. The posts keep their author ID.
+	AuthorDelete(ctx context.Context, id string) error
+	// AuthorFindByID retrieves an author profile by ID, or nil if not found.
+	AuthorFindByID(ctx context.Context, id string) (*Author, error)
+	// AuthorList retrieves the author profiles matching the query options,
+	// ordered by display name.
+	AuthorList(ctx context.Context, options AuthorQueryOptions) ([]Author, error)
+
+	// MenusEnabled returns true if navigation menus are stored in the menu tables.
+	MenusEnabled() bool
+	// GetMenusTableName returns the menus table name
+	GetMenusTableName() string
+	// GetMenuItemsTableName returns the menu items table name
+	GetMenuItemsTableName() string
+	// MenuCreate inserts a menu.
+	MenuCreate(ctx context.Context, menu *Menu) error
+	// MenuUpdate saves the changes of a menu.
+	MenuUpdate(ctx context.Context, menu *Menu) error
+	// MenuDelete removes a menu with all its items.
+	MenuDelete(ctx context.Context, id string) error
+	// MenuFindByID retrieves a menu by ID, or nil if not found.
+	MenuFindByID(ctx context.Context, id string) (*Menu, error)
+	// MenuFindByName retrieves a menu by name, or nil if not found.
+	MenuFindByName(ctx context.Context, name string) (*Menu, error)
+	// MenuList retrieves the menus matching the query options, ordered by name.
+	MenuList(ctx context.Context, options MenuQueryOptions) ([]Menu, error)
+	// MenuItemCreate inserts a menu item.
+	MenuItemCreate(ctx context.Context, item *MenuItem) error
+	// MenuItemUpdate saves the changes of a menu item, e.g. moving it under
+	// another parent or changing its sequence.
+	MenuItemUpdate(ctx context.Context, item *MenuItem) error
+	// MenuItemDelete removes a menu item with all its sub-items.
+	MenuItemDelete(ctx context.Context, id string) error
+	// MenuItemFindByID retrieves a menu item by ID, or nil if not found.
+	MenuItemFindByID(ctx context.Context, id string) (*MenuItem, error)
+	// MenuItemList retrieves all the items of a menu, ordered by sequence.
+	MenuItemList(ctx context.Context, menuID string) ([]MenuItem, error)
+	// MenuTree retrieves the items of a menu nested under their parents.
+	MenuTree(ctx context.Context, menuID string) ([]MenuItemNode, error)
+
+	// StartWorkers starts the maintenance jobs (due publishing, trash purge,
+	// version pruning, stats refresh) on the intervals set in the options.
+	StartWorkers(ctx context.Context, options WorkerOptions) (*Workers, error)
+
+	// EraseAuthorData removes the references to an author from the posts,
+	// the post versions and the audit entries, and the author profile, in a
+	// single transaction.
+	EraseAuthorData(ctx context.Context, authorID string) error
+
 	// TaxonomyEnabled returns true if taxonomy support is enabled for this store.
 	TaxonomyEnabled() bool
 
+	// PostStatuses returns the post statuses accepted by the store, the built
+	// in ones followed by those registered with NewStoreOptions.PostStatuses.
+	PostStatuses() []string
+
 	// PostCount returns the total number of posts matching the provided query options.
 	// Uses PostQueryOptions to filter by status, type, or other criteria.
 	PostCount(ctx context.Context, options PostQueryOptions) (int64, error)
 
+	// PostCountByStatus returns the number of posts matching the provided
+	// query options per status, counted in a single query. Statuses without
+	// posts are absent from the map.
+	PostCountByStatus(ctx context.Context, options PostQueryOptions) (map[string]int64, error)
+
 	// PostCreate inserts a new post into the store.
 	// Returns an error if the post cannot be created (e.g., duplicate ID or validation failure).
+	// A post with an idempotency key already used by another post is not created again.
 	PostCreate(ctx context.Context, post PostInterface) error
 
+	// PostCreateMany inserts many posts in a single transaction, using
+	// multi-row inserts sized for the driver.
+	PostCreateMany(ctx context.Context, posts []PostInterface) error
+
+	// PostStatusUpdateByIDs sets the status of the posts with the given IDs
+	// in a single statement and returns how many were updated.
+	PostStatusUpdateByIDs(ctx context.Context, ids []string, status string) (int, error)
+
+	// PostDeleteByIDs permanently removes the posts with the given IDs in a
+	// single statement and returns how many were deleted.
+	PostDeleteByIDs(ctx context.Context, ids []string) (int, error)
+
+	// PostSoftDeleteByIDs marks the posts with the given IDs as deleted in a
+	// single statement and returns how many were marked.
+	PostSoftDeleteByIDs(ctx context.Context, ids []string) (int, error)
+
 	// PostDelete permanently removes a post from the store.
 	// This is a hard delete that cannot be undone.
 	PostDelete(ctx context.Context, post PostInterface) error
@@ -86,10 +315,61 @@ type StoreInterface interface {
 	// Returns the post and nil error on success, or nil and an error if not found.
 	PostFindByOldSlug(ctx context.Context, oldSlug string) (PostInterface, error)
 
+	// PostFindByCanonicalURL retrieves a post by its canonical URL, so
+	// importers can detect articles that already exist before creating them.
+	// Returns nil and nil error if no post has the URL.
+	PostFindByCanonicalURL(ctx context.Context, canonicalURL string) (PostInterface, error)
+
+	// PostTranslations returns the other language versions of the post,
+	// the posts sharing its translation group, ordered by locale.
+	PostTranslations(ctx context.Context, postID string) ([]PostInterface, error)
+
+	// PostSlugEnsureUnique sets a slug on the post that no other post uses,
+	// appending a numeric suffix on collision (my-post, my-post-2).
+	// PostCreate calls it for posts created without a slug.
+	PostSlugEnsureUnique(ctx context.Context, post PostInterface) error
+
+	// SlugHistoryEnabled returns true if slug changes are recorded in the slug history table.
+	SlugHistoryEnabled() bool
+	// GetSlugHistoryTableName returns the slug history table name
+	GetSlugHistoryTableName() string
+
+	// PostStatusHistory returns the status changes of a post, oldest first.
+	PostStatusHistory(ctx context.Context, postID string) ([]StatusChange, error)
+	// StatusHistoryEnabled returns true if status changes are recorded in the status history table.
+	StatusHistoryEnabled() bool
+	// GetStatusHistoryTableName returns the status history table name
+	GetStatusHistoryTableName() string
+	// PostFindBySlugOrHistory retrieves a post by its current or a previous slug.
+	// Moved is true when a previous slug matched, so the caller can redirect (301).
+	PostFindBySlugOrHistory(ctx context.Context, slug string) (post PostInterface, moved bool, err error)
+	// RedirectResolve returns the post that left the old slug, or nil if no post had it before.
+	RedirectResolve(ctx context.Context, oldSlug string) (PostInterface, error)
+
+	// PostFindPreviousPublished retrieves the published post published
+	// immediately before the given post, for "previous post" navigation.
+	// Returns nil and nil error if there is none.
+	PostFindPreviousPublished(ctx context.Context, post PostInterface) (PostInterface, error)
+	// PostFindNextPublished retrieves the published post published
+	// immediately after the given post, for "next post" navigation.
+	// Returns nil and nil error if there is none.
+	PostFindNextPublished(ctx context.Context, post PostInterface) (PostInterface, error)
+
 	// PostList retrieves a list of posts matching the provided query options.
 	// Supports pagination, sorting, and filtering through PostQueryOptions.
 	PostList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error)
 
+	// PostEach calls fn for each post matching the options, loading the posts
+	// in batches instead of all at once. It stops at the first error of fn.
+	PostEach(ctx context.Context, options PostQueryOptions, fn func(post PostInterface) error) error
+
+	// PostSearchRanked searches the title and content of the posts and returns
+	// the matches most relevant first, with a score and highlighted snippets.
+	PostSearchRanked(ctx context.Context, query string, options SearchOptions) ([]SearchResult, error)
+
+	// PostLoadContent loads the content of a post listed with PostQueryOptions.WithoutContent.
+	PostLoadContent(ctx context.Context, post PostInterface) error
+
 	// PostSoftDelete marks a post as deleted without removing it from the database.
 	// The post can be restored later. Requires versioning to be enabled.
 	PostSoftDelete(ctx context.Context, post PostInterface) error
@@ -98,10 +378,50 @@ type StoreInterface interface {
 	// Returns an error if the post does not exist.
 	PostSoftDeleteByID(ctx context.Context, postID string) error
 
+	// PostDuplicate creates a draft copy of a post and returns it.
+	PostDuplicate(ctx context.Context, postID string) (PostInterface, error)
+
+	// PostRestore undoes a soft delete, making the post active again.
+	PostRestore(ctx context.Context, post PostInterface) error
+
+	// PostRestoreByID undoes the soft delete of a post by ID, unarchiving it
+	// if needed, and returns the restored post.
+	PostRestoreByID(ctx context.Context, postID string) (PostInterface, error)
+
 	// PostTrash moves a post to the trash status.
 	// Trashed posts are not visible in normal queries but can be restored.
 	PostTrash(ctx context.Context, post PostInterface) error
 
+	// PostUntrash moves a post out of the trash, back to the status it had
+	// before it was trashed, or to draft if that is unknown.
+	PostUntrash(ctx context.Context, post PostInterface) error
+
+	// PostTrashPurge permanently deletes the posts in the trash for longer than
+	// NewStoreOptions.TrashRetentionDays, returning how many were deleted.
+	PostTrashPurge(ctx context.Context) (int, error)
+
+	// PostIncrementViewCount adds one to the total views of a post.
+	PostIncrementViewCount(ctx context.Context, postID string) error
+
+	// PostExpireDue unpublishes the published posts past their expiry and
+	// returns how many were unpublished.
+	PostExpireDue(ctx context.Context) (int, error)
+
+	// PostPurgeSoftDeletedBefore permanently deletes the posts soft deleted
+	// before the cutoff and returns how many were deleted.
+	PostPurgeSoftDeletedBefore(ctx context.Context, cutoff string) (int, error)
+
+	// PostCountSoftDeletedBefore returns how many posts were soft deleted
+	// before the cutoff.
+	PostCountSoftDeletedBefore(ctx context.Context, cutoff string) (int64, error)
+
+	// PostSubmitForReview moves a post to POST_STATUS_PENDING_REVIEW.
+	PostSubmitForReview(ctx context.Context, id string) error
+	// PostApprove publishes a post pending review, recording the reviewer.
+	PostApprove(ctx context.Context, id string, reviewerID string) error
+	// PostReject returns a post pending review to the drafts, recording the reason.
+	PostReject(ctx context.Context, id string, reason string) error
+
 	// PostUpdate modifies an existing post in the store.
 	// Returns an error if the post does not exist or validation fails.
 	PostUpdate(ctx context.Context, post PostInterface) error
@@ -120,9 +440,20 @@ type StoreInterface interface {
 	// VersioningFindByID retrieves a specific version record by ID.
 	VersioningFindByID(ctx context.Context, versioningID string) (VersioningInterface, error)
 
-	// VersioningList retrieves version records matching the provided query.
+	// VersioningList retrieves version records matching the provided query,
+	// newest first unless ordered otherwise. Pages taken with offset and limit
+	// are stable.
 	VersioningList(ctx context.Context, query VersioningQueryInterface) ([]VersioningInterface, error)
 
+	// VersioningCount returns the number of version records matching the
+	// query, ignoring its limit and offset.
+	VersioningCount(ctx context.Context, query VersioningQueryInterface) (int64, error)
+
+	// VersioningLabel tags a version record with a label bookmarking it, or
+	// removes the tag when the label is empty. Labeled versions are never
+	// pruned.
+	VersioningLabel(ctx context.Context, versionID string, label string) error
+
 	// VersioningSoftDelete marks a version record as deleted without permanent removal.
 	VersioningSoftDelete(ctx context.Context, versioning VersioningInterface) error
 
@@ -132,6 +463,11 @@ type StoreInterface interface {
 	// VersioningUpdate modifies an existing version record.
 	VersioningUpdate(ctx context.Context, versioning VersioningInterface) error
 
+	// PostRevertToVersion restores a post to the content of one of its
+	// versions and saves it, recording a new version noting the revert in
+	// its change note. A permanently deleted post is created again.
+	PostRevertToVersion(ctx context.Context, postID string, versionID string) error
+
 	// Taxonomy methods manage classification systems (e.g., categories, tags).
 
 	// TaxonomyCount returns the number of taxonomies matching the query options.
@@ -244,12 +580,19 @@ type StoreInterface interface {
 
 	// MediaUpdate modifies an existing media in the store.
 	MediaUpdate(ctx context.Context, media MediaInterface) error
+
+	// PostAttachMedia attaches a media to a post, after its other media,
+	// creating the media if it is not stored yet. List the media of a post
+	// with MediaListByEntityID.
+	PostAttachMedia(ctx context.Context, postID string, media MediaInterface) error
 }
 
 var _ StoreInterface = (*storeImplementation)(nil) // verify it extends the interface
 
 // storeImplementation is the concrete implementation of the StoreInterface.
 // It provides database operations for posts, taxonomies, terms, and term relations.
+// Fields changed after construction are guarded (atomics or mutexes); all other
+// fields are read-only once NewStore returns.
 type storeImplementation struct {
 	postTableName         string
 	taxonomyTableName     string
@@ -257,72 +600,270 @@ type storeImplementation struct {
 	termRelationTableName string
 	mediaTableName        string
 	db                    *neat.Database
+	readDB                *neat.Database
 	timeoutSeconds        int64
+	defaultTimeout        time.Duration
+	timezone              *time.Location
+	nowFunc               func() time.Time
+	dateTimeFormat        string
 	automigrateEnabled    bool
-	debugEnabled          bool
+	migrationsTableName   string
+	debugEnabled          atomic.Bool
 
-	versioningEnabled   bool
-	versioningTableName string
+	versioningEnabled            bool
+	versioningTableName          string
+	versioningSkipDeleteSnapshot bool
 
 	taxonomyEnabled bool
-}
 
-// migrateSlugColumn adds the slug column if it doesn't exist (for existing installations)
-// TODO: Remove this function after May 2027 (1 year from implementation)
-func (store *storeImplementation) migrateSlugColumn() error {
-	// Use raw SQL to add column if it doesn't exist
-	// This is a temporary migration for existing installations
-	sql := `ALTER TABLE ` + store.postTableName + ` ADD COLUMN ` + COLUMN_SLUG + ` VARCHAR(255)`
+	logger             *slog.Logger
+	slowQueryThreshold time.Duration
+	explainEnabled     bool
 
-	// Get underlying DB to execute raw SQL
-	db, err := store.db.DB()
-	if err != nil {
-		return err
-	}
+	versioningAsync *versioningAsyncWriter
 
-	// Try to execute, ignore error if column already exists
-	_, err = db.Exec(sql)
-	if err != nil {
-		// Column might already exist, which is fine
-		return nil
+	archiveEnabled   bool
+	archiveTableName string
+
+	auditEnabled   bool
+	auditTableName string
+
+	previewEnabled   bool
+	previewTableName string
+
+	autosaveEnabled   bool
+	autosaveTableName string
+
+	lockEnabled   bool
+	lockTableName string
+
+	viewsEnabled   bool
+	viewsTableName string
+
+	reactionsEnabled   bool
+	reactionsTableName string
+
+	variantsEnabled   bool
+	variantsTableName string
+
+	authorsEnabled   bool
+	authorsTableName string
+
+	menusEnabled       bool
+	menusTableName     string
+	menuItemsTableName string
+
+	slugHistoryEnabled   bool
+	slugHistoryTableName string
+
+	statusHistoryEnabled   bool
+	statusHistoryTableName string
+
+	authorizer AuthorizerInterface
+
+	hooks *Hooks
+
+	eventPublisher EventPublisherInterface
+
+	metaSchema *MetaSchema
+
+	sanitizer SanitizerInterface
+
+	encryptedColumns      []string
+	encryptionKeyProvider EncryptionKeyProviderInterface
+
+	blobStore     BlobStoreInterface
+	blobThreshold int
+
+	postStatuses []string
+
+	slugUniqueEnabled bool
+
+	trashRetention time.Duration
+
+	statsMu       sync.Mutex
+	statsCache    *Stats
+	statsCacheTTL time.Duration
+}
+
+// postTableBlueprint returns the definition of the columns of the post table
+// on the driver. The archive table shares the same definition.
+func postTableBlueprint(driver contractsdatabase.Driver) func(table contractsschema.Blueprint) {
+	return func(table contractsschema.Blueprint) {
+		table.String(COLUMN_ID, 21)
+		table.Primary(COLUMN_ID)
+		table.String(COLUMN_SLUG, 255).Default("")
+		table.String(COLUMN_TITLE, 255)
+		table.Text(COLUMN_CONTENT)
+		table.Text(COLUMN_SUMMARY)
+		table.String(COLUMN_STATUS, 50).Default(POST_STATUS_DRAFT)
+		table.String(COLUMN_AUTHOR_ID, 40)
+		table.String(COLUMN_CANONICAL_URL, 255).Default("")
+		table.String(COLUMN_IMAGE_URL, 255).Default("")
+		table.String(COLUMN_MEMO, postMemoMaxLength).Default("")
+		table.String(COLUMN_META_DESCRIPTION, 255).Default("")
+		table.String(COLUMN_META_KEYWORDS, 255).Default("")
+		table.String(COLUMN_META_ROBOTS, 50).Default("")
+		postMetasColumn(driver, table)
+		table.String(COLUMN_FEATURED, 3).Default("no")
+		table.String(COLUMN_IDEMPOTENCY_KEY, 255).Default("")
+		table.String(COLUMN_LOCALE, 20).Default("")
+		table.String(COLUMN_TRANSLATION_GROUP_ID, 40).Default("")
+		table.DateTime(COLUMN_PUBLISHED_AT).Default(neat.NullDateTime)
+		table.DateTime(COLUMN_EXPIRES_AT).Default(neat.NullDateTime)
+		table.DateTime(COLUMN_TRASHED_AT).Default(neat.NullDateTime)
+		table.BigInteger(COLUMN_VIEWS).Default(0)
+		table.DateTime(COLUMN_CREATED_AT).GetUseCurrent()
+		table.DateTime(COLUMN_UPDATED_AT).GetUseCurrent()
+		table.DateTime(constants.SoftDeleteAtColumn).Default(constants.MaxSoftDeletedAtDefault)
+		table.Index(COLUMN_IDEMPOTENCY_KEY)
+		table.Index(COLUMN_CANONICAL_URL)
+		table.Index(COLUMN_TRANSLATION_GROUP_ID)
+		table.Index(COLUMN_EXPIRES_AT)
+		table.Index(COLUMN_TRASHED_AT)
 	}
-	return nil
 }
 
 // MigrateUp creates the blog store tables
+// It is not bounded by DefaultTimeout, as the schema migrations may alter
+// large tables.
 func (store *storeImplementation) MigrateUp(ctx context.Context, tx ...*sql.Tx) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
 	// Create main post table
 	if !store.db.Schema().HasTable(store.postTableName) {
-		err := store.db.Schema().Create(store.postTableName, func(table contractsschema.Blueprint) {
-			table.String(COLUMN_ID, 21)
-			table.Primary(COLUMN_ID)
-			table.String(COLUMN_SLUG, 255).Default("")
-			table.String(COLUMN_TITLE, 255)
-			table.Text(COLUMN_CONTENT)
-			table.Text(COLUMN_SUMMARY)
-			table.String(COLUMN_STATUS, 50).Default(POST_STATUS_DRAFT)
-			table.String(COLUMN_AUTHOR_ID, 40)
-			table.String(COLUMN_CANONICAL_URL, 255).Default("")
-			table.String(COLUMN_IMAGE_URL, 255).Default("")
-			table.String(COLUMN_MEMO, 255).Default("")
-			table.String(COLUMN_META_DESCRIPTION, 255).Default("")
-			table.String(COLUMN_META_KEYWORDS, 255).Default("")
-			table.String(COLUMN_META_ROBOTS, 50).Default("")
-			table.Text(COLUMN_METAS).Default("{}")
-			table.String(COLUMN_FEATURED, 3).Default("no")
-			table.DateTime(COLUMN_PUBLISHED_AT).Default(neat.NullDateTime)
-			table.DateTime(COLUMN_CREATED_AT).GetUseCurrent()
-			table.DateTime(COLUMN_UPDATED_AT).GetUseCurrent()
-			table.DateTime(constants.SoftDeleteAtColumn).Default(constants.MaxSoftDeletedAtDefault)
-		})
+		err := store.db.Schema().Create(store.postTableName, postTableBlueprint(store.driver()))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create archive table only if enabled
+	if store.archiveEnabled && !store.db.Schema().HasTable(store.archiveTableName) {
+		err := store.db.Schema().Create(store.archiveTableName, postTableBlueprint(store.driver()))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Bring post tables created by older versions up to date
+	if err := store.migrateSchema(ctx); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if store.slugUniqueEnabled {
+		if err := store.migrateSlugUniqueIndex(); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create audit table only if enabled
+	if store.auditEnabled && !store.db.Schema().HasTable(store.auditTableName) {
+		err := store.db.Schema().Create(store.auditTableName, auditTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create preview table only if enabled
+	if store.previewEnabled && !store.db.Schema().HasTable(store.previewTableName) {
+		err := store.db.Schema().Create(store.previewTableName, previewTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create autosave table only if enabled
+	if store.autosaveEnabled && !store.db.Schema().HasTable(store.autosaveTableName) {
+		err := store.db.Schema().Create(store.autosaveTableName, autosaveTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create lock table only if enabled
+	if store.lockEnabled && !store.db.Schema().HasTable(store.lockTableName) {
+		err := store.db.Schema().Create(store.lockTableName, lockTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create views table only if enabled
+	if store.viewsEnabled && !store.db.Schema().HasTable(store.viewsTableName) {
+		err := store.db.Schema().Create(store.viewsTableName, viewsTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create reactions table only if enabled
+	if store.reactionsEnabled && !store.db.Schema().HasTable(store.reactionsTableName) {
+		err := store.db.Schema().Create(store.reactionsTableName, reactionsTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create variants table only if enabled
+	if store.variantsEnabled && !store.db.Schema().HasTable(store.variantsTableName) {
+		err := store.db.Schema().Create(store.variantsTableName, variantsTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create authors table only if enabled
+	if store.authorsEnabled && !store.db.Schema().HasTable(store.authorsTableName) {
+		err := store.db.Schema().Create(store.authorsTableName, authorsTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create menu tables only if enabled
+	if store.menusEnabled && !store.db.Schema().HasTable(store.menusTableName) {
+		err := store.db.Schema().Create(store.menusTableName, menusTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	if store.menusEnabled && !store.db.Schema().HasTable(store.menuItemsTableName) {
+		err := store.db.Schema().Create(store.menuItemsTableName, menuItemsTableBlueprint)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Create slug history table only if enabled
+	if store.slugHistoryEnabled && !store.db.Schema().HasTable(store.slugHistoryTableName) {
+		err := store.db.Schema().Create(store.slugHistoryTableName, slugHistoryTableBlueprint)
 		if err != nil {
 			log.Println(err)
 			return err
 		}
+	}
 
-		// TODO: Remove this migration logic after May 2027 (1 year from implementation)
-		// This allows existing installations to auto-migrate the slug column
-		err = store.migrateSlugColumn()
+	// Create status history table only if enabled
+	if store.statusHistoryEnabled && !store.db.Schema().HasTable(store.statusHistoryTableName) {
+		err := store.db.Schema().Create(store.statusHistoryTableName, statusHistoryTableBlueprint)
 		if err != nil {
 			log.Println(err)
 			return err
@@ -398,6 +939,9 @@ func (store *storeImplementation) MigrateUp(ctx context.Context, tx ...*sql.Tx)
 				table.String(COLUMN_ENTITY_TYPE, 40)
 				table.String(COLUMN_ENTITY_ID, 40)
 				table.Text(COLUMN_CONTENT)
+				table.String(COLUMN_CHANGED_BY, 40).Default("")
+				table.String(COLUMN_CHANGE_NOTE, 255).Default("")
+				table.String(COLUMN_LABEL, versioningLabelMaxLength).Default("")
 				table.DateTime(COLUMN_CREATED_AT)
 				table.DateTime(COLUMN_SOFT_DELETED_AT)
 			})
@@ -441,6 +985,9 @@ func (store *storeImplementation) MigrateUp(ctx context.Context, tx ...*sql.Tx)
 
 // MigrateDown drops the blog store tables
 func (store *storeImplementation) MigrateDown(ctx context.Context, tx ...*sql.Tx) error {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	// Drop tables in reverse order of creation (due to potential foreign key constraints)
 	if store.taxonomyEnabled {
 		// Drop term relation table first
@@ -492,6 +1039,122 @@ func (store *storeImplementation) MigrateDown(ctx context.Context, tx ...*sql.Tx
 		}
 	}
 
+	// Drop status history table
+	if store.statusHistoryEnabled && store.db.Schema().HasTable(store.statusHistoryTableName) {
+		err := store.db.Schema().Drop(store.statusHistoryTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop slug history table
+	if store.slugHistoryEnabled && store.db.Schema().HasTable(store.slugHistoryTableName) {
+		err := store.db.Schema().Drop(store.slugHistoryTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop menu tables
+	if store.menusEnabled && store.db.Schema().HasTable(store.menuItemsTableName) {
+		err := store.db.Schema().Drop(store.menuItemsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	if store.menusEnabled && store.db.Schema().HasTable(store.menusTableName) {
+		err := store.db.Schema().Drop(store.menusTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop authors table
+	if store.authorsEnabled && store.db.Schema().HasTable(store.authorsTableName) {
+		err := store.db.Schema().Drop(store.authorsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop reactions table
+	if store.reactionsEnabled && store.db.Schema().HasTable(store.reactionsTableName) {
+		err := store.db.Schema().Drop(store.reactionsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop variants table
+	if store.variantsEnabled && store.db.Schema().HasTable(store.variantsTableName) {
+		err := store.db.Schema().Drop(store.variantsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop views table
+	if store.viewsEnabled && store.db.Schema().HasTable(store.viewsTableName) {
+		err := store.db.Schema().Drop(store.viewsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop lock table
+	if store.lockEnabled && store.db.Schema().HasTable(store.lockTableName) {
+		err := store.db.Schema().Drop(store.lockTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop autosave table
+	if store.autosaveEnabled && store.db.Schema().HasTable(store.autosaveTableName) {
+		err := store.db.Schema().Drop(store.autosaveTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop preview table
+	if store.previewEnabled && store.db.Schema().HasTable(store.previewTableName) {
+		err := store.db.Schema().Drop(store.previewTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop audit table
+	if store.auditEnabled && store.db.Schema().HasTable(store.auditTableName) {
+		err := store.db.Schema().Drop(store.auditTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	// Drop archive table
+	if store.archiveEnabled && store.db.Schema().HasTable(store.archiveTableName) {
+		err := store.db.Schema().Drop(store.archiveTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
 	// Drop post table
 	if store.db.Schema().HasTable(store.postTableName) {
 		err := store.db.Schema().Drop(store.postTableName)
@@ -501,9 +1164,27 @@ func (store *storeImplementation) MigrateDown(ctx context.Context, tx ...*sql.Tx
 		}
 	}
 
+	// Drop migrations table
+	if store.db.Schema().HasTable(store.migrationsTableName) {
+		err := store.db.Schema().Drop(store.migrationsTableName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
 	return nil
 }
 
+// getLogger returns the logger used for diagnostic output.
+// Falls back to the default slog logger when none was injected.
+func (st *storeImplementation) getLogger() *slog.Logger {
+	if st.logger != nil {
+		return st.logger
+	}
+	return slog.Default()
+}
+
 // VersioningEnabled returns true if versioning is enabled for this store.
 func (st *storeImplementation) VersioningEnabled() bool {
 	return st.versioningEnabled
@@ -516,10 +1197,20 @@ func (st *storeImplementation) TaxonomyEnabled() bool {
 
 // EnableDebug enables or disables debug logging for SQL queries.
 func (st *storeImplementation) EnableDebug(debug bool) StoreInterface {
-	st.debugEnabled = debug
+	st.debugEnabled.Store(debug)
 	return st
 }
 
+// DBStats returns a snapshot of the connection pool statistics of the underlying database.
+// Returns zero stats if the database connection is not available.
+func (st *storeImplementation) DBStats() sql.DBStats {
+	db, err := st.db.DB()
+	if err != nil {
+		return sql.DBStats{}
+	}
+	return db.Stats()
+}
+
 // GetPostTableName returns the post table name
 func (st *storeImplementation) GetPostTableName() string {
 	return st.postTableName
@@ -573,84 +1264,310 @@ func (st *storeImplementation) SetMediaTableName(tableName string) {
 // PostCreate inserts a new post into the database.
 // It sets the created_at and updated_at timestamps automatically.
 // Also tracks the creation in the versioning store if versioning is enabled.
+// If the post has an idempotency key and a post was already created with the
+// same key, that post is loaded into the given post and nothing is inserted.
 func (store *storeImplementation) PostCreate(ctx context.Context, post PostInterface) error {
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if post.GetID() == "" {
 		post.SetID(GenerateShortID())
 	}
 
-	post.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
-	post.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+	if err := store.postStatusValidate(post.GetStatus()); err != nil {
+		return err
+	}
 
-	db, err := store.db.DB()
-	if err != nil {
+	if err := store.authorize(ctx, ACTION_CREATE, post); err != nil {
 		return err
 	}
 
-	metas, _ := post.GetMetas()
-	metasJSON := ""
-	if len(metas) > 0 {
-		metasBytes, err := json.Marshal(metas)
+	if post.GetIdempotencyKey() != "" {
+		existing, err := store.postFindByIdempotencyKey(ctx, post.GetIdempotencyKey())
 		if err != nil {
 			return err
 		}
-		metasJSON = string(metasBytes)
+		if existing != nil {
+			// a retry of a create that already succeeded
+			post.Hydrate(existing.GetData())
+			return nil
+		}
 	}
 
-	_, err = db.ExecContext(ctx, "INSERT INTO "+store.postTableName+" (id, slug, title, content, summary, status, author_id, canonical_url, image_url, memo, meta_description, meta_keywords, meta_robots, metas, featured, published_at, created_at, updated_at, soft_deleted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		post.GetID(),
-		post.GetSlug(),
-		post.GetTitle(),
-		post.GetContent(),
-		post.GetSummary(),
-		post.GetStatus(),
-		post.GetAuthorID(),
-		post.GetCanonicalURL(),
-		post.GetImageUrl(),
-		post.GetMemo(),
-		post.GetMetaDescription(),
+	if err := store.hookRun(ctx, hookBeforePostCreate, post); err != nil {
+		return err
+	}
+
+	store.contentSanitize(post)
+
+	if err := store.metaValidate(post); err != nil {
+		return err
+	}
+
+	// a post created without a slug gets a free one derived from its title
+	if post.Get(COLUMN_SLUG) == "" {
+		if err := store.PostSlugEnsureUnique(ctx, post); err != nil {
+			return err
+		}
+	}
+
+	if store.slugUniqueEnabled {
+		post.SetSlug(postUniqueSlug(post))
+	}
+
+	post.SetCreatedAt(store.now().ToDateTimeString())
+	post.SetUpdatedAt(store.now().ToDateTimeString())
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	values, err := store.postInsertValues(ctx, post)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostCreate", "INSERT INTO "+store.postTableName+" ("+postInsertColumns+") VALUES "+insertPlaceholders(1, len(values)), values...)
+
+	if err != nil && post.GetIdempotencyKey() != "" {
+		// a concurrent create with the same key inserted first, rejected
+		// by the unique idempotency key index
+		existing, lookupErr := store.postFindByIdempotencyKey(ctx, post.GetIdempotencyKey())
+		if lookupErr == nil && existing != nil && existing.GetID() != post.GetID() {
+			post.Hydrate(existing.GetData())
+			return nil
+		}
+	}
+
+	if err != nil {
+		return store.slugConflictError(ctx, post.GetID(), post.GetSlug(), err)
+	}
+
+	post.MarkAsNotDirty()
+	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, post.GetID(), post); err != nil {
+		return err
+	}
+
+	if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, post.GetID(), ACTION_CREATE, nil, post); err != nil {
+		return err
+	}
+
+	if err := store.statusHistoryRecord(ctx, post.GetID(), "", post.GetStatus()); err != nil {
+		return err
+	}
+
+	if err := store.eventPublish(ctx, nil, post); err != nil {
+		return err
+	}
+
+	return store.hookRun(ctx, hookAfterPostCreate, post)
+}
+
+// postFindByIdempotencyKey returns the post created with the idempotency
+// key, including soft deleted ones, or nil if there is none.
+func (store *storeImplementation) postFindByIdempotencyKey(ctx context.Context, key string) (PostInterface, error) {
+	existing, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{
+		IdempotencyKey: key,
+		WithDeleted:    true,
+		Limit:          1,
+	})
+	if err != nil || len(existing) == 0 {
+		return nil, err
+	}
+
+	return existing[0], nil
+}
+
+// postInsertColumns lists the columns written by PostCreate and
+// PostCreateMany, in the order of the values of postInsertValues.
+const postInsertColumns = "id, slug, title, content, summary, status, author_id, canonical_url, image_url, memo, meta_description, meta_keywords, meta_robots, metas, featured, idempotency_key, locale, translation_group_id, published_at, expires_at, trashed_at, created_at, updated_at, soft_deleted_at"
+
+// postInsertValues returns the values of a new post row, with the content
+// and memo encrypted and the content offloaded to the blob store as
+// configured.
+func (store *storeImplementation) postInsertValues(ctx context.Context, post PostInterface) ([]any, error) {
+	content, err := store.encryptField(ctx, COLUMN_CONTENT, post.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = store.blobContentOffload(ctx, post.GetID(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	memo, err := store.encryptField(ctx, COLUMN_MEMO, post.GetMemo())
+	if err != nil {
+		return nil, err
+	}
+
+	metas, _ := post.GetMetas()
+	metasJSON := "{}"
+	if len(metas) > 0 {
+		metasBytes, err := json.Marshal(metas)
+		if err != nil {
+			return nil, err
+		}
+		metasJSON = string(metasBytes)
+	}
+
+	return []any{
+		post.GetID(),
+		post.GetSlug(),
+		post.GetTitle(),
+		content,
+		post.GetSummary(),
+		post.GetStatus(),
+		post.GetAuthorID(),
+		post.GetCanonicalURL(),
+		post.GetImageUrl(),
+		memo,
+		post.GetMetaDescription(),
 		post.GetMetaKeywords(),
 		post.GetMetaRobots(),
 		metasJSON,
 		post.GetFeatured(),
+		post.GetIdempotencyKey(),
+		post.GetLocale(),
+		post.GetTranslationGroupID(),
 		post.GetPublishedAtCarbon().StdTime(),
+		postExpiresAtValue(post),
+		store.postTrashedAtValue(post.GetStatus()),
 		post.GetCreatedAtCarbon().StdTime(),
 		post.GetUpdatedAtCarbon().StdTime(),
 		post.GetSoftDeletedAtCarbon().StdTime(),
-	)
+	}, nil
+}
 
-	if err != nil {
-		return err
+// postExpiresAtValue returns the expires at column value of a post, the
+// null datetime if the post does not expire.
+func postExpiresAtValue(post PostInterface) time.Time {
+	if post.GetExpiresAtTime().IsZero() {
+		return carbon.Parse(neat.NullDateTime, carbon.UTC).StdTime()
 	}
+	return post.GetExpiresAtTime()
+}
 
-	post.MarkAsNotDirty()
-	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, post.GetID(), post); err != nil {
+// postTrashedAtValue returns the trashed at column of a post moving to the
+// status: now for the trash, and the null datetime otherwise.
+func (store *storeImplementation) postTrashedAtValue(status string) time.Time {
+	if status != POST_STATUS_TRASH {
+		return carbon.Parse(neat.NullDateTime, carbon.UTC).StdTime()
+	}
+	return store.now().StdTime()
+}
+
+// postTrashedAtUpdate keeps the trashed at column of an updated post in step
+// with its status. A post moving to the trash is stamped before the update,
+// as the stored status tells whether it was already there; a post leaving
+// the trash has it cleared with the update.
+func (st *storeImplementation) postTrashedAtUpdate(ctx context.Context, id string, status string, updateData map[string]any) error {
+	if status != POST_STATUS_TRASH {
+		updateData[COLUMN_TRASHED_AT] = st.postTrashedAtValue(status)
+		return nil
+	}
+
+	db, err := st.db.DB()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = st.execContext(ctx, db, "PostUpdate", "UPDATE "+st.postTableName+" SET "+COLUMN_TRASHED_AT+" = ? WHERE "+COLUMN_ID+" = ? AND "+COLUMN_STATUS+" <> ?",
+		st.postTrashedAtValue(status), id, POST_STATUS_TRASH)
+	return err
 }
 
 // PostCount returns the total number of posts matching the given query options.
+// Ordering and pagination options are ignored, so the count is always the total.
 func (store *storeImplementation) PostCount(ctx context.Context, options PostQueryOptions) (int64, error) {
 	if ctx == nil {
 		return 0, errors.New("ctx is nil")
 	}
 
-	q := store.buildPostQuery(options)
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return 0, err
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return 0, err
+	}
+
+	where, args := joinQueryConditions(store.postQueryConditions(options))
 
 	var count int64
-	err := q.Table(store.postTableName).Count(&count)
-	return count, err
+	err = store.queryRowContext(ctx, db, "PostCount", "SELECT COUNT(*) FROM "+store.postTableName+" WHERE "+where, args, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// PostCountByStatus counts the posts matching the options, grouped by status.
+// Pagination and ordering options are ignored, as for PostCount.
+func (store *storeImplementation) PostCountByStatus(ctx context.Context, options PostQueryOptions) (map[string]int64, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return nil, err
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := joinQueryConditions(store.postQueryConditions(options))
+
+	rows, err := store.queryContext(ctx, db, "PostCountByStatus", "SELECT "+COLUMN_STATUS+", COUNT(*) FROM "+store.postTableName+" WHERE "+where+" GROUP BY "+COLUMN_STATUS, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int64{}
+	for rows.Next() {
+		var status sql.NullString
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status.String] += count
+	}
+
+	return counts, rows.Err()
 }
 
 // PostTrash moves a post to trash by setting its status to POST_STATUS_TRASH.
+// The previous status is kept in the META_KEY_TRASHED_FROM_STATUS meta for
+// PostUntrash.
 func (store *storeImplementation) PostTrash(ctx context.Context, post PostInterface) error {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if post.GetStatus() != POST_STATUS_TRASH {
+		if err := post.SetMeta(META_KEY_TRASHED_FROM_STATUS, post.GetStatus()); err != nil {
+			return err
+		}
+	}
+
 	post.SetStatus(POST_STATUS_TRASH)
 
-	return store.PostUpdate(ctx, post)
+	return store.PostUpdate(contextWithAction(ctx, ACTION_TRASH), post)
 }
 
 // PostDelete permanently removes a post from the database.
@@ -658,6 +1575,10 @@ func (store *storeImplementation) PostDelete(ctx context.Context, post PostInter
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if post == nil {
 		return errors.New("post is nil")
 	}
@@ -670,21 +1591,74 @@ func (store *storeImplementation) PostDeleteByID(ctx context.Context, id string)
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if id == "" {
 		return errors.New("post id is empty")
 	}
 
-	_, err := store.db.Query().
+	before, err := store.postSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := store.authorize(ctx, ACTION_DELETE, before); err != nil {
+		return err
+	}
+
+	if err := store.hookRun(ctx, hookBeforePostDelete, before); err != nil {
+		return err
+	}
+
+	var loaded []PostInterface
+	if before != nil {
+		loaded = []PostInterface{before}
+	}
+	if err := store.versioningDeleteSnapshot(ctx, []string{id}, loaded); err != nil {
+		return err
+	}
+
+	q := store.query(ctx).
 		Table(store.postTableName).
-		Where(COLUMN_ID+" = ?", id).
-		Delete()
+		Where(COLUMN_ID+" = ?", id)
 
-	return err
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Delete() })
+	store.explainQuery(ctx, nil, "PostDeleteByID", sqlFn)
+
+	start := time.Now()
+	_, err = q.Delete()
+	store.logSlowQuery(ctx, "PostDeleteByID", start, sqlFn)
+	if err != nil {
+		return err
+	}
+
+	if err := store.blobContentDelete(ctx, id); err != nil {
+		return err
+	}
+
+	if before == nil {
+		return nil
+	}
+
+	if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, id, ACTION_DELETE, before, nil); err != nil {
+		return err
+	}
+
+	if err := store.eventPublish(ctx, before, nil); err != nil {
+		return err
+	}
+
+	return store.hookRun(ctx, hookAfterPostDelete, before)
 }
 
 // PostFindByID retrieves a post by its ID.
 // Supports both full IDs and shortened IDs with automatic unshortening.
 func (store *storeImplementation) PostFindByID(ctx context.Context, id string) (PostInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if id == "" {
 		return nil, errors.New("post id is empty")
 	}
@@ -703,7 +1677,7 @@ func (store *storeImplementation) PostFindByID(ctx context.Context, id string) (
 	}
 
 	if len(list) > 0 {
-		return list[0], nil
+		return store.postAuthorizeRead(ctx, list[0])
 	}
 
 	// If not found and ID looks shortened, try unshortening
@@ -720,7 +1694,7 @@ func (store *storeImplementation) PostFindByID(ctx context.Context, id string) (
 			}
 
 			if len(list) > 0 {
-				return list[0], nil
+				return store.postAuthorizeRead(ctx, list[0])
 			}
 		}
 	}
@@ -730,6 +1704,9 @@ func (store *storeImplementation) PostFindByID(ctx context.Context, id string) (
 
 // PostFindBySlug retrieves a post by its slug.
 func (store *storeImplementation) PostFindBySlug(ctx context.Context, slug string) (PostInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if slug == "" {
 		return nil, errors.New("slug is empty")
 	}
@@ -744,7 +1721,32 @@ func (store *storeImplementation) PostFindBySlug(ctx context.Context, slug strin
 	}
 
 	if len(list) > 0 {
-		return list[0], nil
+		return store.postAuthorizeRead(ctx, list[0])
+	}
+
+	return nil, nil
+}
+
+// PostFindByCanonicalURL retrieves a post by its canonical URL.
+func (store *storeImplementation) PostFindByCanonicalURL(ctx context.Context, canonicalURL string) (PostInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if canonicalURL == "" {
+		return nil, errors.New("canonical url is empty")
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{
+		CanonicalURL: canonicalURL,
+		Limit:        1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return store.postAuthorizeRead(ctx, list[0])
 	}
 
 	return nil, nil
@@ -752,6 +1754,9 @@ func (store *storeImplementation) PostFindBySlug(ctx context.Context, slug strin
 
 // PostFindByOldSlug retrieves a post by its old slug (for redirect handling).
 func (store *storeImplementation) PostFindByOldSlug(ctx context.Context, oldSlug string) (PostInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if oldSlug == "" {
 		return nil, errors.New("old slug is empty")
 	}
@@ -765,7 +1770,7 @@ func (store *storeImplementation) PostFindByOldSlug(ctx context.Context, oldSlug
 	}
 
 	if len(list) > 0 {
-		return list[0], nil
+		return store.postAuthorizeRead(ctx, list[0])
 	}
 
 	return nil, nil
@@ -811,38 +1816,133 @@ func (st *storeImplementation) PostFindNext(post PostInterface) (PostInterface,
 	return nil, nil
 }
 
+// PostFindPreviousPublished finds the published post published immediately
+// before the given post. Drafts, scheduled and soft-deleted posts are skipped.
+func (st *storeImplementation) PostFindPreviousPublished(ctx context.Context, post PostInterface) (PostInterface, error) {
+	if post == nil {
+		return nil, errors.New("post is nil")
+	}
+
+	return st.postFindPublished(ctx, PostQueryOptions{
+		PublishedAtLessThan: post.GetPublishedAtCarbon().ToDateTimeString(carbon.UTC),
+		OrderBy:             COLUMN_PUBLISHED_AT,
+		SortOrder:           SORT_ORDER_DESC,
+	})
+}
+
+// PostFindNextPublished finds the published post published immediately after
+// the given post. Drafts, scheduled and soft-deleted posts are skipped.
+func (st *storeImplementation) PostFindNextPublished(ctx context.Context, post PostInterface) (PostInterface, error) {
+	if post == nil {
+		return nil, errors.New("post is nil")
+	}
+
+	return st.postFindPublished(ctx, PostQueryOptions{
+		PublishedAtGreaterThan: post.GetPublishedAtCarbon().ToDateTimeString(carbon.UTC),
+		OrderBy:                COLUMN_PUBLISHED_AT,
+		SortOrder:              SORT_ORDER_ASC,
+	})
+}
+
+// postFindPublished returns the first published post matching the options,
+// or nil if there is none.
+func (st *storeImplementation) postFindPublished(ctx context.Context, options PostQueryOptions) (PostInterface, error) {
+	options.Status = POST_STATUS_PUBLISHED
+	options.PublishedBeforeNow = true
+	options.Limit = 1
+
+	list, err := st.PostList(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
 // PostList retrieves a list of posts matching the given query options.
 func (st *storeImplementation) PostList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error) {
 	if ctx == nil {
 		return nil, errors.New("ctx is nil")
 	}
 
+	ctx, cancel := st.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := st.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return []PostInterface{}, err
+	}
+
+	return st.postListFromDB(ctx, st.reader(ctx), st.postTableName, options)
+}
+
+// postListFromTable retrieves posts from the given table, which is either the
+// post table or the archive table.
+func (st *storeImplementation) postListFromTable(ctx context.Context, tableName string, options PostQueryOptions) ([]PostInterface, error) {
+	return st.postListFromDB(ctx, st.db, tableName, options)
+}
+
+// postListFromDB retrieves posts from the given table of the database, which
+// is the primary or the read replica.
+func (st *storeImplementation) postListFromDB(ctx context.Context, db *neat.Database, tableName string, options PostQueryOptions) ([]PostInterface, error) {
+
 	type postRow struct {
-		ID              string    `db:"id"`
-		Slug            string    `db:"slug"`
-		Title           string    `db:"title"`
-		Content         string    `db:"content"`
-		Summary         string    `db:"summary"`
-		Status          string    `db:"status"`
-		AuthorID        string    `db:"author_id"`
-		CanonicalURL    string    `db:"canonical_url"`
-		ImageURL        string    `db:"image_url"`
-		Memo            string    `db:"memo"`
-		MetaDescription string    `db:"meta_description"`
-		MetaKeywords    string    `db:"meta_keywords"`
-		MetaRobots      string    `db:"meta_robots"`
-		Metas           string    `db:"metas"`
-		Featured        string    `db:"featured"`
-		PublishedAt     time.Time `db:"published_at"`
-		CreatedAt       time.Time `db:"created_at"`
-		UpdatedAt       time.Time `db:"updated_at"`
-		SoftDeletedAt   time.Time `db:"soft_deleted_at"`
-	}
-
-	q := st.buildPostQuery(options)
+		ID                 string    `db:"id"`
+		Slug               string    `db:"slug"`
+		Title              string    `db:"title"`
+		Content            string    `db:"content"`
+		Summary            string    `db:"summary"`
+		Status             string    `db:"status"`
+		AuthorID           string    `db:"author_id"`
+		CanonicalURL       string    `db:"canonical_url"`
+		ImageURL           string    `db:"image_url"`
+		Memo               string    `db:"memo"`
+		MetaDescription    string    `db:"meta_description"`
+		MetaKeywords       string    `db:"meta_keywords"`
+		MetaRobots         string    `db:"meta_robots"`
+		Metas              string    `db:"metas"`
+		Featured           string    `db:"featured"`
+		IdempotencyKey     string    `db:"idempotency_key"`
+		Locale             string    `db:"locale"`
+		TranslationGroupID string    `db:"translation_group_id"`
+		PublishedAt        time.Time `db:"published_at"`
+		ExpiresAt          time.Time `db:"expires_at"`
+		Views              int64     `db:"views"`
+		CreatedAt          time.Time `db:"created_at"`
+		UpdatedAt          time.Time `db:"updated_at"`
+		SoftDeletedAt      time.Time `db:"soft_deleted_at"`
+	}
+
+	q, err := st.buildPostQuery(ctx, db, options)
+	if err != nil {
+		return []PostInterface{}, err
+	}
+
+	columns, err := postSelectColumns(options)
+	if err != nil {
+		return []PostInterface{}, err
+	}
+	if columns != nil {
+		q = q.Select(columns)
+	}
+
+	q = q.Table(tableName)
 
 	var rows []postRow
-	if err := q.Table(st.postTableName).Get(&rows); err != nil {
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Get(&[]postRow{}) })
+	sqlDB, err := db.DB()
+	if err != nil {
+		return []PostInterface{}, err
+	}
+	st.explainQuery(ctx, sqlDB, "PostList", sqlFn)
+
+	start := time.Now()
+	err = q.Get(&rows)
+	st.logSlowQuery(ctx, "PostList", start, sqlFn)
+	if err != nil {
 		return []PostInterface{}, err
 	}
 
@@ -852,13 +1952,25 @@ func (st *storeImplementation) PostList(ctx context.Context, options PostQueryOp
 		p.SetID(r.ID)
 		p.SetSlug(r.Slug)
 		p.SetTitle(r.Title)
-		p.SetContent(r.Content)
+		content, err := st.blobContentResolve(ctx, r.Content)
+		if err != nil {
+			return []PostInterface{}, err
+		}
+		content, err = st.decryptField(ctx, COLUMN_CONTENT, content)
+		if err != nil {
+			return []PostInterface{}, err
+		}
+		p.SetContent(content)
 		p.SetSummary(r.Summary)
 		p.SetStatus(r.Status)
 		p.SetAuthorID(r.AuthorID)
 		p.SetCanonicalURL(r.CanonicalURL)
 		p.SetImageUrl(r.ImageURL)
-		p.SetMemo(r.Memo)
+		memo, err := st.decryptField(ctx, COLUMN_MEMO, r.Memo)
+		if err != nil {
+			return []PostInterface{}, err
+		}
+		p.SetMemo(memo)
 		p.SetMetaDescription(r.MetaDescription)
 		p.SetMetaKeywords(r.MetaKeywords)
 		p.SetMetaRobots(r.MetaRobots)
@@ -872,30 +1984,168 @@ func (st *storeImplementation) PostList(ctx context.Context, options PostQueryOp
 			}
 		}
 		p.SetFeatured(r.Featured)
+		p.SetIdempotencyKey(r.IdempotencyKey)
+		p.SetLocale(r.Locale)
+		p.SetTranslationGroupID(r.TranslationGroupID)
 		if postImpl, ok := p.(*postImplementation); ok {
 			postImpl.PublishedAtField = r.PublishedAt
+			postImpl.ViewsField = r.Views
+			postImpl.ExpiresAtField = postExpiresAtParse(carbon.CreateFromStdTime(r.ExpiresAt).ToDateTimeString(carbon.UTC))
 			postImpl.CreatedAtField.CreatedAt = r.CreatedAt
 			postImpl.UpdatedAtField.UpdatedAt = r.UpdatedAt
 			postImpl.SoftDeletedAt = r.SoftDeletedAt
 		}
+		if columns != nil {
+			data := p.GetData()
+			unloaded := map[string]string{}
+			for _, column := range lo.Without(postTableColumns, columns...) {
+				unloaded[column] = data[column]
+			}
+			postSetUnloadedColumns(p, unloaded)
+		}
 		list = append(list, p)
 	}
 
+	if err := st.postLoadRelations(ctx, list, options.With); err != nil {
+		return []PostInterface{}, err
+	}
+
 	return list, nil
 }
 
+// postSelectColumns returns the columns to select for the Columns and
+// WithoutContent options, or nil to select all of them.
+// Column names are written into the SQL, so they are checked against the
+// post table columns.
+func postSelectColumns(options PostQueryOptions) ([]string, error) {
+	if len(options.Columns) == 0 && !options.WithoutContent {
+		return nil, nil
+	}
+
+	columns := postTableColumns
+	if len(options.Columns) > 0 {
+		columns = []string{COLUMN_ID}
+		for _, column := range options.Columns {
+			if !lo.Contains(postTableColumns, column) {
+				return nil, errors.New("blogstore: invalid column: " + column)
+			}
+			if !lo.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	if options.WithoutContent {
+		columns = lo.Without(columns, COLUMN_CONTENT)
+	}
+
+	return columns, nil
+}
+
+// PostLoadContent loads the content of a post that was listed with
+// PostQueryOptions.WithoutContent and sets it on the post.
+func (st *storeImplementation) PostLoadContent(ctx context.Context, post PostInterface) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := st.contextWithTimeout(ctx)
+	defer cancel()
+
+	if post == nil {
+		return errors.New("post is nil")
+	}
+	if post.GetID() == "" {
+		return errors.New("post id is empty")
+	}
+
+	db, err := st.db.DB()
+	if err != nil {
+		return err
+	}
+
+	var value string
+	err = st.queryRowContext(ctx, db, "PostLoadContent", "SELECT "+COLUMN_CONTENT+" FROM "+st.postTableName+" WHERE "+COLUMN_ID+" = ?", []any{post.GetID()}, &value)
+	if err == sql.ErrNoRows {
+		return errors.New("post not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := st.blobContentResolve(ctx, value)
+	if err != nil {
+		return err
+	}
+
+	content, err = st.decryptField(ctx, COLUMN_CONTENT, content)
+	if err != nil {
+		return err
+	}
+
+	post.SetContent(content)
+	if unloaded := postUnloadedColumns(post); unloaded != nil {
+		delete(unloaded, COLUMN_CONTENT)
+	}
+
+	return nil
+}
+
+// postMergeUnloaded fills the columns a post was listed without, see
+// PostQueryOptions.Columns and WithoutContent, with their stored values, so
+// saving the post does not overwrite them with empty values. Columns set
+// since the listing are kept.
+func (st *storeImplementation) postMergeUnloaded(ctx context.Context, post PostInterface) error {
+	unloaded := postUnloadedColumns(post)
+	if len(unloaded) == 0 {
+		return nil
+	}
+
+	list, err := st.postListFromTable(ctx, st.postTableName, PostQueryOptions{ID: post.GetID(), WithDeleted: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errors.New("post not found")
+	}
+
+	data := post.GetData()
+	stored := list[0].GetData()
+	for column, listed := range unloaded {
+		if data[column] == listed {
+			post.Set(column, stored[column])
+		}
+	}
+	postSetUnloadedColumns(post, nil)
+
+	return nil
+}
+
 // PostSoftDelete marks a post as deleted by setting the soft_deleted_at timestamp.
+// When archiving is enabled, the post is then moved to the archive table.
 func (st *storeImplementation) PostSoftDelete(ctx context.Context, post PostInterface) error {
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := st.contextWithTimeout(ctx)
+	defer cancel()
+
 	if post == nil {
 		return errors.New("post is nil")
 	}
 
-	post.SetSoftDeletedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	post.SetSoftDeletedAt(st.now().ToDateTimeString(carbon.UTC))
+
+	if err := st.PostUpdate(contextWithAction(ctx, ACTION_SOFT_DELETE), post); err != nil {
+		return err
+	}
+
+	if st.archiveEnabled {
+		return st.postMoveToArchive(ctx, post.GetID())
+	}
 
-	return st.PostUpdate(ctx, post)
+	return nil
 }
 
 // PostSoftDeleteByID marks a post as deleted by its ID.
@@ -903,7 +2153,11 @@ func (st *storeImplementation) PostSoftDeleteByID(ctx context.Context, id string
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
-	post, err := st.PostFindByID(ctx, id)
+
+	ctx, cancel := st.contextWithTimeout(ctx)
+	defer cancel()
+
+	post, err := st.PostFindByID(ContextWithForcePrimary(ctx), id)
 
 	if err != nil {
 		return err
@@ -922,17 +2176,60 @@ func (st *storeImplementation) PostUpdate(ctx context.Context, post PostInterfac
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := st.contextWithTimeout(ctx)
+	defer cancel()
+
 	if post == nil {
 		return errors.New("post is nil")
 	}
 
-	post.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString())
+	if err := st.postMergeUnloaded(ctx, post); err != nil {
+		return err
+	}
+
+	if status, ok := post.GetDataChanged()[COLUMN_STATUS]; ok {
+		if err := st.postStatusValidate(status); err != nil {
+			return err
+		}
+	}
+
+	before, err := st.postSnapshot(ctx, post.GetID())
+	if err != nil {
+		return err
+	}
+
+	target := before
+	if target == nil {
+		target = post
+	}
+	if err := st.authorize(ctx, actionFromContext(ctx, ACTION_UPDATE), target); err != nil {
+		return err
+	}
+
+	if err := st.hookRun(ctx, hookBeforePostUpdate, post); err != nil {
+		return err
+	}
+
+	st.contentSanitize(post)
+
+	if err := st.metaValidate(post); err != nil {
+		return err
+	}
+
+	previousSlug, err := st.slugHistoryPrevious(ctx, before, post)
+	if err != nil {
+		return err
+	}
+
+	post.SetUpdatedAt(st.now().ToDateTimeString())
 
 	dataChanged := post.GetDataChanged()
 
-	delete(dataChanged, "id")   // ID is not updatable
-	delete(dataChanged, "hash") // Hash is not updatable
-	delete(dataChanged, "data") // Data is not updatable
+	delete(dataChanged, "id")         // ID is not updatable
+	delete(dataChanged, "hash")       // Hash is not updatable
+	delete(dataChanged, "data")       // Data is not updatable
+	delete(dataChanged, COLUMN_VIEWS) // Views only change with PostIncrementViewCount
 
 	if len(dataChanged) < 1 {
 		return nil
@@ -945,12 +2242,48 @@ func (st *storeImplementation) PostUpdate(ctx context.Context, post PostInterfac
 		updateData[k] = v
 	}
 
+	for _, column := range st.encryptedColumns {
+		if value, ok := updateData[column].(string); ok {
+			encrypted, err := st.encryptField(ctx, column, value)
+			if err != nil {
+				return err
+			}
+			updateData[column] = encrypted
+		}
+	}
+
+	if content, ok := updateData[COLUMN_CONTENT].(string); ok && st.blobStore != nil {
+		value, err := st.blobContentOffload(ctx, post.GetID(), content)
+		if err != nil {
+			return err
+		}
+		if value == content {
+			// content is back under the threshold, drop any previous blob
+			if err := st.blobContentDelete(ctx, post.GetID()); err != nil {
+				return err
+			}
+		}
+		updateData[COLUMN_CONTENT] = value
+	}
+
+	if _, ok := updateData[COLUMN_SLUG]; ok && st.slugUniqueEnabled {
+		updateData[COLUMN_SLUG] = postUniqueSlug(post)
+	}
+
 	// Handle special fields that need conversion
 	if publishedAt, ok := updateData["published_at"]; ok {
 		if publishedAtStr, ok := publishedAt.(string); ok {
 			updateData["published_at"] = carbon.Parse(publishedAtStr, carbon.UTC).StdTime()
 		}
 	}
+	if _, ok := updateData[COLUMN_EXPIRES_AT]; ok {
+		updateData[COLUMN_EXPIRES_AT] = postExpiresAtValue(post)
+	}
+	if status, ok := updateData[COLUMN_STATUS].(string); ok {
+		if err := st.postTrashedAtUpdate(ctx, post.GetID(), status, updateData); err != nil {
+			return err
+		}
+	}
 	if createdAt, ok := updateData["created_at"]; ok {
 		if createdAtStr, ok := createdAt.(string); ok {
 			updateData["created_at"] = carbon.Parse(createdAtStr, carbon.UTC).StdTime()
@@ -967,29 +2300,145 @@ func (st *storeImplementation) PostUpdate(ctx context.Context, post PostInterfac
 		}
 	}
 
-	_, err := st.db.Query().
+	q := st.query(ctx).
 		Table(st.postTableName).
-		Where(COLUMN_ID+" = ?", post.GetID()).
-		Update(updateData)
+		Where(COLUMN_ID+" = ?", post.GetID())
+
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Update(updateData) })
+	st.explainQuery(ctx, nil, "PostUpdate", sqlFn)
+
+	start := time.Now()
+	_, err = q.Update(updateData)
+	st.logSlowQuery(ctx, "PostUpdate", start, sqlFn)
 
 	if err != nil {
+		if slug, ok := updateData[COLUMN_SLUG].(string); ok {
+			return st.slugConflictError(ctx, post.GetID(), slug, err)
+		}
 		return err
 	}
 
 	post.MarkAsNotDirty()
+	if slug, ok := updateData[COLUMN_SLUG].(string); ok {
+		if err := st.slugHistoryRecord(ctx, post.GetID(), previousSlug, slug); err != nil {
+			return err
+		}
+	}
+
 	if err2 := st.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, post.GetID(), post); err2 != nil {
 		return err2
 	}
 
-	return nil
+	if err := st.auditRecord(ctx, AUDIT_ENTITY_POST, post.GetID(), ACTION_UPDATE, before, post); err != nil {
+		return err
+	}
+
+	if before != nil {
+		if err := st.statusHistoryRecord(ctx, post.GetID(), before.GetStatus(), post.GetStatus()); err != nil {
+			return err
+		}
+	}
+
+	if before != nil {
+		if err := st.eventPublish(ctx, before, post); err != nil {
+			return err
+		}
+	}
+
+	return st.hookRun(ctx, hookAfterPostUpdate, post)
 }
 
 // buildPostQuery builds a neat query from the post query options.
-func (st *storeImplementation) buildPostQuery(options PostQueryOptions) contractsorm.Query {
-	q := st.db.Query().Table(st.postTableName)
+// Returns an OrderError if OrderBy or SortOrder is not allowed.
+func (st *storeImplementation) buildPostQuery(ctx context.Context, db *neat.Database, options PostQueryOptions) (contractsorm.Query, error) {
+	q := st.queryOn(ctx, db).Table(st.postTableName)
+
+	for _, condition := range st.postQueryConditions(options) {
+		q = q.Where(condition.sql, condition.args...)
+	}
+
+	if options.OrderBy != "" {
+		if err := validateOrderBy(options.OrderBy, postTableColumns); err != nil {
+			return nil, err
+		}
+		order, err := normalizeSortOrder(options.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		if order == SORT_ORDER_ASC {
+			q = q.OrderBy(options.OrderBy)
+		} else {
+			q = q.OrderByDesc(options.OrderBy)
+		}
+	}
+
+	specs, err := validateOrderSpecs(options.OrderByMulti, postTableColumns)
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		if spec.Direction == SORT_ORDER_ASC {
+			q = q.OrderBy(spec.Column)
+		} else {
+			q = q.OrderByDesc(spec.Column)
+		}
+	}
+
+	if options.Random {
+		// RANDOM() or RAND() depending on the driver
+		q = q.InRandomOrder()
+	}
+
+	if options.Limit > 0 {
+		q = q.Limit(options.Limit)
+	}
+
+	if options.Offset > 0 {
+		q = q.Offset(options.Offset)
+	}
+
+	if options.WithDeleted {
+		q = q.WithSoftDeleted()
+	}
+
+	return q, nil
+}
+
+// queryCondition is a single SQL condition with its "?" placeholder arguments.
+type queryCondition struct {
+	sql  string
+	args []any
+}
+
+// joinQueryConditions combines the conditions into a WHERE clause (without
+// the WHERE keyword) and its arguments. Returns "1=1" when there are none.
+func joinQueryConditions(conditions []queryCondition) (string, []any) {
+	if len(conditions) == 0 {
+		return "1=1", nil
+	}
+
+	parts := make([]string, 0, len(conditions))
+	args := []any{}
+	for _, condition := range conditions {
+		parts = append(parts, "("+condition.sql+")")
+		args = append(args, condition.args...)
+	}
+
+	return strings.Join(parts, " AND "), args
+}
+
+// postQueryConditions returns the filter conditions of the post query options.
+// Ordering and pagination are not part of the conditions, so they can be
+// shared between listing and counting.
+func (st *storeImplementation) postQueryConditions(options PostQueryOptions) []queryCondition {
+	conditions := []queryCondition{}
+	where := func(sql string, args ...any) {
+		conditions = append(conditions, queryCondition{sql: sql, args: args})
+	}
+	driver := st.driver()
 
 	if options.ID != "" {
-		q = q.Where(COLUMN_ID+" = ?", options.ID)
+		where(COLUMN_ID+" = ?", options.ID)
 	}
 
 	if len(options.IDIn) > 0 {
@@ -1004,11 +2453,44 @@ func (st *storeImplementation) buildPostQuery(options PostQueryOptions) contract
 			placeholders = append(placeholders, id)
 		}
 		inClause += ")"
-		q = q.Where(inClause, placeholders...)
+		where(inClause, placeholders...)
 	}
 
 	if options.Slug != "" {
-		q = q.Where(COLUMN_SLUG+" = ?", options.Slug)
+		where(COLUMN_SLUG+" = ?", options.Slug)
+	}
+
+	if options.IdempotencyKey != "" {
+		where(COLUMN_IDEMPOTENCY_KEY+" = ?", options.IdempotencyKey)
+	}
+
+	if options.CanonicalURL != "" {
+		where(COLUMN_CANONICAL_URL+" = ?", options.CanonicalURL)
+	}
+
+	if options.Locale != "" {
+		where(COLUMN_LOCALE+" = ?", options.Locale)
+	}
+
+	if options.TranslationGroupID != "" {
+		where(COLUMN_TRANSLATION_GROUP_ID+" = ?", options.TranslationGroupID)
+	}
+
+	if options.TermID != "" {
+		if st.taxonomyEnabled {
+			where(COLUMN_ID+" IN (SELECT "+COLUMN_POST_ID+" FROM "+st.termRelationTableName+" WHERE "+COLUMN_TERM_ID+" = ?)", options.TermID)
+		} else {
+			where("1 = 0")
+		}
+	}
+
+	if len(options.TermIDIn) > 0 {
+		if st.taxonomyEnabled {
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(options.TermIDIn)), ", ")
+			where(COLUMN_ID+" IN (SELECT "+COLUMN_POST_ID+" FROM "+st.termRelationTableName+" WHERE "+COLUMN_TERM_ID+" IN ("+placeholders+"))", lo.ToAnySlice(options.TermIDIn)...)
+		} else {
+			where("1 = 0")
+		}
 	}
 
 	if options.OldSlug != "" {
@@ -1017,15 +2499,24 @@ func (st *storeImplementation) buildPostQuery(options PostQueryOptions) contract
 		// Note: the value is a string containing JSON array
 		// We search for the pattern: "_old_slugs":"[..."old-slug-1"...]"
 		// Use escaped quotes for the pattern
-		q = q.Where(COLUMN_METAS+" LIKE ?", "%\"_old_slugs\":\"[%\\\""+options.OldSlug+"\\\"%]%")
+		// Native JSON columns are queried on the unescaped meta value instead
+		if expression, arg := metaValueExpression(driver, META_KEY_OLD_SLUGS); expression != "" {
+			where(expression+" LIKE ?", arg, "%\""+options.OldSlug+"\"%")
+		} else {
+			where(COLUMN_METAS+" LIKE ?", "%\"_old_slugs\":\"[%\\\""+options.OldSlug+"\\\"%]%")
+		}
 	}
 
 	if len(options.MetaEquals) > 0 {
 		// For each meta key-value pair, add a JSON contains condition
 		// The JSON structure is: {"key": "value"}
 		for key, value := range options.MetaEquals {
+			if expression, arg := metaValueExpression(driver, key); expression != "" {
+				where(expression+" = ?", arg, value)
+				continue
+			}
 			// Search for pattern: "key":"value"
-			q = q.Where(COLUMN_METAS+" LIKE ?", "%\""+key+"\":\""+value+"\"%")
+			where(COLUMN_METAS+" LIKE ?", "%\""+key+"\":\""+value+"\"%")
 		}
 	}
 
@@ -1033,13 +2524,17 @@ func (st *storeImplementation) buildPostQuery(options PostQueryOptions) contract
 		// For each meta array key-value pair, add a JSON contains condition
 		// The JSON structure is: {"key": "[\"value1\",\"value2\"]"}
 		for key, value := range options.MetaArrayContains {
+			if expression, arg := metaValueExpression(driver, key); expression != "" {
+				where(expression+" LIKE ?", arg, "%\""+value+"\"%")
+				continue
+			}
 			// Search for pattern: "key":"[..."value"...]"
-			q = q.Where(COLUMN_METAS+" LIKE ?", "%\""+key+"\":\"[%\""+value+"\"%]%")
+			where(COLUMN_METAS+" LIKE ?", "%\""+key+"\":\"[%\""+value+"\"%]%")
 		}
 	}
 
 	if options.Status != "" {
-		q = q.Where(COLUMN_STATUS+" = ?", options.Status)
+		where(COLUMN_STATUS+" = ?", options.Status)
 	}
 
 	if len(options.StatusIn) > 0 {
@@ -1054,45 +2549,64 @@ func (st *storeImplementation) buildPostQuery(options PostQueryOptions) contract
 			placeholders = append(placeholders, status)
 		}
 		inClause += ")"
-		q = q.Where(inClause, placeholders...)
+		where(inClause, placeholders...)
+	}
+
+	if options.AuthorID != "" {
+		where(COLUMN_AUTHOR_ID+" = ?", options.AuthorID)
+	}
+
+	if options.Featured != "" {
+		where(COLUMN_FEATURED+" = ?", options.Featured)
 	}
 
 	if options.CreatedAtLessThan != "" {
-		q = q.Where(COLUMN_CREATED_AT+" < ?", carbon.Parse(options.CreatedAtLessThan, carbon.UTC).StdTime())
+		where(COLUMN_CREATED_AT+" < ?", carbon.Parse(options.CreatedAtLessThan, carbon.UTC).StdTime())
 	}
 
 	if options.CreatedAtGreaterThan != "" {
-		q = q.Where(COLUMN_CREATED_AT+" > ?", carbon.Parse(options.CreatedAtGreaterThan, carbon.UTC).StdTime())
+		where(COLUMN_CREATED_AT+" > ?", carbon.Parse(options.CreatedAtGreaterThan, carbon.UTC).StdTime())
 	}
 
-	if options.Search != "" {
-		// Simple search on title and content
-		q = q.Where("("+COLUMN_TITLE+" LIKE ? OR "+COLUMN_CONTENT+" LIKE ?)", "%"+options.Search+"%", "%"+options.Search+"%")
+	if options.UpdatedAtLessThan != "" {
+		where(COLUMN_UPDATED_AT+" < ?", carbon.Parse(options.UpdatedAtLessThan, carbon.UTC).StdTime())
 	}
 
-	if options.OrderBy != "" {
-		order := options.SortOrder
-		if order == "" {
-			order = "DESC"
-		}
-		q = q.OrderBy(options.OrderBy + " " + order)
+	if options.TrashedAtLessThan != "" {
+		where(COLUMN_TRASHED_AT+" > ? AND "+COLUMN_TRASHED_AT+" < ?", carbon.Parse(neat.NullDateTime, carbon.UTC).StdTime(), carbon.Parse(options.TrashedAtLessThan, carbon.UTC).StdTime())
 	}
 
-	if options.Limit > 0 {
-		q = q.Limit(options.Limit)
+	if options.PublishedAtLessThan != "" {
+		where(COLUMN_PUBLISHED_AT+" < ?", carbon.Parse(options.PublishedAtLessThan, carbon.UTC).StdTime())
 	}
 
-	if options.Offset > 0 {
-		q = q.Offset(options.Offset)
+	if options.PublishedAtGreaterThan != "" {
+		where(COLUMN_PUBLISHED_AT+" > ?", carbon.Parse(options.PublishedAtGreaterThan, carbon.UTC).StdTime())
+	}
+
+	if options.PublishedBeforeNow {
+		where(COLUMN_PUBLISHED_AT+" <= ?", st.now().StdTime())
+	}
+
+	if options.ExpiredBeforeNow {
+		where(COLUMN_EXPIRES_AT+" > ? AND "+COLUMN_EXPIRES_AT+" <= ?", carbon.Parse(neat.NullDateTime, carbon.UTC).StdTime(), st.now().StdTime())
+	}
+
+	if options.ViewsGreaterThan > 0 {
+		where(COLUMN_VIEWS+" > ?", options.ViewsGreaterThan)
+	}
+
+	if options.Search != "" {
+		// Simple case-insensitive search on title and content
+		condition, args := containsCondition(driver, options.Search, COLUMN_TITLE, COLUMN_CONTENT)
+		where(condition, args...)
 	}
 
 	// Handle soft delete filtering
 	// Active records have soft_deleted_at > NOW (soft-deleted have soft_deleted_at <= NOW)
-	if options.WithDeleted {
-		q = q.WithSoftDeleted()
-	} else {
-		q = q.Where(COLUMN_SOFT_DELETED_AT+" > ?", carbon.Now(carbon.UTC).StdTime())
+	if !options.WithDeleted {
+		where(COLUMN_SOFT_DELETED_AT+" > ?", st.now().StdTime())
 	}
 
-	return q
+	return conditions
 }
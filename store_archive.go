@@ -0,0 +1,182 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// postTableColumns lists the columns of the post table, which the archive table shares.
+// Columns are listed explicitly, as their physical order may differ between
+// installations (e.g. the slug column added by a later migration).
+var postTableColumns = []string{
+	COLUMN_ID,
+	COLUMN_SLUG,
+	COLUMN_TITLE,
+	COLUMN_CONTENT,
+	COLUMN_SUMMARY,
+	COLUMN_STATUS,
+	COLUMN_AUTHOR_ID,
+	COLUMN_CANONICAL_URL,
+	COLUMN_IMAGE_URL,
+	COLUMN_MEMO,
+	COLUMN_META_DESCRIPTION,
+	COLUMN_META_KEYWORDS,
+	COLUMN_META_ROBOTS,
+	COLUMN_METAS,
+	COLUMN_FEATURED,
+	COLUMN_IDEMPOTENCY_KEY,
+	COLUMN_LOCALE,
+	COLUMN_TRANSLATION_GROUP_ID,
+	COLUMN_PUBLISHED_AT,
+	COLUMN_EXPIRES_AT,
+	COLUMN_VIEWS,
+	COLUMN_CREATED_AT,
+	COLUMN_UPDATED_AT,
+	COLUMN_SOFT_DELETED_AT,
+}
+
+// ArchiveEnabled returns true if soft-deleted posts are moved to the archive table.
+func (store *storeImplementation) ArchiveEnabled() bool {
+	return store.archiveEnabled
+}
+
+// GetArchiveTableName returns the archive table name
+func (store *storeImplementation) GetArchiveTableName() string {
+	return store.archiveTableName
+}
+
+// PostArchiveList retrieves soft-deleted posts from the archive table.
+// Returns an error if archiving is not enabled.
+func (store *storeImplementation) PostArchiveList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error) {
+	if ctx == nil {
+		return []PostInterface{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.archiveEnabled {
+		return []PostInterface{}, errors.New("archive is not enabled")
+	}
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return []PostInterface{}, err
+	}
+
+	// every archived post is soft deleted
+	options.WithDeleted = true
+
+	return store.postListFromTable(ctx, store.archiveTableName, options)
+}
+
+// PostUnarchive moves an archived post back to the post table and clears
+// its soft deleted timestamp, making it active again.
+// Returns an error if archiving is not enabled or the post is not archived.
+func (store *storeImplementation) PostUnarchive(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.archiveEnabled {
+		return errors.New("archive is not enabled")
+	}
+	if id == "" {
+		return errors.New("post id is empty")
+	}
+
+	if store.authorizer != nil {
+		archived, err := store.postListFromTable(ctx, store.archiveTableName, PostQueryOptions{ID: id, WithDeleted: true, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(archived) == 0 {
+			return errors.New("post not found in archive")
+		}
+		if err := store.authorize(ctx, ACTION_UNARCHIVE, archived[0]); err != nil {
+			return err
+		}
+	}
+
+	moved, err := store.postMoveBetweenTables(ctx, store.archiveTableName, store.postTableName, id)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return errors.New("post not found in archive")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	before, err := store.postSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostUnarchive", "UPDATE "+store.postTableName+" SET "+COLUMN_SOFT_DELETED_AT+" = ? WHERE "+COLUMN_ID+" = ?",
+		carbon.Parse(MAX_DATETIME, carbon.UTC).StdTime(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	after, err := store.postSnapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_POST, id, ACTION_UNARCHIVE, before, after)
+}
+
+// postMoveToArchive moves a post from the post table to the archive table.
+func (store *storeImplementation) postMoveToArchive(ctx context.Context, id string) error {
+	_, err := store.postMoveBetweenTables(ctx, store.postTableName, store.archiveTableName, id)
+	return err
+}
+
+// postMoveBetweenTables copies a post row to the target table and deletes it
+// from the source table, in a single transaction.
+// Returns false if the post does not exist in the source table.
+func (store *storeImplementation) postMoveBetweenTables(ctx context.Context, fromTable string, toTable string, id string) (bool, error) {
+	db, err := store.db.DB()
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	columns := strings.Join(postTableColumns, ", ")
+
+	result, err := store.execContext(ctx, tx, "postMoveBetweenTables", "INSERT INTO "+toTable+" ("+columns+") SELECT "+columns+" FROM "+fromTable+" WHERE "+COLUMN_ID+" = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+
+	_, err = store.execContext(ctx, tx, "postMoveBetweenTables", "DELETE FROM "+fromTable+" WHERE "+COLUMN_ID+" = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,90 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/dracory/neat"
+	"github.com/dromara/carbon/v2"
+)
+
+// PostArchiveMonths counts the posts matching the options per publication
+// month, newest month first, in a single GROUP BY query. Unless the options
+// filter by status, only published posts with a publication date in the past
+// are counted. Posts without a publication date are skipped. Pagination and
+// ordering options are ignored.
+func (store *storeImplementation) PostArchiveMonths(ctx context.Context, options PostQueryOptions) ([]ArchiveMonth, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return nil, err
+	}
+
+	if options.Status == "" && len(options.StatusIn) == 0 {
+		options.Status = POST_STATUS_PUBLISHED
+		options.PublishedBeforeNow = true
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := append(store.postQueryConditions(options), queryCondition{
+		sql:  COLUMN_PUBLISHED_AT + " > ?",
+		args: []any{carbon.Parse(neat.NullDateTime, carbon.UTC).StdTime()},
+	})
+	where, args := joinQueryConditions(conditions)
+	month := store.monthExpression(COLUMN_PUBLISHED_AT)
+
+	rows, err := store.queryContext(ctx, db, "PostArchiveMonths", "SELECT "+month+", COUNT(*) FROM "+store.postTableName+" WHERE "+where+" GROUP BY "+month+" ORDER BY "+month+" DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := []ArchiveMonth{}
+	for rows.Next() {
+		var key sql.NullString
+		var count int64
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+
+		year, month, ok := parseArchiveMonth(key.String)
+		if !ok {
+			continue
+		}
+		months = append(months, ArchiveMonth{Year: year, Month: month, Count: count})
+	}
+
+	return months, rows.Err()
+}
+
+// parseArchiveMonth parses a "YYYY-MM" month key.
+func parseArchiveMonth(key string) (int, int, bool) {
+	yearText, monthText, found := strings.Cut(key, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	year, err := strconv.Atoi(yearText)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	month, err := strconv.Atoi(monthText)
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, false
+	}
+
+	return year, month, true
+}
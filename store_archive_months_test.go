@@ -0,0 +1,68 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostArchiveMonths(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	posts := []PostInterface{
+		NewPost().SetStatus(POST_STATUS_PUBLISHED).SetPublishedAt("2024-01-05 10:00:00"),
+		NewPost().SetStatus(POST_STATUS_PUBLISHED).SetPublishedAt("2024-01-20 10:00:00"),
+		NewPost().SetStatus(POST_STATUS_PUBLISHED).SetPublishedAt("2024-03-01 10:00:00"),
+		NewPost().SetStatus(POST_STATUS_PUBLISHED).SetPublishedAt("2023-12-31 23:00:00"),
+		NewPost().SetStatus(POST_STATUS_DRAFT).SetPublishedAt("2024-02-01 10:00:00"),
+		NewPost().SetStatus(POST_STATUS_PUBLISHED).SetPublishedAt("2999-01-01 00:00:00"),
+	}
+	for _, post := range posts {
+		if err := store.PostCreate(ctx, post.SetTitle("Post")); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	months, err := store.PostArchiveMonths(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := []ArchiveMonth{
+		{Year: 2024, Month: 3, Count: 1},
+		{Year: 2024, Month: 1, Count: 2},
+		{Year: 2023, Month: 12, Count: 1},
+	}
+	if len(months) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, months)
+	}
+	for i := range expected {
+		if months[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, months)
+		}
+	}
+
+	months, err = store.PostArchiveMonths(ctx, PostQueryOptions{IDIn: []string{posts[2].GetID(), posts[4].GetID()}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(months) != 1 || months[0] != (ArchiveMonth{Year: 2024, Month: 3, Count: 1}) {
+		t.Fatal("Expected the month of the published post, got:", months)
+	}
+
+	months, err = store.PostArchiveMonths(ctx, PostQueryOptions{Status: POST_STATUS_DRAFT})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(months) != 1 || months[0] != (ArchiveMonth{Year: 2024, Month: 2, Count: 1}) {
+		t.Fatal("Expected the month of the draft, got:", months)
+	}
+}
@@ -0,0 +1,87 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dromara/carbon/v2"
+)
+
+func TestStoreArchiveSoftDeleteAndUnarchive(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		ArchiveEnabled:     true,
+		ArchiveTableName:   "blog_posts_archive",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		MaxOpenConns:       1,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Archived post").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatal("expected post to be moved out of the post table, found:", count)
+	}
+
+	archived, err := store.PostArchiveList(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(archived) != 1 || archived[0].GetID() != post.GetID() {
+		t.Fatal("expected post in archive, found:", len(archived))
+	}
+	if archived[0].GetTitle() != "Archived post" || !archived[0].GetSoftDeletedAtCarbon().Lte(carbon.Now(carbon.UTC)) {
+		t.Fatal("expected archived post to keep its data and be soft deleted")
+	}
+
+	if err := store.PostUnarchive(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	restored, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if restored == nil || restored.GetSoftDeletedAtCarbon().Lte(carbon.Now(carbon.UTC)) {
+		t.Fatal("expected post to be active again after unarchive")
+	}
+
+	archived, err = store.PostArchiveList(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(archived) != 0 {
+		t.Fatal("expected archive to be empty, found:", len(archived))
+	}
+
+	if err := store.PostUnarchive(ctx, post.GetID()); err == nil {
+		t.Fatal("expected error when unarchiving a post that is not archived")
+	}
+}
+
+func TestStoreArchiveRequiresTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:  "blog_posts",
+		ArchiveEnabled: true,
+		DB:             initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error when ArchiveTableName is missing")
+	}
+}
@@ -0,0 +1,206 @@
+package blogstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// AuditEnabled returns true if mutations are recorded in the audit table.
+func (store *storeImplementation) AuditEnabled() bool {
+	return store.auditEnabled
+}
+
+// GetAuditTableName returns the audit table name
+func (store *storeImplementation) GetAuditTableName() string {
+	return store.auditTableName
+}
+
+// AuditList retrieves audit entries matching the given options, newest first.
+func (store *storeImplementation) AuditList(ctx context.Context, options AuditQueryOptions) ([]AuditEntry, error) {
+	if ctx == nil {
+		return []AuditEntry{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.auditEnabled {
+		return []AuditEntry{}, errors.New("audit is not enabled")
+	}
+
+	conditions := []queryCondition{}
+	if options.EntityType != "" {
+		conditions = append(conditions, queryCondition{COLUMN_ENTITY_TYPE + " = ?", []any{options.EntityType}})
+	}
+	if options.EntityID != "" {
+		conditions = append(conditions, queryCondition{COLUMN_ENTITY_ID + " = ?", []any{options.EntityID}})
+	}
+	if options.Action != "" {
+		conditions = append(conditions, queryCondition{COLUMN_ACTION + " = ?", []any{options.Action}})
+	}
+	if options.Actor != "" {
+		conditions = append(conditions, queryCondition{COLUMN_ACTOR + " = ?", []any{options.Actor}})
+	}
+	if !options.CreatedAtGreaterThan.IsZero() {
+		conditions = append(conditions, queryCondition{COLUMN_CREATED_AT + " > ?", []any{options.CreatedAtGreaterThan}})
+	}
+
+	where, args := joinQueryConditions(conditions)
+
+	query := "SELECT id, entity_type, entity_id, action, actor, before_hash, after_hash, created_at FROM " + store.auditTableName +
+		" WHERE " + where + " ORDER BY " + COLUMN_CREATED_AT + " DESC, " + COLUMN_ID + " DESC"
+
+	if options.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(options.Limit)
+		if options.Offset > 0 {
+			query += " OFFSET " + strconv.Itoa(options.Offset)
+		}
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return []AuditEntry{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "AuditList", query, args...)
+	if err != nil {
+		return []AuditEntry{}, err
+	}
+	defer rows.Close()
+
+	list := []AuditEntry{}
+	for rows.Next() {
+		var entry AuditEntry
+		if err := rows.Scan(&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &entry.Actor, &entry.BeforeHash, &entry.AfterHash, &entry.CreatedAt); err != nil {
+			return []AuditEntry{}, err
+		}
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// auditTableBlueprint defines the audit table.
+func auditTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 21)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_ENTITY_TYPE, 40)
+	table.String(COLUMN_ENTITY_ID, 40)
+	table.String(COLUMN_ACTION, 40)
+	table.String(COLUMN_ACTOR, 255).Default("")
+	table.String(COLUMN_BEFORE_HASH, 64).Default("")
+	table.String(COLUMN_AFTER_HASH, 64).Default("")
+	table.DateTime(COLUMN_CREATED_AT)
+	table.Index(COLUMN_ENTITY_TYPE, COLUMN_ENTITY_ID)
+}
+
+// auditRecord inserts an audit entry for a mutation, unless auditing is
+// disabled. The action may be overridden by contextWithAction.
+func (store *storeImplementation) auditRecord(ctx context.Context, entityType string, entityID string, action string, before any, after any) error {
+	if !store.auditEnabled {
+		return nil
+	}
+
+	action = actionFromContext(ctx, action)
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "auditRecord", "INSERT INTO "+store.auditTableName+" (id, entity_type, entity_id, action, actor, before_hash, after_hash, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		GenerateShortID(),
+		entityType,
+		entityID,
+		action,
+		ActorFromContext(ctx),
+		auditHash(before),
+		auditHash(after),
+		store.now().StdTime(),
+	)
+
+	return err
+}
+
+// auditHash returns the SHA-256 digest of the entity data, or an empty string
+// for a nil entity. Timestamps maintained by the store are left out, so equal
+// content hashes equally.
+func auditHash(entity any) string {
+	d, ok := entity.(versioningDataInterface)
+	if !ok || reflect.ValueOf(d).IsNil() {
+		return ""
+	}
+
+	data := map[string]string{}
+	for k, v := range d.GetData() {
+		if k == COLUMN_CREATED_AT || k == COLUMN_UPDATED_AT {
+			continue
+		}
+		data[k] = v
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// postSnapshot loads the stored post, including soft deleted ones, for the
+// audit log and the authorizer. Returns nil when neither is enabled.
+func (store *storeImplementation) postSnapshot(ctx context.Context, id string) (PostInterface, error) {
+	if (!store.auditEnabled && store.authorizer == nil && store.hooks == nil && store.eventPublisher == nil && !store.statusHistoryEnabled) || id == "" {
+		return nil, nil
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: id, WithDeleted: true, Limit: 1})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// auditMediaLoad loads the stored media, including soft deleted ones, to hash
+// its state around a mutation. Returns nil when auditing is disabled.
+func (store *storeImplementation) auditMediaLoad(ctx context.Context, id string) (MediaInterface, error) {
+	if !store.auditEnabled || id == "" {
+		return nil, nil
+	}
+
+	list, err := store.MediaList(ctx, MediaQueryOptions{ID: id, WithDeleted: true, Limit: 1})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
+
+// auditTaxonomyLoad loads the stored taxonomy to hash its state around a
+// mutation. Returns nil when auditing is disabled.
+func (store *storeImplementation) auditTaxonomyLoad(ctx context.Context, id string) (TaxonomyInterface, error) {
+	if !store.auditEnabled || id == "" {
+		return nil, nil
+	}
+
+	return store.TaxonomyFindByID(ctx, id)
+}
+
+// auditTermLoad loads the stored term to hash its state around a mutation.
+// Returns nil when auditing is disabled.
+func (store *storeImplementation) auditTermLoad(ctx context.Context, id string) (TermInterface, error) {
+	if !store.auditEnabled || id == "" {
+		return nil, nil
+	}
+
+	return store.TermFindByID(ctx, id)
+}
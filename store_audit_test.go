@@ -0,0 +1,169 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initAuditStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		MediaTableName:     "blog_media",
+		TaxonomyEnabled:    true,
+		AuditEnabled:       true,
+		AuditTableName:     "blog_audit",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreAuditRequiresTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		AuditEnabled:  true,
+		DB:            initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error when AuditTableName is missing")
+	}
+}
+
+func TestStoreAuditPostLifecycle(t *testing.T) {
+	store := initAuditStore(t)
+	ctx := ContextWithActor(context.Background(), "user-1")
+
+	post := NewPost().SetTitle("Audited")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetTitle("Audited changed")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostDeleteByID(context.Background(), post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	entries, err := store.AuditList(context.Background(), AuditQueryOptions{
+		EntityType: AUDIT_ENTITY_POST,
+		EntityID:   post.GetID(),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(entries) != 4 {
+		t.Fatal("expected 4 audit entries, found:", len(entries))
+	}
+
+	// newest first
+	expected := []string{ACTION_DELETE, ACTION_SOFT_DELETE, ACTION_UPDATE, ACTION_CREATE}
+	for i, action := range expected {
+		if entries[i].Action != action {
+			t.Fatalf("entry %d: expected action %q, found %q", i, action, entries[i].Action)
+		}
+	}
+
+	create := entries[3]
+	if create.Actor != "user-1" || create.BeforeHash != "" || create.AfterHash == "" {
+		t.Fatalf("unexpected create entry: %+v", create)
+	}
+
+	update := entries[2]
+	if update.BeforeHash == "" || update.AfterHash == "" || update.BeforeHash == update.AfterHash {
+		t.Fatalf("expected update entry with differing hashes, found: %+v", update)
+	}
+
+	deleted := entries[0]
+	if deleted.Actor != "" || deleted.BeforeHash == "" || deleted.AfterHash != "" {
+		t.Fatalf("unexpected delete entry: %+v", deleted)
+	}
+
+	byActor, err := store.AuditList(context.Background(), AuditQueryOptions{Actor: "user-1", Limit: 2})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(byActor) != 2 {
+		t.Fatal("expected 2 entries for the actor with limit, found:", len(byActor))
+	}
+}
+
+func TestStoreAuditMediaAndTaxonomy(t *testing.T) {
+	store := initAuditStore(t)
+	ctx := context.Background()
+
+	media := NewMedia().SetEntityID("post-1").SetTitle("image.jpg")
+	if err := store.MediaCreate(ctx, media); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.MediaSoftDelete(ctx, media); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	taxonomy := NewTaxonomy().SetName("Categories").SetSlug("category")
+	if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	term := NewTerm().SetTaxonomyID(taxonomy.GetID()).SetName("Go").SetSlug("go")
+	if err := store.TermCreate(ctx, term); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.TermDelete(ctx, term); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	counts := map[string]int{}
+	entries, err := store.AuditList(ctx, AuditQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, entry := range entries {
+		counts[entry.EntityType+":"+entry.Action]++
+	}
+
+	expected := map[string]int{
+		AUDIT_ENTITY_MEDIA + ":" + ACTION_CREATE:      1,
+		AUDIT_ENTITY_MEDIA + ":" + ACTION_SOFT_DELETE: 1,
+		AUDIT_ENTITY_TAXONOMY + ":" + ACTION_CREATE:   1,
+		AUDIT_ENTITY_TERM + ":" + ACTION_CREATE:       1,
+		AUDIT_ENTITY_TERM + ":" + ACTION_DELETE:       1,
+	}
+	for key, count := range expected {
+		if counts[key] != count {
+			t.Fatalf("expected %d %s entries, found %d (all: %v)", count, key, counts[key], counts)
+		}
+	}
+}
+
+func TestStoreAuditDisabled(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostCreate(context.Background(), NewPost().SetTitle("Not audited")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.AuditList(context.Background(), AuditQueryOptions{}); err == nil {
+		t.Fatal("expected error when audit is not enabled")
+	}
+}
@@ -0,0 +1,279 @@
+package blogstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+	"github.com/samber/lo"
+)
+
+// AuthorsEnabled returns true if author profiles are stored in the authors table.
+func (store *storeImplementation) AuthorsEnabled() bool {
+	return store.authorsEnabled
+}
+
+// GetAuthorsTableName returns the authors table name
+func (store *storeImplementation) GetAuthorsTableName() string {
+	return store.authorsTableName
+}
+
+// AuthorCreate inserts an author profile. The ID is usually the author ID
+// already set on the posts, e.g. the user ID; a new ID is generated if it is
+// empty. The status defaults to AUTHOR_STATUS_ACTIVE and the timestamps are
+// set on the author.
+func (store *storeImplementation) AuthorCreate(ctx context.Context, author *Author) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.authorsEnabled {
+		return errors.New("authors are not enabled")
+	}
+	if author == nil {
+		return errors.New("author is nil")
+	}
+
+	if author.ID == "" {
+		author.ID = GenerateShortID()
+	}
+	if author.Status == "" {
+		author.Status = AUTHOR_STATUS_ACTIVE
+	}
+	if err := authorValidate(author); err != nil {
+		return err
+	}
+
+	socialLinks, err := authorSocialLinksEncode(author.SocialLinks)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	author.CreatedAt = store.now().StdTime()
+	author.UpdatedAt = author.CreatedAt
+
+	_, err = store.execContext(ctx, db, "AuthorCreate", "INSERT INTO "+store.authorsTableName+" (id, display_name, bio, avatar_url, social_links, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		author.ID, author.DisplayName, author.Bio, author.AvatarURL, socialLinks, author.Status, author.CreatedAt, author.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_AUTHOR, author.ID, author)
+}
+
+// AuthorUpdate saves all the fields of an author profile and refreshes its
+// update time. Returns an error if the author does not exist.
+func (store *storeImplementation) AuthorUpdate(ctx context.Context, author *Author) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.authorsEnabled {
+		return errors.New("authors are not enabled")
+	}
+	if author == nil {
+		return errors.New("author is nil")
+	}
+	if author.ID == "" {
+		return errors.New("author id is empty")
+	}
+	if err := authorValidate(author); err != nil {
+		return err
+	}
+
+	socialLinks, err := authorSocialLinksEncode(author.SocialLinks)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	updatedAt := store.now().StdTime()
+
+	result, err := store.execContext(ctx, db, "AuthorUpdate", "UPDATE "+store.authorsTableName+" SET display_name = ?, bio = ?, avatar_url = ?, social_links = ?, status = ?, updated_at = ? WHERE "+COLUMN_ID+" = ?",
+		author.DisplayName, author.Bio, author.AvatarURL, socialLinks, author.Status, updatedAt, author.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("author not found")
+	}
+
+	author.UpdatedAt = updatedAt
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_AUTHOR, author.ID, author)
+}
+
+// AuthorDelete removes an author profile. The posts keep their author ID;
+// use EraseAuthorData to remove it from the posts as well.
+func (store *storeImplementation) AuthorDelete(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.authorsEnabled {
+		return errors.New("authors are not enabled")
+	}
+	if id == "" {
+		return errors.New("author id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "AuthorDelete", "DELETE FROM "+store.authorsTableName+" WHERE "+COLUMN_ID+" = ?", id)
+	return err
+}
+
+// AuthorFindByID retrieves an author profile by ID. Returns nil and nil error
+// if there is no profile with the ID.
+func (store *storeImplementation) AuthorFindByID(ctx context.Context, id string) (*Author, error) {
+	if id == "" {
+		return nil, errors.New("author id is empty")
+	}
+
+	list, err := store.AuthorList(ctx, AuthorQueryOptions{IDIn: []string{id}, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return &list[0], nil
+	}
+
+	return nil, nil
+}
+
+// AuthorList retrieves the author profiles matching the given options,
+// ordered by display name.
+func (store *storeImplementation) AuthorList(ctx context.Context, options AuthorQueryOptions) ([]Author, error) {
+	if ctx == nil {
+		return []Author{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.authorsEnabled {
+		return []Author{}, errors.New("authors are not enabled")
+	}
+
+	conditions := []queryCondition{}
+	if len(options.IDIn) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(options.IDIn)), ", ")
+		conditions = append(conditions, queryCondition{COLUMN_ID + " IN (" + placeholders + ")", lo.ToAnySlice(options.IDIn)})
+	}
+	if options.Status != "" {
+		conditions = append(conditions, queryCondition{COLUMN_STATUS + " = ?", []any{options.Status}})
+	}
+	if options.Search != "" {
+		condition, args := containsCondition(store.driver(), options.Search, COLUMN_DISPLAY_NAME)
+		conditions = append(conditions, queryCondition{condition, args})
+	}
+
+	where, args := joinQueryConditions(conditions)
+
+	query := "SELECT id, display_name, bio, avatar_url, social_links, status, created_at, updated_at FROM " + store.authorsTableName +
+		" WHERE " + where + " ORDER BY " + COLUMN_DISPLAY_NAME + " ASC, " + COLUMN_ID + " ASC"
+
+	if options.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(options.Limit)
+		if options.Offset > 0 {
+			query += " OFFSET " + strconv.Itoa(options.Offset)
+		}
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return []Author{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "AuthorList", query, args...)
+	if err != nil {
+		return []Author{}, err
+	}
+	defer rows.Close()
+
+	list := []Author{}
+	for rows.Next() {
+		var author Author
+		var socialLinks string
+		if err := rows.Scan(&author.ID, &author.DisplayName, &author.Bio, &author.AvatarURL, &socialLinks, &author.Status, &author.CreatedAt, &author.UpdatedAt); err != nil {
+			return []Author{}, err
+		}
+		if socialLinks != "" {
+			if err := json.Unmarshal([]byte(socialLinks), &author.SocialLinks); err != nil {
+				return []Author{}, err
+			}
+		}
+		list = append(list, author)
+	}
+
+	return list, rows.Err()
+}
+
+// authorValidate checks the fields of an author before it is saved.
+func authorValidate(author *Author) error {
+	if strings.TrimSpace(author.DisplayName) == "" {
+		return errors.New("author display name is empty")
+	}
+	if author.Status != AUTHOR_STATUS_ACTIVE && author.Status != AUTHOR_STATUS_INACTIVE {
+		return errors.New("author status is invalid: " + author.Status)
+	}
+	return nil
+}
+
+// authorSocialLinksEncode returns the social links as a JSON object.
+func authorSocialLinksEncode(socialLinks map[string]string) (string, error) {
+	if socialLinks == nil {
+		socialLinks = map[string]string{}
+	}
+
+	encoded, err := json.Marshal(socialLinks)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// authorsTableBlueprint defines the authors table, holding the author
+// profiles keyed by the author IDs of the posts.
+func authorsTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 40)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_DISPLAY_NAME, 255)
+	table.Text(COLUMN_BIO)
+	table.String(COLUMN_AVATAR_URL, 510).Default("")
+	table.Text(COLUMN_SOCIAL_LINKS)
+	table.String(COLUMN_STATUS, 40).Default(AUTHOR_STATUS_ACTIVE)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.DateTime(COLUMN_UPDATED_AT)
+	table.Index(COLUMN_DISPLAY_NAME)
+}
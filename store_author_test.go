@@ -0,0 +1,194 @@
+package blogstore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func initAuthorsStore(t *testing.T) StoreInterface {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		AuthorsEnabled:     true,
+		AuthorsTableName:   "blog_authors",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return store
+}
+
+func TestStoreAuthorCRUD(t *testing.T) {
+	store := initAuthorsStore(t)
+	ctx := context.Background()
+
+	jane := &Author{
+		ID:          "user-jane",
+		DisplayName: "Jane Doe",
+		Bio:         "Writes about Go",
+		AvatarURL:   "https://example.com/jane.png",
+		SocialLinks: map[string]string{"github": "https://github.com/jane"},
+	}
+	if err := store.AuthorCreate(ctx, jane); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if jane.Status != AUTHOR_STATUS_ACTIVE || jane.CreatedAt.IsZero() {
+		t.Fatal("Expected the default status and the timestamps to be set, got:", jane)
+	}
+
+	bob := &Author{DisplayName: "Bob", Status: AUTHOR_STATUS_INACTIVE}
+	if err := store.AuthorCreate(ctx, bob); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if bob.ID == "" {
+		t.Fatal("Expected an ID to be generated")
+	}
+
+	if err := store.AuthorCreate(ctx, &Author{DisplayName: " "}); err == nil {
+		t.Fatal("Expected an error for an empty display name")
+	}
+	if err := store.AuthorCreate(ctx, &Author{DisplayName: "Eve", Status: "banned"}); err == nil {
+		t.Fatal("Expected an error for an unknown status")
+	}
+
+	found, err := store.AuthorFindByID(ctx, "user-jane")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.DisplayName != "Jane Doe" || found.SocialLinks["github"] != "https://github.com/jane" {
+		t.Fatal("Expected the saved author, got:", found)
+	}
+
+	found.Bio = "Writes about Go and SQL"
+	if err := store.AuthorUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, _ = store.AuthorFindByID(ctx, "user-jane"); found.Bio != "Writes about Go and SQL" {
+		t.Fatal("Expected the bio to be updated, got:", found.Bio)
+	}
+	if err := store.AuthorUpdate(ctx, &Author{ID: "missing", DisplayName: "Missing", Status: AUTHOR_STATUS_ACTIVE}); err == nil {
+		t.Fatal("Expected an error for a missing author")
+	}
+
+	list, err := store.AuthorList(ctx, AuthorQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 2 || list[0].DisplayName != "Bob" || list[1].DisplayName != "Jane Doe" {
+		t.Fatal("Expected the authors ordered by display name, got:", list)
+	}
+
+	list, err = store.AuthorList(ctx, AuthorQueryOptions{Status: AUTHOR_STATUS_ACTIVE, Search: "jane"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 || list[0].ID != "user-jane" {
+		t.Fatal("Expected the active author matching the search, got:", list)
+	}
+
+	if err := store.AuthorDelete(ctx, bob.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, err := store.AuthorFindByID(ctx, bob.ID); err != nil || found != nil {
+		t.Fatal("Expected the author to be deleted, got:", found, err)
+	}
+}
+
+func TestStorePostListWithAuthor(t *testing.T) {
+	store := initAuthorsStore(t)
+	ctx := context.Background()
+
+	if err := store.AuthorCreate(ctx, &Author{ID: "user-jane", DisplayName: "Jane Doe"}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	withProfile := NewPost().SetTitle("With profile").SetAuthorID("user-jane")
+	withoutProfile := NewPost().SetTitle("Without profile").SetAuthorID("user-unknown")
+	for _, post := range []PostInterface{withProfile, withoutProfile} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	posts, err := store.PostList(ctx, PostQueryOptions{With: []string{POST_WITH_AUTHOR}, OrderBy: COLUMN_TITLE, SortOrder: SORT_ORDER_ASC})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("Expected 2 posts, got %d", len(posts))
+	}
+	if posts[0].Author() == nil || posts[0].Author().DisplayName != "Jane Doe" {
+		t.Fatal("Expected the author profile to be loaded, got:", posts[0].Author())
+	}
+	if posts[1].Author() != nil {
+		t.Fatal("Expected no profile for an unknown author, got:", posts[1].Author())
+	}
+
+	if err := store.EraseAuthorData(ctx, "user-jane"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, _ := store.AuthorFindByID(ctx, "user-jane"); found != nil {
+		t.Fatal("Expected EraseAuthorData to delete the profile")
+	}
+}
+
+func TestStoreAuthorVersioning(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		AuthorsEnabled:      true,
+		AuthorsTableName:    "blog_authors",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	author := &Author{ID: "user-jane", DisplayName: "Jane"}
+	if err := store.AuthorCreate(ctx, author); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	author.DisplayName = "Jane Doe"
+	if err := store.AuthorUpdate(ctx, author); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	query := NewVersioningQuery().SetEntityType(VERSIONING_TYPE_AUTHOR).SetEntityID(author.ID)
+	versions, err := store.VersioningList(ctx, query)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("VersioningList() = %d author versions, want 2", len(versions))
+	}
+
+	names := map[string]bool{}
+	for _, version := range versions {
+		data := map[string]string{}
+		if err := json.Unmarshal([]byte(version.Content()), &data); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		names[data[COLUMN_DISPLAY_NAME]] = true
+	}
+	if !names["Jane"] || !names["Jane Doe"] {
+		t.Fatalf("author version names = %v, want Jane and Jane Doe", names)
+	}
+
+	if err := store.EraseAuthorData(ctx, author.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	versions, err = store.VersioningList(ctx, query)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 0 {
+		t.Fatalf("VersioningList() = %d author versions after the erase, want 0", len(versions))
+	}
+}
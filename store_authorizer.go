@@ -0,0 +1,43 @@
+package blogstore
+
+import "context"
+
+// authorize asks the configured authorizer whether the action is allowed.
+// Everything is allowed when no authorizer is set.
+func (store *storeImplementation) authorize(ctx context.Context, action string, post PostInterface) error {
+	if store.authorizer == nil {
+		return nil
+	}
+
+	return store.authorizer.Can(ctx, action, post)
+}
+
+// authorizePost asks the configured authorizer whether the action is allowed
+// on the stored post with the ID, which is nil if there is no such post.
+// The post is only loaded when an authorizer is set.
+func (store *storeImplementation) authorizePost(ctx context.Context, action string, postID string) error {
+	if store.authorizer == nil {
+		return nil
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, WithoutContent: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+
+	var post PostInterface
+	if len(list) > 0 {
+		post = list[0]
+	}
+
+	return store.authorizer.Can(ctx, action, post)
+}
+
+// postAuthorizeRead returns the found post if the actor may read it.
+func (store *storeImplementation) postAuthorizeRead(ctx context.Context, post PostInterface) (PostInterface, error) {
+	if err := store.authorize(ctx, ACTION_READ, post); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
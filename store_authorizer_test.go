@@ -0,0 +1,235 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStoreAuthorizer(t *testing.T) {
+	// editors may do anything, everyone else may only list and read
+	authorizer := AuthorizerFunc(func(ctx context.Context, action string, post PostInterface) error {
+		if action == ACTION_LIST || action == ACTION_READ || ActorFromContext(ctx) == "editor" {
+			return nil
+		}
+		return ErrNotAuthorized
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Authorizer:         authorizer,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	editor := ContextWithActor(context.Background(), "editor")
+	reader := ContextWithActor(context.Background(), "reader")
+
+	post := NewPost().SetTitle("Authorized")
+	if err := store.PostCreate(reader, post); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader create, found:", err)
+	}
+	if err := store.PostCreate(editor, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetTitle("Changed by reader")
+	if err := store.PostUpdate(reader, post); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader update, found:", err)
+	}
+	if err := store.PostTrash(reader, post); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader trash, found:", err)
+	}
+	if err := store.PostDeleteByID(reader, post.GetID()); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader delete, found:", err)
+	}
+
+	found, err := store.PostFindByID(reader, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetTitle() != "Authorized" || found.GetStatus() != POST_STATUS_DRAFT {
+		t.Fatal("expected the post to be unchanged by the reader")
+	}
+
+	if err := store.PostDeleteByID(editor, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestStoreAuthorizerActions(t *testing.T) {
+	actions := []string{}
+	authorizer := AuthorizerFunc(func(ctx context.Context, action string, post PostInterface) error {
+		actions = append(actions, action)
+		return nil
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Authorizer:         authorizer,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Actions")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostTrash(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostCount(ctx, PostQueryOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := []string{ACTION_CREATE, ACTION_TRASH, ACTION_SOFT_DELETE, ACTION_LIST}
+	if len(actions) != len(expected) {
+		t.Fatalf("expected actions %v, found %v", expected, actions)
+	}
+	for i := range expected {
+		if actions[i] != expected[i] {
+			t.Fatalf("expected actions %v, found %v", expected, actions)
+		}
+	}
+}
+
+func TestStoreAuthorizerReads(t *testing.T) {
+	// everyone may list, only editors may read a single post
+	authorizer := AuthorizerFunc(func(ctx context.Context, action string, post PostInterface) error {
+		if action == ACTION_READ && ActorFromContext(ctx) != "editor" {
+			return ErrNotAuthorized
+		}
+		return nil
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Authorizer:         authorizer,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	editor := ContextWithActor(context.Background(), "editor")
+	reader := ContextWithActor(context.Background(), "reader")
+
+	post := NewPost().SetTitle("Secret").SetSlug("secret").SetCanonicalURL("https://example.com/secret")
+	if err := store.PostCreate(editor, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostFindByID(reader, post.GetID()); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader find by id, found:", err)
+	}
+	if _, err := store.PostFindBySlug(reader, "secret"); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader find by slug, found:", err)
+	}
+	if _, err := store.PostFindByCanonicalURL(reader, "https://example.com/secret"); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader find by canonical url, found:", err)
+	}
+
+	found, err := store.PostFindByID(editor, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetTitle() != "Secret" {
+		t.Fatal("expected the editor to find the post")
+	}
+}
+
+func TestStoreAuthorizerTaxonomyMediaViewsLocks(t *testing.T) {
+	// editors may do anything, everyone else nothing
+	authorizer := AuthorizerFunc(func(ctx context.Context, action string, post PostInterface) error {
+		if ActorFromContext(ctx) == "editor" {
+			return nil
+		}
+		return ErrNotAuthorized
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		MediaTableName:     "blog_media",
+		TaxonomyEnabled:    true,
+		LockEnabled:        true,
+		LockTableName:      "blog_locks",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Authorizer:         authorizer,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	editor := ContextWithActor(context.Background(), "editor")
+	reader := ContextWithActor(context.Background(), "reader")
+
+	post := NewPost().SetTitle("Post")
+	if err := store.PostCreate(editor, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.TaxonomyCreate(reader, NewTaxonomy().SetName("Topics").SetSlug("topics")); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader taxonomy create, found:", err)
+	}
+	taxonomy := NewTaxonomy().SetName("Topics").SetSlug("topics")
+	if err := store.TaxonomyCreate(editor, taxonomy); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.TermCreate(reader, NewTerm().SetTaxonomyID(taxonomy.GetID()).SetName("Go").SetSlug("go")); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader term create, found:", err)
+	}
+	term := NewTerm().SetTaxonomyID(taxonomy.GetID()).SetName("Go").SetSlug("go")
+	if err := store.TermCreate(editor, term); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostSetTerms(reader, post.GetID(), "topics", []string{term.GetID()}); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader set terms, found:", err)
+	}
+	if err := store.PostSetTerms(editor, post.GetID(), "topics", []string{term.GetID()}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MediaCreate(reader, NewMedia().SetURL("https://example.com/a.png")); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader media create, found:", err)
+	}
+	if err := store.PostAttachMedia(reader, post.GetID(), NewMedia().SetURL("https://example.com/b.png")); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader attach media, found:", err)
+	}
+	if err := store.PostAttachMedia(editor, post.GetID(), NewMedia().SetURL("https://example.com/b.png")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostIncrementViewCount(reader, post.GetID()); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader view count, found:", err)
+	}
+	if err := store.PostLock(reader, post.GetID(), "reader", time.Minute); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader lock, found:", err)
+	}
+	if _, err := store.Stats(reader); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatal("expected ErrNotAuthorized for reader stats, found:", err)
+	}
+
+	terms, err := store.TermListByPostID(editor, post.GetID(), "topics")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(terms) != 1 {
+		t.Fatal("expected the terms set by the editor only, found:", len(terms))
+	}
+}
@@ -0,0 +1,186 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// AutosaveEnabled returns true if in-progress edits can be autosaved.
+func (store *storeImplementation) AutosaveEnabled() bool {
+	return store.autosaveEnabled
+}
+
+// GetAutosaveTableName returns the autosave table name
+func (store *storeImplementation) GetAutosaveTableName() string {
+	return store.autosaveTableName
+}
+
+// PostAutosave saves the in-progress content of a post in the autosave
+// table, replacing the previous autosave of the post. The post itself is not
+// changed and no version is recorded until the autosave is restored.
+func (store *storeImplementation) PostAutosave(ctx context.Context, postID string, content string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.autosaveEnabled {
+		return errors.New("autosave is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, WithoutContent: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errors.New("post not found")
+	}
+
+	if err := store.authorize(ctx, ACTION_UPDATE, list[0]); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	content, err = store.encryptAutosaveContent(ctx, content)
+	if err != nil {
+		return err
+	}
+
+	now := store.now().StdTime()
+	actor := ActorFromContext(ctx)
+
+	result, err := store.execContext(ctx, db, "PostAutosave", "UPDATE "+store.autosaveTableName+" SET "+COLUMN_CONTENT+" = ?, "+COLUMN_ACTOR+" = ?, "+COLUMN_UPDATED_AT+" = ? WHERE "+COLUMN_POST_ID+" = ?",
+		content, actor, now, postID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostAutosave", "INSERT INTO "+store.autosaveTableName+" (post_id, content, actor, updated_at) VALUES (?, ?, ?, ?)",
+		postID, content, actor, now)
+	return err
+}
+
+// PostAutosaveFind returns the autosave of a post, or nil if there is none.
+func (store *storeImplementation) PostAutosaveFind(ctx context.Context, postID string) (*Autosave, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.autosaveEnabled {
+		return nil, errors.New("autosave is not enabled")
+	}
+	if postID == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	autosave := Autosave{}
+	err = store.queryRowContext(ctx, db, "PostAutosaveFind", "SELECT post_id, content, actor, updated_at FROM "+store.autosaveTableName+" WHERE "+COLUMN_POST_ID+" = ?",
+		[]any{postID}, &autosave.PostID, &autosave.Content, &autosave.Actor, &autosave.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	autosave.Content, err = store.decryptField(ctx, encryptedAutosaveContent, autosave.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autosave, nil
+}
+
+// PostAutosaveRestore copies the autosaved content into the post, saving it
+// with PostUpdate, and removes the autosave.
+func (store *storeImplementation) PostAutosaveRestore(ctx context.Context, postID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	autosave, err := store.PostAutosaveFind(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if autosave == nil {
+		return errors.New("autosave not found")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errors.New("post not found")
+	}
+
+	post := list[0]
+	post.SetContent(autosave.Content)
+	if err := store.PostUpdate(ctx, post); err != nil {
+		return err
+	}
+
+	return store.PostAutosaveDiscard(ctx, postID)
+}
+
+// PostAutosaveDiscard removes the autosave of a post, leaving the post as it is.
+// Discarding a post without an autosave is not an error.
+func (store *storeImplementation) PostAutosaveDiscard(ctx context.Context, postID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.autosaveEnabled {
+		return errors.New("autosave is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostAutosaveDiscard", "DELETE FROM "+store.autosaveTableName+" WHERE "+COLUMN_POST_ID+" = ?", postID)
+	return err
+}
+
+// autosaveTableBlueprint defines the autosave table, holding at most one
+// autosave per post.
+func autosaveTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_POST_ID, 40)
+	table.Primary(COLUMN_POST_ID)
+	table.Text(COLUMN_CONTENT)
+	table.String(COLUMN_ACTOR, 255).Default("")
+	table.DateTime(COLUMN_UPDATED_AT)
+}
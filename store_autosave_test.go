@@ -0,0 +1,134 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initAutosaveStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		AutosaveEnabled:     true,
+		AutosaveTableName:   "blog_autosave",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreAutosaveRequiresTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:   "blog_posts",
+		AutosaveEnabled: true,
+		DB:              initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error when AutosaveTableName is missing")
+	}
+}
+
+func TestStorePostAutosaveKeepsPostUnchanged(t *testing.T) {
+	store := initAutosaveStore(t)
+	ctx := ContextWithActor(context.Background(), "editor-1")
+
+	post := NewPost().SetTitle("Live").SetContent("published text")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostAutosave(ctx, post.GetID(), "first draft"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostAutosave(ctx, post.GetID(), "second draft"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	autosave, err := store.PostAutosaveFind(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if autosave == nil || autosave.Content != "second draft" || autosave.Actor != "editor-1" {
+		t.Fatalf("autosave = %+v, want the latest content by editor-1", autosave)
+	}
+
+	stored, _ := store.PostFindByID(ctx, post.GetID())
+	if stored.GetContent() != "published text" {
+		t.Fatalf("content = %q, want the post unchanged by autosaves", stored.GetContent())
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("got %d versions, want only the one of the create", len(versions))
+	}
+}
+
+func TestStorePostAutosaveRestore(t *testing.T) {
+	store := initAutosaveStore(t)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Live").SetContent("published text")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostAutosaveRestore(ctx, post.GetID()); err == nil {
+		t.Fatal("expected error when there is no autosave")
+	}
+
+	if err := store.PostAutosave(ctx, post.GetID(), "edited text"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostAutosaveRestore(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	stored, _ := store.PostFindByID(ctx, post.GetID())
+	if stored.GetContent() != "edited text" {
+		t.Fatalf("content = %q, want the restored autosave", stored.GetContent())
+	}
+
+	if autosave, _ := store.PostAutosaveFind(ctx, post.GetID()); autosave != nil {
+		t.Fatal("expected the autosave to be removed once restored")
+	}
+}
+
+func TestStorePostAutosaveDiscard(t *testing.T) {
+	store := initAutosaveStore(t)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Live").SetContent("published text")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostAutosave(ctx, "missing", "text"); err == nil {
+		t.Fatal("expected error when autosaving a missing post")
+	}
+
+	if err := store.PostAutosave(ctx, post.GetID(), "abandoned"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostAutosaveDiscard(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if autosave, _ := store.PostAutosaveFind(ctx, post.GetID()); autosave != nil {
+		t.Fatal("expected the autosave to be discarded")
+	}
+
+	stored, _ := store.PostFindByID(ctx, post.GetID())
+	if stored.GetContent() != "published text" {
+		t.Fatalf("content = %q, want the post unchanged", stored.GetContent())
+	}
+}
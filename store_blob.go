@@ -0,0 +1,50 @@
+package blogstore
+
+import (
+	"context"
+	"strings"
+)
+
+// blobContentReferencePrefix marks a content column value as a reference to a
+// blob, followed by the blob key.
+const blobContentReferencePrefix = "blobstore:"
+
+// blobContentOffload stores the content in the blob store when it exceeds the
+// configured threshold, and returns the value to write to the content column:
+// either the content itself or a reference to the blob.
+func (store *storeImplementation) blobContentOffload(ctx context.Context, postID string, content string) (string, error) {
+	if store.blobStore == nil || len(content) <= store.blobThreshold {
+		return content, nil
+	}
+
+	if err := store.blobStore.Put(ctx, postID, []byte(content)); err != nil {
+		return "", err
+	}
+
+	return blobContentReferencePrefix + postID, nil
+}
+
+// blobContentResolve returns the content of a content column value, loading
+// it from the blob store if the value is a blob reference.
+func (store *storeImplementation) blobContentResolve(ctx context.Context, value string) (string, error) {
+	key, ok := strings.CutPrefix(value, blobContentReferencePrefix)
+	if !ok || store.blobStore == nil {
+		return value, nil
+	}
+
+	data, err := store.blobStore.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// blobContentDelete removes the blob of a post, if blob storage is configured.
+func (store *storeImplementation) blobContentDelete(ctx context.Context, postID string) error {
+	if store.blobStore == nil {
+		return nil
+	}
+
+	return store.blobStore.Delete(ctx, postID)
+}
@@ -0,0 +1,83 @@
+package blogstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStoreBlobContentOffload(t *testing.T) {
+	blobs := NewFilesystemBlobStore(t.TempDir())
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		MaxOpenConns:       1,
+		BlobStore:          blobs,
+		BlobThreshold:      100,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	large := strings.Repeat("large content ", 20)
+
+	post := NewPost().SetTitle("Large").SetContent(large)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the row only holds a reference
+	impl := store.(*storeImplementation)
+	db, _ := impl.db.DB()
+	var stored string
+	if err := db.QueryRow("SELECT content FROM blog_posts WHERE id = ?", post.GetID()).Scan(&stored); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if stored != blobContentReferencePrefix+post.GetID() {
+		t.Fatal("expected blob reference in row, found:", stored)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetContent() != large {
+		t.Fatal("expected content to be reassembled on read")
+	}
+
+	// shrinking the content stores it inline and removes the blob
+	found.SetContent("small")
+	if err := store.PostUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := blobs.Get(ctx, post.GetID()); err == nil {
+		t.Fatal("expected blob to be removed once content is inline")
+	}
+
+	found, err = store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetContent() != "small" {
+		t.Fatal("expected inline content, found:", found.GetContent())
+	}
+
+	// growing it again offloads it, and deleting the post deletes the blob
+	found.SetContent(large)
+	if err := store.PostUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := blobs.Get(ctx, post.GetID()); err != nil {
+		t.Fatal("expected blob after update, got:", err)
+	}
+
+	if err := store.PostDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := blobs.Get(ctx, post.GetID()); err == nil {
+		t.Fatal("expected blob to be deleted with the post")
+	}
+}
@@ -0,0 +1,12 @@
+package blogstore
+
+import "github.com/dromara/carbon/v2"
+
+// now returns the current time in UTC, read from the clock set with
+// NewStoreOptions.Now, if any.
+func (store *storeImplementation) now() *carbon.Carbon {
+	if store.nowFunc == nil {
+		return carbon.Now(carbon.UTC)
+	}
+	return carbon.CreateFromStdTime(store.nowFunc(), carbon.UTC)
+}
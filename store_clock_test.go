@@ -0,0 +1,65 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStoreNowOption(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Clocked")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if post.GetCreatedAt() != "2024-05-01 10:00:00" {
+		t.Fatalf("CreatedAt = %q, want the injected time", post.GetCreatedAt())
+	}
+
+	now = now.Add(time.Hour)
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if post.GetUpdatedAt() != "2024-05-01 11:00:00" {
+		t.Fatalf("UpdatedAt = %q, want the injected time", post.GetUpdatedAt())
+	}
+
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if post.GetSoftDeletedAt() != "2024-05-01 11:00:00" {
+		t.Fatalf("SoftDeletedAt = %q, want the injected time", post.GetSoftDeletedAt())
+	}
+
+	// soft-deleted posts are told apart with the same clock
+	now = now.Add(-2 * time.Hour)
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil {
+		t.Fatal("expected the post to be active before its deletion time")
+	}
+
+	now = now.Add(4 * time.Hour)
+	found, err = store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found != nil {
+		t.Fatal("expected the post to be soft deleted after its deletion time")
+	}
+}
@@ -0,0 +1,90 @@
+package blogstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestStoreConcurrentUse hammers a shared store from many goroutines.
+// Run with -race to detect unsynchronized access to store state.
+func TestStoreConcurrentUse(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_posts_version",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+		// a single connection keeps the in-memory database shared between goroutines
+		MaxOpenConns: 1,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	const workers = 8
+	const iterations = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*iterations)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < iterations; i++ {
+				post := NewPost().
+					SetID(fmt.Sprintf("w%di%d", w, i)).
+					SetTitle(fmt.Sprintf("Worker %d post %d", w, i))
+
+				if err := store.PostCreate(ctx, post); err != nil {
+					errs <- err
+					return
+				}
+
+				post.SetStatus(POST_STATUS_PUBLISHED)
+				if err := store.PostUpdate(ctx, post); err != nil {
+					errs <- err
+					return
+				}
+
+				if _, err := store.PostList(ctx, PostQueryOptions{Limit: 5}); err != nil {
+					errs <- err
+					return
+				}
+
+				if _, err := store.PostCount(ctx, PostQueryOptions{}); err != nil {
+					errs <- err
+					return
+				}
+
+				if _, err := store.Stats(ctx); err != nil {
+					errs <- err
+					return
+				}
+
+				store.EnableDebug(i%2 == 0)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{Status: POST_STATUS_PUBLISHED})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if count != workers*iterations {
+		t.Fatal("expected", workers*iterations, "published posts, found:", count)
+	}
+}
@@ -0,0 +1,38 @@
+package blogstore
+
+import (
+	"context"
+
+	"github.com/dracory/neat"
+	contractsorm "github.com/dracory/neat/contracts/database/orm"
+)
+
+// contextWithTimeout applies NewStoreOptions.DefaultTimeout to a context
+// without a deadline of its own, so a caller passing context.Background()
+// cannot block on the database forever. The cancel func must always be called.
+func (store *storeImplementation) contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil || store.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, store.defaultTimeout)
+}
+
+// query returns a new neat query bound to the context, so cancelling the
+// context aborts the query.
+func (store *storeImplementation) query(ctx context.Context) contractsorm.Query {
+	return store.queryOn(ctx, store.db)
+}
+
+// queryOn returns a new neat query on the database bound to the context.
+func (store *storeImplementation) queryOn(ctx context.Context, db *neat.Database) contractsorm.Query {
+	q := db.Query()
+	if withContext, ok := q.(contractsorm.QueryWithContext); ok && ctx != nil {
+		return withContext.WithContext(ctx)
+	}
+	return q
+}
@@ -0,0 +1,116 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextWithTimeout(t *testing.T) {
+	store := &storeImplementation{defaultTimeout: time.Minute}
+
+	ctx, cancel := store.contextWithTimeout(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("expected default timeout to set a deadline")
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	parent, parentCancel := context.WithDeadline(context.Background(), deadline)
+	defer parentCancel()
+
+	ctx, cancel = store.contextWithTimeout(parent)
+	defer cancel()
+	if d, _ := ctx.Deadline(); !d.Equal(deadline) {
+		t.Fatal("expected caller deadline to be kept, found:", d)
+	}
+
+	store.defaultTimeout = 0
+	ctx, cancel = store.contextWithTimeout(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected no deadline without a default timeout")
+	}
+}
+
+func TestStoreCancelledContext(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post := NewPost().SetTitle("Cancelled")
+	if err := store.PostCreate(context.Background(), post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := store.PostCreate(cancelled, NewPost().SetTitle("Never")); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected PostCreate to fail with context.Canceled, found:", err)
+	}
+
+	if _, err := store.PostList(cancelled, PostQueryOptions{}); err == nil {
+		t.Fatal("expected PostList to fail with a cancelled context")
+	}
+
+	post.SetTitle("Changed")
+	if err := store.PostUpdate(cancelled, post); err == nil {
+		t.Fatal("expected PostUpdate to fail with a cancelled context")
+	}
+
+	version := NewVersioning().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()).
+		SetContent("{}")
+	if err := store.VersioningCreate(cancelled, version); err == nil {
+		t.Fatal("expected VersioningCreate to fail with a cancelled context")
+	}
+
+	versions, err := store.VersioningList(context.Background(), NewVersioningQuery().SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 1 {
+		t.Fatal("expected only the version of the original create, found:", len(versions))
+	}
+
+	count, err := store.PostCount(context.Background(), PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatal("expected 1 post, found:", count)
+	}
+}
+
+func TestStoreDefaultTimeout(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		DefaultTimeout:     time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(context.Background(), PostQueryOptions{}); err == nil {
+		t.Fatal("expected PostList to exceed the default timeout")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, err := store.PostList(ctx, PostQueryOptions{}); err != nil {
+		t.Fatal("expected the caller deadline to override the default timeout, found:", err)
+	}
+}
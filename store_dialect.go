@@ -0,0 +1,156 @@
+package blogstore
+
+import (
+	"strconv"
+	"strings"
+
+	contractsdatabase "github.com/dracory/neat/contracts/database"
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// driver returns the database driver the store is connected to.
+func (st *storeImplementation) driver() contractsdatabase.Driver {
+	return st.db.Query().Driver()
+}
+
+// rebind converts the "?" placeholders used by the raw SQL statements of the
+// store into the positional "$n" placeholders required by PostgreSQL.
+// Queries for other drivers are returned unchanged.
+func (st *storeImplementation) rebind(query string) string {
+	return rebindForDriver(st.driver(), query)
+}
+
+// rebindForDriver converts "?" placeholders into the placeholder style of the driver.
+func rebindForDriver(driver contractsdatabase.Driver, query string) string {
+	if driver != contractsdatabase.DriverPostgres {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + 10)
+
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// maxInsertParams returns how many bound parameters a single statement may
+// carry on the driver, which limits the rows of a multi-row insert.
+func (st *storeImplementation) maxInsertParams() int {
+	switch st.driver() {
+	case contractsdatabase.DriverPostgres, contractsdatabase.DriverMysql:
+		return 65535
+	case contractsdatabase.DriverSqlserver:
+		return 2100
+	default:
+		// the lowest limit of the SQLite versions in use
+		return 999
+	}
+}
+
+// insertPlaceholders returns the VALUES placeholders of a multi-row insert,
+// e.g. "(?, ?), (?, ?)" for 2 rows of 2 columns.
+func insertPlaceholders(rows int, columns int) string {
+	row := "(" + strings.Repeat("?, ", columns-1) + "?)"
+	return strings.Repeat(row+", ", rows-1) + row
+}
+
+// monthExpression returns a SQL expression formatting a datetime column as "YYYY-MM".
+func (st *storeImplementation) monthExpression(column string) string {
+	switch st.driver() {
+	case contractsdatabase.DriverPostgres:
+		return "to_char(" + column + ", 'YYYY-MM')"
+	case contractsdatabase.DriverMysql:
+		return "DATE_FORMAT(" + column + ", '%Y-%m')"
+	case contractsdatabase.DriverSqlserver:
+		return "FORMAT(" + column + ", 'yyyy-MM')"
+	default:
+		// SQLite stores datetimes as text starting with "YYYY-MM"
+		return "substr(" + column + ", 1, 7)"
+	}
+}
+
+// explainPrefix returns the statement prefix that makes the database describe
+// a query plan, or an empty string when the driver has no such statement.
+func (st *storeImplementation) explainPrefix() string {
+	switch st.driver() {
+	case contractsdatabase.DriverSqlite:
+		return "EXPLAIN QUERY PLAN "
+	case contractsdatabase.DriverPostgres, contractsdatabase.DriverMysql:
+		return "EXPLAIN "
+	default:
+		return ""
+	}
+}
+
+// postMetasColumn defines the metas column of the post tables. PostgreSQL and
+// MySQL get a native JSON type, so the database validates the metas and meta
+// queries use its JSON operators; other drivers store the metas as text.
+func postMetasColumn(driver contractsdatabase.Driver, table contractsschema.Blueprint) {
+	switch driver {
+	case contractsdatabase.DriverPostgres:
+		table.Jsonb(COLUMN_METAS).Default("{}")
+	case contractsdatabase.DriverMysql:
+		// MySQL does not accept a literal default on JSON columns, inserts
+		// always set the metas
+		table.Json(COLUMN_METAS)
+	default:
+		table.Text(COLUMN_METAS).Default("{}")
+	}
+}
+
+// metaValueExpression returns a SQL expression extracting the value of a meta
+// key from a native JSON metas column, with the argument it binds, or an
+// empty expression for drivers storing the metas as text.
+func metaValueExpression(driver contractsdatabase.Driver, key string) (string, any) {
+	switch driver {
+	case contractsdatabase.DriverPostgres:
+		return COLUMN_METAS + " ->> CAST(? AS TEXT)", key
+	case contractsdatabase.DriverMysql:
+		return "JSON_UNQUOTE(JSON_EXTRACT(" + COLUMN_METAS + ", ?))", `$."` + strings.ReplaceAll(key, `"`, `\"`) + `"`
+	default:
+		return "", nil
+	}
+}
+
+// uniqueNonEmptyIndexStatement returns the statement creating a unique index
+// on the column that ignores empty values. MySQL has no partial indexes, so
+// it indexes the column with empty values turned into NULLs, as a unique
+// index allows many NULLs.
+func uniqueNonEmptyIndexStatement(driver contractsdatabase.Driver, index string, tableName string, column string) string {
+	switch driver {
+	case contractsdatabase.DriverMysql:
+		return "CREATE UNIQUE INDEX " + index + " ON " + tableName + " ((NULLIF(" + column + ", '')))"
+	default:
+		return "CREATE UNIQUE INDEX " + index + " ON " + tableName + " (" + column + ") WHERE " + column + " <> ''"
+	}
+}
+
+// containsCondition returns a condition matching rows where any of the
+// columns contains the text, ignoring case on every driver. PostgreSQL
+// matches with ILIKE. The other drivers compare lowercased values, as LIKE
+// only ignores the case of ASCII letters on SQLite and follows the column
+// collation on MySQL and SQL Server.
+func containsCondition(driver contractsdatabase.Driver, text string, columns ...string) (string, []any) {
+	parts := make([]string, 0, len(columns))
+	args := make([]any, 0, len(columns))
+	for _, column := range columns {
+		switch driver {
+		case contractsdatabase.DriverPostgres:
+			parts = append(parts, column+" ILIKE ?")
+		default:
+			parts = append(parts, "LOWER("+column+") LIKE LOWER(?)")
+		}
+		args = append(args, "%"+text+"%")
+	}
+
+	return "(" + strings.Join(parts, " OR ") + ")", args
+}
@@ -0,0 +1,86 @@
+package blogstore
+
+import (
+	"testing"
+
+	contractsdatabase "github.com/dracory/neat/contracts/database"
+)
+
+func TestRebindForDriver(t *testing.T) {
+	query := "SELECT COUNT(*) FROM blog_posts WHERE (status = ?) AND (id IN (?, ?))"
+
+	tests := []struct {
+		driver contractsdatabase.Driver
+		want   string
+	}{
+		{contractsdatabase.DriverSqlite, query},
+		{contractsdatabase.DriverMysql, query},
+		{contractsdatabase.DriverPostgres, "SELECT COUNT(*) FROM blog_posts WHERE (status = $1) AND (id IN ($2, $3))"},
+	}
+
+	for _, tt := range tests {
+		if got := rebindForDriver(tt.driver, query); got != tt.want {
+			t.Errorf("rebindForDriver(%s) = %q, want %q", tt.driver, got, tt.want)
+		}
+	}
+}
+
+func TestJoinQueryConditions(t *testing.T) {
+	where, args := joinQueryConditions(nil)
+	if where != "1=1" || len(args) != 0 {
+		t.Fatalf("joinQueryConditions(nil) = %q, %v", where, args)
+	}
+
+	where, args = joinQueryConditions([]queryCondition{
+		{sql: "status = ?", args: []any{"published"}},
+		{sql: "title LIKE ? OR content LIKE ?", args: []any{"%a%", "%a%"}},
+	})
+
+	if where != "(status = ?) AND (title LIKE ? OR content LIKE ?)" {
+		t.Fatalf("unexpected where clause: %q", where)
+	}
+	if len(args) != 3 {
+		t.Fatalf("expected 3 args, got %d", len(args))
+	}
+}
+
+func TestMetaValueExpression(t *testing.T) {
+	tests := []struct {
+		driver     contractsdatabase.Driver
+		expression string
+		arg        any
+	}{
+		{contractsdatabase.DriverSqlite, "", nil},
+		{contractsdatabase.DriverPostgres, "metas ->> CAST(? AS TEXT)", "layout"},
+		{contractsdatabase.DriverMysql, "JSON_UNQUOTE(JSON_EXTRACT(metas, ?))", `$."layout"`},
+	}
+
+	for _, tt := range tests {
+		expression, arg := metaValueExpression(tt.driver, "layout")
+		if expression != tt.expression || arg != tt.arg {
+			t.Errorf("metaValueExpression(%s) = %q, %v, want %q, %v", tt.driver, expression, arg, tt.expression, tt.arg)
+		}
+	}
+}
+
+func TestContainsCondition(t *testing.T) {
+	tests := []struct {
+		driver contractsdatabase.Driver
+		want   string
+	}{
+		{contractsdatabase.DriverSqlite, "(LOWER(title) LIKE LOWER(?) OR LOWER(content) LIKE LOWER(?))"},
+		{contractsdatabase.DriverMysql, "(LOWER(title) LIKE LOWER(?) OR LOWER(content) LIKE LOWER(?))"},
+		{contractsdatabase.DriverSqlserver, "(LOWER(title) LIKE LOWER(?) OR LOWER(content) LIKE LOWER(?))"},
+		{contractsdatabase.DriverPostgres, "(title ILIKE ? OR content ILIKE ?)"},
+	}
+
+	for _, tt := range tests {
+		condition, args := containsCondition(tt.driver, "Go", "title", "content")
+		if condition != tt.want {
+			t.Errorf("containsCondition(%s) = %q, want %q", tt.driver, condition, tt.want)
+		}
+		if len(args) != 2 || args[0] != "%Go%" || args[1] != "%Go%" {
+			t.Errorf("containsCondition(%s) args = %v", tt.driver, args)
+		}
+	}
+}
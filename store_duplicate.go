@@ -0,0 +1,73 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// postDuplicateSkippedMetas lists the metas describing the history of a post,
+// which are not copied by PostDuplicate.
+var postDuplicateSkippedMetas = []string{
+	META_KEY_OLD_SLUGS,
+	META_KEY_TRASHED_FROM_STATUS,
+	META_KEY_REVIEW_STATUS,
+	META_KEY_REVIEW_SUBMITTED_BY,
+	META_KEY_REVIEW_SUBMITTED_AT,
+	META_KEY_REVIEWED_BY,
+	META_KEY_REVIEWED_AT,
+	META_KEY_REVIEW_REASON,
+}
+
+// PostDuplicate creates a draft copy of the post with the ID and returns it.
+// The copy gets a new ID, a "Copy of" title with a slug derived from it, and
+// the content, SEO fields and metas of the original, except the metas
+// recording the history of the original such as its old slugs and review
+// state. The expiry and idempotency key are not copied, and the terms of the
+// original are not assigned to the copy.
+func (store *storeImplementation) PostDuplicate(ctx context.Context, id string) (PostInterface, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	original, err := store.PostFindByID(ContextWithForcePrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, errors.New("post not found")
+	}
+
+	metas, err := original.GetMetas()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range postDuplicateSkippedMetas {
+		delete(metas, key)
+	}
+
+	now := store.now().ToDateTimeString(carbon.UTC)
+
+	duplicate := NewPostFromExistingData(original.GetData())
+	duplicate.SetID(GenerateShortID()).
+		SetTitle("Copy of " + original.GetTitle()).
+		SetSlug("").
+		SetStatus(POST_STATUS_DRAFT).
+		SetIdempotencyKey("").
+		SetExpiresAt("").
+		SetPublishedAt(now).
+		SetSoftDeletedAt(MAX_DATETIME)
+	if err := duplicate.SetMetas(metas); err != nil {
+		return nil, err
+	}
+
+	if err := store.PostCreate(ctx, duplicate); err != nil {
+		return nil, err
+	}
+
+	return duplicate, nil
+}
@@ -0,0 +1,72 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostDuplicate(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	original := NewPost().
+		SetTitle("Launch notes").
+		SetContent("Everything we shipped").
+		SetSlug("launch-notes").
+		SetStatus(POST_STATUS_PUBLISHED).
+		SetMetaDescription("What shipped")
+	if err := original.SetMeta("color", "blue"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := original.SetMeta(META_KEY_REVIEWED_BY, "editor-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostCreate(ctx, original); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	duplicate, err := store.PostDuplicate(ctx, original.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, duplicate.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil {
+		t.Fatal("expected the duplicate to be saved")
+	}
+	if found.GetID() == original.GetID() {
+		t.Fatal("expected the duplicate to get a new ID")
+	}
+	if found.GetTitle() != "Copy of Launch notes" {
+		t.Fatalf("title = %q, want %q", found.GetTitle(), "Copy of Launch notes")
+	}
+	if found.GetSlug() == original.GetSlug() {
+		t.Fatalf("slug = %q, want a slug of its own", found.GetSlug())
+	}
+	if found.GetStatus() != POST_STATUS_DRAFT {
+		t.Fatalf("status = %q, want draft", found.GetStatus())
+	}
+	if found.GetContent() != "Everything we shipped" || found.GetMetaDescription() != "What shipped" {
+		t.Fatal("expected the content and SEO fields to be copied")
+	}
+	if found.GetMeta("color") != "blue" {
+		t.Fatalf("meta color = %q, want the copied meta", found.GetMeta("color"))
+	}
+	if found.GetMeta(META_KEY_REVIEWED_BY) != "" {
+		t.Fatal("expected the review history not to be copied")
+	}
+
+	if _, err := store.PostDuplicate(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown post")
+	}
+}
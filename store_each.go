@@ -0,0 +1,63 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// postEachBatchSize is the number of posts PostEach loads per query.
+const postEachBatchSize = 500
+
+// PostEach calls fn for each post matching the options, loading the posts in
+// batches of postEachBatchSize, so exports of many posts do not hold them all
+// in memory. The Limit and Offset of the options bound the whole iteration.
+// The posts are ordered as set by the options, then by ID, so the batches do
+// not overlap; posts created or deleted during the iteration may still be
+// skipped or seen twice. Each batch is bounded by DefaultTimeout on its own.
+// The iteration stops at the first error returned by fn, which PostEach
+// returns. Random order is not supported, as the batches would overlap.
+func (store *storeImplementation) PostEach(ctx context.Context, options PostQueryOptions, fn func(post PostInterface) error) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	if fn == nil {
+		return errors.New("fn is nil")
+	}
+
+	if options.Random {
+		return errors.New("PostEach does not support random order")
+	}
+
+	batch := options
+	batch.CountOnly = false
+	batch.OrderByMulti = append(slices.Clone(options.OrderByMulti), OrderSpec{Column: COLUMN_ID, Direction: SORT_ORDER_ASC})
+
+	for seen := 0; ; {
+		batch.Offset = options.Offset + seen
+		batch.Limit = postEachBatchSize
+		if options.Limit > 0 {
+			if seen >= options.Limit {
+				return nil
+			}
+			batch.Limit = min(batch.Limit, options.Limit-seen)
+		}
+
+		posts, err := store.PostList(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			if err := fn(post); err != nil {
+				return err
+			}
+		}
+
+		seen += len(posts)
+		if len(posts) < batch.Limit {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestStorePostEach(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	total := postEachBatchSize*2 + 10
+	posts := make([]PostInterface, 0, total)
+	for i := range total {
+		posts = append(posts, NewPost().SetTitle("Post "+strconv.Itoa(i)).SetStatus(POST_STATUS_PUBLISHED))
+	}
+	if err := store.PostCreateMany(ctx, posts); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	seen := map[string]bool{}
+	err = store.PostEach(ctx, PostQueryOptions{Status: POST_STATUS_PUBLISHED}, func(post PostInterface) error {
+		if seen[post.GetID()] {
+			t.Fatal("post visited twice:", post.GetID())
+		}
+		seen[post.GetID()] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(seen) != total {
+		t.Fatalf("Expected %d posts, got %d", total, len(seen))
+	}
+
+	count := 0
+	err = store.PostEach(ctx, PostQueryOptions{Offset: 5, Limit: postEachBatchSize + 1}, func(post PostInterface) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != postEachBatchSize+1 {
+		t.Fatalf("Expected the limit to bound the iteration, got %d posts", count)
+	}
+
+	errStop := errors.New("stop")
+	count = 0
+	err = store.PostEach(ctx, PostQueryOptions{}, func(post PostInterface) error {
+		count++
+		if count == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || count != 3 {
+		t.Fatal("Expected the iteration to stop at the error, got:", err, count)
+	}
+}
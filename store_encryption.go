@@ -0,0 +1,151 @@
+package blogstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// encryptedValuePrefix marks a column value as encrypted. It is followed by
+// the key ID, a colon and the base64 encoded nonce and ciphertext.
+const encryptedValuePrefix = "blogstore:aesgcm:"
+
+// encryptableColumns lists the post columns that may be encrypted. Other
+// columns are used for filtering and ordering, which encryption would break.
+var encryptableColumns = []string{COLUMN_MEMO, COLUMN_CONTENT}
+
+// postMemoMaxLength is the longest memo the post tables hold. An encrypted
+// memo is about twice as long as its plaintext, so it is checked against it.
+const postMemoMaxLength = 255
+
+// encryptedVersioningContent and encryptedAutosaveContent name the version
+// snapshots and the autosaved content when they are encrypted, so a value
+// cannot be moved between them and the post columns.
+const encryptedVersioningContent = "versioning_content"
+const encryptedAutosaveContent = "autosave_content"
+
+// encryptField encrypts the value with AES-GCM if the column is configured
+// for encryption. Empty values are stored as is.
+func (store *storeImplementation) encryptField(ctx context.Context, column string, value string) (string, error) {
+	if value == "" || !slices.Contains(store.encryptedColumns, column) {
+		return value, nil
+	}
+
+	encrypted, err := store.encryptValue(ctx, column, value)
+	if err != nil {
+		return "", err
+	}
+
+	if column == COLUMN_MEMO && len(encrypted) > postMemoMaxLength {
+		return "", errors.New("blogstore: encrypted memo is " + strconv.Itoa(len(encrypted)) + " characters, over the " + strconv.Itoa(postMemoMaxLength) + " of the memo column; shorten the memo")
+	}
+
+	return encrypted, nil
+}
+
+// encryptVersioningContent encrypts a version snapshot if any post column is
+// encrypted, as the snapshot holds the values of all the columns.
+func (store *storeImplementation) encryptVersioningContent(ctx context.Context, content string) (string, error) {
+	if content == "" || len(store.encryptedColumns) == 0 {
+		return content, nil
+	}
+
+	return store.encryptValue(ctx, encryptedVersioningContent, content)
+}
+
+// encryptAutosaveContent encrypts autosaved content if the post content is
+// encrypted.
+func (store *storeImplementation) encryptAutosaveContent(ctx context.Context, content string) (string, error) {
+	if content == "" || !slices.Contains(store.encryptedColumns, COLUMN_CONTENT) {
+		return content, nil
+	}
+
+	return store.encryptValue(ctx, encryptedAutosaveContent, content)
+}
+
+// encryptValue encrypts the value with AES-GCM using the current key,
+// authenticating the column it is stored in.
+func (store *storeImplementation) encryptValue(ctx context.Context, column string, value string) (string, error) {
+	keyID, key, err := store.encryptionKeyProvider.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(keyID, ":") {
+		return "", errors.New("blogstore: encryption key id must not contain a colon")
+	}
+
+	gcm, err := encryptionCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	// the column name is authenticated, so a value cannot be moved to another column
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(column))
+
+	return encryptedValuePrefix + keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptField returns the plaintext of an encrypted column value. Values
+// without the encryption prefix, e.g. written before encryption was enabled,
+// are returned as is.
+func (store *storeImplementation) decryptField(ctx context.Context, column string, value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, encryptedValuePrefix)
+	if !ok {
+		return value, nil
+	}
+
+	if store.encryptionKeyProvider == nil {
+		return "", errors.New("blogstore: encrypted value found but no encryption key provider is set")
+	}
+
+	keyID, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("blogstore: malformed encrypted value")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := store.encryptionKeyProvider.Key(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := encryptionCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("blogstore: malformed encrypted value")
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(column))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// encryptionCipher returns an AES-GCM cipher for the key.
+func encryptionCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
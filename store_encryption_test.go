@@ -0,0 +1,286 @@
+package blogstore
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestStoreEncryptedColumns(t *testing.T) {
+	db := initDB()
+	key := bytes.Repeat([]byte("k"), 32)
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		DB:                    db,
+		AutomigrateEnabled:    true,
+		EncryptedColumns:      []string{COLUMN_MEMO, COLUMN_CONTENT},
+		EncryptionKeyProvider: NewStaticKeyProvider("2026-01", key),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Secret").SetMemo("internal note").SetContent("draft body")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	assertStoredEncrypted(t, db, post.GetID(), "internal note", "draft body")
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetMemo() != "internal note" || found.GetContent() != "draft body" {
+		t.Fatalf("expected decrypted values, found memo %q content %q", found.GetMemo(), found.GetContent())
+	}
+
+	found.SetContent("revised body")
+	if err := store.PostUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	assertStoredEncrypted(t, db, post.GetID(), "internal note", "revised body")
+
+	list, err := store.PostList(ctx, PostQueryOptions{WithoutContent: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostLoadContent(ctx, list[0]); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if list[0].GetContent() != "revised body" {
+		t.Fatal("expected PostLoadContent to decrypt, found:", list[0].GetContent())
+	}
+}
+
+func assertStoredEncrypted(t *testing.T, db *sql.DB, id string, memo string, content string) {
+	t.Helper()
+
+	var storedMemo, storedContent string
+	if err := db.QueryRow("SELECT memo, content FROM blog_posts WHERE id = ?", id).Scan(&storedMemo, &storedContent); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !strings.HasPrefix(storedMemo, encryptedValuePrefix+"2026-01:") || strings.Contains(storedMemo, memo) {
+		t.Fatal("expected memo to be stored encrypted, found:", storedMemo)
+	}
+	if !strings.HasPrefix(storedContent, encryptedValuePrefix+"2026-01:") || strings.Contains(storedContent, content) {
+		t.Fatal("expected content to be stored encrypted, found:", storedContent)
+	}
+}
+
+func TestStoreEncryptedVersionsAndAutosaves(t *testing.T) {
+	db := initDB()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		VersioningEnabled:     true,
+		VersioningTableName:   "blog_versioning",
+		AutosaveEnabled:       true,
+		AutosaveTableName:     "blog_autosaves",
+		DB:                    db,
+		AutomigrateEnabled:    true,
+		EncryptedColumns:      []string{COLUMN_CONTENT},
+		EncryptionKeyProvider: NewStaticKeyProvider("2026-01", bytes.Repeat([]byte("k"), 32)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Secret").SetContent("draft body").SetAuthorID("author-1")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	post.SetContent("revised body")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	rows, err := db.Query("SELECT content FROM blog_versioning")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	stored := []string{}
+	for rows.Next() {
+		var content string
+		if err := rows.Scan(&content); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		stored = append(stored, content)
+	}
+	rows.Close()
+	if len(stored) != 2 {
+		t.Fatal("expected 2 versions, found:", len(stored))
+	}
+	for _, content := range stored {
+		if !strings.HasPrefix(content, encryptedValuePrefix+"2026-01:") || strings.Contains(content, "body") {
+			t.Fatal("expected the version to be stored encrypted, found:", content)
+		}
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	var original VersioningInterface
+	for _, version := range versions {
+		if strings.Contains(version.Content(), "draft body") {
+			original = version
+		}
+	}
+	if original == nil {
+		t.Fatal("expected the versions to be decrypted when listed")
+	}
+
+	if err := store.PostRevertToVersion(ctx, post.GetID(), original.ID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetContent() != "draft body" {
+		t.Fatal("expected the revert to restore the decrypted content, found:", found.GetContent())
+	}
+
+	if err := store.PostAutosave(ctx, post.GetID(), "autosaved body"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	var autosaved string
+	if err := db.QueryRow("SELECT content FROM blog_autosaves WHERE post_id = ?", post.GetID()).Scan(&autosaved); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !strings.HasPrefix(autosaved, encryptedValuePrefix+"2026-01:") || strings.Contains(autosaved, "autosaved body") {
+		t.Fatal("expected the autosave to be stored encrypted, found:", autosaved)
+	}
+	autosave, err := store.PostAutosaveFind(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if autosave == nil || autosave.Content != "autosaved body" {
+		t.Fatal("expected the autosave to be decrypted")
+	}
+
+	// the author is erased from the encrypted versions too
+	if err := store.EraseAuthorData(ctx, "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	versions, err = store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, version := range versions {
+		if strings.Contains(version.Content(), "author-1") {
+			t.Fatal("expected the author to be erased from version:", version.Content())
+		}
+	}
+}
+
+func TestStoreEncryptionKeyRotation(t *testing.T) {
+	db := initDB()
+	oldKey := bytes.Repeat([]byte("o"), 32)
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		DB:                    db,
+		AutomigrateEnabled:    true,
+		EncryptedColumns:      []string{COLUMN_MEMO},
+		EncryptionKeyProvider: NewStaticKeyProvider("old", oldKey),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Rotated").SetMemo("note")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the old key is no longer available
+	rotated, err := NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		DB:                    db,
+		EncryptedColumns:      []string{COLUMN_MEMO},
+		EncryptionKeyProvider: NewStaticKeyProvider("new", bytes.Repeat([]byte("n"), 32)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := rotated.PostFindByID(ctx, post.GetID()); err == nil || !strings.Contains(err.Error(), "unknown encryption key") {
+		t.Fatal("expected unknown encryption key error, found:", err)
+	}
+}
+
+func TestStoreEncryptionOptionsValidation(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:    "blog_posts",
+		DB:               initDB(),
+		EncryptedColumns: []string{COLUMN_MEMO},
+	})
+	if err == nil {
+		t.Fatal("expected error without a key provider")
+	}
+
+	_, err = NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		DB:                    initDB(),
+		EncryptedColumns:      []string{COLUMN_TITLE},
+		EncryptionKeyProvider: NewStaticKeyProvider("k", bytes.Repeat([]byte("k"), 32)),
+	})
+	if err == nil {
+		t.Fatal("expected error for a column that cannot be encrypted")
+	}
+}
+
+func TestStoreEncryptedMemoLength(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:         "blog_posts",
+		DB:                    initDB(),
+		AutomigrateEnabled:    true,
+		EncryptedColumns:      []string{COLUMN_MEMO},
+		EncryptionKeyProvider: NewStaticKeyProvider("2026-01", bytes.Repeat([]byte("k"), 32)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	long := strings.Repeat("m", 200)
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Long memo").SetMemo(long)); err == nil || !strings.Contains(err.Error(), "memo") {
+		t.Fatal("expected an error for an encrypted memo over the column length, got:", err)
+	}
+
+	post := NewPost().SetTitle("Short memo").SetMemo("internal note")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetMemo(long)
+	if err := store.PostUpdate(ctx, post); err == nil {
+		t.Fatal("expected PostUpdate to reject an encrypted memo over the column length")
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetMemo() != "internal note" {
+		t.Fatalf("memo = %q, want the memo saved before", found.GetMemo())
+	}
+}
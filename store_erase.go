@@ -0,0 +1,287 @@
+package blogstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// EraseAuthorData removes the references to an author, e.g. for a
+// right-to-be-forgotten request, in a single transaction:
+//   - the author ID of the author's posts (active and archived) is cleared
+//   - the author ID inside the post versions is cleared
+//   - the changed by of the versions made by the author is cleared
+//   - the review submitter and reviewer metas naming the author are cleared,
+//     on the posts and inside the post versions
+//   - the actor of the audit entries made by the author is cleared
+//   - the actor of the status changes and autosaves made by the author is
+//     cleared
+//   - the edit locks held by the author are released
+//   - the author profile is deleted, with the versions of the profile
+//
+// The posts themselves are kept. Comments are not managed by this store,
+// and must be erased by the application.
+func (store *storeImplementation) EraseAuthorData(ctx context.Context, authorID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if authorID == "" {
+		return errors.New("blogstore: author id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	postTables := []string{store.postTableName}
+	if store.archiveEnabled {
+		postTables = append(postTables, store.archiveTableName)
+	}
+
+	for _, table := range postTables {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+table+" SET "+COLUMN_AUTHOR_ID+" = ? WHERE "+COLUMN_AUTHOR_ID+" = ?", "", authorID)
+		if err != nil {
+			return err
+		}
+
+		if err := store.eraseAuthorReviewMetas(ctx, tx, table, authorID); err != nil {
+			return err
+		}
+	}
+
+	if store.versioningEnabled {
+		if err := store.eraseAuthorVersions(ctx, tx, authorID); err != nil {
+			return err
+		}
+
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+store.versioningTableName+" SET "+COLUMN_CHANGED_BY+" = ? WHERE "+COLUMN_CHANGED_BY+" = ?", "", authorID)
+		if err != nil {
+			return err
+		}
+
+		_, err = store.execContext(ctx, tx, "EraseAuthorData", "DELETE FROM "+store.versioningTableName+" WHERE "+COLUMN_ENTITY_TYPE+" = ? AND "+COLUMN_ENTITY_ID+" = ?", VERSIONING_TYPE_AUTHOR, authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if store.auditEnabled {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+store.auditTableName+" SET "+COLUMN_ACTOR+" = ? WHERE "+COLUMN_ACTOR+" = ?", "", authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if store.statusHistoryEnabled {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+store.statusHistoryTableName+" SET "+COLUMN_ACTOR+" = ? WHERE "+COLUMN_ACTOR+" = ?", "", authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if store.autosaveEnabled {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+store.autosaveTableName+" SET "+COLUMN_ACTOR+" = ? WHERE "+COLUMN_ACTOR+" = ?", "", authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if store.lockEnabled {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "DELETE FROM "+store.lockTableName+" WHERE "+COLUMN_OWNER+" = ?", authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if store.authorsEnabled {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "DELETE FROM "+store.authorsTableName+" WHERE "+COLUMN_ID+" = ?", authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// eraseAuthorReviewMetas clears the review metas naming the author on the
+// posts of the table.
+func (store *storeImplementation) eraseAuthorReviewMetas(ctx context.Context, tx sqlExecutor, table string, authorID string) error {
+	// narrows down the candidates, the match is confirmed on the decoded metas
+	conditions := []string{}
+	args := []any{}
+	for _, key := range []string{META_KEY_REVIEW_SUBMITTED_BY, META_KEY_REVIEWED_BY} {
+		if expression, arg := metaValueExpression(store.driver(), key); expression != "" {
+			conditions = append(conditions, expression+" = ?")
+			args = append(args, arg, authorID)
+			continue
+		}
+		conditions = append(conditions, COLUMN_METAS+" LIKE ?")
+		args = append(args, "%\""+key+"\":\""+authorID+"\"%")
+	}
+
+	rows, err := store.queryContext(ctx, tx, "EraseAuthorData", "SELECT "+COLUMN_ID+", "+COLUMN_METAS+" FROM "+table+
+		" WHERE "+strings.Join(conditions, " OR "), args...)
+	if err != nil {
+		return err
+	}
+
+	erased := map[string]string{}
+	for rows.Next() {
+		var id string
+		var value []byte
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return err
+		}
+
+		metas := map[string]string{}
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &metas); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+
+		if !eraseAuthorFromReviewMetas(metas, authorID) {
+			continue
+		}
+
+		b, err := json.Marshal(metas)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		erased[id] = string(b)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for id, metas := range erased {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+table+" SET "+COLUMN_METAS+" = ? WHERE "+COLUMN_ID+" = ?", metas, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eraseAuthorFromReviewMetas clears the review submitter and reviewer metas
+// naming the author. Returns true if any was cleared.
+func eraseAuthorFromReviewMetas(metas map[string]string, authorID string) bool {
+	erased := false
+	for _, key := range []string{META_KEY_REVIEW_SUBMITTED_BY, META_KEY_REVIEWED_BY} {
+		if metas[key] == authorID {
+			metas[key] = ""
+			erased = true
+		}
+	}
+	return erased
+}
+
+// eraseAuthorVersions clears the author ID and the review metas naming the
+// author inside the content of the post versions.
+func (store *storeImplementation) eraseAuthorVersions(ctx context.Context, tx sqlExecutor, authorID string) error {
+	// narrows down the candidates, the match is confirmed on the decoded
+	// content; encrypted snapshots can only be matched once decrypted
+	pattern := "%" + authorID + "%"
+	if len(store.encryptedColumns) > 0 {
+		pattern = "%"
+	}
+
+	rows, err := store.queryContext(ctx, tx, "EraseAuthorData", "SELECT "+COLUMN_ID+", "+COLUMN_CONTENT+" FROM "+store.versioningTableName+
+		" WHERE "+COLUMN_ENTITY_TYPE+" = ? AND "+COLUMN_CONTENT+" LIKE ?", VERSIONING_TYPE_POST, pattern)
+	if err != nil {
+		return err
+	}
+
+	erased := map[string]string{}
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return err
+		}
+
+		content, err = store.decryptField(ctx, encryptedVersioningContent, content)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		data := map[string]string{}
+		if err := json.Unmarshal([]byte(content), &data); err != nil {
+			rows.Close()
+			return err
+		}
+
+		changed := false
+		if data[COLUMN_AUTHOR_ID] == authorID {
+			data[COLUMN_AUTHOR_ID] = ""
+			changed = true
+		}
+
+		if data[COLUMN_METAS] != "" {
+			metas := map[string]string{}
+			if err := json.Unmarshal([]byte(data[COLUMN_METAS]), &metas); err == nil && eraseAuthorFromReviewMetas(metas, authorID) {
+				b, err := json.Marshal(metas)
+				if err != nil {
+					rows.Close()
+					return err
+				}
+				data[COLUMN_METAS] = string(b)
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		b, err := json.Marshal(data)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		content, err = store.encryptVersioningContent(ctx, string(b))
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		erased[id] = content
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for id, content := range erased {
+		_, err := store.execContext(ctx, tx, "EraseAuthorData", "UPDATE "+store.versioningTableName+" SET "+COLUMN_CONTENT+" = ? WHERE "+COLUMN_ID+" = ?", content, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package blogstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreEraseAuthorData(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		AuditEnabled:        true,
+		AuditTableName:      "blog_audit",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := ContextWithActor(context.Background(), "author-1")
+
+	erased := NewPost().SetTitle("Erased").SetAuthorID("author-1")
+	if err := store.PostCreate(ctx, erased); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	kept := NewPost().SetTitle("Kept").SetAuthorID("author-2")
+	if err := store.PostCreate(context.Background(), kept); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// author-1 edits a post of another author
+	kept.SetSummary("Edited by author-1")
+	if err := store.PostUpdate(ctx, kept); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.EraseAuthorData(context.Background(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(context.Background(), erased.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetAuthorID() != "" {
+		t.Fatal("expected author id to be erased")
+	}
+	if found.GetTitle() != "Erased" {
+		t.Fatal("expected the post to be kept, got title:", found.GetTitle())
+	}
+
+	found, err = store.PostFindByID(context.Background(), kept.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetAuthorID() != "author-2" {
+		t.Fatal("expected other authors to be kept")
+	}
+
+	versions, err := store.VersioningList(context.Background(), NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(erased.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("expected versions of the erased post")
+	}
+	for _, version := range versions {
+		if strings.Contains(version.Content(), "author-1") {
+			t.Fatal("expected author id to be erased from version:", version.Content())
+		}
+	}
+
+	versions, err = store.VersioningList(context.Background(), NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, version := range versions {
+		if version.ChangedBy() == "author-1" {
+			t.Fatalf("expected the changed by of version %s to be erased", version.ID())
+		}
+	}
+
+	entries, err := store.AuditList(context.Background(), AuditQueryOptions{Actor: "author-1"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(entries) != 0 {
+		t.Fatal("expected audit actor to be erased, got entries:", len(entries))
+	}
+
+	entries, err = store.AuditList(context.Background(), AuditQueryOptions{EntityID: erased.GetID()})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected audit entries to be kept")
+	}
+}
+
+func TestStoreEraseAuthorDataRequiresAuthorID(t *testing.T) {
+	store := initAuditStore(t)
+
+	if err := store.EraseAuthorData(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty author id")
+	}
+}
+
+func TestStoreEraseAuthorDataStatusHistory(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:          "blog_posts",
+		StatusHistoryEnabled:   true,
+		StatusHistoryTableName: "blog_status_history",
+		DB:                     initDB(),
+		AutomigrateEnabled:     true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := ContextWithActor(context.Background(), "author-1")
+
+	post := NewPost().SetTitle("Post").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.EraseAuthorData(context.Background(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	changes, err := store.PostStatusHistory(context.Background(), post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(changes) == 0 {
+		t.Fatal("expected the status changes to be kept")
+	}
+	for _, change := range changes {
+		if change.Actor != "" {
+			t.Fatal("expected the actor of the status change to be erased, got:", change.Actor)
+		}
+	}
+}
+
+func TestStoreEraseAuthorDataAutosave(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		AutosaveEnabled:    true,
+		AutosaveTableName:  "blog_autosaves",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := ContextWithActor(context.Background(), "author-1")
+
+	post := NewPost().SetTitle("Post")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostAutosave(ctx, post.GetID(), "In progress"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.EraseAuthorData(context.Background(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	autosave, err := store.PostAutosaveFind(context.Background(), post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if autosave == nil || autosave.Content != "In progress" {
+		t.Fatal("expected the autosave to be kept")
+	}
+	if autosave.Actor != "" {
+		t.Fatal("expected the actor of the autosave to be erased, got:", autosave.Actor)
+	}
+}
+
+func TestStoreEraseAuthorDataLock(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		LockEnabled:        true,
+		LockTableName:      "blog_locks",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	erased := NewPost().SetTitle("Erased")
+	if err := store.PostCreate(context.Background(), erased); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	kept := NewPost().SetTitle("Kept")
+	if err := store.PostCreate(context.Background(), kept); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostLock(context.Background(), erased.GetID(), "author-1", time.Hour); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostLock(context.Background(), kept.GetID(), "author-2", time.Hour); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.EraseAuthorData(context.Background(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	lock, err := store.PostLockStatus(context.Background(), erased.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if lock != nil {
+		t.Fatal("expected the lock of the author to be released, got owner:", lock.Owner)
+	}
+
+	lock, err = store.PostLockStatus(context.Background(), kept.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if lock == nil || lock.Owner != "author-2" {
+		t.Fatal("expected the locks of other authors to be kept")
+	}
+}
+
+func TestStoreEraseAuthorDataReviewMetas(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post := NewPost().SetTitle("Post").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(context.Background(), post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostSubmitForReview(ContextWithActor(context.Background(), "author-1"), post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostApprove(context.Background(), post.GetID(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.EraseAuthorData(context.Background(), "author-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(context.Background(), post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil {
+		t.Fatal("expected the post to be kept")
+	}
+	if v := found.GetMeta(META_KEY_REVIEW_SUBMITTED_BY); v != "" {
+		t.Fatal("expected the review submitter to be erased, got:", v)
+	}
+	if v := found.GetMeta(META_KEY_REVIEWED_BY); v != "" {
+		t.Fatal("expected the reviewer to be erased, got:", v)
+	}
+	if found.GetMeta(META_KEY_REVIEW_STATUS) != REVIEW_STATUS_APPROVED {
+		t.Fatal("expected the other review metas to be kept")
+	}
+
+	versions, err := store.VersioningList(context.Background(), NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("expected versions of the post")
+	}
+	for _, version := range versions {
+		if strings.Contains(version.Content(), "author-1") {
+			t.Fatal("expected the review metas to be erased from version:", version.Content())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package blogstore
+
+import "context"
+
+// eventPublish publishes the events of a saved change to the post. Before is
+// the stored post ahead of an update, nil for a create, and after is nil for
+// a delete. Nothing is published without a publisher.
+func (store *storeImplementation) eventPublish(ctx context.Context, before PostInterface, after PostInterface) error {
+	if store.eventPublisher == nil {
+		return nil
+	}
+
+	for _, eventType := range eventTypes(before, after) {
+		post := after
+		if post == nil {
+			post = before
+		}
+
+		err := store.eventPublisher.Publish(ctx, Event{
+			Type:       eventType,
+			PostID:     post.GetID(),
+			Post:       post,
+			ActorID:    ActorFromContext(ctx),
+			OccurredAt: store.now().StdTime(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eventTypes returns the types of the events of a change from before to
+// after, see eventPublish.
+func eventTypes(before PostInterface, after PostInterface) []string {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []string{EVENT_POST_CREATED}
+	case after == nil:
+		return []string{EVENT_POST_DELETED}
+	}
+
+	types := []string{EVENT_POST_UPDATED}
+	if after.GetStatus() != before.GetStatus() {
+		switch after.GetStatus() {
+		case POST_STATUS_PUBLISHED:
+			types = append(types, EVENT_POST_PUBLISHED)
+		case POST_STATUS_TRASH:
+			types = append(types, EVENT_POST_TRASHED)
+		}
+	}
+
+	return types
+}
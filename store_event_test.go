@@ -0,0 +1,101 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStoreEventPublisher(t *testing.T) {
+	events := []Event{}
+	publisher := EventPublisherFunc(func(ctx context.Context, event Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		EventPublisher:     publisher,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := ContextWithActor(context.Background(), "editor")
+
+	post := NewPost().SetTitle("Evented").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, post.SetStatus(POST_STATUS_PUBLISHED)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, post.SetTitle("Evented again")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostTrash(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.Type)
+		if event.PostID != post.GetID() || event.Post == nil {
+			t.Fatalf("event %s is for post %q, want %q", event.Type, event.PostID, post.GetID())
+		}
+		if event.ActorID != "editor" {
+			t.Fatalf("event %s actor = %q, want editor", event.Type, event.ActorID)
+		}
+		if event.OccurredAt.IsZero() {
+			t.Fatalf("event %s has no time", event.Type)
+		}
+	}
+
+	want := []string{
+		EVENT_POST_CREATED,
+		EVENT_POST_UPDATED, EVENT_POST_PUBLISHED,
+		EVENT_POST_UPDATED,
+		EVENT_POST_UPDATED, EVENT_POST_TRASHED,
+		EVENT_POST_DELETED,
+	}
+	if !slices.Equal(types, want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+}
+
+func TestStoreEventPublisherError(t *testing.T) {
+	errUnavailable := errors.New("broker unavailable")
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		EventPublisher: EventPublisherFunc(func(ctx context.Context, event Event) error {
+			return errUnavailable
+		}),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Saved anyway")
+	if err := store.PostCreate(ctx, post); !errors.Is(err, errUnavailable) {
+		t.Fatal("expected the publisher error, found:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil {
+		t.Fatal("expected the post to be saved despite the publisher error")
+	}
+}
@@ -0,0 +1,43 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// PostExpireDue unpublishes the published posts whose expiry timestamp has
+// passed, setting their status to POST_STATUS_UNPUBLISHED, and returns how
+// many were unpublished. Run it periodically, e.g. with the expire job of
+// StartWorkers.
+func (store *storeImplementation) PostExpireDue(ctx context.Context) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{
+		Status:           POST_STATUS_PUBLISHED,
+		ExpiredBeforeNow: true,
+		WithoutContent:   true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, post := range posts {
+		if err := store.PostLoadContent(ctx, post); err != nil {
+			return expired, err
+		}
+
+		post.SetStatus(POST_STATUS_UNPUBLISHED)
+		if err := store.PostUpdate(ctx, post); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
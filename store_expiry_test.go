@@ -0,0 +1,91 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStorePostExpireDue(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	expired := NewPost().SetTitle("Expired").SetContent("Spring sale").SetStatus(POST_STATUS_PUBLISHED).SetExpiresAt("2026-05-01 11:00:00")
+	running := NewPost().SetTitle("Running").SetStatus(POST_STATUS_PUBLISHED).SetExpiresAt("2026-05-02 11:00:00")
+	forever := NewPost().SetTitle("Forever").SetStatus(POST_STATUS_PUBLISHED)
+	draft := NewPost().SetTitle("Draft").SetStatus(POST_STATUS_DRAFT).SetExpiresAt("2026-04-01 00:00:00")
+	for _, post := range []PostInterface{expired, running, forever, draft} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	found, err := store.PostFindByID(ctx, running.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetExpiresAt() != "2026-05-02 11:00:00" {
+		t.Fatalf("ExpiresAt = %q, want the stored expiry", found.GetExpiresAt())
+	}
+
+	found, err = store.PostFindByID(ctx, forever.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetExpiresAt() != "" {
+		t.Fatalf("ExpiresAt = %q, want empty for a post without expiry", found.GetExpiresAt())
+	}
+
+	due, err := store.PostCount(ctx, PostQueryOptions{ExpiredBeforeNow: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if due != 2 {
+		t.Fatalf("PostCount(ExpiredBeforeNow) = %d, want the expired and draft posts", due)
+	}
+
+	count, err := store.PostExpireDue(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("PostExpireDue() = %d, want 1", count)
+	}
+
+	found, err = store.PostFindByID(ctx, expired.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetStatus() != POST_STATUS_UNPUBLISHED {
+		t.Fatalf("status = %q, want unpublished", found.GetStatus())
+	}
+	if found.GetContent() != "Spring sale" {
+		t.Fatalf("content = %q, want it kept when expiring", found.GetContent())
+	}
+
+	found, err = store.PostFindByID(ctx, running.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, found.SetExpiresAt("")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	now = now.Add(48 * time.Hour)
+
+	count, err = store.PostExpireDue(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatalf("PostExpireDue() = %d, want 0 after clearing the expiry", count)
+	}
+}
@@ -0,0 +1,55 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// Ping verifies the database connection is alive.
+func (store *storeImplementation) Ping(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
+
+// Healthy runs the health checks of the store: the database is reachable
+// and the post table (and versioning table, if enabled) exists.
+// Suitable for wiring into readiness probes.
+func (store *storeImplementation) Healthy(ctx context.Context) HealthReport {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	report := HealthReport{Errors: []string{}}
+
+	if err := store.Ping(ctx); err != nil {
+		report.Errors = append(report.Errors, "database is not reachable: "+err.Error())
+		return report
+	}
+	report.DatabaseReachable = true
+
+	report.PostTableExists = store.db.Schema().HasTable(store.postTableName)
+	if !report.PostTableExists {
+		report.Errors = append(report.Errors, "post table "+store.postTableName+" does not exist")
+	}
+
+	if store.versioningEnabled {
+		report.VersioningTableExists = store.db.Schema().HasTable(store.versioningTableName)
+		if !report.VersioningTableExists {
+			report.Errors = append(report.Errors, "versioning table "+store.versioningTableName+" does not exist")
+		}
+	}
+
+	report.Healthy = len(report.Errors) == 0
+
+	return report
+}
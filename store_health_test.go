@@ -0,0 +1,81 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePingAndHealthy(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_posts_version",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+		MaxOpenConns:        1,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	report := store.Healthy(ctx)
+	if !report.Healthy || !report.DatabaseReachable || !report.PostTableExists || !report.VersioningTableExists {
+		t.Fatal("expected healthy report, found:", report)
+	}
+}
+
+func TestStoreHealthyMissingTables(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_posts_version",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		MaxOpenConns:        1,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	report := store.Healthy(context.Background())
+	if report.Healthy {
+		t.Fatal("expected unhealthy report when tables are missing")
+	}
+	if !report.DatabaseReachable {
+		t.Fatal("expected database to be reachable")
+	}
+	if report.PostTableExists || report.VersioningTableExists {
+		t.Fatal("expected tables to be reported missing, found:", report)
+	}
+	if len(report.Errors) != 2 {
+		t.Fatal("expected 2 errors, found:", report.Errors)
+	}
+}
+
+func TestStoreHealthyDatabaseClosed(t *testing.T) {
+	db := initDB()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		DB:            db,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_ = db.Close()
+
+	if err := store.Ping(context.Background()); err == nil {
+		t.Fatal("expected ping error for closed database")
+	}
+
+	report := store.Healthy(context.Background())
+	if report.Healthy || report.DatabaseReachable {
+		t.Fatal("expected unreachable database, found:", report)
+	}
+}
@@ -0,0 +1,19 @@
+package blogstore
+
+import "context"
+
+// hookRun calls the functions registered for the event with the post,
+// stopping at the first error. Nothing is called without hooks or a post.
+func (store *storeImplementation) hookRun(ctx context.Context, event string, post PostInterface) error {
+	if store.hooks == nil || post == nil {
+		return nil
+	}
+
+	for _, fn := range store.hooks.funcs[event] {
+		if err := fn(ctx, post); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStoreHooks(t *testing.T) {
+	events := []string{}
+	record := func(event string) PostHookFunc {
+		return func(ctx context.Context, post PostInterface) error {
+			events = append(events, event+":"+post.GetTitle())
+			return nil
+		}
+	}
+
+	errEmptyTitle := errors.New("title is required")
+
+	hooks := NewHooks().
+		OnBeforePostCreate(func(ctx context.Context, post PostInterface) error {
+			if strings.TrimSpace(post.GetTitle()) == "" {
+				return errEmptyTitle
+			}
+			post.SetSummary("Enriched")
+			return nil
+		}).
+		OnBeforePostCreate(record("before_create")).
+		OnAfterPostCreate(record("after_create")).
+		OnBeforePostUpdate(record("before_update")).
+		OnAfterPostUpdate(record("after_update")).
+		OnBeforePostDelete(record("before_delete")).
+		OnAfterPostDelete(record("after_delete"))
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Hooks:              hooks,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost()); !errors.Is(err, errEmptyTitle) {
+		t.Fatal("expected the before hook error, found:", err)
+	}
+	count, err := store.PostCount(ctx, PostQueryOptions{WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatalf("count = %d, want 0 after a rejected create", count)
+	}
+
+	post := NewPost().SetTitle("Hooked")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetSummary() != "Enriched" {
+		t.Fatalf("summary = %q, want the hook enrichment saved", found.GetSummary())
+	}
+
+	if err := store.PostUpdate(ctx, post.SetTitle("Hooked again")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := []string{
+		"before_create:Hooked",
+		"after_create:Hooked",
+		"before_update:Hooked again",
+		"after_update:Hooked again",
+		"before_delete:Hooked again",
+		"after_delete:Hooked again",
+	}
+	if !slices.Equal(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+}
+
+func TestStoreHooksBulk(t *testing.T) {
+	counts := map[string]int{}
+	count := func(event string) PostHookFunc {
+		return func(ctx context.Context, post PostInterface) error {
+			counts[event]++
+			return nil
+		}
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Hooks: NewHooks().
+			OnAfterPostCreate(count("create")).
+			OnAfterPostUpdate(count("update")).
+			OnAfterPostDelete(count("delete")),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	posts := []PostInterface{NewPost().SetTitle("One"), NewPost().SetTitle("Two")}
+	if err := store.PostCreateMany(ctx, posts); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ids := []string{posts[0].GetID(), posts[1].GetID()}
+
+	if _, err := store.PostStatusUpdateByIDs(ctx, ids, POST_STATUS_PUBLISHED); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostDeleteByIDs(ctx, ids); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := map[string]int{"create": 2, "update": 2, "delete": 2}
+	for event, n := range want {
+		if counts[event] != n {
+			t.Fatalf("%s hooks called %d times, want %d", event, counts[event], n)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// ErrPostLocked is returned by PostLock and PostUnlock when another owner
+// holds an unexpired lock on the post.
+var ErrPostLocked = errors.New("blogstore: post is locked by another owner")
+
+// LockEnabled returns true if posts can be locked for editing.
+func (store *storeImplementation) LockEnabled() bool {
+	return store.lockEnabled
+}
+
+// GetLockTableName returns the lock table name
+func (store *storeImplementation) GetLockTableName() string {
+	return store.lockTableName
+}
+
+// PostLock locks a post for editing by the owner (a user, a session or an
+// agent) until the TTL elapses. Locking a post the owner already holds
+// extends the lock. Returns ErrPostLocked if another owner holds it.
+// Locks are advisory: editors check PostLockStatus before saving,
+// PostUpdate does not enforce them.
+func (store *storeImplementation) PostLock(ctx context.Context, postID string, owner string, ttl time.Duration) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.lockEnabled {
+		return errors.New("lock is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	if err := store.authorizePost(ctx, ACTION_LOCK, postID); err != nil {
+		return err
+	}
+	if owner == "" {
+		return errors.New("lock owner is empty")
+	}
+	if ttl <= 0 {
+		return errors.New("lock ttl must be positive")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := store.lockFind(ctx, tx, postID)
+	if err != nil {
+		return err
+	}
+
+	now := store.now().StdTime()
+	if current != nil && current.Owner != owner && current.ExpiresAt.After(now) {
+		return ErrPostLocked
+	}
+
+	if current != nil {
+		_, err = store.execContext(ctx, tx, "PostLock", "UPDATE "+store.lockTableName+" SET "+COLUMN_OWNER+" = ?, "+COLUMN_EXPIRES_AT+" = ?, "+COLUMN_CREATED_AT+" = ? WHERE "+COLUMN_POST_ID+" = ?",
+			owner, now.Add(ttl), now, postID)
+	} else {
+		_, err = store.execContext(ctx, tx, "PostLock", "INSERT INTO "+store.lockTableName+" (post_id, owner, expires_at, created_at) VALUES (?, ?, ?, ?)",
+			postID, owner, now.Add(ttl), now)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PostUnlock releases the owner's lock on a post. Releasing a post that is
+// not locked, or whose lock expired, is not an error. Returns ErrPostLocked
+// if another owner holds the lock.
+func (store *storeImplementation) PostUnlock(ctx context.Context, postID string, owner string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.lockEnabled {
+		return errors.New("lock is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	if err := store.authorizePost(ctx, ACTION_LOCK, postID); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := store.lockFind(ctx, tx, postID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return nil
+	}
+	if current.Owner != owner && current.ExpiresAt.After(store.now().StdTime()) {
+		return ErrPostLocked
+	}
+
+	_, err = store.execContext(ctx, tx, "PostUnlock", "DELETE FROM "+store.lockTableName+" WHERE "+COLUMN_POST_ID+" = ?", postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PostLockStatus returns the lock on a post, or nil if the post is not
+// locked or its lock expired.
+func (store *storeImplementation) PostLockStatus(ctx context.Context, postID string) (*Lock, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.lockEnabled {
+		return nil, errors.New("lock is not enabled")
+	}
+	if postID == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	if err := store.authorizePost(ctx, ACTION_READ, postID); err != nil {
+		return nil, err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := store.lockFind(ctx, db, postID)
+	if err != nil || lock == nil {
+		return nil, err
+	}
+	if !lock.ExpiresAt.After(store.now().StdTime()) {
+		return nil, nil
+	}
+
+	return lock, nil
+}
+
+// lockFind loads the lock row of a post, expired or not.
+// Returns nil if there is none.
+func (store *storeImplementation) lockFind(ctx context.Context, db sqlExecutor, postID string) (*Lock, error) {
+	lock := Lock{}
+	err := store.queryRowContext(ctx, db, "lockFind", "SELECT post_id, owner, expires_at, created_at FROM "+store.lockTableName+" WHERE "+COLUMN_POST_ID+" = ?",
+		[]any{postID}, &lock.PostID, &lock.Owner, &lock.ExpiresAt, &lock.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// lockTableBlueprint defines the lock table, holding at most one lock per post.
+func lockTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_POST_ID, 40)
+	table.Primary(COLUMN_POST_ID)
+	table.String(COLUMN_OWNER, 255)
+	table.DateTime(COLUMN_EXPIRES_AT)
+	table.DateTime(COLUMN_CREATED_AT)
+}
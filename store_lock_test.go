@@ -0,0 +1,98 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func initLockStore(t *testing.T, now *time.Time) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		LockEnabled:        true,
+		LockTableName:      "blog_locks",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Now:                func() time.Time { return *now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreLockRequiresTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		LockEnabled:   true,
+		DB:            initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error when LockTableName is missing")
+	}
+}
+
+func TestStorePostLock(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := initLockStore(t, &now)
+	ctx := context.Background()
+
+	if lock, err := store.PostLockStatus(ctx, "post-1"); err != nil || lock != nil {
+		t.Fatalf("PostLockStatus() = %v, %v, want no lock", lock, err)
+	}
+
+	if err := store.PostLock(ctx, "post-1", "alice", 5*time.Minute); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostLock(ctx, "post-1", "bob", 5*time.Minute); !errors.Is(err, ErrPostLocked) {
+		t.Fatalf("expected ErrPostLocked for a second owner, got %v", err)
+	}
+	if err := store.PostUnlock(ctx, "post-1", "bob"); !errors.Is(err, ErrPostLocked) {
+		t.Fatalf("expected ErrPostLocked when unlocking another owner's lock, got %v", err)
+	}
+
+	// The owner extends the lock
+	now = now.Add(4 * time.Minute)
+	if err := store.PostLock(ctx, "post-1", "alice", 5*time.Minute); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	lock, err := store.PostLockStatus(ctx, "post-1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if lock == nil || lock.Owner != "alice" || !lock.ExpiresAt.Equal(now.Add(5*time.Minute)) {
+		t.Fatalf("PostLockStatus() = %+v, want alice's extended lock", lock)
+	}
+
+	if err := store.PostUnlock(ctx, "post-1", "alice"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostLock(ctx, "post-1", "bob", 5*time.Minute); err != nil {
+		t.Fatal("expected the released post to be lockable, got:", err)
+	}
+}
+
+func TestStorePostLockExpires(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := initLockStore(t, &now)
+	ctx := context.Background()
+
+	if err := store.PostLock(ctx, "post-1", "alice", time.Minute); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if lock, err := store.PostLockStatus(ctx, "post-1"); err != nil || lock != nil {
+		t.Fatalf("PostLockStatus() = %+v, %v, want the lock expired", lock, err)
+	}
+	if err := store.PostLock(ctx, "post-1", "bob", time.Minute); err != nil {
+		t.Fatal("expected an expired lock to be taken over, got:", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"strings"
 	"time"
 
 	contractsorm "github.com/dracory/neat/contracts/database/orm"
@@ -20,6 +19,14 @@ func (store *storeImplementation) MediaCreate(ctx context.Context, media MediaIn
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_MANAGE_MEDIA, nil); err != nil {
+		return err
+	}
+
 	if media == nil {
 		return errors.New("media is nil")
 	}
@@ -30,8 +37,8 @@ func (store *storeImplementation) MediaCreate(ctx context.Context, media MediaIn
 		return errors.New("media entity_id is empty")
 	}
 
-	media.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
-	media.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	media.SetCreatedAt(store.now().ToDateTimeString(carbon.UTC))
+	media.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
 
 	if media.GetSoftDeletedAt() == "" {
 		media.SetSoftDeletedAt(MAX_DATETIME)
@@ -65,7 +72,15 @@ func (store *storeImplementation) MediaCreate(ctx context.Context, media MediaIn
 		COLUMN_SOFT_DELETED_AT: media.GetSoftDeletedAtCarbon().StdTime(),
 	}
 
-	return store.db.Query().Table(store.mediaTableName).Create(row)
+	if err := store.query(ctx).Table(store.mediaTableName).Create(row); err != nil {
+		return err
+	}
+
+	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_MEDIA, media.GetID(), media); err != nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_MEDIA, media.GetID(), ACTION_CREATE, nil, media)
 }
 
 // MediaCount returns the total number of media matching the given query options.
@@ -77,10 +92,21 @@ func (store *storeImplementation) MediaCount(ctx context.Context, options MediaQ
 		return 0, errors.New("ctx is nil")
 	}
 
-	q := store.buildMediaQuery(options)
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	q, err := store.buildMediaQuery(ctx, options)
+	if err != nil {
+		return 0, err
+	}
 
 	var count int64
-	err := q.Table(store.mediaTableName).Count(&count)
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Count() })
+	store.explainQuery(ctx, nil, "MediaCount", sqlFn)
+
+	start := time.Now()
+	err = q.Table(store.mediaTableName).Count(&count)
+	store.logSlowQuery(ctx, "MediaCount", start, sqlFn)
 	return count, err
 }
 
@@ -92,6 +118,10 @@ func (store *storeImplementation) MediaDelete(ctx context.Context, media MediaIn
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if media == nil {
 		return errors.New("media is nil")
 	}
@@ -107,20 +137,39 @@ func (store *storeImplementation) MediaDeleteByID(ctx context.Context, id string
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_MANAGE_MEDIA, nil); err != nil {
+		return err
+	}
+
 	if id == "" {
 		return errors.New("media id is empty")
 	}
 
-	_, err := store.db.Query().
+	before, err := store.auditMediaLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.query(ctx).
 		Table(store.mediaTableName).
 		Where(COLUMN_ID+" = ?", id).
 		Delete()
+	if err != nil || before == nil {
+		return err
+	}
 
-	return err
+	return store.auditRecord(ctx, AUDIT_ENTITY_MEDIA, id, ACTION_DELETE, before, nil)
 }
 
 // MediaFindByID retrieves a media by its ID.
 func (store *storeImplementation) MediaFindByID(ctx context.Context, id string) (MediaInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if store.mediaTableName == "" {
 		return nil, errors.New("blogstore: media table name is empty")
 	}
@@ -152,6 +201,9 @@ func (store *storeImplementation) MediaList(ctx context.Context, options MediaQu
 		return nil, errors.New("ctx is nil")
 	}
 
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	type mediaRow struct {
 		ID            string    `db:"id"`
 		EntityID      string    `db:"entity_id"`
@@ -170,10 +222,19 @@ func (store *storeImplementation) MediaList(ctx context.Context, options MediaQu
 		SoftDeletedAt time.Time `db:"soft_deleted_at"`
 	}
 
-	q := store.buildMediaQuery(options)
+	q, err := store.buildMediaQuery(ctx, options)
+	if err != nil {
+		return []MediaInterface{}, err
+	}
 
 	var rows []mediaRow
-	if err := q.Table(store.mediaTableName).Get(&rows); err != nil {
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Get(&[]mediaRow{}) })
+	store.explainQuery(ctx, nil, "MediaList", sqlFn)
+
+	start := time.Now()
+	err = q.Table(store.mediaTableName).Get(&rows)
+	store.logSlowQuery(ctx, "MediaList", start, sqlFn)
+	if err != nil {
 		return []MediaInterface{}, err
 	}
 
@@ -204,6 +265,9 @@ func (store *storeImplementation) MediaList(ctx context.Context, options MediaQu
 
 // MediaListByEntityID retrieves all media attached to a specific entity.
 func (store *storeImplementation) MediaListByEntityID(ctx context.Context, entityID string) ([]MediaInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if store.mediaTableName == "" {
 		return nil, errors.New("blogstore: media table name is empty")
 	}
@@ -226,13 +290,17 @@ func (store *storeImplementation) MediaSoftDelete(ctx context.Context, media Med
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if media == nil {
 		return errors.New("media is nil")
 	}
 
-	media.SetSoftDeletedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	media.SetSoftDeletedAt(store.now().ToDateTimeString(carbon.UTC))
 
-	return store.MediaUpdate(ctx, media)
+	return store.MediaUpdate(contextWithAction(ctx, ACTION_SOFT_DELETE), media)
 }
 
 // MediaSoftDeleteByID marks a media as deleted by its ID.
@@ -243,6 +311,14 @@ func (store *storeImplementation) MediaSoftDeleteByID(ctx context.Context, id st
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_MANAGE_MEDIA, nil); err != nil {
+		return err
+	}
+
 	if id == "" {
 		return errors.New("media id is empty")
 	}
@@ -266,11 +342,19 @@ func (store *storeImplementation) MediaUpdate(ctx context.Context, media MediaIn
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_MANAGE_MEDIA, nil); err != nil {
+		return err
+	}
+
 	if media == nil {
 		return errors.New("media is nil")
 	}
 
-	media.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	media.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
 
 	metas, _ := media.GetMetas()
 	metasJSON := ""
@@ -298,17 +382,86 @@ func (store *storeImplementation) MediaUpdate(ctx context.Context, media MediaIn
 		COLUMN_SOFT_DELETED_AT: media.GetSoftDeletedAtCarbon().StdTime(),
 	}
 
-	_, err := store.db.Query().
+	before, err := store.auditMediaLoad(ctx, media.GetID())
+	if err != nil {
+		return err
+	}
+
+	_, err = store.query(ctx).
 		Table(store.mediaTableName).
 		Where(COLUMN_ID+" = ?", media.GetID()).
 		Update(row)
+	if err != nil {
+		return err
+	}
+
+	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_MEDIA, media.GetID(), media); err != nil {
+		return err
+	}
 
-	return err
+	return store.auditRecord(ctx, AUDIT_ENTITY_MEDIA, media.GetID(), ACTION_UPDATE, before, media)
+}
+
+// PostAttachMedia attaches the media to the post, e.g. its featured image or
+// an inline upload. A media without a sequence is placed after the other
+// media of the post. The media is created if it is not stored yet, and
+// moved from its previous entity otherwise.
+func (store *storeImplementation) PostAttachMedia(ctx context.Context, postID string, media MediaInterface) error {
+	if store.mediaTableName == "" {
+		return errors.New("blogstore: media table name is empty")
+	}
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+	if media == nil {
+		return errors.New("media is nil")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, WithoutContent: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errors.New("post not found")
+	}
+
+	if err := store.authorize(ctx, ACTION_UPDATE, list[0]); err != nil {
+		return err
+	}
+
+	if media.GetSequence() == 0 {
+		count, err := store.MediaCount(ctx, MediaQueryOptions{EntityID: postID})
+		if err != nil {
+			return err
+		}
+		media.SetSequence(int(count) + 1)
+	}
+	media.SetEntityID(postID)
+
+	if media.GetID() != "" {
+		existing, err := store.MediaFindByID(ctx, media.GetID())
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return store.MediaUpdate(ctx, media)
+		}
+	}
+
+	return store.MediaCreate(ctx, media)
 }
 
 // buildMediaQuery builds a neat query from the media query options.
-func (store *storeImplementation) buildMediaQuery(options MediaQueryOptions) contractsorm.Query {
-	q := store.db.Query().Table(store.mediaTableName)
+// Returns an OrderError if OrderBy or SortOrder is not allowed.
+func (store *storeImplementation) buildMediaQuery(ctx context.Context, options MediaQueryOptions) (contractsorm.Query, error) {
+	q := store.query(ctx).Table(store.mediaTableName)
 
 	if options.ID != "" {
 		q = q.Where(COLUMN_ID+" = ?", options.ID)
@@ -359,15 +512,19 @@ func (store *storeImplementation) buildMediaQuery(options MediaQueryOptions) con
 	}
 
 	if options.Search != "" {
-		q = q.Where(COLUMN_TITLE+" LIKE ?", "%"+options.Search+"%")
+		condition, args := containsCondition(store.driver(), options.Search, COLUMN_TITLE)
+		q = q.Where(condition, args...)
 	}
 
 	if options.OrderBy != "" {
-		order := options.SortOrder
-		if order == "" {
-			order = "DESC"
+		if err := validateOrderBy(options.OrderBy, mediaTableColumns); err != nil {
+			return nil, err
+		}
+		order, err := normalizeSortOrder(options.SortOrder)
+		if err != nil {
+			return nil, err
 		}
-		if strings.ToLower(order) == "asc" {
+		if order == SORT_ORDER_ASC {
 			q = q.OrderBy(options.OrderBy)
 		} else {
 			q = q.OrderByDesc(options.OrderBy)
@@ -386,8 +543,8 @@ func (store *storeImplementation) buildMediaQuery(options MediaQueryOptions) con
 	if options.WithDeleted {
 		q = q.WithSoftDeleted()
 	} else {
-		q = q.Where(COLUMN_SOFT_DELETED_AT+" > ?", carbon.Now(carbon.UTC).StdTime())
+		q = q.Where(COLUMN_SOFT_DELETED_AT+" > ?", store.now().StdTime())
 	}
 
-	return q
+	return q, nil
 }
@@ -2,6 +2,7 @@ package blogstore
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 )
 
@@ -614,3 +615,106 @@ func TestStoreMediaSetGetTableName(t *testing.T) {
 		t.Errorf("GetMediaTableName() after SetMediaTableName() = %q, want %q", store.GetMediaTableName(), "renamed_media")
 	}
 }
+
+func TestStorePostAttachMedia(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		MediaTableName:     "blog_media",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Post")
+	other := NewPost().SetTitle("Other")
+	for _, p := range []PostInterface{post, other} {
+		if err := store.PostCreate(ctx, p); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	featured := NewMedia().SetURL("https://example.com/featured.jpg").SetType("image/jpeg").SetAltText("A sunset")
+	inline := NewMedia().SetURL("https://example.com/inline.png").SetType("image/png")
+	for _, media := range []MediaInterface{featured, inline} {
+		if err := store.PostAttachMedia(ctx, post.GetID(), media); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	list, err := store.MediaListByEntityID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 2 || list[0].GetID() != featured.GetID() || list[1].GetSequence() != 2 {
+		t.Fatal("Expected the media in attachment order, got:", list)
+	}
+	if list[0].GetAltText() != "A sunset" {
+		t.Fatal("Expected the alt text to be stored, got:", list[0].GetAltText())
+	}
+
+	if err := store.PostAttachMedia(ctx, other.GetID(), inline.SetSequence(0)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count, _ := store.MediaCount(ctx, MediaQueryOptions{EntityID: post.GetID()}); count != 1 {
+		t.Fatal("Expected the media to move to the other post, got count:", count)
+	}
+
+	if err := store.PostAttachMedia(ctx, "missing", NewMedia()); err == nil {
+		t.Fatal("Expected an error for a missing post")
+	}
+}
+
+func TestStoreMediaVersioning(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		MediaTableName:      "blog_media",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	media := NewMedia().
+		SetEntityID("post-1").
+		SetTitle("original.jpg").
+		SetURL("https://example.com/original.jpg")
+	if err := store.MediaCreate(ctx, media); err != nil {
+		t.Fatalf("MediaCreate() error = %v", err)
+	}
+
+	media.SetTitle("updated.jpg")
+	if err := store.MediaUpdate(ctx, media); err != nil {
+		t.Fatalf("MediaUpdate() error = %v", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_MEDIA).
+		SetEntityID(media.GetID()))
+	if err != nil {
+		t.Fatalf("VersioningList() error = %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("VersioningList() = %d media versions, want 2", len(versions))
+	}
+
+	titles := map[string]bool{}
+	for _, version := range versions {
+		data := map[string]string{}
+		if err := json.Unmarshal([]byte(version.Content()), &data); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		titles[data[COLUMN_TITLE]] = true
+	}
+	if !titles["original.jpg"] || !titles["updated.jpg"] {
+		t.Errorf("media version titles = %v, want original.jpg and updated.jpg", titles)
+	}
+}
@@ -0,0 +1,565 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+	"github.com/samber/lo"
+)
+
+// MenusEnabled returns true if navigation menus are stored in the menu tables.
+func (store *storeImplementation) MenusEnabled() bool {
+	return store.menusEnabled
+}
+
+// GetMenusTableName returns the menus table name
+func (store *storeImplementation) GetMenusTableName() string {
+	return store.menusTableName
+}
+
+// GetMenuItemsTableName returns the menu items table name
+func (store *storeImplementation) GetMenuItemsTableName() string {
+	return store.menuItemsTableName
+}
+
+// MenuCreate inserts a menu. A new ID is generated if it is empty and the
+// timestamps are set on the menu.
+func (store *storeImplementation) MenuCreate(ctx context.Context, menu *Menu) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if menu == nil {
+		return errors.New("menu is nil")
+	}
+	if strings.TrimSpace(menu.Name) == "" {
+		return errors.New("menu name is empty")
+	}
+
+	if menu.ID == "" {
+		menu.ID = GenerateShortID()
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	menu.CreatedAt = store.now().StdTime()
+	menu.UpdatedAt = menu.CreatedAt
+
+	_, err = store.execContext(ctx, db, "MenuCreate", "INSERT INTO "+store.menusTableName+" (id, name, created_at, updated_at) VALUES (?, ?, ?, ?)",
+		menu.ID, menu.Name, menu.CreatedAt, menu.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_MENU, menu.ID, menu)
+}
+
+// MenuUpdate saves the name of a menu and refreshes its update time.
+// Returns an error if the menu does not exist.
+func (store *storeImplementation) MenuUpdate(ctx context.Context, menu *Menu) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if menu == nil {
+		return errors.New("menu is nil")
+	}
+	if menu.ID == "" {
+		return errors.New("menu id is empty")
+	}
+	if strings.TrimSpace(menu.Name) == "" {
+		return errors.New("menu name is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	updatedAt := store.now().StdTime()
+
+	result, err := store.execContext(ctx, db, "MenuUpdate", "UPDATE "+store.menusTableName+" SET name = ?, updated_at = ? WHERE "+COLUMN_ID+" = ?",
+		menu.Name, updatedAt, menu.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("menu not found")
+	}
+
+	menu.UpdatedAt = updatedAt
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_MENU, menu.ID, menu)
+}
+
+// MenuDelete removes a menu with all its items.
+func (store *storeImplementation) MenuDelete(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if id == "" {
+		return errors.New("menu id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = store.execContext(ctx, tx, "MenuDelete", "DELETE FROM "+store.menuItemsTableName+" WHERE "+COLUMN_MENU_ID+" = ?", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, tx, "MenuDelete", "DELETE FROM "+store.menusTableName+" WHERE "+COLUMN_ID+" = ?", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MenuFindByID retrieves a menu by ID. Returns nil and nil error if there is
+// no menu with the ID.
+func (store *storeImplementation) MenuFindByID(ctx context.Context, id string) (*Menu, error) {
+	if id == "" {
+		return nil, errors.New("menu id is empty")
+	}
+
+	return store.menuFind(ctx, MenuQueryOptions{IDIn: []string{id}})
+}
+
+// MenuFindByName retrieves a menu by name, e.g. "header". Returns nil and
+// nil error if there is no menu with the name.
+func (store *storeImplementation) MenuFindByName(ctx context.Context, name string) (*Menu, error) {
+	if name == "" {
+		return nil, errors.New("menu name is empty")
+	}
+
+	return store.menuFind(ctx, MenuQueryOptions{Name: name})
+}
+
+// menuFind returns the first menu matching the options, or nil.
+func (store *storeImplementation) menuFind(ctx context.Context, options MenuQueryOptions) (*Menu, error) {
+	list, err := store.MenuList(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return &list[0], nil
+	}
+
+	return nil, nil
+}
+
+// MenuList retrieves the menus matching the given options, ordered by name.
+func (store *storeImplementation) MenuList(ctx context.Context, options MenuQueryOptions) ([]Menu, error) {
+	if ctx == nil {
+		return []Menu{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return []Menu{}, errors.New("menus are not enabled")
+	}
+
+	conditions := []queryCondition{}
+	if len(options.IDIn) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(options.IDIn)), ", ")
+		conditions = append(conditions, queryCondition{COLUMN_ID + " IN (" + placeholders + ")", lo.ToAnySlice(options.IDIn)})
+	}
+	if options.Name != "" {
+		conditions = append(conditions, queryCondition{COLUMN_NAME + " = ?", []any{options.Name}})
+	}
+
+	where, args := joinQueryConditions(conditions)
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return []Menu{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "MenuList", "SELECT id, name, created_at, updated_at FROM "+store.menusTableName+
+		" WHERE "+where+" ORDER BY "+COLUMN_NAME+" ASC, "+COLUMN_ID+" ASC", args...)
+	if err != nil {
+		return []Menu{}, err
+	}
+	defer rows.Close()
+
+	list := []Menu{}
+	for rows.Next() {
+		var menu Menu
+		if err := rows.Scan(&menu.ID, &menu.Name, &menu.CreatedAt, &menu.UpdatedAt); err != nil {
+			return []Menu{}, err
+		}
+		list = append(list, menu)
+	}
+
+	return list, rows.Err()
+}
+
+// MenuItemCreate inserts a menu item. A new ID is generated if it is empty
+// and the timestamps are set on the item. The menu must exist, and the
+// parent, if set, must be an item of the same menu.
+func (store *storeImplementation) MenuItemCreate(ctx context.Context, item *MenuItem) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if item == nil {
+		return errors.New("menu item is nil")
+	}
+	if err := menuItemValidate(item); err != nil {
+		return err
+	}
+
+	menu, err := store.MenuFindByID(ctx, item.MenuID)
+	if err != nil {
+		return err
+	}
+	if menu == nil {
+		return errors.New("menu not found")
+	}
+
+	if item.ID == "" {
+		item.ID = GenerateShortID()
+	}
+
+	if err := store.menuItemParentValidate(ctx, item); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	item.CreatedAt = store.now().StdTime()
+	item.UpdatedAt = item.CreatedAt
+
+	_, err = store.execContext(ctx, db, "MenuItemCreate", "INSERT INTO "+store.menuItemsTableName+" (id, menu_id, parent_id, label, url, post_id, sequence, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		item.ID, item.MenuID, item.ParentID, item.Label, item.URL, item.PostID, item.Sequence, item.CreatedAt, item.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_MENU_ITEM, item.ID, item)
+}
+
+// MenuItemUpdate saves all the fields of a menu item and refreshes its
+// update time. The item stays in its menu; the parent, if set, must be an
+// item of the menu that is not the item itself or one of its sub-items.
+// Returns an error if the item does not exist.
+func (store *storeImplementation) MenuItemUpdate(ctx context.Context, item *MenuItem) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if item == nil {
+		return errors.New("menu item is nil")
+	}
+	if item.ID == "" {
+		return errors.New("menu item id is empty")
+	}
+	if err := menuItemValidate(item); err != nil {
+		return err
+	}
+	if err := store.menuItemParentValidate(ctx, item); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	updatedAt := store.now().StdTime()
+
+	result, err := store.execContext(ctx, db, "MenuItemUpdate", "UPDATE "+store.menuItemsTableName+" SET parent_id = ?, label = ?, url = ?, post_id = ?, sequence = ?, updated_at = ? WHERE "+COLUMN_ID+" = ? AND "+COLUMN_MENU_ID+" = ?",
+		item.ParentID, item.Label, item.URL, item.PostID, item.Sequence, updatedAt, item.ID, item.MenuID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("menu item not found")
+	}
+
+	item.UpdatedAt = updatedAt
+	return store.versioningTrackEntity(ctx, VERSIONING_TYPE_MENU_ITEM, item.ID, item)
+}
+
+// MenuItemDelete removes a menu item with all its sub-items.
+func (store *storeImplementation) MenuItemDelete(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return errors.New("menus are not enabled")
+	}
+	if id == "" {
+		return errors.New("menu item id is empty")
+	}
+
+	item, err := store.MenuItemFindByID(ctx, id)
+	if err != nil || item == nil {
+		return err
+	}
+
+	items, err := store.MenuItemList(ctx, item.MenuID)
+	if err != nil {
+		return err
+	}
+
+	ids := append([]string{id}, menuItemDescendantIDs(items, id)...)
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	_, err = store.execContext(ctx, db, "MenuItemDelete", "DELETE FROM "+store.menuItemsTableName+" WHERE "+COLUMN_ID+" IN ("+placeholders+")", lo.ToAnySlice(ids)...)
+	return err
+}
+
+// MenuItemFindByID retrieves a menu item by ID. Returns nil and nil error if
+// there is no item with the ID.
+func (store *storeImplementation) MenuItemFindByID(ctx context.Context, id string) (*MenuItem, error) {
+	if id == "" {
+		return nil, errors.New("menu item id is empty")
+	}
+
+	items, err := store.menuItemList(ctx, "MenuItemFindByID", COLUMN_ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) > 0 {
+		return &items[0], nil
+	}
+
+	return nil, nil
+}
+
+// MenuItemList retrieves all the items of a menu, at all levels, ordered by
+// sequence. Use MenuTree to get them nested under their parents.
+func (store *storeImplementation) MenuItemList(ctx context.Context, menuID string) ([]MenuItem, error) {
+	if menuID == "" {
+		return []MenuItem{}, errors.New("menu id is empty")
+	}
+
+	return store.menuItemList(ctx, "MenuItemList", COLUMN_MENU_ID, menuID)
+}
+
+// menuItemList retrieves the menu items with the column equal to the value.
+func (store *storeImplementation) menuItemList(ctx context.Context, op string, column string, value string) ([]MenuItem, error) {
+	if ctx == nil {
+		return []MenuItem{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.menusEnabled {
+		return []MenuItem{}, errors.New("menus are not enabled")
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return []MenuItem{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, op, "SELECT id, menu_id, parent_id, label, url, post_id, sequence, created_at, updated_at FROM "+store.menuItemsTableName+
+		" WHERE "+column+" = ? ORDER BY "+COLUMN_SEQUENCE+" ASC, "+COLUMN_ID+" ASC", value)
+	if err != nil {
+		return []MenuItem{}, err
+	}
+	defer rows.Close()
+
+	items := []MenuItem{}
+	for rows.Next() {
+		var item MenuItem
+		if err := rows.Scan(&item.ID, &item.MenuID, &item.ParentID, &item.Label, &item.URL, &item.PostID, &item.Sequence, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return []MenuItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// MenuTree retrieves the items of a menu nested under their parents, each
+// level ordered by sequence. Items whose parent no longer exists are
+// returned at the top level.
+func (store *storeImplementation) MenuTree(ctx context.Context, menuID string) ([]MenuItemNode, error) {
+	items, err := store.MenuItemList(ctx, menuID)
+	if err != nil {
+		return []MenuItemNode{}, err
+	}
+
+	itemIDs := map[string]bool{}
+	for _, item := range items {
+		itemIDs[item.ID] = true
+	}
+
+	children := map[string][]MenuItem{}
+	for _, item := range items {
+		parentID := item.ParentID
+		if !itemIDs[parentID] {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], item)
+	}
+
+	return menuItemNodes(children, ""), nil
+}
+
+// menuItemNodes returns the nodes of the items under the parent, with their
+// sub-items.
+func menuItemNodes(children map[string][]MenuItem, parentID string) []MenuItemNode {
+	nodes := make([]MenuItemNode, 0, len(children[parentID]))
+	for _, item := range children[parentID] {
+		nodes = append(nodes, MenuItemNode{
+			MenuItem: item,
+			Children: menuItemNodes(children, item.ID),
+		})
+	}
+	return nodes
+}
+
+// menuItemDescendantIDs returns the IDs of the sub-items of the item, at all
+// levels.
+func menuItemDescendantIDs(items []MenuItem, id string) []string {
+	ids := []string{}
+	for _, item := range items {
+		if item.ParentID == id && item.ID != id {
+			ids = append(ids, item.ID)
+			ids = append(ids, menuItemDescendantIDs(items, item.ID)...)
+		}
+	}
+	return ids
+}
+
+// menuItemValidate checks the fields of a menu item before it is saved.
+func menuItemValidate(item *MenuItem) error {
+	if item.MenuID == "" {
+		return errors.New("menu item menu id is empty")
+	}
+	if strings.TrimSpace(item.Label) == "" {
+		return errors.New("menu item label is empty")
+	}
+	if item.URL == "" && item.PostID == "" {
+		return errors.New("menu item requires a url or a post id")
+	}
+	return nil
+}
+
+// menuItemParentValidate checks that the parent of the item is another item
+// of the same menu, and not one of the sub-items of the item.
+func (store *storeImplementation) menuItemParentValidate(ctx context.Context, item *MenuItem) error {
+	if item.ParentID == "" {
+		return nil
+	}
+	if item.ParentID == item.ID {
+		return errors.New("menu item cannot be its own parent")
+	}
+
+	items, err := store.MenuItemList(ctx, item.MenuID)
+	if err != nil {
+		return err
+	}
+
+	_, found := lo.Find(items, func(i MenuItem) bool { return i.ID == item.ParentID })
+	if !found {
+		return errors.New("menu item parent not found in menu")
+	}
+	if lo.Contains(menuItemDescendantIDs(items, item.ID), item.ParentID) {
+		return errors.New("menu item cannot be moved under its own sub-item")
+	}
+
+	return nil
+}
+
+// menusTableBlueprint defines the menus table.
+func menusTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 40)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_NAME, 255)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.DateTime(COLUMN_UPDATED_AT)
+	table.Index(COLUMN_NAME)
+}
+
+// menuItemsTableBlueprint defines the menu items table, holding the items of
+// all the menus.
+func menuItemsTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 40)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_MENU_ID, 40)
+	table.String(COLUMN_PARENT_ID, 40).Default("")
+	table.String(COLUMN_LABEL, 255)
+	table.String(COLUMN_URL, 510).Default("")
+	table.String(COLUMN_POST_ID, 40).Default("")
+	table.Integer(COLUMN_SEQUENCE).Default(0)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.DateTime(COLUMN_UPDATED_AT)
+	table.Index(COLUMN_MENU_ID)
+}
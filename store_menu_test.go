@@ -0,0 +1,243 @@
+package blogstore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func initMenusStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		MenusEnabled:       true,
+		MenusTableName:     "blog_menus",
+		MenuItemsTableName: "blog_menu_items",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestNewStoreMenusRequireTableNames(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:  "blog_posts",
+		MenusEnabled:   true,
+		MenusTableName: "blog_menus",
+		DB:             initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error for missing MenuItemsTableName")
+	}
+}
+
+func TestStoreMenuCRUD(t *testing.T) {
+	store := initMenusStore(t)
+	ctx := context.Background()
+
+	menu := &Menu{Name: "header"}
+	if err := store.MenuCreate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if menu.ID == "" {
+		t.Fatal("expected MenuCreate to generate an ID")
+	}
+
+	found, err := store.MenuFindByName(ctx, "header")
+	if err != nil || found == nil || found.ID != menu.ID {
+		t.Fatalf("MenuFindByName() = %v, %v, want the menu", found, err)
+	}
+
+	menu.Name = "main"
+	if err := store.MenuUpdate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	found, err = store.MenuFindByID(ctx, menu.ID)
+	if err != nil || found == nil || found.Name != "main" {
+		t.Fatalf("MenuFindByID() = %v, %v, want the renamed menu", found, err)
+	}
+
+	if err := store.MenuUpdate(ctx, &Menu{ID: "missing", Name: "x"}); err == nil {
+		t.Fatal("expected error updating a missing menu")
+	}
+	if err := store.MenuCreate(ctx, &Menu{Name: " "}); err == nil {
+		t.Fatal("expected error for empty menu name")
+	}
+
+	item := &MenuItem{MenuID: menu.ID, Label: "Home", URL: "/"}
+	if err := store.MenuItemCreate(ctx, item); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MenuDelete(ctx, menu.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, err := store.MenuFindByID(ctx, menu.ID); err != nil || found != nil {
+		t.Fatalf("MenuFindByID() after delete = %v, %v, want nil", found, err)
+	}
+	if found, err := store.MenuItemFindByID(ctx, item.ID); err != nil || found != nil {
+		t.Fatalf("MenuItemFindByID() after menu delete = %v, %v, want nil", found, err)
+	}
+}
+
+func TestStoreMenuTree(t *testing.T) {
+	store := initMenusStore(t)
+	ctx := context.Background()
+
+	menu := &Menu{Name: "header"}
+	if err := store.MenuCreate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	blog := &MenuItem{MenuID: menu.ID, Label: "Blog", URL: "/blog", Sequence: 2}
+	home := &MenuItem{MenuID: menu.ID, Label: "Home", URL: "/", Sequence: 1}
+	for _, item := range []*MenuItem{blog, home} {
+		if err := store.MenuItemCreate(ctx, item); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	news := &MenuItem{MenuID: menu.ID, ParentID: blog.ID, Label: "News", URL: "/blog/news", Sequence: 2}
+	about := &MenuItem{MenuID: menu.ID, ParentID: blog.ID, Label: "About this blog", PostID: "post-about", Sequence: 1}
+	for _, item := range []*MenuItem{news, about} {
+		if err := store.MenuItemCreate(ctx, item); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	tree, err := store.MenuTree(ctx, menu.ID)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(tree) != 2 || tree[0].ID != home.ID || tree[1].ID != blog.ID {
+		t.Fatalf("MenuTree() top level = %+v, want Home and Blog", tree)
+	}
+	if len(tree[1].Children) != 2 || tree[1].Children[0].ID != about.ID || tree[1].Children[1].ID != news.ID {
+		t.Fatalf("MenuTree() children of Blog = %+v, want About and News", tree[1].Children)
+	}
+	if tree[1].Children[0].PostID != "post-about" {
+		t.Fatalf("PostID = %q, want post-about", tree[1].Children[0].PostID)
+	}
+
+	// an item cannot be moved under its own sub-item
+	blog.ParentID = news.ID
+	if err := store.MenuItemUpdate(ctx, blog); err == nil {
+		t.Fatal("expected error moving an item under its sub-item")
+	}
+	blog.ParentID = ""
+
+	news.ParentID = home.ID
+	if err := store.MenuItemUpdate(ctx, news); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.MenuItemDelete(ctx, blog.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	items, err := store.MenuItemList(ctx, menu.ID)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(items) != 2 || items[0].ID != home.ID || items[1].ID != news.ID {
+		t.Fatalf("MenuItemList() after delete = %+v, want Home and News", items)
+	}
+}
+
+func TestStoreMenuItemValidation(t *testing.T) {
+	store := initMenusStore(t)
+	ctx := context.Background()
+
+	menu := &Menu{Name: "footer"}
+	if err := store.MenuCreate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	invalid := []*MenuItem{
+		{MenuID: menu.ID, URL: "/"},
+		{MenuID: menu.ID, Label: "Nowhere"},
+		{MenuID: "missing", Label: "Home", URL: "/"},
+		{MenuID: menu.ID, Label: "Orphan", URL: "/", ParentID: "missing"},
+	}
+	for _, item := range invalid {
+		if err := store.MenuItemCreate(ctx, item); err == nil {
+			t.Fatalf("expected error creating %+v", item)
+		}
+	}
+}
+
+func TestStoreMenuVersioning(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		MenusEnabled:        true,
+		MenusTableName:      "blog_menus",
+		MenuItemsTableName:  "blog_menu_items",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	menu := &Menu{Name: "header"}
+	if err := store.MenuCreate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	menu.Name = "main"
+	if err := store.MenuUpdate(ctx, menu); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	item := &MenuItem{MenuID: menu.ID, Label: "Home", URL: "/"}
+	if err := store.MenuItemCreate(ctx, item); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	item.Label = "Start"
+	if err := store.MenuItemUpdate(ctx, item); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	cases := []struct {
+		entityType string
+		entityID   string
+		column     string
+		want       []string
+	}{
+		{VERSIONING_TYPE_MENU, menu.ID, COLUMN_NAME, []string{"header", "main"}},
+		{VERSIONING_TYPE_MENU_ITEM, item.ID, COLUMN_LABEL, []string{"Home", "Start"}},
+	}
+	for _, c := range cases {
+		versions, err := store.VersioningList(ctx, NewVersioningQuery().
+			SetEntityType(c.entityType).
+			SetEntityID(c.entityID))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if len(versions) != len(c.want) {
+			t.Fatalf("VersioningList() = %d %s versions, want %d", len(versions), c.entityType, len(c.want))
+		}
+
+		values := map[string]bool{}
+		for _, version := range versions {
+			data := map[string]string{}
+			if err := json.Unmarshal([]byte(version.Content()), &data); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			values[data[c.column]] = true
+		}
+		for _, want := range c.want {
+			if !values[want] {
+				t.Fatalf("%s version %s values = %v, want %v", c.entityType, c.column, values, c.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package blogstore
+
+// metaValidate checks the metas of the post against the meta schema, if
+// one is set.
+func (store *storeImplementation) metaValidate(post PostInterface) error {
+	if store.metaSchema == nil {
+		return nil
+	}
+
+	metas, err := post.GetMetas()
+	if err != nil {
+		return err
+	}
+
+	return store.metaSchema.Validate(metas)
+}
@@ -0,0 +1,604 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dracory/neat"
+	contractsdatabase "github.com/dracory/neat/contracts/database"
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// schemaMigration is a versioned change of the store tables, applied to each
+// of its existing target tables, e.g. the post table and, when archiving is
+// enabled, the archive table. Both steps check the current schema first, so
+// tables created with the latest blueprint are left unchanged.
+type schemaMigration struct {
+	version int
+	name    string
+	tables  func() []string
+	up      func(ctx context.Context, tableName string) error
+	down    func(ctx context.Context, tableName string) error
+}
+
+// schemaMigrations returns the migrations of the store tables, oldest first.
+// New migrations are appended with the next version; released versions are
+// never changed.
+func (store *storeImplementation) schemaMigrations() []schemaMigration {
+	return []schemaMigration{
+		{1, "add_slug_column", store.schemaMigrationTables, store.migrateSlugColumn, store.migrateSlugColumnDown},
+		{2, "add_idempotency_key_column", store.schemaMigrationTables, store.migrateIdempotencyKeyColumn, store.migrateIdempotencyKeyColumnDown},
+		{3, "add_expires_at_column", store.schemaMigrationTables, store.migrateExpiresAtColumn, store.migrateExpiresAtColumnDown},
+		{4, "add_views_column", store.schemaMigrationTables, store.migrateViewsColumn, store.migrateViewsColumnDown},
+		{5, "json_metas_column", store.schemaMigrationTables, store.migrateMetasColumnType, store.migrateMetasColumnTypeDown},
+		{6, "add_canonical_url_index", store.schemaMigrationTables, store.migrateCanonicalURLIndex, store.migrateCanonicalURLIndexDown},
+		{7, "add_translation_columns", store.schemaMigrationTables, store.migrateTranslationColumns, store.migrateTranslationColumnsDown},
+		{8, "add_versioning_change_columns", store.versioningMigrationTables, store.migrateVersioningChangeColumns, store.migrateVersioningChangeColumnsDown},
+		{9, "add_versioning_label_column", store.versioningMigrationTables, store.migrateVersioningLabelColumn, store.migrateVersioningLabelColumnDown},
+		{10, "add_idempotency_key_unique_index", store.schemaMigrationTables, store.migrateIdempotencyKeyUniqueIndex, store.migrateIdempotencyKeyUniqueIndexDown},
+		{11, "add_expires_at_index", store.schemaMigrationTables, store.migrateExpiresAtIndex, store.migrateExpiresAtIndexDown},
+		{12, "add_trashed_at_column", store.schemaMigrationTables, store.migrateTrashedAtColumn, store.migrateTrashedAtColumnDown},
+	}
+}
+
+// SchemaVersion returns the version of the latest schema migration applied
+// to the store tables, or 0 if none was applied.
+func (store *storeImplementation) SchemaVersion(ctx context.Context) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	applied, err := store.schemaMigrationsApplied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for applied := range applied {
+		version = max(version, applied)
+	}
+
+	return version, nil
+}
+
+// MigrateDownTo reverts the schema migrations applied after the version,
+// newest first. Reverting drops the columns the migrations added, with their
+// data. MigrateUp applies them again. Like MigrateUp, it is not bounded by
+// DefaultTimeout.
+func (store *storeImplementation) MigrateDownTo(ctx context.Context, version int) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	applied, err := store.schemaMigrationsApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	migrations := store.schemaMigrations()
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.version <= version || !applied[migration.version] {
+			continue
+		}
+
+		for _, tableName := range migration.tables() {
+			if err := migration.down(ctx, tableName); err != nil {
+				return errors.New("blog store: migration " + migration.name + " down: " + err.Error())
+			}
+		}
+
+		_, err := store.execContext(ctx, db, "MigrateDownTo", "DELETE FROM "+store.migrationsTableName+" WHERE "+COLUMN_VERSION+" = ?", migration.version)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMigrationsTableName returns the table recording the applied schema
+// migrations.
+func (store *storeImplementation) GetMigrationsTableName() string {
+	return store.migrationsTableName
+}
+
+// migrateSchema applies the schema migrations not recorded in the
+// migrations table yet, oldest first, recording each once applied. A
+// migration with none of its tables, e.g. of the versions while versioning
+// is disabled, is skipped without being recorded.
+func (store *storeImplementation) migrateSchema(ctx context.Context) error {
+	if !store.db.Schema().HasTable(store.migrationsTableName) {
+		if err := store.db.Schema().Create(store.migrationsTableName, migrationsTableBlueprint); err != nil {
+			return err
+		}
+	}
+
+	applied, err := store.schemaMigrationsApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range store.schemaMigrations() {
+		if applied[migration.version] {
+			continue
+		}
+
+		// not recorded, so it is applied once the tables exist
+		tables := migration.tables()
+		if len(tables) == 0 {
+			continue
+		}
+
+		for _, tableName := range tables {
+			if err := migration.up(ctx, tableName); err != nil {
+				return errors.New("blog store: migration " + migration.name + ": " + err.Error())
+			}
+		}
+
+		_, err := store.execContext(ctx, db, "MigrateUp", "INSERT INTO "+store.migrationsTableName+" ("+COLUMN_VERSION+", "+COLUMN_NAME+", "+COLUMN_APPLIED_AT+") VALUES (?, ?, ?)",
+			migration.version, migration.name, store.now().StdTime())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaMigrationsApplied returns the versions recorded in the migrations
+// table, which is empty if the table does not exist.
+func (store *storeImplementation) schemaMigrationsApplied(ctx context.Context) (map[int]bool, error) {
+	applied := map[int]bool{}
+	if !store.db.Schema().HasTable(store.migrationsTableName) {
+		return applied, nil
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "SchemaVersion", "SELECT "+COLUMN_VERSION+" FROM "+store.migrationsTableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// schemaMigrationTables returns the existing post tables the schema
+// migrations apply to.
+func (store *storeImplementation) schemaMigrationTables() []string {
+	tables := []string{}
+	if store.db.Schema().HasTable(store.postTableName) {
+		tables = append(tables, store.postTableName)
+	}
+	if store.archiveEnabled && store.db.Schema().HasTable(store.archiveTableName) {
+		tables = append(tables, store.archiveTableName)
+	}
+	return tables
+}
+
+// versioningMigrationTables returns the versioning table if versioning is
+// enabled and the table exists, for the migrations of the versions.
+func (store *storeImplementation) versioningMigrationTables() []string {
+	if !store.versioningEnabled || !store.db.Schema().HasTable(store.versioningTableName) {
+		return []string{}
+	}
+	return []string{store.versioningTableName}
+}
+
+// migrationsTableBlueprint defines the migrations table, holding the
+// versions of the applied schema migrations.
+func migrationsTableBlueprint(table contractsschema.Blueprint) {
+	table.Integer(COLUMN_VERSION)
+	table.Primary(COLUMN_VERSION)
+	table.String(COLUMN_NAME, 255)
+	table.DateTime(COLUMN_APPLIED_AT)
+}
+
+// migrateSlugColumn adds the slug column to a post table created before the
+// column existed.
+func (store *storeImplementation) migrateSlugColumn(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_SLUG) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.String(COLUMN_SLUG, 255).Default("")
+	})
+}
+
+// migrateSlugColumnDown drops the slug column and its unique index.
+func (store *storeImplementation) migrateSlugColumnDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasColumn(tableName, COLUMN_SLUG) {
+		return nil
+	}
+
+	index := strings.ToLower(tableName + "_" + COLUMN_SLUG + "_unique")
+	if store.db.Schema().HasIndex(tableName, index) {
+		if err := store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+			table.DropIndexByName(index)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropColumn(COLUMN_SLUG)
+	})
+}
+
+// migrateIdempotencyKeyColumn adds the idempotency key column to a post
+// table created before the column existed.
+func (store *storeImplementation) migrateIdempotencyKeyColumn(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_IDEMPOTENCY_KEY) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.String(COLUMN_IDEMPOTENCY_KEY, 255).Default("")
+		table.Index(COLUMN_IDEMPOTENCY_KEY)
+	})
+}
+
+// migrateIdempotencyKeyColumnDown drops the idempotency key column and its
+// index.
+func (store *storeImplementation) migrateIdempotencyKeyColumnDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasColumn(tableName, COLUMN_IDEMPOTENCY_KEY) {
+		return nil
+	}
+
+	if err := store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndex(COLUMN_IDEMPOTENCY_KEY)
+	}); err != nil {
+		return err
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropColumn(COLUMN_IDEMPOTENCY_KEY)
+	})
+}
+
+// migrateExpiresAtColumn adds the expires at column to a post table created
+// before the column existed.
+func (store *storeImplementation) migrateExpiresAtColumn(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_EXPIRES_AT) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DateTime(COLUMN_EXPIRES_AT).Default(neat.NullDateTime)
+	})
+}
+
+// migrateExpiresAtColumnDown drops the expires at column.
+func (store *storeImplementation) migrateExpiresAtColumnDown(_ context.Context, tableName string) error {
+	return store.migrateDropColumn(tableName, COLUMN_EXPIRES_AT)
+}
+
+// migrateViewsColumn adds the views column to a post table created before
+// the column existed.
+func (store *storeImplementation) migrateViewsColumn(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_VIEWS) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.BigInteger(COLUMN_VIEWS).Default(0)
+	})
+}
+
+// migrateViewsColumnDown drops the views column.
+func (store *storeImplementation) migrateViewsColumnDown(_ context.Context, tableName string) error {
+	return store.migrateDropColumn(tableName, COLUMN_VIEWS)
+}
+
+// migrateCanonicalURLIndex indexes the canonical URL column of a post table
+// created before PostFindByCanonicalURL existed.
+func (store *storeImplementation) migrateCanonicalURLIndex(_ context.Context, tableName string) error {
+	if store.db.Schema().HasIndex(tableName, strings.ToLower(tableName+"_"+COLUMN_CANONICAL_URL+"_index")) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.Index(COLUMN_CANONICAL_URL)
+	})
+}
+
+// migrateCanonicalURLIndexDown drops the canonical URL index.
+func (store *storeImplementation) migrateCanonicalURLIndexDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasIndex(tableName, strings.ToLower(tableName+"_"+COLUMN_CANONICAL_URL+"_index")) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndex(COLUMN_CANONICAL_URL)
+	})
+}
+
+// migrateTranslationColumns adds the locale and translation group columns
+// to a post table created before translations existed.
+func (store *storeImplementation) migrateTranslationColumns(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_TRANSLATION_GROUP_ID) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.String(COLUMN_LOCALE, 20).Default("")
+		table.String(COLUMN_TRANSLATION_GROUP_ID, 40).Default("")
+		table.Index(COLUMN_TRANSLATION_GROUP_ID)
+	})
+}
+
+// migrateTranslationColumnsDown drops the translation group index and the
+// locale and translation group columns.
+func (store *storeImplementation) migrateTranslationColumnsDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasColumn(tableName, COLUMN_TRANSLATION_GROUP_ID) {
+		return nil
+	}
+
+	if err := store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndex(COLUMN_TRANSLATION_GROUP_ID)
+	}); err != nil {
+		return err
+	}
+
+	if err := store.migrateDropColumn(tableName, COLUMN_TRANSLATION_GROUP_ID); err != nil {
+		return err
+	}
+
+	return store.migrateDropColumn(tableName, COLUMN_LOCALE)
+}
+
+// migrateVersioningChangeColumns adds the changed by and change note
+// columns to a versioning table created before they existed.
+func (store *storeImplementation) migrateVersioningChangeColumns(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_CHANGED_BY) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.String(COLUMN_CHANGED_BY, 40).Default("")
+		table.String(COLUMN_CHANGE_NOTE, 255).Default("")
+	})
+}
+
+// migrateVersioningChangeColumnsDown drops the changed by and change note
+// columns.
+func (store *storeImplementation) migrateVersioningChangeColumnsDown(_ context.Context, tableName string) error {
+	if err := store.migrateDropColumn(tableName, COLUMN_CHANGE_NOTE); err != nil {
+		return err
+	}
+
+	return store.migrateDropColumn(tableName, COLUMN_CHANGED_BY)
+}
+
+// migrateVersioningLabelColumn adds the label column to a versioning table
+// created before versions could be labeled.
+func (store *storeImplementation) migrateVersioningLabelColumn(_ context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_LABEL) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.String(COLUMN_LABEL, versioningLabelMaxLength).Default("")
+	})
+}
+
+// migrateVersioningLabelColumnDown drops the label column.
+func (store *storeImplementation) migrateVersioningLabelColumnDown(_ context.Context, tableName string) error {
+	return store.migrateDropColumn(tableName, COLUMN_LABEL)
+}
+
+// migrateIdempotencyKeyUniqueIndex adds a unique index on the non-empty
+// idempotency keys of a post table, so two concurrent creates with the same
+// key cannot both insert a post. Existing duplicate keys must be resolved
+// first.
+func (store *storeImplementation) migrateIdempotencyKeyUniqueIndex(ctx context.Context, tableName string) error {
+	index := strings.ToLower(tableName + "_" + COLUMN_IDEMPOTENCY_KEY + "_unique")
+	if store.db.Schema().HasIndex(tableName, index) {
+		return nil
+	}
+
+	return store.migrateExec(ctx, []string{uniqueNonEmptyIndexStatement(store.driver(), index, tableName, COLUMN_IDEMPOTENCY_KEY)})
+}
+
+// migrateIdempotencyKeyUniqueIndexDown drops the unique idempotency key index.
+func (store *storeImplementation) migrateIdempotencyKeyUniqueIndexDown(_ context.Context, tableName string) error {
+	index := strings.ToLower(tableName + "_" + COLUMN_IDEMPOTENCY_KEY + "_unique")
+	if !store.db.Schema().HasIndex(tableName, index) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndexByName(index)
+	})
+}
+
+// migrateExpiresAtIndex indexes the expires at column of a post table
+// created before PostExpireDue filtered on it.
+func (store *storeImplementation) migrateExpiresAtIndex(_ context.Context, tableName string) error {
+	if store.db.Schema().HasIndex(tableName, strings.ToLower(tableName+"_"+COLUMN_EXPIRES_AT+"_index")) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.Index(COLUMN_EXPIRES_AT)
+	})
+}
+
+// migrateExpiresAtIndexDown drops the expires at index.
+func (store *storeImplementation) migrateExpiresAtIndexDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasIndex(tableName, strings.ToLower(tableName+"_"+COLUMN_EXPIRES_AT+"_index")) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndex(COLUMN_EXPIRES_AT)
+	})
+}
+
+// migrateTrashedAtColumn adds the indexed trashed at column to a post table
+// created before the trash retention was counted from it. The posts already
+// in the trash count from their last update.
+func (store *storeImplementation) migrateTrashedAtColumn(ctx context.Context, tableName string) error {
+	if store.db.Schema().HasColumn(tableName, COLUMN_TRASHED_AT) {
+		return nil
+	}
+
+	if err := store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DateTime(COLUMN_TRASHED_AT).Default(neat.NullDateTime)
+		table.Index(COLUMN_TRASHED_AT)
+	}); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "MigrateUp", "UPDATE "+tableName+" SET "+COLUMN_TRASHED_AT+" = "+COLUMN_UPDATED_AT+" WHERE "+COLUMN_STATUS+" = ?", POST_STATUS_TRASH)
+	return err
+}
+
+// migrateTrashedAtColumnDown drops the trashed at index and column.
+func (store *storeImplementation) migrateTrashedAtColumnDown(_ context.Context, tableName string) error {
+	if !store.db.Schema().HasColumn(tableName, COLUMN_TRASHED_AT) {
+		return nil
+	}
+
+	if err := store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropIndex(COLUMN_TRASHED_AT)
+	}); err != nil {
+		return err
+	}
+
+	return store.migrateDropColumn(tableName, COLUMN_TRASHED_AT)
+}
+
+// migrateDropColumn drops the column if the table has it.
+func (store *storeImplementation) migrateDropColumn(tableName string, column string) error {
+	if !store.db.Schema().HasColumn(tableName, column) {
+		return nil
+	}
+
+	return store.db.Schema().Table(tableName, func(table contractsschema.Blueprint) {
+		table.DropColumn(column)
+	})
+}
+
+// migrateMetasColumnType converts the text metas column of a post table
+// created before the metas had a native JSON type on PostgreSQL and MySQL.
+// Empty metas are set to an empty JSON object first.
+func (store *storeImplementation) migrateMetasColumnType(ctx context.Context, tableName string) error {
+	statements := []string{}
+	switch store.driver() {
+	case contractsdatabase.DriverPostgres:
+		statements = []string{
+			"UPDATE " + tableName + " SET " + COLUMN_METAS + " = '{}' WHERE " + COLUMN_METAS + " IS NULL OR " + COLUMN_METAS + " = ''",
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " DROP DEFAULT",
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " TYPE JSONB USING " + COLUMN_METAS + "::jsonb",
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " SET DEFAULT '{}'",
+		}
+	case contractsdatabase.DriverMysql:
+		statements = []string{
+			"UPDATE " + tableName + " SET " + COLUMN_METAS + " = '{}' WHERE " + COLUMN_METAS + " IS NULL OR " + COLUMN_METAS + " = ''",
+			"ALTER TABLE " + tableName + " MODIFY " + COLUMN_METAS + " JSON",
+		}
+	default:
+		return nil
+	}
+
+	isJSON, err := store.metasColumnIsJSON(tableName)
+	if err != nil || isJSON {
+		return err
+	}
+
+	return store.migrateExec(ctx, statements)
+}
+
+// migrateMetasColumnTypeDown converts the native JSON metas column back to
+// text.
+func (store *storeImplementation) migrateMetasColumnTypeDown(ctx context.Context, tableName string) error {
+	statements := []string{}
+	switch store.driver() {
+	case contractsdatabase.DriverPostgres:
+		statements = []string{
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " DROP DEFAULT",
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " TYPE TEXT USING " + COLUMN_METAS + "::text",
+			"ALTER TABLE " + tableName + " ALTER COLUMN " + COLUMN_METAS + " SET DEFAULT '{}'",
+		}
+	case contractsdatabase.DriverMysql:
+		statements = []string{
+			"ALTER TABLE " + tableName + " MODIFY " + COLUMN_METAS + " TEXT",
+		}
+	default:
+		return nil
+	}
+
+	isJSON, err := store.metasColumnIsJSON(tableName)
+	if err != nil || !isJSON {
+		return err
+	}
+
+	return store.migrateExec(ctx, statements)
+}
+
+// metasColumnIsJSON returns true if the metas column of the table has a
+// native JSON type.
+func (store *storeImplementation) metasColumnIsJSON(tableName string) (bool, error) {
+	columns, err := store.db.Schema().GetColumns(tableName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, column := range columns {
+		if column.Name == COLUMN_METAS {
+			return strings.HasPrefix(strings.ToLower(column.TypeName), "json"), nil
+		}
+	}
+
+	return false, nil
+}
+
+// migrateExec executes the statements of a migration in order.
+func (store *storeImplementation) migrateExec(ctx context.Context, statements []string) error {
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		if _, err := store.execContext(ctx, db, "MigrateUp", statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStoreSchemaMigrations(t *testing.T) {
+	db := initDB()
+
+	// a post table created before the slug, idempotency key, expires at and
+	// views columns existed
+	_, err := db.Exec(`CREATE TABLE blog_posts (
+		id VARCHAR(21) PRIMARY KEY,
+		title VARCHAR(255),
+		content TEXT,
+		summary TEXT,
+		status VARCHAR(50) DEFAULT 'draft',
+		author_id VARCHAR(40),
+		canonical_url VARCHAR(255) DEFAULT '',
+		image_url VARCHAR(255) DEFAULT '',
+		memo VARCHAR(255) DEFAULT '',
+		meta_description VARCHAR(255) DEFAULT '',
+		meta_keywords VARCHAR(255) DEFAULT '',
+		meta_robots VARCHAR(50) DEFAULT '',
+		metas TEXT DEFAULT '{}',
+		featured VARCHAR(3) DEFAULT 'no',
+		published_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME,
+		soft_deleted_at DATETIME DEFAULT '9999-12-31 23:59:59'
+	)`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	_, err = db.Exec(`INSERT INTO blog_posts (id, title, content, summary, author_id, metas, published_at, created_at, updated_at)
+		VALUES ('legacy1', 'Legacy post', 'Kept content', '', 'author', '{}', '2020-01-01 00:00:00', '2020-01-01 00:00:00', '2020-01-01 00:00:00')`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 db,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if version != 12 {
+		t.Fatal("Expected schema version 12, got:", version)
+	}
+	if store.GetMigrationsTableName() != "blog_posts_migrations" {
+		t.Fatal("unexpected migrations table:", store.GetMigrationsTableName())
+	}
+
+	post, err := store.PostFindByID(ctx, "legacy1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if post == nil || post.GetContent() != "Kept content" {
+		t.Fatal("Expected the legacy post to be kept, got:", post)
+	}
+
+	if err := store.PostIncrementViewCount(ctx, "legacy1"); err != nil {
+		t.Fatal("Expected the views column to be added:", err)
+	}
+
+	var indexes int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'blog_posts_canonical_url_index'").Scan(&indexes); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if indexes != 1 {
+		t.Fatal("Expected the canonical url index to be added")
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'blog_posts_expires_at_index'").Scan(&indexes); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if indexes != 1 {
+		t.Fatal("Expected the expires at index to be added")
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'blog_posts_trashed_at_index'").Scan(&indexes); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if indexes != 1 {
+		t.Fatal("Expected the trashed at column and index to be added")
+	}
+
+	post.SetLocale("en").SetTranslationGroupID("legacy1")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("Expected the translation columns to be added:", err)
+	}
+
+	if err := store.MigrateDownTo(ctx, 3); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if version, _ := store.SchemaVersion(ctx); version != 3 {
+		t.Fatal("Expected schema version 3 after MigrateDownTo, got:", version)
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('blog_posts') WHERE name = 'views'").Scan(&count); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatal("Expected MigrateDownTo to drop the views column")
+	}
+
+	if err := store.MigrateUp(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if version, _ := store.SchemaVersion(ctx); version != 12 {
+		t.Fatal("Expected schema version 12 after MigrateUp, got:", version)
+	}
+	if post, _ := store.PostFindByID(ctx, "legacy1"); post == nil || post.GetTitle() != "Legacy post" {
+		t.Fatal("Expected the legacy post to survive the migrations, got:", post)
+	}
+
+	if err := store.MigrateDownTo(ctx, 0); err != nil {
+		t.Fatal("Expected all migrations to revert:", err)
+	}
+	if version, _ := store.SchemaVersion(ctx); version != 0 {
+		t.Fatal("Expected schema version 0, got:", version)
+	}
+}
+
+func TestStoreSchemaMigrationsVersioning(t *testing.T) {
+	db := initDB()
+	ctx := context.Background()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 db,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	applied := func() map[int]bool {
+		t.Helper()
+		versions, err := store.(*storeImplementation).schemaMigrationsApplied(ctx)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		return versions
+	}
+
+	if versions := applied(); versions[8] || versions[9] {
+		t.Fatal("Expected the versioning migrations not to be recorded while versioning is disabled, got:", versions)
+	}
+
+	// a versioning table created before versions had a changed by, change
+	// note and label
+	_, err = db.Exec(`CREATE TABLE blog_versioning (
+		id VARCHAR(21) PRIMARY KEY,
+		entity_type VARCHAR(40),
+		entity_id VARCHAR(40),
+		content TEXT,
+		created_at DATETIME,
+		soft_deleted_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err = NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+		DB:                  db,
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if versions := applied(); !versions[8] || !versions[9] {
+		t.Fatal("Expected the versioning migrations to be recorded once versioning is enabled, got:", versions)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('blog_versioning') WHERE name IN ('changed_by', 'change_note', 'label')").Scan(&count); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 3 {
+		t.Fatal("Expected the versioning columns to be added, found:", count)
+	}
+}
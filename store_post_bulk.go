@@ -0,0 +1,398 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+)
+
+// postCreateManyMaxRows caps the rows of one INSERT statement of
+// PostCreateMany, whatever the driver allows.
+const postCreateManyMaxRows = 500
+
+// PostCreateMany inserts many posts in a single transaction, batching them
+// into multi-row INSERT statements sized for the driver. Each post is
+// prepared as by PostCreate: it gets an ID, its status is validated, its
+// creation authorized, and a post without a slug gets a free one, also
+// unique within the batch. Either all posts are created or none. On
+// success the posts are marked as not dirty, then versioned and audited.
+// Unlike PostCreate, idempotency keys are stored but not checked.
+func (store *storeImplementation) PostCreateMany(ctx context.Context, posts []PostInterface) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if len(posts) == 0 {
+		return nil
+	}
+
+	now := store.now().ToDateTimeString()
+	slugs := map[string]bool{}
+	rows := make([][]any, 0, len(posts))
+
+	for _, post := range posts {
+		if post == nil {
+			return errors.New("post is nil")
+		}
+
+		if post.GetID() == "" {
+			post.SetID(GenerateShortID())
+		}
+
+		if err := store.postStatusValidate(post.GetStatus()); err != nil {
+			return err
+		}
+
+		if err := store.authorize(ctx, ACTION_CREATE, post); err != nil {
+			return err
+		}
+
+		if err := store.hookRun(ctx, hookBeforePostCreate, post); err != nil {
+			return err
+		}
+
+		store.contentSanitize(post)
+
+		if err := store.metaValidate(post); err != nil {
+			return err
+		}
+
+		if post.Get(COLUMN_SLUG) == "" {
+			if err := store.postSlugUnique(ctx, post, slugs); err != nil {
+				return err
+			}
+		}
+
+		if store.slugUniqueEnabled {
+			post.SetSlug(postUniqueSlug(post))
+		}
+		slugs[post.GetSlug()] = true
+
+		post.SetCreatedAt(now)
+		post.SetUpdatedAt(now)
+
+		values, err := store.postInsertValues(ctx, post)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, values)
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	columns := len(rows[0])
+	perStatement := min(postCreateManyMaxRows, store.maxInsertParams()/columns)
+
+	for start := 0; start < len(rows); start += perStatement {
+		chunk := rows[start:min(start+perStatement, len(rows))]
+
+		args := make([]any, 0, len(chunk)*columns)
+		for _, values := range chunk {
+			args = append(args, values...)
+		}
+
+		_, err := store.execContext(ctx, tx, "PostCreateMany", "INSERT INTO "+store.postTableName+" ("+postInsertColumns+") VALUES "+insertPlaceholders(len(chunk), columns), args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.MarkAsNotDirty()
+		if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, post.GetID(), post); err != nil {
+			return err
+		}
+		if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, post.GetID(), ACTION_CREATE, nil, post); err != nil {
+			return err
+		}
+		if err := store.statusHistoryRecord(ctx, post.GetID(), "", post.GetStatus()); err != nil {
+			return err
+		}
+		if err := store.eventPublish(ctx, nil, post); err != nil {
+			return err
+		}
+		if err := store.hookRun(ctx, hookAfterPostCreate, post); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PostStatusUpdateByIDs sets the status of the posts with the given IDs in a
+// single statement, e.g. to publish or trash the posts selected in an admin
+// screen, and returns how many were updated. The status is validated and
+// every post authorized first; unknown IDs and soft deleted posts are
+// skipped. The updated posts are versioned and audited like PostUpdate.
+// The update hooks are called for each post, but changes made by the before
+// hooks are not saved.
+func (store *storeImplementation) PostStatusUpdateByIDs(ctx context.Context, ids []string, status string) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.postStatusValidate(status); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	action := ACTION_UPDATE
+	if status == POST_STATUS_TRASH {
+		action = ACTION_TRASH
+	}
+
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{IDIn: ids})
+	if err != nil {
+		return 0, err
+	}
+	if len(posts) == 0 {
+		return 0, nil
+	}
+
+	now := store.now()
+	afters := make([]PostInterface, len(posts))
+
+	for i, before := range posts {
+		if err := store.authorize(ctx, actionFromContext(ctx, action), before); err != nil {
+			return 0, err
+		}
+
+		afters[i] = NewPostFromExistingData(before.GetData())
+		afters[i].SetStatus(status)
+		afters[i].SetUpdatedAt(now.ToDateTimeString())
+
+		if err := store.hookRun(ctx, hookBeforePostUpdate, afters[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	postIDs := lo.Map(posts, func(post PostInterface, _ int) string { return post.GetID() })
+
+	db, err := store.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	// the trashed at is set before the status, as MySQL sees the updated
+	// values in the later assignments; posts already in the trash keep theirs
+	result, err := store.execContext(ctx, db, "PostStatusUpdateByIDs", "UPDATE "+store.postTableName+" SET "+
+		COLUMN_TRASHED_AT+" = CASE WHEN "+COLUMN_STATUS+" = ? THEN "+COLUMN_TRASHED_AT+" ELSE ? END, "+
+		COLUMN_STATUS+" = ?, "+COLUMN_UPDATED_AT+" = ? WHERE "+COLUMN_ID+" IN ("+inPlaceholders(len(postIDs))+")",
+		append([]any{status, store.postTrashedAtValue(status), status, now.StdTime()}, lo.ToAnySlice(postIDs)...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, after := range afters {
+		if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, after.GetID(), after); err != nil {
+			return int(affected), err
+		}
+		if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, after.GetID(), action, posts[i], after); err != nil {
+			return int(affected), err
+		}
+		if err := store.statusHistoryRecord(ctx, after.GetID(), posts[i].GetStatus(), status); err != nil {
+			return int(affected), err
+		}
+		if err := store.eventPublish(ctx, posts[i], after); err != nil {
+			return int(affected), err
+		}
+		if err := store.hookRun(ctx, hookAfterPostUpdate, after); err != nil {
+			return int(affected), err
+		}
+	}
+
+	return int(affected), nil
+}
+
+// inPlaceholders returns the placeholders of an IN clause of n values,
+// e.g. "?, ?, ?".
+func inPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// PostDeleteByIDs permanently removes the posts with the given IDs in a
+// single statement and returns how many were deleted. Every post is
+// authorized first; unknown IDs are skipped.
+func (store *storeImplementation) PostDeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var befores []PostInterface
+	if store.auditEnabled || store.authorizer != nil || store.hooks != nil || store.eventPublisher != nil {
+		posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{IDIn: ids, WithDeleted: true})
+		if err != nil {
+			return 0, err
+		}
+		befores = posts
+	}
+
+	for _, before := range befores {
+		if err := store.authorize(ctx, ACTION_DELETE, before); err != nil {
+			return 0, err
+		}
+		if err := store.hookRun(ctx, hookBeforePostDelete, before); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := store.versioningDeleteSnapshot(ctx, ids, befores); err != nil {
+		return 0, err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := store.execContext(ctx, db, "PostDeleteByIDs", "DELETE FROM "+store.postTableName+" WHERE "+COLUMN_ID+" IN ("+inPlaceholders(len(ids))+")",
+		lo.ToAnySlice(ids)...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := store.blobContentDelete(ctx, id); err != nil {
+			return int(affected), err
+		}
+	}
+
+	for _, before := range befores {
+		if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, before.GetID(), ACTION_DELETE, before, nil); err != nil {
+			return int(affected), err
+		}
+		if err := store.eventPublish(ctx, before, nil); err != nil {
+			return int(affected), err
+		}
+		if err := store.hookRun(ctx, hookAfterPostDelete, before); err != nil {
+			return int(affected), err
+		}
+	}
+
+	return int(affected), nil
+}
+
+// PostSoftDeleteByIDs marks the posts with the given IDs as deleted in a
+// single statement and returns how many were marked. Every post is
+// authorized first; unknown IDs and posts already soft deleted are skipped.
+// The marked posts are versioned, audited and, when archiving is enabled,
+// moved to the archive table like PostSoftDelete. The update hooks are
+// called for each post, but changes made by the before hooks are not saved.
+func (store *storeImplementation) PostSoftDeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{IDIn: ids})
+	if err != nil {
+		return 0, err
+	}
+	if len(posts) == 0 {
+		return 0, nil
+	}
+
+	now := store.now()
+	afters := make([]PostInterface, len(posts))
+
+	for i, before := range posts {
+		if err := store.authorize(ctx, ACTION_SOFT_DELETE, before); err != nil {
+			return 0, err
+		}
+
+		afters[i] = NewPostFromExistingData(before.GetData())
+		afters[i].SetSoftDeletedAt(now.ToDateTimeString(carbon.UTC))
+		afters[i].SetUpdatedAt(now.ToDateTimeString())
+
+		if err := store.hookRun(ctx, hookBeforePostUpdate, afters[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	postIDs := lo.Map(posts, func(post PostInterface, _ int) string { return post.GetID() })
+
+	db, err := store.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := store.execContext(ctx, db, "PostSoftDeleteByIDs", "UPDATE "+store.postTableName+" SET "+COLUMN_SOFT_DELETED_AT+" = ?, "+COLUMN_UPDATED_AT+" = ? WHERE "+COLUMN_ID+" IN ("+inPlaceholders(len(postIDs))+") AND "+COLUMN_SOFT_DELETED_AT+" > ?",
+		append(append([]any{now.StdTime(), now.StdTime()}, lo.ToAnySlice(postIDs)...), now.StdTime())...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, after := range afters {
+		if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, after.GetID(), after); err != nil {
+			return int(affected), err
+		}
+		if err := store.auditRecord(ctx, AUDIT_ENTITY_POST, after.GetID(), ACTION_SOFT_DELETE, posts[i], after); err != nil {
+			return int(affected), err
+		}
+		if err := store.eventPublish(ctx, posts[i], after); err != nil {
+			return int(affected), err
+		}
+		if err := store.hookRun(ctx, hookAfterPostUpdate, after); err != nil {
+			return int(affected), err
+		}
+		if store.archiveEnabled {
+			if err := store.postMoveToArchive(ctx, after.GetID()); err != nil {
+				return int(affected), err
+			}
+		}
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,256 @@
+package blogstore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestStorePostCreateMany(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Imported")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// more rows than fit in one SQLite statement, with colliding titles
+	posts := []PostInterface{}
+	for i := range 120 {
+		title := "Post " + strconv.Itoa(i)
+		if i%40 == 0 {
+			title = "Imported"
+		}
+		posts = append(posts, NewPost().SetID("bulk-"+strconv.Itoa(i)).SetTitle(title).SetStatus(POST_STATUS_PUBLISHED))
+	}
+
+	if err := store.PostCreateMany(ctx, posts); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 121 {
+		t.Fatalf("PostCount() = %d, want 121", count)
+	}
+
+	slugs := map[string]bool{}
+	for _, post := range posts {
+		if slugs[post.GetSlug()] {
+			t.Fatalf("slug %q is used twice", post.GetSlug())
+		}
+		slugs[post.GetSlug()] = true
+	}
+	for _, slug := range []string{"imported-2", "imported-3", "imported-4"} {
+		if !slugs[slug] {
+			t.Fatalf("expected slug %q among %d created posts", slug, len(posts))
+		}
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID("bulk-119"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("got %d versions of the last post, want 1", len(versions))
+	}
+}
+
+func TestStorePostCreateManyIsAtomic(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	// the duplicate ID fails the insert, after the first rows were written
+	posts := []PostInterface{
+		NewPost().SetID("same").SetTitle("First"),
+		NewPost().SetID("other").SetTitle("Second"),
+		NewPost().SetID("same").SetTitle("Third"),
+	}
+	if err := store.PostCreateMany(ctx, posts); err == nil {
+		t.Fatal("expected an error for a duplicate ID")
+	}
+
+	if err := store.PostCreateMany(ctx, []PostInterface{NewPost().SetStatus("bogus")}); err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatalf("PostCount() = %d, want 0 after failed batches", count)
+	}
+}
+
+func TestStorePostStatusUpdateByIDs(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	ids := []string{}
+	for i := range 3 {
+		post := NewPost().SetTitle("Draft " + strconv.Itoa(i))
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		ids = append(ids, post.GetID())
+	}
+
+	if _, err := store.PostStatusUpdateByIDs(ctx, ids, "bogus"); err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+
+	updated, err := store.PostStatusUpdateByIDs(ctx, append(ids[:2:2], "missing"), POST_STATUS_PUBLISHED)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if updated != 2 {
+		t.Fatalf("updated = %d, want 2", updated)
+	}
+
+	published, err := store.PostCount(ctx, PostQueryOptions{Status: POST_STATUS_PUBLISHED})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if published != 2 {
+		t.Fatalf("published = %d, want 2", published)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(ids[0]))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d versions, want 2 (create and status change)", len(versions))
+	}
+}
+
+func TestStorePostDeleteByIDs(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	ids := []string{}
+	for i := range 3 {
+		post := NewPost().SetTitle("Post " + strconv.Itoa(i))
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		ids = append(ids, post.GetID())
+	}
+
+	deleted, err := store.PostDeleteByIDs(ctx, append(ids[:2:2], "missing"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("deleted = %d, want 2", deleted)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("count = %d, want 1", count)
+	}
+
+	deleted, err = store.PostDeleteByIDs(ctx, nil)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if deleted != 0 {
+		t.Fatalf("deleted = %d, want 0", deleted)
+	}
+}
+
+func TestStorePostSoftDeleteByIDs(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	ids := []string{}
+	for i := range 3 {
+		post := NewPost().SetTitle("Post " + strconv.Itoa(i))
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		ids = append(ids, post.GetID())
+	}
+
+	deleted, err := store.PostSoftDeleteByIDs(ctx, append(ids[:2:2], "missing"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("deleted = %d, want 2", deleted)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("count = %d, want 1", count)
+	}
+
+	count, err = store.PostCount(ctx, PostQueryOptions{WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 3 {
+		t.Fatalf("count with deleted = %d, want 3", count)
+	}
+
+	deleted, err = store.PostSoftDeleteByIDs(ctx, ids[:2])
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if deleted != 0 {
+		t.Fatalf("deleted again = %d, want 0", deleted)
+	}
+}
@@ -0,0 +1,173 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostListWithoutContentAndLoadContent(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Lazy").SetContent("Heavy content").SetSummary("Summary")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{WithoutContent: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 {
+		t.Fatal("expected 1 post, found:", len(list))
+	}
+	if list[0].GetContent() != "" {
+		t.Fatal("expected content to be omitted, found:", list[0].GetContent())
+	}
+	if list[0].GetTitle() != "Lazy" || list[0].GetSummary() != "Summary" {
+		t.Fatal("expected other columns to be loaded")
+	}
+
+	if err := store.PostLoadContent(ctx, list[0]); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if list[0].GetContent() != "Heavy content" {
+		t.Fatal("expected content to be loaded, found:", list[0].GetContent())
+	}
+
+	if err := store.PostLoadContent(ctx, NewPost()); err == nil {
+		t.Fatal("expected error for missing post")
+	}
+}
+
+func TestStorePostUpdateKeepsContentNotLoaded(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Lazy").SetContent("Heavy content")
+	other := NewPost().SetTitle("Rewritten").SetContent("Old content")
+	for _, p := range []PostInterface{post, other} {
+		if err := store.PostCreate(ctx, p); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{WithoutContent: true, OrderBy: COLUMN_TITLE, SortOrder: SORT_ORDER_ASC})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(list))
+	}
+
+	// the title changes, the content that was not loaded stays
+	list[0].SetTitle("Lazy edited")
+	// content set after the listing is saved
+	list[1].SetContent("New content")
+	for _, p := range list {
+		if err := store.PostUpdate(ctx, p); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	reloaded, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if reloaded.GetTitle() != "Lazy edited" || reloaded.GetContent() != "Heavy content" {
+		t.Fatalf("reloaded post = %q %q, want the new title and the stored content", reloaded.GetTitle(), reloaded.GetContent())
+	}
+
+	reloaded, err = store.PostFindByID(ctx, other.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if reloaded.GetContent() != "New content" {
+		t.Fatalf("reloaded content = %q, want New content", reloaded.GetContent())
+	}
+}
+
+func TestStorePostListColumns(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Light").SetContent("Heavy content").SetSummary("Summary").SetAuthorID("author-1")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{Columns: []string{COLUMN_TITLE, COLUMN_SUMMARY}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(list))
+	}
+
+	listed := list[0]
+	if listed.GetID() != post.GetID() || listed.GetTitle() != "Light" || listed.GetSummary() != "Summary" {
+		t.Fatalf("listed post = %q %q %q, want the ID, title and summary", listed.GetID(), listed.GetTitle(), listed.GetSummary())
+	}
+	if listed.GetContent() != "" || listed.GetAuthorID() != "" {
+		t.Fatalf("unselected fields = %q %q, want empty", listed.GetContent(), listed.GetAuthorID())
+	}
+
+	// saving the listed post keeps the columns that were not selected
+	listed.SetTitle("Light edited")
+	if err := store.PostUpdate(ctx, listed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	reloaded, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if reloaded == nil {
+		t.Fatal("expected the post to stay active after saving the listed post")
+	}
+	if reloaded.GetTitle() != "Light edited" || reloaded.GetContent() != "Heavy content" || reloaded.GetAuthorID() != "author-1" || reloaded.GetStatus() != post.GetStatus() {
+		t.Fatalf("reloaded post = %q %q %q %q, want the new title and the stored columns", reloaded.GetTitle(), reloaded.GetContent(), reloaded.GetAuthorID(), reloaded.GetStatus())
+	}
+
+	// a soft deleted post listed with some columns stays deleted when saved
+	if err := store.PostSoftDelete(ctx, reloaded); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	list, err = store.PostList(ctx, PostQueryOptions{Columns: []string{COLUMN_TITLE}, WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, list[0].SetTitle("Still deleted")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, err := store.PostFindByID(ctx, post.GetID()); err != nil || found != nil {
+		t.Fatalf("PostFindByID() = %v, %v, want the post to stay soft deleted", found, err)
+	}
+
+	if _, err := store.PostList(ctx, PostQueryOptions{Columns: []string{"title; DROP TABLE blog_posts"}}); err == nil {
+		t.Fatal("expected an error for an unknown column")
+	}
+}
@@ -0,0 +1,172 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// postLoadRelations eager loads the requested relations for the given posts.
+// Each relation is loaded with a fixed number of batched queries, independent
+// of the number of posts, to avoid N+1 query patterns on listing pages.
+func (store *storeImplementation) postLoadRelations(ctx context.Context, posts []PostInterface, with []string) error {
+	if len(posts) == 0 || len(with) == 0 {
+		return nil
+	}
+
+	taxonomySlugs := []string{}
+
+	for _, relation := range lo.Uniq(with) {
+		switch relation {
+		case POST_WITH_CATEGORIES:
+			taxonomySlugs = append(taxonomySlugs, TAXONOMY_CATEGORY)
+		case POST_WITH_TAGS:
+			taxonomySlugs = append(taxonomySlugs, TAXONOMY_TAG)
+		case POST_WITH_MEDIA:
+			if err := store.postLoadMedia(ctx, posts); err != nil {
+				return err
+			}
+		case POST_WITH_AUTHOR:
+			if err := store.postLoadAuthors(ctx, posts); err != nil {
+				return err
+			}
+		default:
+			return errors.New("blogstore: unsupported relation: " + relation)
+		}
+	}
+
+	if len(taxonomySlugs) > 0 {
+		return store.postLoadTerms(ctx, posts, taxonomySlugs)
+	}
+
+	return nil
+}
+
+// postLoadTerms loads the terms of the given taxonomies for all posts
+// using one query for the relations, one for the terms and one per taxonomy.
+func (store *storeImplementation) postLoadTerms(ctx context.Context, posts []PostInterface, taxonomySlugs []string) error {
+	if !store.taxonomyEnabled {
+		return errors.New("taxonomy is not enabled")
+	}
+
+	postIDs := lo.Map(posts, func(post PostInterface, _ int) string { return post.GetID() })
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(postIDs)), ", ")
+	args := lo.Map(postIDs, func(id string, _ int) any { return id })
+
+	rows, err := store.queryContext(ctx, db, "postLoadTerms", "SELECT post_id, term_id FROM "+store.termRelationTableName+" WHERE post_id IN ("+placeholders+") ORDER BY sequence ASC", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	postTermIDs := map[string][]string{}
+	termIDs := []string{}
+	for rows.Next() {
+		var postID, termID string
+		if err := rows.Scan(&postID, &termID); err != nil {
+			return err
+		}
+		postTermIDs[postID] = append(postTermIDs[postID], termID)
+		termIDs = append(termIDs, termID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	termsByID := map[string]TermInterface{}
+	if len(termIDs) > 0 {
+		terms, err := store.TermList(ctx, TermQueryOptions{IDIn: lo.Uniq(termIDs)})
+		if err != nil {
+			return err
+		}
+		for _, term := range terms {
+			termsByID[term.GetID()] = term
+		}
+	}
+
+	for _, taxonomySlug := range taxonomySlugs {
+		taxonomy, err := store.TaxonomyFindBySlug(ctx, taxonomySlug)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			terms := []TermInterface{}
+			for _, termID := range postTermIDs[post.GetID()] {
+				term, ok := termsByID[termID]
+				if ok && taxonomy != nil && term.GetTaxonomyID() == taxonomy.GetID() {
+					terms = append(terms, term)
+				}
+			}
+			post.SetTerms(taxonomySlug, terms)
+		}
+	}
+
+	return nil
+}
+
+// postLoadMedia loads the media of all posts with a single query.
+func (store *storeImplementation) postLoadMedia(ctx context.Context, posts []PostInterface) error {
+	if store.mediaTableName == "" {
+		return errors.New("blogstore: media table name is empty")
+	}
+
+	postIDs := lo.Map(posts, func(post PostInterface, _ int) string { return post.GetID() })
+
+	mediaList, err := store.MediaList(ctx, MediaQueryOptions{
+		EntityIDIn: postIDs,
+		OrderBy:    COLUMN_SEQUENCE,
+		SortOrder:  "ASC",
+	})
+	if err != nil {
+		return err
+	}
+
+	mediaByPostID := lo.GroupBy(mediaList, func(media MediaInterface) string { return media.GetEntityID() })
+
+	for _, post := range posts {
+		media := mediaByPostID[post.GetID()]
+		if media == nil {
+			media = []MediaInterface{}
+		}
+		post.SetMedia(media)
+	}
+
+	return nil
+}
+
+// postLoadAuthors loads the author profiles of all posts with a single query.
+func (store *storeImplementation) postLoadAuthors(ctx context.Context, posts []PostInterface) error {
+	if !store.authorsEnabled {
+		return errors.New("authors are not enabled")
+	}
+
+	authorIDs := lo.Uniq(lo.Compact(lo.Map(posts, func(post PostInterface, _ int) string { return post.GetAuthorID() })))
+	if len(authorIDs) == 0 {
+		return nil
+	}
+
+	authors, err := store.AuthorList(ctx, AuthorQueryOptions{IDIn: authorIDs})
+	if err != nil {
+		return err
+	}
+
+	authorsByID := map[string]*Author{}
+	for i := range authors {
+		authorsByID[authors[i].ID] = &authors[i]
+	}
+
+	for _, post := range posts {
+		post.SetAuthor(authorsByID[post.GetAuthorID()])
+	}
+
+	return nil
+}
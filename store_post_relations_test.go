@@ -0,0 +1,120 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostListWithRelations(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TaxonomyEnabled:    true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	category := NewTaxonomy().SetName("Categories").SetSlug(TAXONOMY_CATEGORY)
+	tag := NewTaxonomy().SetName("Tags").SetSlug(TAXONOMY_TAG)
+	for _, taxonomy := range []TaxonomyInterface{category, tag} {
+		if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	news := NewTerm().SetTaxonomyID(category.GetID()).SetName("News").SetSlug("news")
+	golang := NewTerm().SetTaxonomyID(tag.GetID()).SetName("Go").SetSlug("go")
+	for _, term := range []TermInterface{news, golang} {
+		if err := store.TermCreate(ctx, term); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	post1 := NewPost().SetTitle("Post 1")
+	post2 := NewPost().SetTitle("Post 2")
+	for _, post := range []PostInterface{post1, post2} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.PostAddTerm(ctx, post1.GetID(), news.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostAddTerm(ctx, post1.GetID(), golang.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	media := NewMedia().SetEntityID(post2.GetID()).SetTitle("Cover").SetURL("https://example.com/cover.png")
+	if err := store.MediaCreate(ctx, media); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{
+		OrderBy:   COLUMN_TITLE,
+		SortOrder: "ASC",
+		With:      []string{POST_WITH_CATEGORIES, POST_WITH_TAGS, POST_WITH_MEDIA},
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatal("expected 2 posts, found:", len(list))
+	}
+
+	if len(list[0].Categories()) != 1 || list[0].Categories()[0].GetID() != news.GetID() {
+		t.Fatal("expected post 1 to have the news category, found:", list[0].Categories())
+	}
+
+	if len(list[0].Tags()) != 1 || list[0].Tags()[0].GetID() != golang.GetID() {
+		t.Fatal("expected post 1 to have the go tag, found:", list[0].Tags())
+	}
+
+	if len(list[0].Media()) != 0 {
+		t.Fatal("expected post 1 to have no media, found:", len(list[0].Media()))
+	}
+
+	if list[1].Categories() == nil || len(list[1].Categories()) != 0 {
+		t.Fatal("expected post 2 to have loaded, empty categories")
+	}
+
+	if len(list[1].Media()) != 1 || list[1].Media()[0].GetID() != media.GetID() {
+		t.Fatal("expected post 2 to have the cover media")
+	}
+}
+
+func TestStorePostListWithUnsupportedRelation(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Post")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = store.PostList(ctx, PostQueryOptions{With: []string{"unknown"}})
+	if err == nil {
+		t.Fatal("expected error for unsupported relation")
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if list[0].Categories() != nil {
+		t.Fatal("expected relations not to be loaded without With")
+	}
+}
@@ -0,0 +1,33 @@
+package blogstore
+
+import (
+	"errors"
+	"slices"
+)
+
+// postStatusesBuiltIn returns the post statuses known to the store.
+func postStatusesBuiltIn() []string {
+	return []string{
+		POST_STATUS_DRAFT,
+		POST_STATUS_PUBLISHED,
+		POST_STATUS_UNPUBLISHED,
+		POST_STATUS_TRASH,
+		POST_STATUS_PENDING_REVIEW,
+	}
+}
+
+// PostStatuses returns the post statuses accepted by the store, the built in
+// ones followed by those registered with NewStoreOptions.PostStatuses.
+func (store *storeImplementation) PostStatuses() []string {
+	return slices.Clone(store.postStatuses)
+}
+
+// postStatusValidate returns an error unless the status is built in or was
+// registered with NewStoreOptions.PostStatuses, so a typo does not persist
+// a post no status filter will find.
+func (store *storeImplementation) postStatusValidate(status string) error {
+	if !slices.Contains(store.postStatuses, status) {
+		return errors.New("blogstore: invalid post status: " + status)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package blogstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPostStatusValidation(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	err = store.PostCreate(ctx, NewPost().SetTitle("Typo").SetStatus("pubilshed"))
+	if err == nil || !strings.Contains(err.Error(), "invalid post status") {
+		t.Fatal("expected invalid post status error, found:", err)
+	}
+
+	post := NewPost().SetTitle("Valid").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetStatus("archived")
+	err = store.PostUpdate(ctx, post)
+	if err == nil || !strings.Contains(err.Error(), "invalid post status") {
+		t.Fatal("expected invalid post status error, found:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetStatus() != POST_STATUS_PUBLISHED {
+		t.Fatal("expected status to remain published, found:", found.GetStatus())
+	}
+}
+
+func TestPostStatusCustom(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		PostStatuses:       []string{"scheduled"},
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Scheduled").SetStatus("scheduled")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,200 @@
+package blogstore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// ErrPreviewTokenInvalid is returned by PostPreviewTokenValidate for unknown,
+// revoked and expired tokens, and for tokens of posts that no longer exist.
+var ErrPreviewTokenInvalid = errors.New("blogstore: preview token is invalid or expired")
+
+// PreviewEnabled returns true if preview tokens can be created.
+func (store *storeImplementation) PreviewEnabled() bool {
+	return store.previewEnabled
+}
+
+// GetPreviewTableName returns the preview token table name
+func (store *storeImplementation) GetPreviewTableName() string {
+	return store.previewTableName
+}
+
+// PostPreviewTokenCreate creates a token granting read access to the post
+// until the TTL elapses, whatever its status, e.g. for sharing a draft with
+// reviewers without an account. Only a hash of the token is stored, so the
+// returned token must be handed out right away.
+func (store *storeImplementation) PostPreviewTokenCreate(ctx context.Context, postID string, ttl time.Duration) (string, error) {
+	if ctx == nil {
+		return "", errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.previewEnabled {
+		return "", errors.New("preview is not enabled")
+	}
+	if postID == "" {
+		return "", errors.New("post id is empty")
+	}
+	if ttl <= 0 {
+		return "", errors.New("preview token ttl must be positive")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, WithoutContent: true, Limit: 1})
+	if err != nil {
+		return "", err
+	}
+	if len(list) == 0 {
+		return "", errors.New("post not found")
+	}
+
+	if err := store.authorize(ctx, ACTION_PREVIEW, list[0]); err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(secret)
+
+	db, err := store.db.DB()
+	if err != nil {
+		return "", err
+	}
+
+	now := store.now()
+	_, err = store.execContext(ctx, db, "PostPreviewTokenCreate", "INSERT INTO "+store.previewTableName+" (id, post_id, expires_at, created_at) VALUES (?, ?, ?, ?)",
+		previewTokenHash(token),
+		postID,
+		now.StdTime().Add(ttl),
+		now.StdTime(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// PostPreviewTokenValidate returns the post the token grants access to,
+// or ErrPreviewTokenInvalid if the token is unknown, revoked or expired.
+func (store *storeImplementation) PostPreviewTokenValidate(ctx context.Context, token string) (PostInterface, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.previewEnabled {
+		return nil, errors.New("preview is not enabled")
+	}
+	if token == "" {
+		return nil, ErrPreviewTokenInvalid
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	var postID string
+	var expiresAt time.Time
+	err = store.queryRowContext(ctx, db, "PostPreviewTokenValidate", "SELECT post_id, expires_at FROM "+store.previewTableName+" WHERE "+COLUMN_ID+" = ?",
+		[]any{previewTokenHash(token)}, &postID, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPreviewTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.After(store.now().StdTime()) {
+		return nil, ErrPreviewTokenInvalid
+	}
+
+	// The token is the permission, so the authorizer is not asked
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, ErrPreviewTokenInvalid
+	}
+
+	return list[0], nil
+}
+
+// PostPreviewTokenRevoke invalidates a preview token.
+// Revoking an unknown token is not an error.
+func (store *storeImplementation) PostPreviewTokenRevoke(ctx context.Context, token string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.previewEnabled {
+		return errors.New("preview is not enabled")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostPreviewTokenRevoke", "DELETE FROM "+store.previewTableName+" WHERE "+COLUMN_ID+" = ?", previewTokenHash(token))
+	return err
+}
+
+// PostPreviewTokenRevokeAll invalidates all preview tokens of a post,
+// e.g. once it is published.
+func (store *storeImplementation) PostPreviewTokenRevokeAll(ctx context.Context, postID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.previewEnabled {
+		return errors.New("preview is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostPreviewTokenRevokeAll", "DELETE FROM "+store.previewTableName+" WHERE "+COLUMN_POST_ID+" = ?", postID)
+	return err
+}
+
+// previewTableBlueprint defines the preview token table.
+func previewTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 64)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_POST_ID, 40)
+	table.DateTime(COLUMN_EXPIRES_AT)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.Index(COLUMN_POST_ID)
+}
+
+// previewTokenHash returns the SHA-256 digest of a preview token,
+// which is what the preview table stores.
+func previewTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func initPreviewStore(t *testing.T, now *time.Time) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		PreviewEnabled:     true,
+		PreviewTableName:   "blog_preview",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Now:                func() time.Time { return *now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStorePreviewRequiresTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:  "blog_posts",
+		PreviewEnabled: true,
+		DB:             initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error when PreviewTableName is missing")
+	}
+}
+
+func TestStorePostPreviewToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := initPreviewStore(t, &now)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Draft for review").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	token, err := store.PostPreviewTokenCreate(ctx, post.GetID(), time.Hour)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(token) != 64 {
+		t.Fatalf("token = %q, want 64 hex characters", token)
+	}
+
+	previewed, err := store.PostPreviewTokenValidate(ctx, token)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if previewed.GetID() != post.GetID() {
+		t.Fatalf("previewed post %q, want %q", previewed.GetID(), post.GetID())
+	}
+
+	if _, err := store.PostPreviewTokenValidate(ctx, token+"x"); !errors.Is(err, ErrPreviewTokenInvalid) {
+		t.Fatalf("expected ErrPreviewTokenInvalid for an unknown token, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := store.PostPreviewTokenValidate(ctx, token); !errors.Is(err, ErrPreviewTokenInvalid) {
+		t.Fatalf("expected ErrPreviewTokenInvalid for an expired token, got %v", err)
+	}
+}
+
+func TestStorePostPreviewTokenRevoke(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := initPreviewStore(t, &now)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Draft")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	first, _ := store.PostPreviewTokenCreate(ctx, post.GetID(), time.Hour)
+	second, _ := store.PostPreviewTokenCreate(ctx, post.GetID(), time.Hour)
+	third, _ := store.PostPreviewTokenCreate(ctx, post.GetID(), time.Hour)
+
+	if err := store.PostPreviewTokenRevoke(ctx, first); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostPreviewTokenValidate(ctx, first); !errors.Is(err, ErrPreviewTokenInvalid) {
+		t.Fatalf("expected the revoked token to be invalid, got %v", err)
+	}
+	if _, err := store.PostPreviewTokenValidate(ctx, second); err != nil {
+		t.Fatal("expected the other tokens to remain valid, got:", err)
+	}
+
+	if err := store.PostPreviewTokenRevokeAll(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, token := range []string{second, third} {
+		if _, err := store.PostPreviewTokenValidate(ctx, token); !errors.Is(err, ErrPreviewTokenInvalid) {
+			t.Fatalf("expected all tokens of the post to be revoked, got %v", err)
+		}
+	}
+}
+
+func TestStorePostPreviewTokenAuthorizer(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		PreviewEnabled:     true,
+		PreviewTableName:   "blog_preview",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Authorizer: AuthorizerFunc(func(ctx context.Context, action string, post PostInterface) error {
+			if action == ACTION_PREVIEW && ActorFromContext(ctx) != "editor" {
+				return ErrNotAuthorized
+			}
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post := NewPost().SetTitle("Draft")
+	if err := store.PostCreate(context.Background(), post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostPreviewTokenCreate(context.Background(), post.GetID(), time.Hour); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatalf("expected ErrNotAuthorized, got %v", err)
+	}
+
+	token, err := store.PostPreviewTokenCreate(ContextWithActor(context.Background(), "editor"), post.GetID(), time.Hour)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostPreviewTokenValidate(context.Background(), token); err != nil {
+		t.Fatal("expected anyone holding the token to preview, got:", err)
+	}
+}
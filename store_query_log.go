@@ -0,0 +1,163 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// logSlowQuery logs the query when the time elapsed since start exceeds the
+// configured SlowQueryThreshold. The SQL is built lazily by the sqlFn callback,
+// so there is no overhead for fast queries or when the threshold is disabled.
+func (st *storeImplementation) logSlowQuery(ctx context.Context, operation string, start time.Time, sqlFn func() (string, []any)) {
+	if st.slowQueryThreshold <= 0 {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < st.slowQueryThreshold {
+		return
+	}
+
+	query, args := sqlFn()
+
+	st.getLogger().WarnContext(ctx, "blogstore: slow query",
+		slog.String("operation", operation),
+		slog.Duration("duration", elapsed),
+		slog.Duration("threshold", st.slowQueryThreshold),
+		slog.String("sql", query),
+		slog.Any("args", args),
+	)
+}
+
+// explainQuery runs EXPLAIN for the query and logs the plan, when debug mode
+// and ExplainEnabled are both on. It must run before the query itself, so it
+// does not compete with open rows for a connection. Errors are logged, never returned.
+func (st *storeImplementation) explainQuery(ctx context.Context, executor sqlExecutor, operation string, sqlFn func() (string, []any)) {
+	if !st.explainEnabled || !st.debugEnabled.Load() {
+		return
+	}
+
+	prefix := st.explainPrefix()
+	if prefix == "" {
+		return
+	}
+
+	query, args := sqlFn()
+
+	statement := strings.ToUpper(strings.TrimSpace(query))
+	if !lo.SomeBy([]string{"SELECT", "INSERT", "UPDATE", "DELETE", "WITH"}, func(keyword string) bool {
+		return strings.HasPrefix(statement, keyword)
+	}) {
+		return
+	}
+
+	if executor == nil {
+		db, err := st.db.DB()
+		if err != nil {
+			return
+		}
+		executor = db
+	}
+
+	plan, err := st.explainPlan(ctx, executor, prefix+query, args)
+	if err != nil {
+		st.getLogger().WarnContext(ctx, "blogstore: explain failed",
+			slog.String("operation", operation),
+			slog.String("sql", query),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	st.getLogger().InfoContext(ctx, "blogstore: query plan",
+		slog.String("operation", operation),
+		slog.String("sql", query),
+		slog.Any("args", args),
+		slog.String("plan", plan),
+	)
+}
+
+// explainPlan runs the EXPLAIN statement and returns its rows, one line per row
+// with the columns separated by spaces, as the columns differ between databases.
+func (st *storeImplementation) explainPlan(ctx context.Context, executor sqlExecutor, query string, args []any) (string, error) {
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return "", err
+		}
+		lines = append(lines, strings.Join(lo.Map(values, func(v sql.NullString, _ int) string { return v.String }), " "))
+	}
+
+	return strings.Join(lines, "\n"), rows.Err()
+}
+
+// sqlExecutor is implemented by both *sql.DB and *sql.Tx.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// execContext executes a raw SQL statement, explaining it in debug mode and
+// reporting it if it is slow.
+func (st *storeImplementation) execContext(ctx context.Context, db sqlExecutor, operation string, query string, args ...any) (sql.Result, error) {
+	query = st.rebind(query)
+	sqlFn := func() (string, []any) { return query, args }
+	st.explainQuery(ctx, db, operation, sqlFn)
+
+	start := time.Now()
+	result, err := db.ExecContext(ctx, query, args...)
+	st.logSlowQuery(ctx, operation, start, sqlFn)
+	return result, err
+}
+
+// queryContext executes a raw SQL query and reports it if it is slow.
+func (st *storeImplementation) queryContext(ctx context.Context, db sqlExecutor, operation string, query string, args ...any) (*sql.Rows, error) {
+	query = st.rebind(query)
+	sqlFn := func() (string, []any) { return query, args }
+	st.explainQuery(ctx, db, operation, sqlFn)
+
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query, args...)
+	st.logSlowQuery(ctx, operation, start, sqlFn)
+	return rows, err
+}
+
+// queryRowContext executes a raw SQL query expected to return a single row and
+// scans it into dest, reporting the query if it is slow.
+func (st *storeImplementation) queryRowContext(ctx context.Context, db sqlExecutor, operation string, query string, args []any, dest ...any) error {
+	query = st.rebind(query)
+	sqlFn := func() (string, []any) { return query, args }
+	st.explainQuery(ctx, db, operation, sqlFn)
+
+	start := time.Now()
+	err := db.QueryRowContext(ctx, query, args...).Scan(dest...)
+	st.logSlowQuery(ctx, operation, start, sqlFn)
+	return err
+}
+
+// rawSQL adapts a neat raw SQL builder (with interpolated values) to logSlowQuery and explainQuery.
+func rawSQL(build func() string) func() (string, []any) {
+	return func() (string, []any) { return build(), nil }
+}
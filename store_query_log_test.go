@@ -0,0 +1,178 @@
+package blogstore
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreSlowQueryLogging(t *testing.T) {
+	var buf bytes.Buffer
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts_slow",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Logger:             slog.New(slog.NewTextHandler(&buf, nil)),
+		SlowQueryThreshold: time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Slow post")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(ctx, PostQueryOptions{Status: POST_STATUS_DRAFT}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	output := buf.String()
+
+	if !strings.Contains(output, "blogstore: slow query") {
+		t.Fatal("expected slow query message, found:", output)
+	}
+
+	if !strings.Contains(output, "operation=PostCreate") || !strings.Contains(output, "INSERT INTO blog_posts_slow") {
+		t.Fatal("expected PostCreate SQL to be logged, found:", output)
+	}
+
+	if !strings.Contains(output, "operation=PostList") || !strings.Contains(output, POST_STATUS_DRAFT) {
+		t.Fatal("expected PostList SQL with its parameters to be logged, found:", output)
+	}
+}
+
+func TestStoreSlowQueryLoggingDisabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts_slow",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Logger:             slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(context.Background(), PostQueryOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Fatal("expected no log output when threshold is zero, found:", buf.String())
+	}
+}
+
+func TestStoreExplainLogging(t *testing.T) {
+	var buf bytes.Buffer
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts_explain",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		DebugEnabled:       true,
+		ExplainEnabled:     true,
+		Logger:             slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Explained post")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(ctx, PostQueryOptions{Status: POST_STATUS_DRAFT}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostCount(ctx, PostQueryOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	output := buf.String()
+
+	if !strings.Contains(output, "blogstore: query plan") {
+		t.Fatal("expected query plan message, found:", output)
+	}
+
+	if !strings.Contains(output, "operation=PostList") || !strings.Contains(output, "blog_posts_explain") {
+		t.Fatal("expected PostList plan to be logged, found:", output)
+	}
+
+	if !strings.Contains(output, "operation=PostCount") {
+		t.Fatal("expected PostCount plan to be logged, found:", output)
+	}
+
+	if strings.Contains(output, "blogstore: explain failed") {
+		t.Fatal("expected explain to succeed, found:", output)
+	}
+}
+
+func TestStoreExplainLoggingRequiresDebug(t *testing.T) {
+	var buf bytes.Buffer
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts_explain",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		ExplainEnabled:     true,
+		Logger:             slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(context.Background(), PostQueryOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if strings.Contains(buf.String(), "blogstore: query plan") {
+		t.Fatal("expected no query plan without debug mode, found:", buf.String())
+	}
+}
+
+func TestStoreExplainLoggingReadReplica(t *testing.T) {
+	var buf bytes.Buffer
+
+	// only the replica has the table, so explaining on the primary would fail
+	replica := initDB()
+	if _, err := NewStore(NewStoreOptions{PostTableName: "blog_posts_explain", DB: replica, AutomigrateEnabled: true}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:  "blog_posts_explain",
+		DB:             initDB(),
+		ReadDB:         replica,
+		DebugEnabled:   true,
+		ExplainEnabled: true,
+		Logger:         slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostList(context.Background(), PostQueryOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	output := buf.String()
+
+	if strings.Contains(output, "blogstore: explain failed") {
+		t.Fatal("expected the plan to be explained on the replica, found:", output)
+	}
+
+	if !strings.Contains(output, "operation=PostList") {
+		t.Fatal("expected PostList plan to be logged, found:", output)
+	}
+}
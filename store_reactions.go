@@ -0,0 +1,130 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// reactionMaxLength is the longest reaction type the reactions table holds.
+const reactionMaxLength = 40
+
+// ReactionsEnabled returns true if post reactions are counted in the reactions table.
+func (store *storeImplementation) ReactionsEnabled() bool {
+	return store.reactionsEnabled
+}
+
+// GetReactionsTableName returns the reactions table name
+func (store *storeImplementation) GetReactionsTableName() string {
+	return store.reactionsTableName
+}
+
+// PostReactionIncrement counts a reaction to the post, e.g. "like" or
+// "clap". Reactions are aggregated into one counter per post and reaction
+// type; visitors are not tracked, so the application must prevent repeated
+// reactions if needed.
+func (store *storeImplementation) PostReactionIncrement(ctx context.Context, postID string, reaction string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.reactionsEnabled {
+		return errors.New("reactions are not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+	if strings.TrimSpace(reaction) == "" {
+		return errors.New("reaction is empty")
+	}
+	if len(reaction) > reactionMaxLength {
+		return errors.New("reaction is too long")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	increment := func() (bool, error) {
+		result, err := store.execContext(ctx, db, "PostReactionIncrement", "UPDATE "+store.reactionsTableName+" SET "+COLUMN_COUNT+" = "+COLUMN_COUNT+" + 1 WHERE "+COLUMN_POST_ID+" = ? AND "+COLUMN_REACTION+" = ?",
+			postID, reaction)
+		if err != nil {
+			return false, err
+		}
+		affected, err := result.RowsAffected()
+		return affected > 0, err
+	}
+
+	if counted, err := increment(); counted || err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostReactionIncrement", "INSERT INTO "+store.reactionsTableName+" (post_id, reaction, count) VALUES (?, ?, 1)",
+		postID, reaction)
+	if err == nil {
+		return nil
+	}
+
+	// A concurrent reaction inserted the row first
+	if counted, retryErr := increment(); counted || retryErr != nil {
+		return retryErr
+	}
+	return err
+}
+
+// PostReactionsSummary returns the number of reactions to the post per
+// reaction type. Reaction types without reactions are absent from the map.
+func (store *storeImplementation) PostReactionsSummary(ctx context.Context, postID string) (map[string]int64, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.reactionsEnabled {
+		return nil, errors.New("reactions are not enabled")
+	}
+	if postID == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	db, err := store.reader(ctx).DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostReactionsSummary", "SELECT "+COLUMN_REACTION+", "+COLUMN_COUNT+" FROM "+store.reactionsTableName+" WHERE "+COLUMN_POST_ID+" = ? AND "+COLUMN_COUNT+" > 0", postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := map[string]int64{}
+	for rows.Next() {
+		var reaction sql.NullString
+		var count int64
+		if err := rows.Scan(&reaction, &count); err != nil {
+			return nil, err
+		}
+		summary[reaction.String] = count
+	}
+
+	return summary, rows.Err()
+}
+
+// reactionsTableBlueprint defines the reactions table, holding one counter
+// per post and reaction type.
+func reactionsTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_POST_ID, 40)
+	table.String(COLUMN_REACTION, reactionMaxLength)
+	table.Primary(COLUMN_POST_ID, COLUMN_REACTION)
+	table.BigInteger(COLUMN_COUNT).Default(0)
+}
@@ -0,0 +1,112 @@
+package blogstore
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func initReactionsStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		ReactionsEnabled:   true,
+		ReactionsTableName: "blog_post_reactions",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestNewStoreReactionsRequireTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:    "blog_posts",
+		ReactionsEnabled: true,
+		DB:               initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error for missing ReactionsTableName")
+	}
+}
+
+func TestStorePostReactionIncrement(t *testing.T) {
+	store := initReactionsStore(t)
+	ctx := context.Background()
+
+	reactions := []struct {
+		postID   string
+		reaction string
+		count    int
+	}{
+		{"post-a", "like", 3},
+		{"post-a", "clap", 2},
+		{"post-b", "like", 1},
+	}
+	for _, r := range reactions {
+		for range r.count {
+			if err := store.PostReactionIncrement(ctx, r.postID, r.reaction); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	summary, err := store.PostReactionsSummary(ctx, "post-a")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want := map[string]int64{"like": 3, "clap": 2}
+	if !reflect.DeepEqual(summary, want) {
+		t.Fatalf("PostReactionsSummary() = %v, want %v", summary, want)
+	}
+
+	summary, err = store.PostReactionsSummary(ctx, "post-c")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(summary) != 0 {
+		t.Fatalf("PostReactionsSummary() of post without reactions = %v, want empty", summary)
+	}
+}
+
+func TestStorePostReactionIncrementValidation(t *testing.T) {
+	store := initReactionsStore(t)
+	ctx := context.Background()
+
+	if err := store.PostReactionIncrement(ctx, "", "like"); err == nil {
+		t.Fatal("expected error for empty post id")
+	}
+	if err := store.PostReactionIncrement(ctx, "post-a", " "); err == nil {
+		t.Fatal("expected error for empty reaction")
+	}
+	if err := store.PostReactionIncrement(ctx, "post-a", strings.Repeat("x", 41)); err == nil {
+		t.Fatal("expected error for too long reaction")
+	}
+}
+
+func TestStoreReactionsDisabled(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	if store.ReactionsEnabled() {
+		t.Fatal("ReactionsEnabled() = true, want false")
+	}
+	if err := store.PostReactionIncrement(ctx, "post-a", "like"); err == nil {
+		t.Fatal("expected error when reactions are disabled")
+	}
+	if _, err := store.PostReactionsSummary(ctx, "post-a"); err == nil {
+		t.Fatal("expected error when reactions are disabled")
+	}
+}
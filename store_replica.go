@@ -0,0 +1,17 @@
+package blogstore
+
+import (
+	"context"
+
+	"github.com/dracory/neat"
+)
+
+// reader returns the database the reads of PostList, PostCount and
+// PostFindByID go to: the read replica if one is configured and the context
+// does not force the primary, and the primary otherwise.
+func (store *storeImplementation) reader(ctx context.Context) *neat.Database {
+	if store.readDB == nil || forcePrimaryFromContext(ctx) {
+		return store.db
+	}
+	return store.readDB
+}
@@ -0,0 +1,72 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStoreReadReplica(t *testing.T) {
+	primary := initDB()
+	replica := initDB()
+
+	// the replica has the schema, but has not caught up with the writes
+	if _, err := NewStore(NewStoreOptions{PostTableName: "blog_posts", DB: replica, AutomigrateEnabled: true}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 primary,
+		ReadDB:             replica,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Written to the primary")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found != nil {
+		t.Fatal("Expected PostFindByID to read from the replica")
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatal("Expected PostCount to read from the replica, got:", count)
+	}
+
+	primaryCtx := ContextWithForcePrimary(ctx)
+
+	found, err = store.PostFindByID(primaryCtx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetTitle() != "Written to the primary" {
+		t.Fatal("Expected ContextWithForcePrimary to read from the primary, got:", found)
+	}
+
+	list, err := store.PostList(primaryCtx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 {
+		t.Fatal("Expected 1 post on the primary, got:", len(list))
+	}
+
+	// mutations read from the primary
+	if err := store.PostSoftDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
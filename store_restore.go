@@ -0,0 +1,81 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// PostRestore undoes a soft delete, making the post active again by resetting
+// its soft deleted timestamp. The post is updated in place. Restoring a post
+// that is not soft deleted does nothing.
+func (store *storeImplementation) PostRestore(ctx context.Context, post PostInterface) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if post == nil {
+		return errors.New("post is nil")
+	}
+
+	if post.GetSoftDeletedAtCarbon().StdTime().After(store.now().StdTime()) {
+		return nil
+	}
+
+	post.SetSoftDeletedAt(MAX_DATETIME)
+
+	return store.PostUpdate(contextWithAction(ctx, ACTION_RESTORE), post)
+}
+
+// PostRestoreByID undoes the soft delete of the post with the ID and returns
+// the restored post. When archiving is enabled and the post was moved to the
+// archive table, it is unarchived instead.
+func (store *storeImplementation) PostRestoreByID(ctx context.Context, id string) (PostInterface, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if id == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	id = NormalizeID(id)
+
+	post, err := store.postRestoreFind(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if post == nil && store.archiveEnabled {
+		if err := store.PostUnarchive(ctx, id); err != nil {
+			return nil, err
+		}
+		return store.postRestoreFind(ctx, id)
+	}
+
+	if post == nil {
+		return nil, errors.New("post not found")
+	}
+
+	if err := store.PostRestore(ctx, post); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// postRestoreFind loads the post with the ID from the post table, soft
+// deleted or not. Returns nil if there is no such post.
+func (store *storeImplementation) postRestoreFind(ctx context.Context, id string) (PostInterface, error) {
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: id, WithDeleted: true, Limit: 1})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return list[0], nil
+}
@@ -0,0 +1,109 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostRestore(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Restorable")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, _ := store.PostFindByID(ctx, post.GetID()); found != nil {
+		t.Fatal("expected the soft deleted post to be hidden")
+	}
+
+	if err := store.PostRestore(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found, _ := store.PostFindByID(ctx, post.GetID()); found == nil {
+		t.Fatal("expected the restored post to be found")
+	}
+
+	// restoring an active post does nothing
+	if err := store.PostRestore(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestStorePostRestoreByID(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Restorable")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSoftDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	restored, err := store.PostRestoreByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if restored == nil || restored.GetTitle() != "Restorable" {
+		t.Fatal("expected the restored post to be returned")
+	}
+	if found, _ := store.PostFindByID(ctx, post.GetID()); found == nil {
+		t.Fatal("expected the restored post to be found")
+	}
+
+	if _, err := store.PostRestoreByID(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown post")
+	}
+}
+
+func TestStorePostRestoreByIDUnarchives(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		ArchiveEnabled:     true,
+		ArchiveTableName:   "blog_posts_archive",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Archived")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSoftDelete(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	restored, err := store.PostRestoreByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if restored == nil {
+		t.Fatal("expected the unarchived post to be returned")
+	}
+	if found, _ := store.PostFindByID(ctx, post.GetID()); found == nil {
+		t.Fatal("expected the unarchived post to be found")
+	}
+}
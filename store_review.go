@@ -0,0 +1,137 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPostNotPendingReview is returned by PostApprove and PostReject for
+// posts that were not submitted for review.
+var ErrPostNotPendingReview = errors.New("blogstore: post is not pending review")
+
+// PostSubmitForReview moves a post to POST_STATUS_PENDING_REVIEW, recording
+// the actor in the context as the submitter.
+func (store *storeImplementation) PostSubmitForReview(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	post, err := store.postReviewLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+	if post.GetStatus() == POST_STATUS_TRASH {
+		return errors.New("blogstore: post is in the trash")
+	}
+
+	post.SetStatus(POST_STATUS_PENDING_REVIEW)
+	if err := store.postReviewSetMetas(post, map[string]string{
+		META_KEY_REVIEW_STATUS:       REVIEW_STATUS_PENDING,
+		META_KEY_REVIEW_SUBMITTED_BY: ActorFromContext(ctx),
+		META_KEY_REVIEW_SUBMITTED_AT: store.now().ToDateTimeString(),
+		META_KEY_REVIEWED_BY:         "",
+		META_KEY_REVIEWED_AT:         "",
+		META_KEY_REVIEW_REASON:       "",
+	}); err != nil {
+		return err
+	}
+
+	return store.PostUpdate(contextWithAction(ctx, ACTION_SUBMIT_FOR_REVIEW), post)
+}
+
+// PostApprove publishes a post pending review, recording the reviewer.
+// Returns ErrPostNotPendingReview if the post was not submitted for review.
+func (store *storeImplementation) PostApprove(ctx context.Context, id string, reviewerID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if reviewerID == "" {
+		return errors.New("reviewer id is empty")
+	}
+
+	post, err := store.postReviewLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+	if post.GetStatus() != POST_STATUS_PENDING_REVIEW {
+		return ErrPostNotPendingReview
+	}
+
+	post.SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.postReviewSetMetas(post, map[string]string{
+		META_KEY_REVIEW_STATUS: REVIEW_STATUS_APPROVED,
+		META_KEY_REVIEWED_BY:   reviewerID,
+		META_KEY_REVIEWED_AT:   store.now().ToDateTimeString(),
+		META_KEY_REVIEW_REASON: "",
+	}); err != nil {
+		return err
+	}
+
+	return store.PostUpdate(contextWithAction(ctx, ACTION_APPROVE), post)
+}
+
+// PostReject returns a post pending review to the drafts, recording the
+// reason and the actor in the context as the reviewer.
+// Returns ErrPostNotPendingReview if the post was not submitted for review.
+func (store *storeImplementation) PostReject(ctx context.Context, id string, reason string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	post, err := store.postReviewLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+	if post.GetStatus() != POST_STATUS_PENDING_REVIEW {
+		return ErrPostNotPendingReview
+	}
+
+	post.SetStatus(POST_STATUS_DRAFT)
+	if err := store.postReviewSetMetas(post, map[string]string{
+		META_KEY_REVIEW_STATUS: REVIEW_STATUS_REJECTED,
+		META_KEY_REVIEWED_BY:   ActorFromContext(ctx),
+		META_KEY_REVIEWED_AT:   store.now().ToDateTimeString(),
+		META_KEY_REVIEW_REASON: reason,
+	}); err != nil {
+		return err
+	}
+
+	return store.PostUpdate(contextWithAction(ctx, ACTION_REJECT), post)
+}
+
+// postReviewLoad loads the post to move through the review workflow.
+func (store *storeImplementation) postReviewLoad(ctx context.Context, id string) (PostInterface, error) {
+	if id == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: id, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.New("post not found")
+	}
+
+	return list[0], nil
+}
+
+// postReviewSetMetas records the review decision in the post metas.
+func (store *storeImplementation) postReviewSetMetas(post PostInterface, metas map[string]string) error {
+	for key, value := range metas {
+		if err := post.SetMeta(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStorePostReviewApprove(t *testing.T) {
+	store := initAuditStore(t)
+	author := ContextWithActor(context.Background(), "author-1")
+
+	post := NewPost().SetTitle("Needs approval")
+	if err := store.PostCreate(author, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostApprove(author, post.GetID(), "editor-1"); !errors.Is(err, ErrPostNotPendingReview) {
+		t.Fatalf("expected ErrPostNotPendingReview before submission, got %v", err)
+	}
+
+	if err := store.PostSubmitForReview(author, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	submitted, _ := store.PostFindByID(author, post.GetID())
+	if submitted.GetStatus() != POST_STATUS_PENDING_REVIEW {
+		t.Fatalf("status = %q, want %q", submitted.GetStatus(), POST_STATUS_PENDING_REVIEW)
+	}
+	if submitted.GetMeta(META_KEY_REVIEW_SUBMITTED_BY) != "author-1" {
+		t.Fatalf("submitted by = %q, want author-1", submitted.GetMeta(META_KEY_REVIEW_SUBMITTED_BY))
+	}
+
+	editor := ContextWithActor(context.Background(), "editor-1")
+	if err := store.PostApprove(editor, post.GetID(), "editor-1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	approved, _ := store.PostFindByID(editor, post.GetID())
+	if approved.GetStatus() != POST_STATUS_PUBLISHED {
+		t.Fatalf("status = %q, want published", approved.GetStatus())
+	}
+	if approved.GetMeta(META_KEY_REVIEW_STATUS) != REVIEW_STATUS_APPROVED || approved.GetMeta(META_KEY_REVIEWED_BY) != "editor-1" {
+		t.Fatalf("review metas = %q by %q, want approved by editor-1",
+			approved.GetMeta(META_KEY_REVIEW_STATUS), approved.GetMeta(META_KEY_REVIEWED_BY))
+	}
+
+	entries, err := store.AuditList(context.Background(), AuditQueryOptions{EntityID: post.GetID(), Action: ACTION_APPROVE})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(entries) != 1 || entries[0].Actor != "editor-1" {
+		t.Fatalf("expected one approval audited for editor-1, got %+v", entries)
+	}
+}
+
+func TestStorePostReviewReject(t *testing.T) {
+	store := initAuditStore(t)
+	ctx := ContextWithActor(context.Background(), "editor-1")
+
+	post := NewPost().SetTitle("Not yet")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSubmitForReview(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostReject(ctx, post.GetID(), "Needs sources"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	rejected, _ := store.PostFindByID(ctx, post.GetID())
+	if rejected.GetStatus() != POST_STATUS_DRAFT {
+		t.Fatalf("status = %q, want draft", rejected.GetStatus())
+	}
+	if rejected.GetMeta(META_KEY_REVIEW_STATUS) != REVIEW_STATUS_REJECTED || rejected.GetMeta(META_KEY_REVIEW_REASON) != "Needs sources" {
+		t.Fatalf("review metas = %q (%q), want rejected with the reason",
+			rejected.GetMeta(META_KEY_REVIEW_STATUS), rejected.GetMeta(META_KEY_REVIEW_REASON))
+	}
+
+	if err := store.PostReject(ctx, post.GetID(), "again"); !errors.Is(err, ErrPostNotPendingReview) {
+		t.Fatalf("expected ErrPostNotPendingReview once rejected, got %v", err)
+	}
+}
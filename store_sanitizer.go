@@ -0,0 +1,11 @@
+package blogstore
+
+// contentSanitize cleans the content of the post with the sanitizer of the
+// store, if one is set and the post has the html content type.
+func (store *storeImplementation) contentSanitize(post PostInterface) {
+	if store.sanitizer == nil || post.GetContentType() != POST_CONTENT_TYPE_HTML {
+		return
+	}
+
+	post.SetContent(store.sanitizer.Sanitize(post.GetContent()))
+}
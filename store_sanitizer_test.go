@@ -0,0 +1,60 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStoreSanitizer(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Sanitizer:          NewHTMLSanitizer(),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().
+		SetTitle("HTML").
+		SetContentType(POST_CONTENT_TYPE_HTML).
+		SetContent(`<p onclick="steal()">Hello</p><script>steal()</script>`)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetContent() != "<p>Hello</p>" {
+		t.Fatal("Expected the created content to be sanitized, got:", found.GetContent())
+	}
+
+	found.SetContent(`<a href="javascript:steal()">Link</a>`)
+	if err := store.PostUpdate(ctx, found); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err = store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found.GetContent() != "<a>Link</a>" {
+		t.Fatal("Expected the updated content to be sanitized, got:", found.GetContent())
+	}
+
+	markdown := NewPost().
+		SetTitle("Markdown").
+		SetContentType(POST_CONTENT_TYPE_MARKDOWN).
+		SetContent("Use `<script>` tags")
+	if err := store.PostCreate(ctx, markdown); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if markdown.GetContent() != "Use `<script>` tags" {
+		t.Fatal("Expected markdown content to be kept, got:", markdown.GetContent())
+	}
+}
@@ -0,0 +1,85 @@
+package blogstore
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// PostSearchRanked searches the title and content of the posts for the words
+// of the query and returns the posts containing any of them, most relevant
+// first. Candidates are found with the search of PostList, one query per
+// word, then scored by how often the words occur, title matches weighing
+// more and the whole query found as a phrase adding a bonus. Ties are
+// ordered by the most recently published.
+func (store *storeImplementation) PostSearchRanked(ctx context.Context, query string, options SearchOptions) ([]SearchResult, error) {
+	if ctx == nil {
+		return []SearchResult{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []SearchResult{}, errors.New("search query is empty")
+	}
+	if options.Offset < 0 || options.Limit < 0 {
+		return []SearchResult{}, errors.New("offset and limit must not be negative")
+	}
+
+	if options.HighlightOpen == "" && options.HighlightClose == "" {
+		options.HighlightOpen = SEARCH_HIGHLIGHT_OPEN
+		options.HighlightClose = SEARCH_HIGHLIGHT_CLOSE
+	}
+	if options.SnippetLength <= 0 {
+		options.SnippetLength = SEARCH_SNIPPET_LENGTH
+	}
+
+	candidates := map[string]PostInterface{}
+	for _, term := range terms {
+		posts, err := store.PostList(ctx, PostQueryOptions{Search: term, Status: options.Status})
+		if err != nil {
+			return []SearchResult{}, err
+		}
+		for _, post := range posts {
+			candidates[post.GetID()] = post
+		}
+	}
+
+	results := make([]SearchResult, 0, len(candidates))
+	for _, post := range candidates {
+		content := searchPlainText(post.GetContent())
+		score := searchScore(post.GetTitle(), content, query, terms)
+		if score == 0 {
+			continue
+		}
+
+		title := []rune(post.GetTitle())
+		results = append(results, SearchResult{
+			Post:           post,
+			Score:          score,
+			TitleSnippet:   searchHighlight(title, searchMatches(title, terms), options.HighlightOpen, options.HighlightClose),
+			ContentSnippet: searchSnippet(content, terms, options.SnippetLength, options.HighlightOpen, options.HighlightClose),
+		})
+	}
+
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(b.Post.GetPublishedAt(), a.Post.GetPublishedAt()),
+			cmp.Compare(a.Post.GetID(), b.Post.GetID()),
+		)
+	})
+
+	if options.Offset >= len(results) {
+		return []SearchResult{}, nil
+	}
+	results = results[options.Offset:]
+	if options.Limit > 0 && options.Limit < len(results) {
+		results = results[:options.Limit]
+	}
+
+	return results, nil
+}
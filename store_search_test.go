@@ -0,0 +1,62 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostSearchRanked(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	posts := []PostInterface{
+		NewPost().SetTitle("Gardening basics").SetContent("<p>Water your tomato plants in the morning.</p>").SetStatus(POST_STATUS_PUBLISHED),
+		NewPost().SetTitle("Tomato soup").SetContent("<p>A tomato soup recipe with fresh tomato.</p>").SetStatus(POST_STATUS_PUBLISHED),
+		NewPost().SetTitle("Unrelated").SetContent("Nothing to see.").SetStatus(POST_STATUS_PUBLISHED),
+		NewPost().SetTitle("Tomato draft").SetContent("Draft about soup").SetStatus(POST_STATUS_DRAFT),
+	}
+	for _, post := range posts {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	results, err := store.PostSearchRanked(ctx, "tomato soup", SearchOptions{Status: POST_STATUS_PUBLISHED})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Post.GetTitle() != "Tomato soup" || results[1].Post.GetTitle() != "Gardening basics" {
+		t.Fatalf("ranking = [%s %s], want [Tomato soup, Gardening basics]", results[0].Post.GetTitle(), results[1].Post.GetTitle())
+	}
+	if results[0].Score <= results[1].Score {
+		t.Fatalf("scores = %v, %v, want the first higher", results[0].Score, results[1].Score)
+	}
+	if results[0].TitleSnippet != "<mark>Tomato</mark> <mark>soup</mark>" {
+		t.Fatalf("TitleSnippet = %q", results[0].TitleSnippet)
+	}
+	if results[1].ContentSnippet != "Water your <mark>tomato</mark> plants in the morning." {
+		t.Fatalf("ContentSnippet = %q", results[1].ContentSnippet)
+	}
+
+	limited, err := store.PostSearchRanked(ctx, "tomato", SearchOptions{Offset: 1, Limit: 1, HighlightOpen: "<em>", HighlightClose: "</em>"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(limited) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(limited))
+	}
+
+	if _, err := store.PostSearchRanked(ctx, "   ", SearchOptions{}); err == nil {
+		t.Fatal("expected an error for an empty query")
+	}
+}
@@ -0,0 +1,130 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrSlugTaken is returned by PostCreate and PostUpdate when unique slugs are
+// enforced and another post already uses the slug.
+var ErrSlugTaken = errors.New("blogstore: slug is already taken")
+
+// postUniqueSlug returns the slug to store for a post when unique slugs are
+// enforced. A post without a slug, e.g. an untitled draft, uses its ID, so
+// such posts do not collide.
+func postUniqueSlug(post PostInterface) string {
+	if slug := post.GetSlug(); slug != "" {
+		return slug
+	}
+	return post.GetID()
+}
+
+// migrateSlugUniqueIndex adds the unique slug index to the post table,
+// unless it already exists. Existing duplicate slugs must be resolved first.
+func (store *storeImplementation) migrateSlugUniqueIndex() error {
+	index := strings.ToLower(store.postTableName + "_" + COLUMN_SLUG + "_unique")
+	if store.db.Schema().HasIndex(store.postTableName, index) {
+		return nil
+	}
+
+	// Blueprint.Unique compiles to a non-unique index on SQLite,
+	// so the statement is written out, it is the same on every dialect
+	return store.db.Schema().Sql("CREATE UNIQUE INDEX " + index + " ON " + store.postTableName + " (" + COLUMN_SLUG + ")")
+}
+
+// slugConflictError returns ErrSlugTaken if the error is a violation of the
+// unique slug index, and the error unchanged otherwise.
+// The drivers report it as e.g. "UNIQUE constraint failed: posts.slug" (SQLite),
+// "duplicate key value violates unique constraint" (Postgres) or
+// "Duplicate entry ... for key" (MySQL), all naming the slug column or index.
+// The ORM hides these details outside of debug mode, in which case the slug
+// is looked up to tell whether another post holds it.
+func (store *storeImplementation) slugConflictError(ctx context.Context, postID string, slug string, err error) error {
+	if err == nil || !store.slugUniqueEnabled {
+		return err
+	}
+
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, COLUMN_SLUG) &&
+		(strings.Contains(message, "unique") || strings.Contains(message, "duplicate")) {
+		return ErrSlugTaken
+	}
+
+	holders, lookupErr := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{
+		Slug:           slug,
+		WithDeleted:    true,
+		WithoutContent: true,
+		Limit:          1,
+	})
+	if lookupErr == nil && len(holders) > 0 && holders[0].GetID() != postID {
+		return ErrSlugTaken
+	}
+
+	return err
+}
+
+// PostSlugEnsureUnique sets a slug on the post that no other post uses,
+// including soft deleted ones. The slug is the post's own, or one derived
+// from the title when it has none; on a collision the lowest free numeric
+// suffix is appended, e.g. "my-post-2". A post without a slug or title is
+// left unchanged. Two concurrent calls may still pick the same slug; enable
+// SlugUniqueEnabled to have the database reject the second one.
+func (store *storeImplementation) PostSlugEnsureUnique(ctx context.Context, post PostInterface) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if post == nil {
+		return errors.New("post is nil")
+	}
+
+	return store.postSlugUnique(ctx, post, nil)
+}
+
+// postSlugUnique sets a slug on the post that no stored post uses and that
+// is not among the reserved slugs, e.g. those of the other posts of a batch.
+func (store *storeImplementation) postSlugUnique(ctx context.Context, post PostInterface, reserved map[string]bool) error {
+	base := post.GetSlug()
+	if base == "" {
+		return nil
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostSlugEnsureUnique", "SELECT "+COLUMN_ID+", "+COLUMN_SLUG+" FROM "+store.postTableName+
+		" WHERE "+COLUMN_SLUG+" = ? OR "+COLUMN_SLUG+" LIKE ?", base, base+"-%")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var id, slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			return err
+		}
+		if id != post.GetID() {
+			taken[slug] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	slug := base
+	for n := 2; taken[slug] || reserved[slug]; n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+
+	post.SetSlug(slug)
+	return nil
+}
@@ -0,0 +1,182 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// SlugHistoryEnabled returns true if slug changes are recorded in the slug
+// history table.
+func (store *storeImplementation) SlugHistoryEnabled() bool {
+	return store.slugHistoryEnabled
+}
+
+// GetSlugHistoryTableName returns the slug history table name
+func (store *storeImplementation) GetSlugHistoryTableName() string {
+	return store.slugHistoryTableName
+}
+
+// PostFindBySlugOrHistory retrieves a post by its current slug or, failing
+// that, by a slug it had before: first from the slug history table, then
+// from the old slugs kept in the post metas. Moved is true when the post was
+// found by a previous slug, telling the caller to redirect permanently (301)
+// to the current slug. Returns nil if no post ever had the slug.
+func (store *storeImplementation) PostFindBySlugOrHistory(ctx context.Context, slug string) (post PostInterface, moved bool, err error) {
+	if ctx == nil {
+		return nil, false, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	post, err = store.PostFindBySlug(ctx, slug)
+	if err != nil || post != nil {
+		return post, false, err
+	}
+
+	post, err = store.postFindByPreviousSlug(ctx, slug)
+	return post, post != nil, err
+}
+
+// RedirectResolve returns the post that left the old slug, for the caller
+// to redirect permanently (301) to its current slug. Returns nil if no post
+// had the slug before, including when it is still a post's current slug.
+func (store *storeImplementation) RedirectResolve(ctx context.Context, oldSlug string) (PostInterface, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if oldSlug == "" {
+		return nil, errors.New("slug is empty")
+	}
+
+	current, err := store.PostFindBySlug(ctx, oldSlug)
+	if err != nil || current != nil {
+		return nil, err
+	}
+
+	return store.postFindByPreviousSlug(ctx, oldSlug)
+}
+
+// postFindByPreviousSlug retrieves the post that had the slug before: first
+// from the slug history table, then from the old slugs kept in the post
+// metas. Returns nil if no post had the slug.
+func (store *storeImplementation) postFindByPreviousSlug(ctx context.Context, slug string) (PostInterface, error) {
+	if store.slugHistoryEnabled {
+		postIDs, err := store.slugHistoryPostIDs(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, postID := range postIDs {
+			post, err := store.PostFindByID(ctx, postID)
+			if err != nil {
+				return nil, err
+			}
+			if post != nil {
+				return post, nil
+			}
+		}
+	}
+
+	return store.PostFindByOldSlug(ctx, slug)
+}
+
+// slugHistoryPostIDs returns the IDs of the posts that had the slug, the
+// most recent change first.
+func (store *storeImplementation) slugHistoryPostIDs(ctx context.Context, slug string) ([]string, error) {
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "slugHistoryPostIDs", "SELECT "+COLUMN_POST_ID+" FROM "+store.slugHistoryTableName+
+		" WHERE "+COLUMN_SLUG+" = ? ORDER BY "+COLUMN_CREATED_AT+" DESC", slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	postIDs := []string{}
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	return postIDs, rows.Err()
+}
+
+// slugHistoryPrevious returns the stored slug of a post about to be updated
+// with a new slug, or "" if slug history is disabled or the slug is not
+// changed. The snapshot taken for auditing is reused when there is one.
+func (store *storeImplementation) slugHistoryPrevious(ctx context.Context, before PostInterface, post PostInterface) (string, error) {
+	if !store.slugHistoryEnabled {
+		return "", nil
+	}
+	if _, ok := post.GetDataChanged()[COLUMN_SLUG]; !ok {
+		return "", nil
+	}
+
+	if before == nil {
+		list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: post.GetID(), WithDeleted: true, WithoutContent: true, Limit: 1})
+		if err != nil || len(list) == 0 {
+			return "", err
+		}
+		before = list[0]
+	}
+
+	return before.GetSlug(), nil
+}
+
+// slugHistoryRecord records that a post moved from the previous slug to the
+// new one. A post moving back to one of its previous slugs drops that slug
+// from its history, so it never redirects to itself. Posts without a slug,
+// stored under their ID when slugs are unique, leave no history.
+func (store *storeImplementation) slugHistoryRecord(ctx context.Context, postID string, previous string, slug string) error {
+	if !store.slugHistoryEnabled || previous == "" || previous == postID || previous == slug {
+		return nil
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "slugHistoryRecord", "DELETE FROM "+store.slugHistoryTableName+" WHERE "+COLUMN_POST_ID+" = ? AND "+COLUMN_SLUG+" = ?",
+		postID, slug)
+	if err != nil {
+		return err
+	}
+
+	now := store.now().StdTime()
+	result, err := store.execContext(ctx, db, "slugHistoryRecord", "UPDATE "+store.slugHistoryTableName+" SET "+COLUMN_CREATED_AT+" = ? WHERE "+COLUMN_POST_ID+" = ? AND "+COLUMN_SLUG+" = ?",
+		now, postID, previous)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "slugHistoryRecord", "INSERT INTO "+store.slugHistoryTableName+" (slug, post_id, created_at) VALUES (?, ?, ?)",
+		previous, postID, now)
+	return err
+}
+
+// slugHistoryTableBlueprint defines the slug history table, holding the
+// previous slugs of the posts with the time they were left.
+func slugHistoryTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_SLUG, 255)
+	table.String(COLUMN_POST_ID, 40)
+	table.Primary(COLUMN_SLUG, COLUMN_POST_ID)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.Index(COLUMN_POST_ID)
+}
@@ -0,0 +1,112 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initSlugHistoryStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:        "blog_posts",
+		SlugHistoryEnabled:   true,
+		SlugHistoryTableName: "blog_slug_history",
+		DB:                   initDB(),
+		AutomigrateEnabled:   true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStorePostFindBySlugOrHistory(t *testing.T) {
+	store := initSlugHistoryStore(t)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Moving post").SetSlug("first-slug").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, moved, err := store.PostFindBySlugOrHistory(ctx, "first-slug")
+	if err != nil || found == nil || moved {
+		t.Fatalf("PostFindBySlugOrHistory(current) = %v, %v, %v, want the post, not moved", found, moved, err)
+	}
+
+	post.SetSlug("second-slug")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	post.SetSlug("third-slug")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, slug := range []string{"first-slug", "second-slug"} {
+		found, moved, err := store.PostFindBySlugOrHistory(ctx, slug)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if found == nil || found.GetID() != post.GetID() || !moved {
+			t.Fatalf("PostFindBySlugOrHistory(%q) = %v, moved %v, want the post, moved", slug, found, moved)
+		}
+		if found.GetSlug() != "third-slug" {
+			t.Fatalf("redirect slug = %q, want third-slug", found.GetSlug())
+		}
+	}
+
+	// Moving back to a previous slug resolves it directly again
+	post.SetSlug("first-slug")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	found, moved, err = store.PostFindBySlugOrHistory(ctx, "first-slug")
+	if err != nil || found == nil || moved {
+		t.Fatalf("PostFindBySlugOrHistory(restored) = %v, %v, %v, want the post, not moved", found, moved, err)
+	}
+
+	found, moved, err = store.PostFindBySlugOrHistory(ctx, "never-used")
+	if err != nil || found != nil || moved {
+		t.Fatalf("PostFindBySlugOrHistory(unknown) = %v, %v, %v, want nil", found, moved, err)
+	}
+}
+
+func TestStoreRedirectResolve(t *testing.T) {
+	store := initSlugHistoryStore(t)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Renamed post").SetSlug("old-slug").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.RedirectResolve(ctx, "old-slug")
+	if err != nil || found != nil {
+		t.Fatalf("RedirectResolve(current) = %v, %v, want nil", found, err)
+	}
+
+	post.SetSlug("new-slug")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err = store.RedirectResolve(ctx, "old-slug")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetID() != post.GetID() || found.GetSlug() != "new-slug" {
+		t.Fatalf("RedirectResolve(old-slug) = %v, want the post at new-slug", found)
+	}
+
+	found, err = store.RedirectResolve(ctx, "never-used")
+	if err != nil || found != nil {
+		t.Fatalf("RedirectResolve(unknown) = %v, %v, want nil", found, err)
+	}
+
+	if _, err := store.RedirectResolve(ctx, ""); err == nil {
+		t.Fatal("expected error for empty slug")
+	}
+}
@@ -0,0 +1,139 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func initSlugUniqueStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		SlugUniqueEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreSlugUniqueCreate(t *testing.T) {
+	store := initSlugUniqueStore(t)
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Hello").SetSlug("hello")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err := store.PostCreate(ctx, NewPost().SetTitle("Hello again").SetSlug("hello"))
+	if !errors.Is(err, ErrSlugTaken) {
+		t.Fatalf("PostCreate() error = %v, want ErrSlugTaken", err)
+	}
+
+	// posts without a slug fall back to their ID and do not collide
+	for range 2 {
+		if err := store.PostCreate(ctx, NewPost()); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestStoreSlugUniqueUpdate(t *testing.T) {
+	store := initSlugUniqueStore(t)
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetSlug("first")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	second := NewPost().SetSlug("second")
+	if err := store.PostCreate(ctx, second); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	second.SetSlug("first")
+	if err := store.PostUpdate(ctx, second); !errors.Is(err, ErrSlugTaken) {
+		t.Fatalf("PostUpdate() error = %v, want ErrSlugTaken", err)
+	}
+}
+
+func TestStoreSlugUniqueMigrateUpIsRepeatable(t *testing.T) {
+	store := initSlugUniqueStore(t)
+
+	if err := store.MigrateUp(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestStoreSlugNotUniqueByDefault(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for range 2 {
+		if err := store.PostCreate(context.Background(), NewPost().SetSlug("same")); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestStorePostCreateGeneratesUniqueSlug(t *testing.T) {
+	store := initSlugUniqueStore(t)
+	ctx := context.Background()
+
+	slugs := []string{}
+	for range 3 {
+		post := NewPost().SetTitle("My Post")
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		slugs = append(slugs, post.GetSlug())
+	}
+
+	want := []string{"my-post", "my-post-2", "my-post-3"}
+	if !reflect.DeepEqual(slugs, want) {
+		t.Fatalf("slugs = %v, want %v", slugs, want)
+	}
+
+	found, err := store.PostFindBySlug(ctx, "my-post-3")
+	if err != nil || found == nil {
+		t.Fatalf("PostFindBySlug() = %v, %v, want the third post", found, err)
+	}
+}
+
+func TestStorePostSlugEnsureUnique(t *testing.T) {
+	store := initSlugUniqueStore(t)
+	ctx := context.Background()
+
+	first := NewPost().SetSlug("taken")
+	if err := store.PostCreate(ctx, first); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the post's own slug is not a collision
+	if err := store.PostSlugEnsureUnique(ctx, first); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if first.GetSlug() != "taken" {
+		t.Fatalf("slug = %q, want taken", first.GetSlug())
+	}
+
+	other := NewPost().SetSlug("taken")
+	if err := store.PostSlugEnsureUnique(ctx, other); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if other.GetSlug() != "taken-2" {
+		t.Fatalf("slug = %q, want taken-2", other.GetSlug())
+	}
+}
@@ -0,0 +1,127 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// statsDefaultCacheTTL is how long statistics are cached when StatsCacheTTL is not set.
+const statsDefaultCacheTTL = 5 * time.Minute
+
+// Stats returns aggregate statistics about the posts and versions.
+// The numbers are cached and recomputed once they are older than the
+// configured StatsCacheTTL, so dashboards can call this on every page view.
+func (store *storeImplementation) Stats(ctx context.Context) (Stats, error) {
+	if ctx == nil {
+		return Stats{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return Stats{}, err
+	}
+
+	store.statsMu.Lock()
+	defer store.statsMu.Unlock()
+
+	ttl := store.statsCacheTTL
+	if ttl <= 0 {
+		ttl = statsDefaultCacheTTL
+	}
+
+	if store.statsCache != nil && store.now().StdTime().Sub(store.statsCache.GeneratedAt) < ttl {
+		return *store.statsCache, nil
+	}
+
+	return store.statsRefreshLocked(ctx)
+}
+
+// StatsRefresh recomputes the statistics, bypassing and updating the cache.
+func (store *storeImplementation) StatsRefresh(ctx context.Context) (Stats, error) {
+	if ctx == nil {
+		return Stats{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return Stats{}, err
+	}
+
+	store.statsMu.Lock()
+	defer store.statsMu.Unlock()
+
+	return store.statsRefreshLocked(ctx)
+}
+
+// statsRefreshLocked computes the statistics and stores them in the cache.
+// The caller must hold statsMu.
+func (store *storeImplementation) statsRefreshLocked(ctx context.Context) (Stats, error) {
+	db, err := store.db.DB()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	now := store.now().StdTime()
+	active := COLUMN_SOFT_DELETED_AT + " > ?"
+
+	stats := Stats{
+		GeneratedAt: store.now().StdTime(),
+	}
+
+	stats.PostsByStatus, err = store.statsGroupCount(ctx, db, COLUMN_STATUS, active, now)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats.PostsByAuthor, err = store.statsGroupCount(ctx, db, COLUMN_AUTHOR_ID, active, now)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats.PostsByMonth, err = store.statsGroupCount(ctx, db, store.monthExpression(COLUMN_CREATED_AT), active, now)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	for _, count := range stats.PostsByStatus {
+		stats.TotalPosts += count
+	}
+
+	if store.versioningEnabled {
+		err = store.queryRowContext(ctx, db, "Stats", "SELECT COUNT(*) FROM "+store.versioningTableName+" WHERE "+active, []any{now}, &stats.TotalVersions)
+		if err != nil {
+			return Stats{}, err
+		}
+	}
+
+	store.statsCache = &stats
+
+	return stats, nil
+}
+
+// statsGroupCount counts the posts matching the condition, grouped by the expression.
+func (store *storeImplementation) statsGroupCount(ctx context.Context, db *sql.DB, expression string, where string, args ...any) (map[string]int64, error) {
+	rows, err := store.queryContext(ctx, db, "Stats", "SELECT "+expression+", COUNT(*) FROM "+store.postTableName+" WHERE "+where+" GROUP BY "+expression, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int64{}
+	for rows.Next() {
+		var key sql.NullString
+		var count int64
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key.String] += count
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,130 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+func TestStoreStats(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_posts_version",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+		StatsCacheTTL:       time.Hour,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	posts := []PostInterface{
+		NewPost().SetTitle("Post 1").SetStatus(POST_STATUS_PUBLISHED).SetAuthorID("alice"),
+		NewPost().SetTitle("Post 2").SetStatus(POST_STATUS_PUBLISHED).SetAuthorID("bob"),
+		NewPost().SetTitle("Post 3").SetStatus(POST_STATUS_DRAFT).SetAuthorID("alice"),
+	}
+	for _, post := range posts {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	stats, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if stats.TotalPosts != 3 {
+		t.Fatal("expected 3 posts, found:", stats.TotalPosts)
+	}
+	if stats.PostsByStatus[POST_STATUS_PUBLISHED] != 2 || stats.PostsByStatus[POST_STATUS_DRAFT] != 1 {
+		t.Fatal("unexpected posts by status:", stats.PostsByStatus)
+	}
+	if stats.PostsByAuthor["alice"] != 2 || stats.PostsByAuthor["bob"] != 1 {
+		t.Fatal("unexpected posts by author:", stats.PostsByAuthor)
+	}
+	month := carbon.Now(carbon.UTC).Format("Y-m")
+	if stats.PostsByMonth[month] != 3 {
+		t.Fatal("expected 3 posts in", month, "found:", stats.PostsByMonth)
+	}
+	if stats.TotalVersions != 3 {
+		t.Fatal("expected 3 versions, found:", stats.TotalVersions)
+	}
+
+	// cached until refreshed
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Post 4")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	cached, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if cached.TotalPosts != 3 {
+		t.Fatal("expected cached total of 3, found:", cached.TotalPosts)
+	}
+
+	refreshed, err := store.StatsRefresh(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if refreshed.TotalPosts != 4 {
+		t.Fatal("expected refreshed total of 4, found:", refreshed.TotalPosts)
+	}
+}
+
+func TestStoreStatsCacheExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		StatsCacheTTL:      time.Hour,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Post 1")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	stats, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !stats.GeneratedAt.Equal(now) {
+		t.Fatalf("GeneratedAt = %v, want the injected time %v", stats.GeneratedAt, now)
+	}
+
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Post 2")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	now = now.Add(59 * time.Minute)
+	stats, err = store.Stats(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if stats.TotalPosts != 1 {
+		t.Fatal("expected the cached total of 1 within the TTL, found:", stats.TotalPosts)
+	}
+
+	now = now.Add(time.Minute)
+	stats, err = store.Stats(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if stats.TotalPosts != 2 {
+		t.Fatal("expected a recomputed total of 2 after the TTL, found:", stats.TotalPosts)
+	}
+}
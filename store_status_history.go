@@ -0,0 +1,92 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// StatusHistoryEnabled returns true if status changes are recorded in the
+// status history table.
+func (store *storeImplementation) StatusHistoryEnabled() bool {
+	return store.statusHistoryEnabled
+}
+
+// GetStatusHistoryTableName returns the status history table name
+func (store *storeImplementation) GetStatusHistoryTableName() string {
+	return store.statusHistoryTableName
+}
+
+// PostStatusHistory returns the status changes of the post, oldest first,
+// starting with the status the post was created in.
+func (store *storeImplementation) PostStatusHistory(ctx context.Context, postID string) ([]StatusChange, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.statusHistoryEnabled {
+		return nil, errors.New("status history is not enabled")
+	}
+	if postID == "" {
+		return nil, errors.New("post id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostStatusHistory", "SELECT "+COLUMN_ID+", "+COLUMN_POST_ID+", "+COLUMN_FROM_STATUS+", "+COLUMN_TO_STATUS+", "+COLUMN_ACTOR+", "+COLUMN_CREATED_AT+
+		" FROM "+store.statusHistoryTableName+" WHERE "+COLUMN_POST_ID+" = ? ORDER BY "+COLUMN_CREATED_AT+" ASC, "+COLUMN_ID+" ASC", NormalizeID(postID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []StatusChange{}
+	for rows.Next() {
+		var change StatusChange
+		if err := rows.Scan(&change.ID, &change.PostID, &change.FromStatus, &change.ToStatus, &change.Actor, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// statusHistoryRecord records that the post moved from one status to
+// another. Nothing is recorded when status history is disabled or the status
+// did not change.
+func (store *storeImplementation) statusHistoryRecord(ctx context.Context, postID string, from string, to string) error {
+	if !store.statusHistoryEnabled || from == to {
+		return nil
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "statusHistoryRecord", "INSERT INTO "+store.statusHistoryTableName+
+		" ("+COLUMN_ID+", "+COLUMN_POST_ID+", "+COLUMN_FROM_STATUS+", "+COLUMN_TO_STATUS+", "+COLUMN_ACTOR+", "+COLUMN_CREATED_AT+") VALUES (?, ?, ?, ?, ?, ?)",
+		GenerateShortID(), postID, from, to, ActorFromContext(ctx), store.now().StdTime())
+	return err
+}
+
+// statusHistoryTableBlueprint defines the status history table, holding the
+// status changes of the posts.
+func statusHistoryTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 21)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_POST_ID, 40)
+	table.String(COLUMN_FROM_STATUS, 50).Default("")
+	table.String(COLUMN_TO_STATUS, 50)
+	table.String(COLUMN_ACTOR, 255).Default("")
+	table.DateTime(COLUMN_CREATED_AT)
+	table.Index(COLUMN_POST_ID)
+}
@@ -0,0 +1,81 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostStatusHistory(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:          "blog_posts",
+		StatusHistoryEnabled:   true,
+		StatusHistoryTableName: "blog_status_history",
+		DB:                     initDB(),
+		AutomigrateEnabled:     true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	author := ContextWithActor(context.Background(), "author-1")
+	editor := ContextWithActor(context.Background(), "editor-1")
+
+	post := NewPost().SetTitle("Tracked")
+	if err := store.PostCreate(author, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(author, post.SetTitle("Tracked, retitled")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(editor, post.SetStatus(POST_STATUS_PUBLISHED)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostStatusUpdateByIDs(editor, []string{post.GetID()}, POST_STATUS_UNPUBLISHED); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	changes, err := store.PostStatusHistory(context.Background(), post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := []StatusChange{
+		{FromStatus: "", ToStatus: POST_STATUS_DRAFT, Actor: "author-1"},
+		{FromStatus: POST_STATUS_DRAFT, ToStatus: POST_STATUS_PUBLISHED, Actor: "editor-1"},
+		{FromStatus: POST_STATUS_PUBLISHED, ToStatus: POST_STATUS_UNPUBLISHED, Actor: "editor-1"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d status changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, change := range changes {
+		if change.PostID != post.GetID() || change.CreatedAt.IsZero() {
+			t.Fatalf("change %d = %+v, want the post ID and a time", i, change)
+		}
+		if change.FromStatus != want[i].FromStatus || change.ToStatus != want[i].ToStatus || change.Actor != want[i].Actor {
+			t.Fatalf("change %d = %+v, want %+v", i, change, want[i])
+		}
+	}
+}
+
+func TestStorePostStatusHistoryNotEnabled(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostStatusHistory(context.Background(), "any"); err == nil {
+		t.Fatal("expected an error when status history is not enabled")
+	}
+
+	if _, err := NewStore(NewStoreOptions{
+		PostTableName:        "blog_posts",
+		DB:                   initDB(),
+		StatusHistoryEnabled: true,
+	}); err == nil {
+		t.Fatal("expected an error without StatusHistoryTableName")
+	}
+}
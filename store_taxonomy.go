@@ -17,20 +17,29 @@ func (store *storeImplementation) TaxonomyCount(ctx context.Context, options Tax
 	if ctx == nil {
 		return 0, errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return -1, errors.New("taxonomy is not enabled")
 	}
 
-	q := store.buildTaxonomyQuery(options)
+	q := store.buildTaxonomyQuery(ctx, options)
 
 	var count int64
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Count() })
+	store.explainQuery(ctx, nil, "TaxonomyCount", sqlFn)
+
+	start := time.Now()
 	err := q.Table(store.taxonomyTableName).Count(&count)
+	store.logSlowQuery(ctx, "TaxonomyCount", start, sqlFn)
 	return count, err
 }
 
 // buildTaxonomyQuery builds a neat query from the taxonomy query options.
-func (store *storeImplementation) buildTaxonomyQuery(options TaxonomyQueryOptions) contractsorm.Query {
-	q := store.db.Query()
+func (store *storeImplementation) buildTaxonomyQuery(ctx context.Context, options TaxonomyQueryOptions) contractsorm.Query {
+	q := store.query(ctx)
 
 	if options.ID != "" {
 		q = q.Where(COLUMN_ID+" = ?", options.ID)
@@ -58,9 +67,17 @@ func (store *storeImplementation) TaxonomyCreate(ctx context.Context, taxonomy T
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if taxonomy == nil {
 		return errors.New("taxonomy is nil")
 	}
@@ -70,10 +87,10 @@ func (store *storeImplementation) TaxonomyCreate(ctx context.Context, taxonomy T
 	}
 
 	if taxonomy.GetCreatedAt() == "" {
-		taxonomy.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+		taxonomy.SetCreatedAt(store.now().ToDateTimeString(carbon.UTC))
 	}
 	if taxonomy.GetUpdatedAt() == "" {
-		taxonomy.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+		taxonomy.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
 	}
 
 	data := taxonomy.GetData()
@@ -85,7 +102,7 @@ func (store *storeImplementation) TaxonomyCreate(ctx context.Context, taxonomy T
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "INSERT INTO "+store.taxonomyTableName+" (id, name, slug, description, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+	_, err = store.execContext(ctx, db, "TaxonomyCreate", "INSERT INTO "+store.taxonomyTableName+" (id, name, slug, description, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
 		taxonomy.GetID(),
 		taxonomy.GetName(),
 		taxonomy.GetSlug(),
@@ -93,8 +110,11 @@ func (store *storeImplementation) TaxonomyCreate(ctx context.Context, taxonomy T
 		taxonomy.GetCreatedAtCarbon().StdTime(),
 		taxonomy.GetUpdatedAtCarbon().StdTime(),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return store.auditRecord(ctx, AUDIT_ENTITY_TAXONOMY, taxonomy.GetID(), ACTION_CREATE, nil, taxonomy)
 }
 
 // TaxonomyDelete permanently removes a taxonomy from the database.
@@ -103,6 +123,10 @@ func (store *storeImplementation) TaxonomyDelete(ctx context.Context, taxonomy T
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -116,23 +140,42 @@ func (store *storeImplementation) TaxonomyDelete(ctx context.Context, taxonomy T
 // TaxonomyDeleteByID permanently removes a taxonomy by its ID.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TaxonomyDeleteByID(ctx context.Context, id string) error {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if id == "" {
 		return errors.New("taxonomy id is empty")
 	}
 
-	_, err := store.db.Query().
+	before, err := store.auditTaxonomyLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.query(ctx).
 		Table(store.taxonomyTableName).
 		Where(COLUMN_ID+" = ?", id).
 		Delete()
-	return err
+	if err != nil || before == nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_TAXONOMY, id, ACTION_DELETE, before, nil)
 }
 
 // TaxonomyFindByID retrieves a taxonomy by its ID.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TaxonomyFindByID(ctx context.Context, id string) (TaxonomyInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return nil, errors.New("taxonomy is not enabled")
 	}
@@ -159,6 +202,9 @@ func (store *storeImplementation) TaxonomyFindByID(ctx context.Context, id strin
 // TaxonomyFindBySlug retrieves a taxonomy by its slug.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TaxonomyFindBySlug(ctx context.Context, slug string) (TaxonomyInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return nil, errors.New("taxonomy is not enabled")
 	}
@@ -192,6 +238,9 @@ func (store *storeImplementation) TaxonomyList(ctx context.Context, options Taxo
 		return nil, errors.New("ctx is nil")
 	}
 
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	type taxonomyRow struct {
 		ID          string    `db:"id"`
 		Name        string    `db:"name"`
@@ -201,10 +250,16 @@ func (store *storeImplementation) TaxonomyList(ctx context.Context, options Taxo
 		UpdatedAt   time.Time `db:"updated_at"`
 	}
 
-	q := store.buildTaxonomyQuery(options)
+	q := store.buildTaxonomyQuery(ctx, options)
 
 	var rows []taxonomyRow
-	if err := q.Table(store.taxonomyTableName).Get(&rows); err != nil {
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Get(&[]taxonomyRow{}) })
+	store.explainQuery(ctx, nil, "TaxonomyList", sqlFn)
+
+	start := time.Now()
+	err := q.Table(store.taxonomyTableName).Get(&rows)
+	store.logSlowQuery(ctx, "TaxonomyList", start, sqlFn)
+	if err != nil {
 		return []TaxonomyInterface{}, err
 	}
 
@@ -231,16 +286,29 @@ func (store *storeImplementation) TaxonomyUpdate(ctx context.Context, taxonomy T
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if taxonomy == nil {
 		return errors.New("taxonomy is nil")
 	}
 
-	taxonomy.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	taxonomy.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
+
+	before, err := store.auditTaxonomyLoad(ctx, taxonomy.GetID())
+	if err != nil {
+		return err
+	}
 
-	_, err := store.db.Query().
+	_, err = store.query(ctx).
 		Table(store.taxonomyTableName).
 		Where(COLUMN_ID+" = ?", taxonomy.GetID()).
 		Update(map[string]interface{}{
@@ -249,8 +317,11 @@ func (store *storeImplementation) TaxonomyUpdate(ctx context.Context, taxonomy T
 			COLUMN_DESCRIPTION: taxonomy.GetDescription(),
 			COLUMN_UPDATED_AT:  taxonomy.GetUpdatedAtCarbon().StdTime(),
 		})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return store.auditRecord(ctx, AUDIT_ENTITY_TAXONOMY, taxonomy.GetID(), ACTION_UPDATE, before, taxonomy)
 }
 
 // ============================ TERM STORE METHODS ============================
@@ -261,20 +332,35 @@ func (store *storeImplementation) TermCount(ctx context.Context, options TermQue
 	if ctx == nil {
 		return 0, errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return -1, errors.New("taxonomy is not enabled")
 	}
 
-	q := store.buildTermQuery(options)
+	// ordering does not change the count
+	options.OrderBy = ""
+	q, err := store.buildTermQuery(ctx, options)
+	if err != nil {
+		return 0, err
+	}
 
 	var count int64
-	err := q.Table(store.termTableName).Count(&count)
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Count() })
+	store.explainQuery(ctx, nil, "TermCount", sqlFn)
+
+	start := time.Now()
+	err = q.Table(store.termTableName).Count(&count)
+	store.logSlowQuery(ctx, "TermCount", start, sqlFn)
 	return count, err
 }
 
 // buildTermQuery builds a neat query from the term query options.
-func (store *storeImplementation) buildTermQuery(options TermQueryOptions) contractsorm.Query {
-	q := store.db.Query()
+// Returns an OrderError if OrderBy or SortOrder is not allowed.
+func (store *storeImplementation) buildTermQuery(ctx context.Context, options TermQueryOptions) (contractsorm.Query, error) {
+	q := store.query(ctx)
 
 	if options.ID != "" {
 		q = q.Where(COLUMN_ID+" = ?", options.ID)
@@ -299,6 +385,10 @@ func (store *storeImplementation) buildTermQuery(options TermQueryOptions) contr
 		q = q.Where(COLUMN_TAXONOMY_ID+" = ?", options.TaxonomyID)
 	}
 
+	if options.TaxonomySlug != "" {
+		q = q.Where(COLUMN_TAXONOMY_ID+" IN (SELECT "+COLUMN_ID+" FROM "+store.taxonomyTableName+" WHERE "+COLUMN_SLUG+" = ?)", options.TaxonomySlug)
+	}
+
 	if options.ParentID != "" {
 		q = q.Where(COLUMN_PARENT_ID+" = ?", options.ParentID)
 	}
@@ -307,6 +397,21 @@ func (store *storeImplementation) buildTermQuery(options TermQueryOptions) contr
 		q = q.Where(COLUMN_SLUG+" = ?", options.Slug)
 	}
 
+	if options.OrderBy != "" {
+		if err := validateOrderBy(options.OrderBy, termTableColumns); err != nil {
+			return nil, err
+		}
+		order, err := normalizeSortOrder(options.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		if order == SORT_ORDER_ASC {
+			q = q.OrderBy(options.OrderBy)
+		} else {
+			q = q.OrderByDesc(options.OrderBy)
+		}
+	}
+
 	if options.Limit > 0 {
 		q = q.Limit(options.Limit)
 	}
@@ -315,7 +420,7 @@ func (store *storeImplementation) buildTermQuery(options TermQueryOptions) contr
 		q = q.Offset(options.Offset)
 	}
 
-	return q
+	return q, nil
 }
 
 // TermCreate inserts a new term into the database.
@@ -325,9 +430,17 @@ func (store *storeImplementation) TermCreate(ctx context.Context, term TermInter
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if term == nil {
 		return errors.New("term is nil")
 	}
@@ -337,17 +450,17 @@ func (store *storeImplementation) TermCreate(ctx context.Context, term TermInter
 	}
 
 	if term.GetCreatedAt() == "" {
-		term.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+		term.SetCreatedAt(store.now().ToDateTimeString(carbon.UTC))
 	}
 	if term.GetUpdatedAt() == "" {
-		term.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+		term.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
 	}
 
 	db, err := store.db.DB()
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "INSERT INTO "+store.termTableName+" (id, taxonomy_id, parent_id, sequence, name, slug, description, count, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	_, err = store.execContext(ctx, db, "TermCreate", "INSERT INTO "+store.termTableName+" (id, taxonomy_id, parent_id, sequence, name, slug, description, count, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		term.GetID(),
 		term.GetTaxonomyID(),
 		term.GetParentID(),
@@ -359,8 +472,15 @@ func (store *storeImplementation) TermCreate(ctx context.Context, term TermInter
 		term.GetCreatedAtCarbon().StdTime(),
 		term.GetUpdatedAtCarbon().StdTime(),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_TERM, term.GetID(), term); err != nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_TERM, term.GetID(), ACTION_CREATE, nil, term)
 }
 
 // TermDelete permanently removes a term from the database.
@@ -369,6 +489,10 @@ func (store *storeImplementation) TermDelete(ctx context.Context, term TermInter
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -382,23 +506,42 @@ func (store *storeImplementation) TermDelete(ctx context.Context, term TermInter
 // TermDeleteByID permanently removes a term by its ID.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TermDeleteByID(ctx context.Context, id string) error {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if id == "" {
 		return errors.New("term id is empty")
 	}
 
-	_, err := store.db.Query().
+	before, err := store.auditTermLoad(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.query(ctx).
 		Table(store.termTableName).
 		Where(COLUMN_ID+" = ?", id).
 		Delete()
-	return err
+	if err != nil || before == nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_TERM, id, ACTION_DELETE, before, nil)
 }
 
 // TermFindByID retrieves a term by its ID.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TermFindByID(ctx context.Context, id string) (TermInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return nil, errors.New("taxonomy is not enabled")
 	}
@@ -425,6 +568,9 @@ func (store *storeImplementation) TermFindByID(ctx context.Context, id string) (
 // TermFindBySlug retrieves a term by its taxonomy slug and term slug.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TermFindBySlug(ctx context.Context, taxonomySlug, termSlug string) (TermInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return nil, errors.New("taxonomy is not enabled")
 	}
@@ -467,6 +613,9 @@ func (store *storeImplementation) TermList(ctx context.Context, options TermQuer
 		return nil, errors.New("ctx is nil")
 	}
 
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	type termRow struct {
 		ID          string    `db:"id"`
 		TaxonomyID  string    `db:"taxonomy_id"`
@@ -480,10 +629,19 @@ func (store *storeImplementation) TermList(ctx context.Context, options TermQuer
 		UpdatedAt   time.Time `db:"updated_at"`
 	}
 
-	q := store.buildTermQuery(options)
+	q, err := store.buildTermQuery(ctx, options)
+	if err != nil {
+		return []TermInterface{}, err
+	}
 
 	var rows []termRow
-	if err := q.Table(store.termTableName).Get(&rows); err != nil {
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Get(&[]termRow{}) })
+	store.explainQuery(ctx, nil, "TermList", sqlFn)
+
+	start := time.Now()
+	err = q.Table(store.termTableName).Get(&rows)
+	store.logSlowQuery(ctx, "TermList", start, sqlFn)
+	if err != nil {
 		return []TermInterface{}, err
 	}
 
@@ -514,16 +672,29 @@ func (store *storeImplementation) TermUpdate(ctx context.Context, term TermInter
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if term == nil {
 		return errors.New("term is nil")
 	}
 
-	term.SetUpdatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	term.SetUpdatedAt(store.now().ToDateTimeString(carbon.UTC))
+
+	before, err := store.auditTermLoad(ctx, term.GetID())
+	if err != nil {
+		return err
+	}
 
-	_, err := store.db.Query().
+	_, err = store.query(ctx).
 		Table(store.termTableName).
 		Where(COLUMN_ID+" = ?", term.GetID()).
 		Update(map[string]interface{}{
@@ -536,8 +707,15 @@ func (store *storeImplementation) TermUpdate(ctx context.Context, term TermInter
 			COLUMN_COUNT:       term.GetCount(),
 			COLUMN_UPDATED_AT:  term.GetUpdatedAtCarbon().StdTime(),
 		})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_TERM, term.GetID(), term); err != nil {
+		return err
+	}
+
+	return store.auditRecord(ctx, AUDIT_ENTITY_TERM, term.GetID(), ACTION_UPDATE, before, term)
 }
 
 // ============================ POST-TERM RELATIONSHIP METHODS ============================
@@ -549,6 +727,10 @@ func (store *storeImplementation) PostInsertTermAt(ctx context.Context, postID s
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -556,14 +738,18 @@ func (store *storeImplementation) PostInsertTermAt(ctx context.Context, postID s
 		return errors.New("post id and term id are required")
 	}
 
+	if err := store.authorizePost(ctx, ACTION_UPDATE, postID); err != nil {
+		return err
+	}
+
 	relationID := GenerateShortID()
-	now := carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC)
+	now := store.now().ToDateTimeString(carbon.UTC)
 
 	db, err := store.db.DB()
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "INSERT INTO "+store.termRelationTableName+" (id, post_id, term_id, sequence, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+	_, err = store.execContext(ctx, db, "PostInsertTermAt", "INSERT INTO "+store.termRelationTableName+" (id, post_id, term_id, sequence, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
 		relationID,
 		postID,
 		termID,
@@ -577,7 +763,7 @@ func (store *storeImplementation) PostInsertTermAt(ctx context.Context, postID s
 	}
 
 	// Increment term count
-	_, err = db.ExecContext(ctx, "UPDATE "+store.termTableName+" SET count = count + 1 WHERE id = ?", termID)
+	_, err = store.execContext(ctx, db, "PostInsertTermAt", "UPDATE "+store.termTableName+" SET count = count + 1 WHERE id = ?", termID)
 	return err
 }
 
@@ -588,6 +774,10 @@ func (store *storeImplementation) PostAddTerm(ctx context.Context, postID string
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -595,6 +785,10 @@ func (store *storeImplementation) PostAddTerm(ctx context.Context, postID string
 		return errors.New("post id and term id are required")
 	}
 
+	if err := store.authorizePost(ctx, ACTION_UPDATE, postID); err != nil {
+		return err
+	}
+
 	maxSeq, err := store.postMaxSequence(ctx, postID)
 	if err != nil {
 		return err
@@ -611,7 +805,7 @@ func (store *storeImplementation) postMaxSequence(ctx context.Context, postID st
 		return 0, err
 	}
 	var maxSeq int
-	err = db.QueryRowContext(ctx, "SELECT COALESCE(MAX(sequence), 0) FROM "+store.termRelationTableName+" WHERE post_id = ?", postID).Scan(&maxSeq)
+	err = store.queryRowContext(ctx, db, "postMaxSequence", "SELECT COALESCE(MAX(sequence), 0) FROM "+store.termRelationTableName+" WHERE post_id = ?", []any{postID}, &maxSeq)
 	return maxSeq, err
 }
 
@@ -622,12 +816,20 @@ func (store *storeImplementation) PostMoveTermTo(ctx context.Context, postID str
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
 	if postID == "" || termID == "" {
 		return errors.New("post id and term id are required")
 	}
+
+	if err := store.authorizePost(ctx, ACTION_UPDATE, postID); err != nil {
+		return err
+	}
 	if sequence < 0 {
 		return errors.New("sequence must be non-negative")
 	}
@@ -693,7 +895,7 @@ func (store *storeImplementation) postTermList(ctx context.Context, postID strin
 	if err != nil {
 		return []TermRelationInterface{}, err
 	}
-	rows, err := db.QueryContext(ctx, "SELECT id, post_id, term_id, sequence, created_at, updated_at FROM "+store.termRelationTableName+" WHERE post_id = ? ORDER BY sequence ASC", postID)
+	rows, err := store.queryContext(ctx, db, "postTermList", "SELECT id, post_id, term_id, sequence, created_at, updated_at FROM "+store.termRelationTableName+" WHERE post_id = ? ORDER BY sequence ASC", postID)
 	if err != nil {
 		return []TermRelationInterface{}, err
 	}
@@ -730,12 +932,12 @@ func (store *storeImplementation) postTermList(ctx context.Context, postID strin
 
 // postTermUpdateSequence updates the sequence of a specific term relation.
 func (store *storeImplementation) postTermUpdateSequence(ctx context.Context, postID, termID string, sequence int) error {
-	_, err := store.db.Query().
+	_, err := store.query(ctx).
 		Table(store.termRelationTableName).
 		Where(COLUMN_POST_ID+" = ? AND "+COLUMN_TERM_ID+" = ?", postID, termID).
 		Update(map[string]interface{}{
 			COLUMN_SEQUENCE:   sequence,
-			COLUMN_UPDATED_AT: carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC),
+			COLUMN_UPDATED_AT: store.now().ToDateTimeString(carbon.UTC),
 		})
 	return err
 }
@@ -747,6 +949,10 @@ func (store *storeImplementation) PostRemoveTerm(ctx context.Context, postID str
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -754,7 +960,11 @@ func (store *storeImplementation) PostRemoveTerm(ctx context.Context, postID str
 		return errors.New("post id and term id are required")
 	}
 
-	_, err := store.db.Query().
+	if err := store.authorizePost(ctx, ACTION_UPDATE, postID); err != nil {
+		return err
+	}
+
+	_, err := store.query(ctx).
 		Table(store.termRelationTableName).
 		Where(COLUMN_POST_ID+" = ? AND "+COLUMN_TERM_ID+" = ?", postID, termID).
 		Delete()
@@ -767,7 +977,7 @@ func (store *storeImplementation) PostRemoveTerm(ctx context.Context, postID str
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "UPDATE "+store.termTableName+" SET count = CASE WHEN count > 0 THEN count - 1 ELSE 0 END WHERE id = ?", termID)
+	_, err = store.execContext(ctx, db, "PostRemoveTerm", "UPDATE "+store.termTableName+" SET count = CASE WHEN count > 0 THEN count - 1 ELSE 0 END WHERE id = ?", termID)
 	return err
 }
 
@@ -775,6 +985,9 @@ func (store *storeImplementation) PostRemoveTerm(ctx context.Context, postID str
 // Optionally filters by taxonomy slug.
 // Returns an error if taxonomy features are not enabled.
 func (store *storeImplementation) TermListByPostID(ctx context.Context, postID string, taxonomySlug string) ([]TermInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return []TermInterface{}, errors.New("taxonomy is not enabled")
 	}
@@ -831,6 +1044,10 @@ func (store *storeImplementation) PostListByTermID(ctx context.Context, termID s
 	if ctx == nil {
 		return []PostInterface{}, errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return []PostInterface{}, errors.New("taxonomy is not enabled")
 	}
@@ -838,30 +1055,7 @@ func (store *storeImplementation) PostListByTermID(ctx context.Context, termID s
 		return []PostInterface{}, errors.New("term id is required")
 	}
 
-	db, err := store.db.DB()
-	if err != nil {
-		return []PostInterface{}, err
-	}
-	rows, err := db.QueryContext(ctx, "SELECT post_id FROM "+store.termRelationTableName+" WHERE term_id = ?", termID)
-	if err != nil {
-		return []PostInterface{}, err
-	}
-	defer rows.Close()
-
-	var postIDs []string
-	for rows.Next() {
-		var postID string
-		if err := rows.Scan(&postID); err != nil {
-			return []PostInterface{}, err
-		}
-		postIDs = append(postIDs, postID)
-	}
-
-	if len(postIDs) == 0 {
-		return []PostInterface{}, nil
-	}
-
-	options.IDIn = postIDs
+	options.TermID = termID
 	return store.PostList(ctx, options)
 }
 
@@ -872,6 +1066,10 @@ func (store *storeImplementation) PostSetTerms(ctx context.Context, postID strin
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
@@ -879,6 +1077,10 @@ func (store *storeImplementation) PostSetTerms(ctx context.Context, postID strin
 		return errors.New("post id is required")
 	}
 
+	if err := store.authorizePost(ctx, ACTION_UPDATE, postID); err != nil {
+		return err
+	}
+
 	taxonomy, err := store.TaxonomyFindBySlug(ctx, taxonomySlug)
 	if err != nil {
 		return err
@@ -934,9 +1136,17 @@ func (store *storeImplementation) TermIncrementCount(ctx context.Context, termID
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if termID == "" {
 		return errors.New("term id is required")
 	}
@@ -945,7 +1155,7 @@ func (store *storeImplementation) TermIncrementCount(ctx context.Context, termID
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "UPDATE "+store.termTableName+" SET count = count + 1 WHERE id = ?", termID)
+	_, err = store.execContext(ctx, db, "TermIncrementCount", "UPDATE "+store.termTableName+" SET count = count + 1 WHERE id = ?", termID)
 	return err
 }
 
@@ -956,9 +1166,17 @@ func (store *storeImplementation) TermDecrementCount(ctx context.Context, termID
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if !store.taxonomyEnabled {
 		return errors.New("taxonomy is not enabled")
 	}
+
+	if err := store.authorize(ctx, ACTION_MANAGE_TAXONOMY, nil); err != nil {
+		return err
+	}
 	if termID == "" {
 		return errors.New("term id is required")
 	}
@@ -967,6 +1185,6 @@ func (store *storeImplementation) TermDecrementCount(ctx context.Context, termID
 	if err != nil {
 		return err
 	}
-	_, err = db.ExecContext(ctx, "UPDATE "+store.termTableName+" SET count = CASE WHEN count > 0 THEN count - 1 ELSE 0 END WHERE id = ?", termID)
+	_, err = store.execContext(ctx, db, "TermDecrementCount", "UPDATE "+store.termTableName+" SET count = CASE WHEN count > 0 THEN count - 1 ELSE 0 END WHERE id = ?", termID)
 	return err
 }
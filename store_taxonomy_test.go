@@ -2,6 +2,8 @@ package blogstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/samber/lo"
@@ -495,6 +497,154 @@ func TestStorePostListByTermID(t *testing.T) {
 	}
 }
 
+func TestStorePostListTermIDFilter(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TaxonomyEnabled:    true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	categories := NewTaxonomy().SetName("Categories").SetSlug(TAXONOMY_CATEGORY)
+	if err := store.TaxonomyCreate(ctx, categories); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	parent := NewTerm().SetTaxonomyID(categories.GetID()).SetName("Programming").SetSlug("programming")
+	if err := store.TermCreate(ctx, parent); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	child := NewTerm().SetTaxonomyID(categories.GetID()).SetParentID(parent.GetID()).SetName("Go").SetSlug("go")
+	if err := store.TermCreate(ctx, child); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	published := NewPost().SetTitle("Published").SetStatus(POST_STATUS_PUBLISHED)
+	draft := NewPost().SetTitle("Draft").SetStatus(POST_STATUS_DRAFT)
+	other := NewPost().SetTitle("Other").SetStatus(POST_STATUS_PUBLISHED)
+	for _, post := range []PostInterface{published, draft, other} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	for _, post := range []PostInterface{published, draft} {
+		if err := store.PostSetTerms(ctx, post.GetID(), TAXONOMY_CATEGORY, []string{child.GetID()}); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	posts, err := store.PostList(ctx, PostQueryOptions{TermID: child.GetID(), Status: POST_STATUS_PUBLISHED})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(posts) != 1 || posts[0].GetID() != published.GetID() {
+		t.Fatal("Expected the published post of the category, got:", posts)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{TermID: child.GetID()})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 2 {
+		t.Fatalf("Expected 2 posts in the category, got %d", count)
+	}
+
+	count, err = store.PostCount(ctx, PostQueryOptions{TermID: parent.GetID()})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 0 {
+		t.Fatalf("Expected no posts in the parent category, got %d", count)
+	}
+}
+
+func TestStorePostListTermIDInAndTagsByCount(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TaxonomyEnabled:    true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	tags := NewTaxonomy().SetName("Tags").SetSlug(TAXONOMY_TAG)
+	categories := NewTaxonomy().SetName("Categories").SetSlug(TAXONOMY_CATEGORY)
+	for _, taxonomy := range []TaxonomyInterface{tags, categories} {
+		if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	golang := NewTerm().SetTaxonomyID(tags.GetID()).SetName("Go").SetSlug("go")
+	rust := NewTerm().SetTaxonomyID(tags.GetID()).SetName("Rust").SetSlug("rust")
+	sql := NewTerm().SetTaxonomyID(tags.GetID()).SetName("SQL").SetSlug("sql")
+	news := NewTerm().SetTaxonomyID(categories.GetID()).SetName("News").SetSlug("news")
+	for _, term := range []TermInterface{golang, rust, sql, news} {
+		if err := store.TermCreate(ctx, term); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	post1 := NewPost().SetTitle("Post 1")
+	post2 := NewPost().SetTitle("Post 2")
+	post3 := NewPost().SetTitle("Post 3")
+	for _, post := range []PostInterface{post1, post2, post3} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	setTags := map[PostInterface][]string{
+		post1: {golang.GetID(), sql.GetID()},
+		post2: {golang.GetID()},
+		post3: {rust.GetID()},
+	}
+	for post, termIDs := range setTags {
+		if err := store.PostSetTerms(ctx, post.GetID(), TAXONOMY_TAG, termIDs); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	posts, err := store.PostList(ctx, PostQueryOptions{TermIDIn: []string{golang.GetID(), sql.GetID()}, OrderBy: COLUMN_TITLE, SortOrder: SORT_ORDER_ASC})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(posts) != 2 || posts[0].GetID() != post1.GetID() || posts[1].GetID() != post2.GetID() {
+		t.Fatal("Expected the posts tagged go or sql once each, got:", posts)
+	}
+
+	terms, err := store.TermList(ctx, TermQueryOptions{TaxonomySlug: TAXONOMY_TAG, OrderBy: COLUMN_COUNT, SortOrder: SORT_ORDER_DESC})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(terms) != 3 || terms[0].GetID() != golang.GetID() || terms[0].GetCount() != 2 {
+		t.Fatal("Expected the tags with the most used first, got:", terms)
+	}
+
+	count, err := store.TermCount(ctx, TermQueryOptions{TaxonomySlug: TAXONOMY_TAG, OrderBy: COLUMN_COUNT})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 3 {
+		t.Fatalf("Expected 3 tags, got %d", count)
+	}
+
+	_, err = store.TermList(ctx, TermQueryOptions{OrderBy: "1=1"})
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) {
+		t.Fatal("Expected an OrderError, got:", err)
+	}
+}
+
 func TestStoreTermIncrementDecrementCount(t *testing.T) {
 	db := initDB()
 
@@ -982,3 +1132,56 @@ func TestStorePostMoveTermToErrors(t *testing.T) {
 		t.Error("PostMoveTermTo() with non-existent term error = nil, want error")
 	}
 }
+
+func TestStoreTermVersioning(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+		TaxonomyEnabled:     true,
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versioning",
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	taxonomy := NewTaxonomy().SetName("Categories").SetSlug(TAXONOMY_CATEGORY)
+	if err := store.TaxonomyCreate(ctx, taxonomy); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	term := NewTerm().SetTaxonomyID(taxonomy.GetID()).SetName("Tech").SetSlug("tech")
+	if err := store.TermCreate(ctx, term); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	term.SetName("Technology")
+	if err := store.TermUpdate(ctx, term); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_TERM).
+		SetEntityID(term.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("VersioningList() = %d term versions, want 2", len(versions))
+	}
+
+	names := []string{}
+	for _, version := range versions {
+		data := map[string]string{}
+		if err := json.Unmarshal([]byte(version.Content()), &data); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		names = append(names, data[COLUMN_NAME])
+	}
+	if !lo.Contains(names, "Tech") || !lo.Contains(names, "Technology") {
+		t.Fatalf("term version names = %v, want Tech and Technology", names)
+	}
+}
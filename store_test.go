@@ -3,9 +3,13 @@ package blogstore
 import (
 	"context"
 	"database/sql"
+	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/dracory/sb"
+	"github.com/dromara/carbon/v2"
 	_ "modernc.org/sqlite"
 )
 
@@ -331,6 +335,215 @@ func TestStorePostListAndCount(t *testing.T) {
 	}
 }
 
+func TestStorePostListPublishedAtFilters(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	now := carbon.Now(carbon.UTC)
+
+	posts := []PostInterface{
+		NewPost().SetTitle("Last year").SetPublishedAt(now.SubYear().ToDateTimeString()),
+		NewPost().SetTitle("Yesterday").SetPublishedAt(now.SubDay().ToDateTimeString()),
+		NewPost().SetTitle("Scheduled").SetPublishedAt(now.AddDay().ToDateTimeString()),
+	}
+	for _, p := range posts {
+		if err := store.PostCreate(ctx, p); err != nil {
+			t.Fatalf("PostCreate() error = %v, want nil", err)
+		}
+	}
+
+	titles := func(options PostQueryOptions) []string {
+		options.OrderBy = COLUMN_PUBLISHED_AT
+		options.SortOrder = SORT_ORDER_ASC
+		list, err := store.PostList(ctx, options)
+		if err != nil {
+			t.Fatalf("PostList() error = %v, want nil", err)
+		}
+		result := []string{}
+		for _, p := range list {
+			result = append(result, p.GetTitle())
+		}
+		return result
+	}
+
+	if got := titles(PostQueryOptions{PublishedBeforeNow: true}); !reflect.DeepEqual(got, []string{"Last year", "Yesterday"}) {
+		t.Fatalf("PublishedBeforeNow = %v, want [Last year Yesterday]", got)
+	}
+
+	monthAgo := now.SubMonth().ToDateTimeString()
+	if got := titles(PostQueryOptions{PublishedAtGreaterThan: monthAgo}); !reflect.DeepEqual(got, []string{"Yesterday", "Scheduled"}) {
+		t.Fatalf("PublishedAtGreaterThan = %v, want [Yesterday Scheduled]", got)
+	}
+	if got := titles(PostQueryOptions{PublishedAtLessThan: monthAgo}); !reflect.DeepEqual(got, []string{"Last year"}) {
+		t.Fatalf("PublishedAtLessThan = %v, want [Last year]", got)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{PublishedBeforeNow: true})
+	if err != nil {
+		t.Fatalf("PostCount() error = %v, want nil", err)
+	}
+	if count != 2 {
+		t.Fatalf("PostCount() = %d, want 2", count)
+	}
+}
+
+func TestStorePostListAuthorAndFeaturedFilters(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	posts := []PostInterface{
+		NewPost().SetTitle("Alice featured").SetAuthorID("alice").SetFeatured(YES),
+		NewPost().SetTitle("Alice").SetAuthorID("alice"),
+		NewPost().SetTitle("Bob featured").SetAuthorID("bob").SetFeatured(YES),
+	}
+	for _, p := range posts {
+		if err := store.PostCreate(ctx, p); err != nil {
+			t.Fatalf("PostCreate() error = %v, want nil", err)
+		}
+	}
+
+	titles := func(options PostQueryOptions) []string {
+		options.OrderBy = COLUMN_TITLE
+		options.SortOrder = SORT_ORDER_ASC
+		list, err := store.PostList(ctx, options)
+		if err != nil {
+			t.Fatalf("PostList() error = %v, want nil", err)
+		}
+		result := []string{}
+		for _, p := range list {
+			result = append(result, p.GetTitle())
+		}
+		return result
+	}
+
+	if got := titles(PostQueryOptions{AuthorID: "alice"}); !reflect.DeepEqual(got, []string{"Alice", "Alice featured"}) {
+		t.Fatalf("AuthorID = %v, want [Alice Alice featured]", got)
+	}
+	if got := titles(PostQueryOptions{Featured: YES}); !reflect.DeepEqual(got, []string{"Alice featured", "Bob featured"}) {
+		t.Fatalf("Featured = %v, want [Alice featured Bob featured]", got)
+	}
+	if got := titles(PostQueryOptions{AuthorID: "alice", Featured: NO}); !reflect.DeepEqual(got, []string{"Alice"}) {
+		t.Fatalf("AuthorID and Featured = %v, want [Alice]", got)
+	}
+}
+
+func TestStorePostCountIgnoresPaginationAndOrdering(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	for _, status := range []string{POST_STATUS_PUBLISHED, POST_STATUS_PUBLISHED, POST_STATUS_PUBLISHED, POST_STATUS_DRAFT} {
+		if err := store.PostCreate(ctx, NewPost().SetTitle("Post").SetStatus(status)); err != nil {
+			t.Fatalf("PostCreate() error = %v, want nil", err)
+		}
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{
+		Status:    POST_STATUS_PUBLISHED,
+		Limit:     1,
+		Offset:    1,
+		OrderBy:   COLUMN_CREATED_AT,
+		SortOrder: sb.DESC,
+	})
+	if err != nil {
+		t.Fatalf("PostCount() error = %v, want nil", err)
+	}
+	if count != 3 {
+		t.Fatalf("PostCount() = %d, want %d", count, 3)
+	}
+
+	count, err = store.PostCount(ctx, PostQueryOptions{IDIn: []string{"missing"}})
+	if err != nil {
+		t.Fatalf("PostCount() error = %v, want nil", err)
+	}
+	if count != 0 {
+		t.Fatalf("PostCount() = %d, want %d", count, 0)
+	}
+}
+
+func TestStorePostCountByStatus(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	for _, status := range []string{POST_STATUS_PUBLISHED, POST_STATUS_PUBLISHED, POST_STATUS_DRAFT, POST_STATUS_TRASH} {
+		if err := store.PostCreate(ctx, NewPost().SetTitle("Post").SetStatus(status)); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	deleted := NewPost().SetTitle("Deleted").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(ctx, deleted); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostSoftDelete(ctx, deleted); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	counts, err := store.PostCountByStatus(ctx, PostQueryOptions{Limit: 1})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(counts) != 3 || counts[POST_STATUS_PUBLISHED] != 2 || counts[POST_STATUS_DRAFT] != 1 || counts[POST_STATUS_TRASH] != 1 {
+		t.Fatal("unexpected counts:", counts)
+	}
+
+	counts, err = store.PostCountByStatus(ctx, PostQueryOptions{WithDeleted: true, StatusIn: []string{POST_STATUS_DRAFT}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(counts) != 1 || counts[POST_STATUS_DRAFT] != 2 {
+		t.Fatal("unexpected counts with deleted drafts:", counts)
+	}
+}
+
+func TestStorePostCountPropagatesErrors(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts_missing",
+		DB:            initDB(),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	count, err := store.PostCount(context.Background(), PostQueryOptions{})
+	if err == nil {
+		t.Fatal("PostCount() error = nil, want error for missing table")
+	}
+	if count != 0 {
+		t.Fatalf("PostCount() = %d, want %d", count, 0)
+	}
+}
+
 func TestStorePostListMetaEquals(t *testing.T) {
 	db := initDB()
 
@@ -447,6 +660,154 @@ func TestStorePostTrashAndUpdate(t *testing.T) {
 	}
 }
 
+func TestStorePostUpdateRefreshesUpdatedAt(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Timestamps")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatalf("PostCreate() error = %v, want nil", err)
+	}
+
+	post.SetUpdatedAt("2020-01-02 03:04:05")
+	post.SetTitle("Timestamps changed")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatalf("PostUpdate() error = %v, want nil", err)
+	}
+
+	found, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatalf("PostFindByID() error = %v, want nil", err)
+	}
+	if !found.GetUpdatedAtCarbon().Gt(carbon.Parse("2020-01-02 03:04:05", carbon.UTC)) {
+		t.Fatalf("UpdatedAt after PostUpdate() = %q, want it refreshed", found.GetUpdatedAt())
+	}
+}
+
+func TestStorePostCreateIdempotencyKey(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	first := NewPost().SetTitle("First attempt").SetIdempotencyKey("req-1")
+	if err := store.PostCreate(ctx, first); err != nil {
+		t.Fatalf("PostCreate() error = %v, want nil", err)
+	}
+
+	retry := NewPost().SetTitle("Retried attempt").SetIdempotencyKey("req-1")
+	if err := store.PostCreate(ctx, retry); err != nil {
+		t.Fatalf("PostCreate() retry error = %v, want nil", err)
+	}
+	if retry.GetID() != first.GetID() {
+		t.Fatalf("retry ID = %q, want %q", retry.GetID(), first.GetID())
+	}
+	if retry.GetTitle() != "First attempt" {
+		t.Fatalf("retry title = %q, want the stored post", retry.GetTitle())
+	}
+
+	other := NewPost().SetTitle("Other request").SetIdempotencyKey("req-2")
+	if err := store.PostCreate(ctx, other); err != nil {
+		t.Fatalf("PostCreate() error = %v, want nil", err)
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 2 {
+		t.Fatalf("PostCount() = %d, want 2", count)
+	}
+}
+
+func TestStorePostCreateIdempotencyKeyConcurrent(t *testing.T) {
+	var store StoreInterface
+	winner := NewPost().SetTitle("Winner").SetIdempotencyKey("req-1")
+
+	// a concurrent create with the same key inserts between the lookup of
+	// the key and the insert
+	raced := false
+	hooks := NewHooks().OnBeforePostCreate(func(ctx context.Context, post PostInterface) error {
+		if raced {
+			return nil
+		}
+		raced = true
+		return store.PostCreate(ctx, winner)
+	})
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Hooks:              hooks,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	loser := NewPost().SetTitle("Loser").SetIdempotencyKey("req-1")
+	if err := store.PostCreate(ctx, loser); err != nil {
+		t.Fatalf("PostCreate() error = %v, want nil", err)
+	}
+	if loser.GetID() != winner.GetID() || loser.GetTitle() != "Winner" {
+		t.Fatalf("PostCreate() = %q %q, want the post created first", loser.GetID(), loser.GetTitle())
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("PostCount() = %d, want 1", count)
+	}
+
+	// posts without a key are not affected by the unique index
+	for range 2 {
+		if err := store.PostCreate(ctx, NewPost().SetTitle("No key")); err != nil {
+			t.Fatalf("PostCreate() error = %v, want nil", err)
+		}
+	}
+}
+
+func TestStoreMigrateUpAddsIdempotencyKeyColumn(t *testing.T) {
+	db := initDB()
+
+	_, err := db.Exec("CREATE TABLE blog_posts (id VARCHAR(21) PRIMARY KEY, slug VARCHAR(255) DEFAULT '', title VARCHAR(255), content TEXT, summary TEXT, status VARCHAR(50), author_id VARCHAR(40), canonical_url VARCHAR(255) DEFAULT '', image_url VARCHAR(255) DEFAULT '', memo VARCHAR(255) DEFAULT '', meta_description VARCHAR(255) DEFAULT '', meta_keywords VARCHAR(255) DEFAULT '', meta_robots VARCHAR(50) DEFAULT '', metas TEXT DEFAULT '{}', featured VARCHAR(3) DEFAULT 'no', published_at DATETIME, created_at DATETIME, updated_at DATETIME, soft_deleted_at DATETIME)")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 db,
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post := NewPost().SetTitle("Migrated").SetIdempotencyKey("req-1")
+	if err := store.PostCreate(context.Background(), post); err != nil {
+		t.Fatalf("PostCreate() error = %v, want nil", err)
+	}
+}
+
 func TestStorePostSoftDeleteAndDelete(t *testing.T) {
 	db := initDB()
 
@@ -600,6 +961,120 @@ func TestStorePostFindPreviousAndNext(t *testing.T) {
 	}
 }
 
+func TestStorePostFindByCanonicalURL(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Imported").SetCanonicalURL("https://example.com/imported")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Local")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	found, err := store.PostFindByCanonicalURL(ctx, "https://example.com/imported")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found == nil || found.GetID() != post.GetID() {
+		t.Fatal("Expected the imported post, got:", found)
+	}
+
+	found, err = store.PostFindByCanonicalURL(ctx, "https://example.com/missing")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if found != nil {
+		t.Fatal("Expected no post, got:", found.GetTitle())
+	}
+
+	if _, err := store.PostFindByCanonicalURL(ctx, ""); err == nil {
+		t.Fatal("Expected an error for an empty canonical url")
+	}
+
+	count, err := store.PostCount(ctx, PostQueryOptions{CanonicalURL: "https://example.com/imported"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 post with the canonical url, got %d", count)
+	}
+}
+
+func TestStorePostFindPreviousAndNextPublished(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	create := func(title, status, publishedAt string) PostInterface {
+		post := NewPost().SetTitle(title).SetStatus(status).SetPublishedAt(publishedAt)
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		return post
+	}
+
+	// created in reverse, so creation order differs from publication order
+	create("Scheduled", POST_STATUS_PUBLISHED, "2999-01-01 00:00:00")
+	last := create("Last", POST_STATUS_PUBLISHED, "2020-01-05 00:00:00")
+	deleted := create("Deleted", POST_STATUS_PUBLISHED, "2020-01-04 00:00:00")
+	middle := create("Middle", POST_STATUS_PUBLISHED, "2020-01-03 00:00:00")
+	create("Draft", POST_STATUS_DRAFT, "2020-01-02 00:00:00")
+	first := create("First", POST_STATUS_PUBLISHED, "2020-01-01 00:00:00")
+
+	if err := store.PostSoftDelete(ctx, deleted); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	previous, err := store.PostFindPreviousPublished(ctx, middle)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if previous == nil || previous.GetID() != first.GetID() {
+		t.Fatal("Expected the first post before the middle one, got:", previous)
+	}
+
+	next, err := store.PostFindNextPublished(ctx, middle)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if next == nil || next.GetID() != last.GetID() {
+		t.Fatal("Expected the last post after the middle one, got:", next)
+	}
+
+	next, err = store.PostFindNextPublished(ctx, last)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if next != nil {
+		t.Fatal("Expected no post after the last one, got:", next.GetTitle())
+	}
+
+	previous, err = store.PostFindPreviousPublished(ctx, first)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if previous != nil {
+		t.Fatal("Expected no post before the first one, got:", previous.GetTitle())
+	}
+}
+
 func TestStorePostListSearchOrderingAndWithDeleted(t *testing.T) {
 	db := initDB()
 
@@ -680,3 +1155,157 @@ func TestStorePostListSearchOrderingAndWithDeleted(t *testing.T) {
 		t.Fatalf("PostList() WithDeleted len = %d, want %d", len(listWithDeleted), 3)
 	}
 }
+
+func TestStoreConnectionPoolOptions(t *testing.T) {
+	db := initDB()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 db,
+		AutomigrateEnabled: true,
+		MaxOpenConns:       5,
+		MaxIdleConns:       2,
+		ConnMaxLifetime:    time.Minute,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	stats := store.DBStats()
+	if stats.MaxOpenConnections != 5 {
+		t.Fatalf("expected max open connections 5, got %d", stats.MaxOpenConnections)
+	}
+
+	if db.Stats().MaxOpenConnections != 5 {
+		t.Fatal("expected pool settings to be applied to the provided DB")
+	}
+}
+
+func TestStoreTablePrefix(t *testing.T) {
+	db := initDB()
+	store, err := NewStore(NewStoreOptions{
+		DB:                  db,
+		TablePrefix:         "app1_",
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		ViewsEnabled:        true,
+		ViewsTableName:      "blog_post_views",
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if store.GetPostTableName() != "app1_blog_posts" {
+		t.Fatal("Expected the prefixed post table name, got:", store.GetPostTableName())
+	}
+	if store.GetTaxonomyTableName() != "app1_blog_taxonomy" {
+		t.Fatal("Expected the prefixed default taxonomy table name, got:", store.GetTaxonomyTableName())
+	}
+	if store.GetViewsTableName() != "app1_blog_post_views" {
+		t.Fatal("Expected the prefixed views table name, got:", store.GetViewsTableName())
+	}
+
+	for _, table := range []string{"app1_blog_posts", "app1_blog_versions", "app1_blog_post_views"} {
+		var name string
+		err := db.QueryRow("SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name)
+		if err != nil {
+			t.Fatal("Expected table", table, "to be created:", err)
+		}
+	}
+
+	if err := store.PostCreate(context.Background(), NewPost().SetTitle("Prefixed")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestStorePostListSearchIgnoresCase(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	for _, post := range []PostInterface{
+		NewPost().SetTitle("Learning GoLang").SetContent("Basics"),
+		NewPost().SetTitle("Cooking").SetContent("A recipe from golang fans"),
+		NewPost().SetTitle("Travel").SetContent("Nothing to see"),
+	} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	for _, search := range []string{"golang", "GOLANG", "GoLang"} {
+		count, err := store.PostCount(ctx, PostQueryOptions{Search: search})
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if count != 2 {
+			t.Fatalf("Expected 2 posts matching %q, got %d", search, count)
+		}
+	}
+}
+
+func TestStorePostListRandom(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	for i := range 20 {
+		post := NewPost().SetTitle("Post " + strconv.Itoa(i)).SetStatus(POST_STATUS_PUBLISHED)
+		if i == 0 {
+			post.SetFeatured(YES)
+		}
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	if err := store.PostCreate(ctx, NewPost().SetTitle("Draft").SetStatus(POST_STATUS_DRAFT)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	orders := map[string]bool{}
+	for range 10 {
+		posts, err := store.PostList(ctx, PostQueryOptions{Status: POST_STATUS_PUBLISHED, Random: true})
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if len(posts) != 20 {
+			t.Fatalf("Expected 20 published posts, got %d", len(posts))
+		}
+
+		order := ""
+		for _, post := range posts {
+			order += post.GetID() + ","
+		}
+		orders[order] = true
+	}
+	if len(orders) < 2 {
+		t.Fatal("Expected the posts in different orders")
+	}
+
+	posts, err := store.PostList(ctx, PostQueryOptions{
+		Status:       POST_STATUS_PUBLISHED,
+		OrderByMulti: []OrderSpec{{Column: COLUMN_FEATURED, Direction: SORT_ORDER_DESC}},
+		Random:       true,
+		Limit:        3,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(posts) != 3 || posts[0].GetFeatured() != YES {
+		t.Fatal("Expected the featured post first, then random posts")
+	}
+}
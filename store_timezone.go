@@ -0,0 +1,57 @@
+package blogstore
+
+import (
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// storedDateTimeLayout is the layout of the datetime strings the post, media
+// and versioning getters return, always in UTC.
+const storedDateTimeLayout = "2006-01-02 15:04:05"
+
+// Timezone returns the location used by FormatDateTime and ParseDateTime.
+// Timestamps are always stored in UTC.
+func (store *storeImplementation) Timezone() *time.Location {
+	if store.timezone == nil {
+		return time.UTC
+	}
+	return store.timezone
+}
+
+// FormatDateTime converts a stored UTC datetime string, e.g. post.GetPublishedAt(),
+// to the store timezone, formatted with the configured DateTimeFormat.
+// Empty, null and max datetimes (not set, not soft deleted) are returned unchanged.
+func (store *storeImplementation) FormatDateTime(value string) string {
+	if value == "" || value == NULL_DATETIME || value == MAX_DATETIME {
+		return value
+	}
+
+	parsed := carbon.Parse(value, carbon.UTC)
+	if parsed.Error != nil || parsed.IsInvalid() {
+		return value
+	}
+
+	return parsed.StdTime().In(store.Timezone()).Format(store.dateTimeLayout())
+}
+
+// ParseDateTime converts a datetime string in the store timezone and the
+// configured DateTimeFormat to the UTC datetime string the store expects,
+// e.g. for post.SetPublishedAt().
+func (store *storeImplementation) ParseDateTime(value string) (string, error) {
+	parsed, err := time.ParseInLocation(store.dateTimeLayout(), value, store.Timezone())
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.UTC().Format(storedDateTimeLayout), nil
+}
+
+// dateTimeLayout returns the configured DateTimeFormat, defaulting to the
+// stored layout.
+func (store *storeImplementation) dateTimeLayout() string {
+	if store.dateTimeFormat == "" {
+		return storedDateTimeLayout
+	}
+	return store.dateTimeFormat
+}
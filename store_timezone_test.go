@@ -0,0 +1,65 @@
+package blogstore
+
+import "testing"
+
+func TestStoreTimezoneDefaultsToUTC(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		DB:            initDB(),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if store.Timezone().String() != "UTC" {
+		t.Fatalf("Timezone() = %q, want UTC", store.Timezone())
+	}
+	if got := store.FormatDateTime("2024-03-10 12:00:00"); got != "2024-03-10 12:00:00" {
+		t.Fatalf("FormatDateTime() = %q, want it unchanged", got)
+	}
+}
+
+func TestStoreTimezoneFormatAndParse(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:  "blog_posts",
+		DB:             initDB(),
+		Timezone:       "Europe/Berlin",
+		DateTimeFormat: "02.01.2006 15:04",
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got := store.FormatDateTime("2024-07-01 10:30:00"); got != "01.07.2024 12:30" {
+		t.Fatalf("FormatDateTime() = %q, want %q", got, "01.07.2024 12:30")
+	}
+
+	got, err := store.ParseDateTime("01.07.2024 12:30")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got != "2024-07-01 10:30:00" {
+		t.Fatalf("ParseDateTime() = %q, want %q", got, "2024-07-01 10:30:00")
+	}
+
+	if _, err := store.ParseDateTime("2024-07-01"); err == nil {
+		t.Fatal("expected error for a value not matching DateTimeFormat")
+	}
+
+	for _, value := range []string{"", NULL_DATETIME, MAX_DATETIME} {
+		if got := store.FormatDateTime(value); got != value {
+			t.Fatalf("FormatDateTime(%q) = %q, want it unchanged", value, got)
+		}
+	}
+}
+
+func TestStoreTimezoneInvalid(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		DB:            initDB(),
+		Timezone:      "Mars/Olympus",
+	})
+	if err == nil {
+		t.Fatal("expected error for an unknown timezone")
+	}
+}
@@ -0,0 +1,1203 @@
+package blogstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope name used for store spans.
+const tracerName = "github.com/dracory/blogstore"
+
+// Span attribute keys used by the tracing store.
+const (
+	attributeOperation = "db.operation.name"
+	attributeTable     = "db.collection.name"
+	attributeRowCount  = "db.response.returned_rows"
+)
+
+// tracingStore wraps the store implementation and records an OpenTelemetry span
+// for every public store operation. It is installed by NewStore when
+// NewStoreOptions.TracerProvider is set.
+type tracingStore struct {
+	*storeImplementation
+	tracer trace.Tracer
+}
+
+var _ StoreInterface = (*tracingStore)(nil) // verify it extends the interface
+
+// newTracingStore creates a tracing wrapper around the given store implementation.
+func newTracingStore(store *storeImplementation, provider trace.TracerProvider) *tracingStore {
+	return &tracingStore{
+		storeImplementation: store,
+		tracer:              provider.Tracer(tracerName),
+	}
+}
+
+// traceStart starts a span for the given store operation and table.
+// A nil context is passed through untouched so the wrapped method can report it.
+func (s *tracingStore) traceStart(ctx context.Context, operation string, table string) (context.Context, trace.Span) {
+	if ctx == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	return s.tracer.Start(ctx, "blogstore."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(attributeOperation, operation),
+			attribute.String(attributeTable, table),
+		))
+}
+
+// traceEnd records the error (if any) on the span and ends it.
+func traceEnd(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EnableDebug toggles debug mode and keeps the tracing wrapper in the chain.
+func (s *tracingStore) EnableDebug(debug bool) StoreInterface {
+	s.storeImplementation.EnableDebug(debug)
+	return s
+}
+
+// MigrateDown traces StoreInterface.MigrateDown.
+func (s *tracingStore) MigrateDown(ctx context.Context, tx ...*sql.Tx) error {
+	ctx, span := s.traceStart(ctx, "MigrateDown", "")
+	err := s.storeImplementation.MigrateDown(ctx, tx...)
+	traceEnd(span, err)
+	return err
+}
+
+// MigrateUp traces StoreInterface.MigrateUp.
+func (s *tracingStore) MigrateUp(ctx context.Context, tx ...*sql.Tx) error {
+	ctx, span := s.traceStart(ctx, "MigrateUp", "")
+	err := s.storeImplementation.MigrateUp(ctx, tx...)
+	traceEnd(span, err)
+	return err
+}
+
+// MigrateDownTo traces StoreInterface.MigrateDownTo.
+func (s *tracingStore) MigrateDownTo(ctx context.Context, version int) error {
+	ctx, span := s.traceStart(ctx, "MigrateDownTo", s.migrationsTableName)
+	err := s.storeImplementation.MigrateDownTo(ctx, version)
+	traceEnd(span, err)
+	return err
+}
+
+// SchemaVersion traces StoreInterface.SchemaVersion.
+func (s *tracingStore) SchemaVersion(ctx context.Context) (int, error) {
+	ctx, span := s.traceStart(ctx, "SchemaVersion", s.migrationsTableName)
+	version, err := s.storeImplementation.SchemaVersion(ctx)
+	traceEnd(span, err)
+	return version, err
+}
+
+// PostCount traces StoreInterface.PostCount.
+func (s *tracingStore) PostCount(ctx context.Context, options PostQueryOptions) (int64, error) {
+	ctx, span := s.traceStart(ctx, "PostCount", s.postTableName)
+	count, err := s.storeImplementation.PostCount(ctx, options)
+	span.SetAttributes(attribute.Int64(attributeRowCount, count))
+	traceEnd(span, err)
+	return count, err
+}
+
+// PostCountByStatus traces StoreInterface.PostCountByStatus.
+func (s *tracingStore) PostCountByStatus(ctx context.Context, options PostQueryOptions) (map[string]int64, error) {
+	ctx, span := s.traceStart(ctx, "PostCountByStatus", s.postTableName)
+	counts, err := s.storeImplementation.PostCountByStatus(ctx, options)
+	traceEnd(span, err)
+	return counts, err
+}
+
+// PostCreate traces StoreInterface.PostCreate.
+func (s *tracingStore) PostCreate(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostCreate", s.postTableName)
+	err := s.storeImplementation.PostCreate(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostCreateMany traces StoreInterface.PostCreateMany.
+func (s *tracingStore) PostCreateMany(ctx context.Context, posts []PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostCreateMany", s.postTableName)
+	err := s.storeImplementation.PostCreateMany(ctx, posts)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(posts)))
+	traceEnd(span, err)
+	return err
+}
+
+// PostStatusUpdateByIDs traces StoreInterface.PostStatusUpdateByIDs.
+func (s *tracingStore) PostStatusUpdateByIDs(ctx context.Context, ids []string, status string) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostStatusUpdateByIDs", s.postTableName)
+	updated, err := s.storeImplementation.PostStatusUpdateByIDs(ctx, ids, status)
+	span.SetAttributes(attribute.Int(attributeRowCount, updated))
+	traceEnd(span, err)
+	return updated, err
+}
+
+// PostEach traces StoreInterface.PostEach.
+func (s *tracingStore) PostEach(ctx context.Context, options PostQueryOptions, fn func(post PostInterface) error) error {
+	ctx, span := s.traceStart(ctx, "PostEach", s.postTableName)
+	count := 0
+	err := s.storeImplementation.PostEach(ctx, options, func(post PostInterface) error {
+		count++
+		return fn(post)
+	})
+	span.SetAttributes(attribute.Int(attributeRowCount, count))
+	traceEnd(span, err)
+	return err
+}
+
+// PostDeleteByIDs traces StoreInterface.PostDeleteByIDs.
+func (s *tracingStore) PostDeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostDeleteByIDs", s.postTableName)
+	deleted, err := s.storeImplementation.PostDeleteByIDs(ctx, ids)
+	span.SetAttributes(attribute.Int(attributeRowCount, deleted))
+	traceEnd(span, err)
+	return deleted, err
+}
+
+// PostSoftDeleteByIDs traces StoreInterface.PostSoftDeleteByIDs.
+func (s *tracingStore) PostSoftDeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostSoftDeleteByIDs", s.postTableName)
+	deleted, err := s.storeImplementation.PostSoftDeleteByIDs(ctx, ids)
+	span.SetAttributes(attribute.Int(attributeRowCount, deleted))
+	traceEnd(span, err)
+	return deleted, err
+}
+
+// PostDelete traces StoreInterface.PostDelete.
+func (s *tracingStore) PostDelete(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostDelete", s.postTableName)
+	err := s.storeImplementation.PostDelete(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostDeleteByID traces StoreInterface.PostDeleteByID.
+func (s *tracingStore) PostDeleteByID(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostDeleteByID", s.postTableName)
+	err := s.storeImplementation.PostDeleteByID(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostFindByID traces StoreInterface.PostFindByID.
+func (s *tracingStore) PostFindByID(ctx context.Context, id string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindByID", s.postTableName)
+	result, err := s.storeImplementation.PostFindByID(ctx, id)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostFindBySlug traces StoreInterface.PostFindBySlug.
+func (s *tracingStore) PostFindBySlug(ctx context.Context, slug string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindBySlug", s.postTableName)
+	result, err := s.storeImplementation.PostFindBySlug(ctx, slug)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostFindPreviousPublished traces StoreInterface.PostFindPreviousPublished.
+func (s *tracingStore) PostFindPreviousPublished(ctx context.Context, post PostInterface) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindPreviousPublished", s.postTableName)
+	result, err := s.storeImplementation.PostFindPreviousPublished(ctx, post)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostFindNextPublished traces StoreInterface.PostFindNextPublished.
+func (s *tracingStore) PostFindNextPublished(ctx context.Context, post PostInterface) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindNextPublished", s.postTableName)
+	result, err := s.storeImplementation.PostFindNextPublished(ctx, post)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostFindByCanonicalURL traces StoreInterface.PostFindByCanonicalURL.
+func (s *tracingStore) PostFindByCanonicalURL(ctx context.Context, canonicalURL string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindByCanonicalURL", s.postTableName)
+	result, err := s.storeImplementation.PostFindByCanonicalURL(ctx, canonicalURL)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostTranslations traces StoreInterface.PostTranslations.
+func (s *tracingStore) PostTranslations(ctx context.Context, postID string) ([]PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostTranslations", s.postTableName)
+	translations, err := s.storeImplementation.PostTranslations(ctx, postID)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(translations)))
+	traceEnd(span, err)
+	return translations, err
+}
+
+// PostFindByOldSlug traces StoreInterface.PostFindByOldSlug.
+func (s *tracingStore) PostFindByOldSlug(ctx context.Context, oldSlug string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostFindByOldSlug", s.postTableName)
+	result, err := s.storeImplementation.PostFindByOldSlug(ctx, oldSlug)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostSlugEnsureUnique traces StoreInterface.PostSlugEnsureUnique.
+func (s *tracingStore) PostSlugEnsureUnique(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostSlugEnsureUnique", s.postTableName)
+	err := s.storeImplementation.PostSlugEnsureUnique(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostFindBySlugOrHistory traces StoreInterface.PostFindBySlugOrHistory.
+func (s *tracingStore) PostFindBySlugOrHistory(ctx context.Context, slug string) (PostInterface, bool, error) {
+	ctx, span := s.traceStart(ctx, "PostFindBySlugOrHistory", s.postTableName)
+	post, moved, err := s.storeImplementation.PostFindBySlugOrHistory(ctx, slug)
+	traceEnd(span, err)
+	return post, moved, err
+}
+
+// RedirectResolve traces StoreInterface.RedirectResolve.
+func (s *tracingStore) RedirectResolve(ctx context.Context, oldSlug string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "RedirectResolve", s.postTableName)
+	post, err := s.storeImplementation.RedirectResolve(ctx, oldSlug)
+	traceEnd(span, err)
+	return post, err
+}
+
+// PostStatusHistory traces StoreInterface.PostStatusHistory.
+func (s *tracingStore) PostStatusHistory(ctx context.Context, postID string) ([]StatusChange, error) {
+	ctx, span := s.traceStart(ctx, "PostStatusHistory", s.statusHistoryTableName)
+	changes, err := s.storeImplementation.PostStatusHistory(ctx, postID)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(changes)))
+	traceEnd(span, err)
+	return changes, err
+}
+
+// PostList traces StoreInterface.PostList.
+func (s *tracingStore) PostList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostList", s.postTableName)
+	list, err := s.storeImplementation.PostList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostSearchRanked traces StoreInterface.PostSearchRanked.
+func (s *tracingStore) PostSearchRanked(ctx context.Context, query string, options SearchOptions) ([]SearchResult, error) {
+	ctx, span := s.traceStart(ctx, "PostSearchRanked", s.postTableName)
+	list, err := s.storeImplementation.PostSearchRanked(ctx, query, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostSoftDelete traces StoreInterface.PostSoftDelete.
+func (s *tracingStore) PostSoftDelete(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostSoftDelete", s.postTableName)
+	err := s.storeImplementation.PostSoftDelete(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostSoftDeleteByID traces StoreInterface.PostSoftDeleteByID.
+func (s *tracingStore) PostSoftDeleteByID(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostSoftDeleteByID", s.postTableName)
+	err := s.storeImplementation.PostSoftDeleteByID(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostDuplicate traces StoreInterface.PostDuplicate.
+func (s *tracingStore) PostDuplicate(ctx context.Context, postID string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostDuplicate", s.postTableName)
+	post, err := s.storeImplementation.PostDuplicate(ctx, postID)
+	traceEnd(span, err)
+	return post, err
+}
+
+// PostRestore traces StoreInterface.PostRestore.
+func (s *tracingStore) PostRestore(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostRestore", s.postTableName)
+	err := s.storeImplementation.PostRestore(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostRestoreByID traces StoreInterface.PostRestoreByID.
+func (s *tracingStore) PostRestoreByID(ctx context.Context, postID string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostRestoreByID", s.postTableName)
+	post, err := s.storeImplementation.PostRestoreByID(ctx, postID)
+	traceEnd(span, err)
+	return post, err
+}
+
+// PostTrash traces StoreInterface.PostTrash.
+func (s *tracingStore) PostTrash(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostTrash", s.postTableName)
+	err := s.storeImplementation.PostTrash(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostUntrash traces StoreInterface.PostUntrash.
+func (s *tracingStore) PostUntrash(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostUntrash", s.postTableName)
+	err := s.storeImplementation.PostUntrash(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// PostUpdate traces StoreInterface.PostUpdate.
+func (s *tracingStore) PostUpdate(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostUpdate", s.postTableName)
+	err := s.storeImplementation.PostUpdate(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningCreate traces StoreInterface.VersioningCreate.
+func (s *tracingStore) VersioningCreate(ctx context.Context, versioning VersioningInterface) error {
+	ctx, span := s.traceStart(ctx, "VersioningCreate", s.versioningTableName)
+	err := s.storeImplementation.VersioningCreate(ctx, versioning)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningDelete traces StoreInterface.VersioningDelete.
+func (s *tracingStore) VersioningDelete(ctx context.Context, versioning VersioningInterface) error {
+	ctx, span := s.traceStart(ctx, "VersioningDelete", s.versioningTableName)
+	err := s.storeImplementation.VersioningDelete(ctx, versioning)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningDeleteByID traces StoreInterface.VersioningDeleteByID.
+func (s *tracingStore) VersioningDeleteByID(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "VersioningDeleteByID", s.versioningTableName)
+	err := s.storeImplementation.VersioningDeleteByID(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningFindByID traces StoreInterface.VersioningFindByID.
+func (s *tracingStore) VersioningFindByID(ctx context.Context, versioningID string) (VersioningInterface, error) {
+	ctx, span := s.traceStart(ctx, "VersioningFindByID", s.versioningTableName)
+	result, err := s.storeImplementation.VersioningFindByID(ctx, versioningID)
+	traceEnd(span, err)
+	return result, err
+}
+
+// VersioningList traces StoreInterface.VersioningList.
+func (s *tracingStore) VersioningList(ctx context.Context, query VersioningQueryInterface) ([]VersioningInterface, error) {
+	ctx, span := s.traceStart(ctx, "VersioningList", s.versioningTableName)
+	list, err := s.storeImplementation.VersioningList(ctx, query)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// VersioningSoftDelete traces StoreInterface.VersioningSoftDelete.
+func (s *tracingStore) VersioningSoftDelete(ctx context.Context, versioning VersioningInterface) error {
+	ctx, span := s.traceStart(ctx, "VersioningSoftDelete", s.versioningTableName)
+	err := s.storeImplementation.VersioningSoftDelete(ctx, versioning)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningSoftDeleteByID traces StoreInterface.VersioningSoftDeleteByID.
+func (s *tracingStore) VersioningSoftDeleteByID(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "VersioningSoftDeleteByID", s.versioningTableName)
+	err := s.storeImplementation.VersioningSoftDeleteByID(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningUpdate traces StoreInterface.VersioningUpdate.
+func (s *tracingStore) VersioningUpdate(ctx context.Context, versioning VersioningInterface) error {
+	ctx, span := s.traceStart(ctx, "VersioningUpdate", s.versioningTableName)
+	err := s.storeImplementation.VersioningUpdate(ctx, versioning)
+	traceEnd(span, err)
+	return err
+}
+
+// VersioningCount traces StoreInterface.VersioningCount.
+func (s *tracingStore) VersioningCount(ctx context.Context, query VersioningQueryInterface) (int64, error) {
+	ctx, span := s.traceStart(ctx, "VersioningCount", s.versioningTableName)
+	count, err := s.storeImplementation.VersioningCount(ctx, query)
+	span.SetAttributes(attribute.Int64(attributeRowCount, count))
+	traceEnd(span, err)
+	return count, err
+}
+
+// VersioningLabel traces StoreInterface.VersioningLabel.
+func (s *tracingStore) VersioningLabel(ctx context.Context, versionID string, label string) error {
+	ctx, span := s.traceStart(ctx, "VersioningLabel", s.versioningTableName)
+	err := s.storeImplementation.VersioningLabel(ctx, versionID, label)
+	traceEnd(span, err)
+	return err
+}
+
+// PostRevertToVersion traces StoreInterface.PostRevertToVersion.
+func (s *tracingStore) PostRevertToVersion(ctx context.Context, postID string, versionID string) error {
+	ctx, span := s.traceStart(ctx, "PostRevertToVersion", s.postTableName)
+	err := s.storeImplementation.PostRevertToVersion(ctx, postID, versionID)
+	traceEnd(span, err)
+	return err
+}
+
+// TaxonomyCount traces StoreInterface.TaxonomyCount.
+func (s *tracingStore) TaxonomyCount(ctx context.Context, options TaxonomyQueryOptions) (int64, error) {
+	ctx, span := s.traceStart(ctx, "TaxonomyCount", s.taxonomyTableName)
+	count, err := s.storeImplementation.TaxonomyCount(ctx, options)
+	span.SetAttributes(attribute.Int64(attributeRowCount, count))
+	traceEnd(span, err)
+	return count, err
+}
+
+// TaxonomyCreate traces StoreInterface.TaxonomyCreate.
+func (s *tracingStore) TaxonomyCreate(ctx context.Context, taxonomy TaxonomyInterface) error {
+	ctx, span := s.traceStart(ctx, "TaxonomyCreate", s.taxonomyTableName)
+	err := s.storeImplementation.TaxonomyCreate(ctx, taxonomy)
+	traceEnd(span, err)
+	return err
+}
+
+// TaxonomyDelete traces StoreInterface.TaxonomyDelete.
+func (s *tracingStore) TaxonomyDelete(ctx context.Context, taxonomy TaxonomyInterface) error {
+	ctx, span := s.traceStart(ctx, "TaxonomyDelete", s.taxonomyTableName)
+	err := s.storeImplementation.TaxonomyDelete(ctx, taxonomy)
+	traceEnd(span, err)
+	return err
+}
+
+// TaxonomyFindByID traces StoreInterface.TaxonomyFindByID.
+func (s *tracingStore) TaxonomyFindByID(ctx context.Context, id string) (TaxonomyInterface, error) {
+	ctx, span := s.traceStart(ctx, "TaxonomyFindByID", s.taxonomyTableName)
+	result, err := s.storeImplementation.TaxonomyFindByID(ctx, id)
+	traceEnd(span, err)
+	return result, err
+}
+
+// TaxonomyFindBySlug traces StoreInterface.TaxonomyFindBySlug.
+func (s *tracingStore) TaxonomyFindBySlug(ctx context.Context, slug string) (TaxonomyInterface, error) {
+	ctx, span := s.traceStart(ctx, "TaxonomyFindBySlug", s.taxonomyTableName)
+	result, err := s.storeImplementation.TaxonomyFindBySlug(ctx, slug)
+	traceEnd(span, err)
+	return result, err
+}
+
+// TaxonomyList traces StoreInterface.TaxonomyList.
+func (s *tracingStore) TaxonomyList(ctx context.Context, options TaxonomyQueryOptions) ([]TaxonomyInterface, error) {
+	ctx, span := s.traceStart(ctx, "TaxonomyList", s.taxonomyTableName)
+	list, err := s.storeImplementation.TaxonomyList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// TaxonomyUpdate traces StoreInterface.TaxonomyUpdate.
+func (s *tracingStore) TaxonomyUpdate(ctx context.Context, taxonomy TaxonomyInterface) error {
+	ctx, span := s.traceStart(ctx, "TaxonomyUpdate", s.taxonomyTableName)
+	err := s.storeImplementation.TaxonomyUpdate(ctx, taxonomy)
+	traceEnd(span, err)
+	return err
+}
+
+// TermCount traces StoreInterface.TermCount.
+func (s *tracingStore) TermCount(ctx context.Context, options TermQueryOptions) (int64, error) {
+	ctx, span := s.traceStart(ctx, "TermCount", s.termTableName)
+	count, err := s.storeImplementation.TermCount(ctx, options)
+	span.SetAttributes(attribute.Int64(attributeRowCount, count))
+	traceEnd(span, err)
+	return count, err
+}
+
+// TermCreate traces StoreInterface.TermCreate.
+func (s *tracingStore) TermCreate(ctx context.Context, term TermInterface) error {
+	ctx, span := s.traceStart(ctx, "TermCreate", s.termTableName)
+	err := s.storeImplementation.TermCreate(ctx, term)
+	traceEnd(span, err)
+	return err
+}
+
+// TermDelete traces StoreInterface.TermDelete.
+func (s *tracingStore) TermDelete(ctx context.Context, term TermInterface) error {
+	ctx, span := s.traceStart(ctx, "TermDelete", s.termTableName)
+	err := s.storeImplementation.TermDelete(ctx, term)
+	traceEnd(span, err)
+	return err
+}
+
+// TermFindByID traces StoreInterface.TermFindByID.
+func (s *tracingStore) TermFindByID(ctx context.Context, id string) (TermInterface, error) {
+	ctx, span := s.traceStart(ctx, "TermFindByID", s.termTableName)
+	result, err := s.storeImplementation.TermFindByID(ctx, id)
+	traceEnd(span, err)
+	return result, err
+}
+
+// TermFindBySlug traces StoreInterface.TermFindBySlug.
+func (s *tracingStore) TermFindBySlug(ctx context.Context, taxonomySlug, termSlug string) (TermInterface, error) {
+	ctx, span := s.traceStart(ctx, "TermFindBySlug", s.termTableName)
+	result, err := s.storeImplementation.TermFindBySlug(ctx, taxonomySlug, termSlug)
+	traceEnd(span, err)
+	return result, err
+}
+
+// TermList traces StoreInterface.TermList.
+func (s *tracingStore) TermList(ctx context.Context, options TermQueryOptions) ([]TermInterface, error) {
+	ctx, span := s.traceStart(ctx, "TermList", s.termTableName)
+	list, err := s.storeImplementation.TermList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// TermUpdate traces StoreInterface.TermUpdate.
+func (s *tracingStore) TermUpdate(ctx context.Context, term TermInterface) error {
+	ctx, span := s.traceStart(ctx, "TermUpdate", s.termTableName)
+	err := s.storeImplementation.TermUpdate(ctx, term)
+	traceEnd(span, err)
+	return err
+}
+
+// PostAddTerm traces StoreInterface.PostAddTerm.
+func (s *tracingStore) PostAddTerm(ctx context.Context, postID string, termID string) error {
+	ctx, span := s.traceStart(ctx, "PostAddTerm", s.termRelationTableName)
+	err := s.storeImplementation.PostAddTerm(ctx, postID, termID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostInsertTermAt traces StoreInterface.PostInsertTermAt.
+func (s *tracingStore) PostInsertTermAt(ctx context.Context, postID string, termID string, sequence int) error {
+	ctx, span := s.traceStart(ctx, "PostInsertTermAt", s.termRelationTableName)
+	err := s.storeImplementation.PostInsertTermAt(ctx, postID, termID, sequence)
+	traceEnd(span, err)
+	return err
+}
+
+// PostMoveTermTo traces StoreInterface.PostMoveTermTo.
+func (s *tracingStore) PostMoveTermTo(ctx context.Context, postID string, termID string, sequence int) error {
+	ctx, span := s.traceStart(ctx, "PostMoveTermTo", s.termRelationTableName)
+	err := s.storeImplementation.PostMoveTermTo(ctx, postID, termID, sequence)
+	traceEnd(span, err)
+	return err
+}
+
+// PostRemoveTerm traces StoreInterface.PostRemoveTerm.
+func (s *tracingStore) PostRemoveTerm(ctx context.Context, postID string, termID string) error {
+	ctx, span := s.traceStart(ctx, "PostRemoveTerm", s.termRelationTableName)
+	err := s.storeImplementation.PostRemoveTerm(ctx, postID, termID)
+	traceEnd(span, err)
+	return err
+}
+
+// TermListByPostID traces StoreInterface.TermListByPostID.
+func (s *tracingStore) TermListByPostID(ctx context.Context, postID string, taxonomySlug string) ([]TermInterface, error) {
+	ctx, span := s.traceStart(ctx, "TermListByPostID", s.termTableName)
+	list, err := s.storeImplementation.TermListByPostID(ctx, postID, taxonomySlug)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostSetTerms traces StoreInterface.PostSetTerms.
+func (s *tracingStore) PostSetTerms(ctx context.Context, postID string, taxonomySlug string, termIDs []string) error {
+	ctx, span := s.traceStart(ctx, "PostSetTerms", s.termRelationTableName)
+	err := s.storeImplementation.PostSetTerms(ctx, postID, taxonomySlug, termIDs)
+	traceEnd(span, err)
+	return err
+}
+
+// PostListByTermID traces StoreInterface.PostListByTermID.
+func (s *tracingStore) PostListByTermID(ctx context.Context, termID string, options PostQueryOptions) ([]PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostListByTermID", s.postTableName)
+	list, err := s.storeImplementation.PostListByTermID(ctx, termID, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// TermIncrementCount traces StoreInterface.TermIncrementCount.
+func (s *tracingStore) TermIncrementCount(ctx context.Context, termID string) error {
+	ctx, span := s.traceStart(ctx, "TermIncrementCount", s.termTableName)
+	err := s.storeImplementation.TermIncrementCount(ctx, termID)
+	traceEnd(span, err)
+	return err
+}
+
+// TermDecrementCount traces StoreInterface.TermDecrementCount.
+func (s *tracingStore) TermDecrementCount(ctx context.Context, termID string) error {
+	ctx, span := s.traceStart(ctx, "TermDecrementCount", s.termTableName)
+	err := s.storeImplementation.TermDecrementCount(ctx, termID)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaCreate traces StoreInterface.MediaCreate.
+func (s *tracingStore) MediaCreate(ctx context.Context, media MediaInterface) error {
+	ctx, span := s.traceStart(ctx, "MediaCreate", s.mediaTableName)
+	err := s.storeImplementation.MediaCreate(ctx, media)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaCount traces StoreInterface.MediaCount.
+func (s *tracingStore) MediaCount(ctx context.Context, options MediaQueryOptions) (int64, error) {
+	ctx, span := s.traceStart(ctx, "MediaCount", s.mediaTableName)
+	count, err := s.storeImplementation.MediaCount(ctx, options)
+	span.SetAttributes(attribute.Int64(attributeRowCount, count))
+	traceEnd(span, err)
+	return count, err
+}
+
+// MediaDelete traces StoreInterface.MediaDelete.
+func (s *tracingStore) MediaDelete(ctx context.Context, media MediaInterface) error {
+	ctx, span := s.traceStart(ctx, "MediaDelete", s.mediaTableName)
+	err := s.storeImplementation.MediaDelete(ctx, media)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaDeleteByID traces StoreInterface.MediaDeleteByID.
+func (s *tracingStore) MediaDeleteByID(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "MediaDeleteByID", s.mediaTableName)
+	err := s.storeImplementation.MediaDeleteByID(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaFindByID traces StoreInterface.MediaFindByID.
+func (s *tracingStore) MediaFindByID(ctx context.Context, id string) (MediaInterface, error) {
+	ctx, span := s.traceStart(ctx, "MediaFindByID", s.mediaTableName)
+	result, err := s.storeImplementation.MediaFindByID(ctx, id)
+	traceEnd(span, err)
+	return result, err
+}
+
+// MediaList traces StoreInterface.MediaList.
+func (s *tracingStore) MediaList(ctx context.Context, options MediaQueryOptions) ([]MediaInterface, error) {
+	ctx, span := s.traceStart(ctx, "MediaList", s.mediaTableName)
+	list, err := s.storeImplementation.MediaList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// MediaListByEntityID traces StoreInterface.MediaListByEntityID.
+func (s *tracingStore) MediaListByEntityID(ctx context.Context, entityID string) ([]MediaInterface, error) {
+	ctx, span := s.traceStart(ctx, "MediaListByEntityID", s.mediaTableName)
+	list, err := s.storeImplementation.MediaListByEntityID(ctx, entityID)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// MediaSoftDelete traces StoreInterface.MediaSoftDelete.
+func (s *tracingStore) MediaSoftDelete(ctx context.Context, media MediaInterface) error {
+	ctx, span := s.traceStart(ctx, "MediaSoftDelete", s.mediaTableName)
+	err := s.storeImplementation.MediaSoftDelete(ctx, media)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaSoftDeleteByID traces StoreInterface.MediaSoftDeleteByID.
+func (s *tracingStore) MediaSoftDeleteByID(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "MediaSoftDeleteByID", s.mediaTableName)
+	err := s.storeImplementation.MediaSoftDeleteByID(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// MediaUpdate traces StoreInterface.MediaUpdate.
+func (s *tracingStore) MediaUpdate(ctx context.Context, media MediaInterface) error {
+	ctx, span := s.traceStart(ctx, "MediaUpdate", s.mediaTableName)
+	err := s.storeImplementation.MediaUpdate(ctx, media)
+	traceEnd(span, err)
+	return err
+}
+
+// PostAttachMedia traces StoreInterface.PostAttachMedia.
+func (s *tracingStore) PostAttachMedia(ctx context.Context, postID string, media MediaInterface) error {
+	ctx, span := s.traceStart(ctx, "PostAttachMedia", s.mediaTableName)
+	err := s.storeImplementation.PostAttachMedia(ctx, postID, media)
+	traceEnd(span, err)
+	return err
+}
+
+// Stats traces StoreInterface.Stats.
+func (s *tracingStore) Stats(ctx context.Context) (Stats, error) {
+	ctx, span := s.traceStart(ctx, "Stats", s.postTableName)
+	result, err := s.storeImplementation.Stats(ctx)
+	traceEnd(span, err)
+	return result, err
+}
+
+// PostArchiveMonths traces StoreInterface.PostArchiveMonths.
+func (s *tracingStore) PostArchiveMonths(ctx context.Context, options PostQueryOptions) ([]ArchiveMonth, error) {
+	ctx, span := s.traceStart(ctx, "PostArchiveMonths", s.postTableName)
+	result, err := s.storeImplementation.PostArchiveMonths(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(result)))
+	traceEnd(span, err)
+	return result, err
+}
+
+// StatsRefresh traces StoreInterface.StatsRefresh.
+func (s *tracingStore) StatsRefresh(ctx context.Context) (Stats, error) {
+	ctx, span := s.traceStart(ctx, "StatsRefresh", s.postTableName)
+	result, err := s.storeImplementation.StatsRefresh(ctx)
+	traceEnd(span, err)
+	return result, err
+}
+
+// Ping traces StoreInterface.Ping.
+func (s *tracingStore) Ping(ctx context.Context) error {
+	ctx, span := s.traceStart(ctx, "Ping", "")
+	err := s.storeImplementation.Ping(ctx)
+	traceEnd(span, err)
+	return err
+}
+
+// PostArchiveList traces StoreInterface.PostArchiveList.
+func (s *tracingStore) PostArchiveList(ctx context.Context, options PostQueryOptions) ([]PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostArchiveList", s.archiveTableName)
+	list, err := s.storeImplementation.PostArchiveList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostUnarchive traces StoreInterface.PostUnarchive.
+func (s *tracingStore) PostUnarchive(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "PostUnarchive", s.archiveTableName)
+	err := s.storeImplementation.PostUnarchive(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// PostLoadContent traces StoreInterface.PostLoadContent.
+func (s *tracingStore) PostLoadContent(ctx context.Context, post PostInterface) error {
+	ctx, span := s.traceStart(ctx, "PostLoadContent", s.postTableName)
+	err := s.storeImplementation.PostLoadContent(ctx, post)
+	traceEnd(span, err)
+	return err
+}
+
+// AuditList traces StoreInterface.AuditList.
+func (s *tracingStore) AuditList(ctx context.Context, options AuditQueryOptions) ([]AuditEntry, error) {
+	ctx, span := s.traceStart(ctx, "AuditList", s.auditTableName)
+	list, err := s.storeImplementation.AuditList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostPreviewTokenCreate traces StoreInterface.PostPreviewTokenCreate.
+func (s *tracingStore) PostPreviewTokenCreate(ctx context.Context, postID string, ttl time.Duration) (string, error) {
+	ctx, span := s.traceStart(ctx, "PostPreviewTokenCreate", s.previewTableName)
+	token, err := s.storeImplementation.PostPreviewTokenCreate(ctx, postID, ttl)
+	traceEnd(span, err)
+	return token, err
+}
+
+// PostPreviewTokenValidate traces StoreInterface.PostPreviewTokenValidate.
+func (s *tracingStore) PostPreviewTokenValidate(ctx context.Context, token string) (PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostPreviewTokenValidate", s.previewTableName)
+	post, err := s.storeImplementation.PostPreviewTokenValidate(ctx, token)
+	traceEnd(span, err)
+	return post, err
+}
+
+// PostPreviewTokenRevoke traces StoreInterface.PostPreviewTokenRevoke.
+func (s *tracingStore) PostPreviewTokenRevoke(ctx context.Context, token string) error {
+	ctx, span := s.traceStart(ctx, "PostPreviewTokenRevoke", s.previewTableName)
+	err := s.storeImplementation.PostPreviewTokenRevoke(ctx, token)
+	traceEnd(span, err)
+	return err
+}
+
+// PostPreviewTokenRevokeAll traces StoreInterface.PostPreviewTokenRevokeAll.
+func (s *tracingStore) PostPreviewTokenRevokeAll(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostPreviewTokenRevokeAll", s.previewTableName)
+	err := s.storeImplementation.PostPreviewTokenRevokeAll(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostAutosave traces StoreInterface.PostAutosave.
+func (s *tracingStore) PostAutosave(ctx context.Context, postID string, content string) error {
+	ctx, span := s.traceStart(ctx, "PostAutosave", s.autosaveTableName)
+	err := s.storeImplementation.PostAutosave(ctx, postID, content)
+	traceEnd(span, err)
+	return err
+}
+
+// PostAutosaveFind traces StoreInterface.PostAutosaveFind.
+func (s *tracingStore) PostAutosaveFind(ctx context.Context, postID string) (*Autosave, error) {
+	ctx, span := s.traceStart(ctx, "PostAutosaveFind", s.autosaveTableName)
+	autosave, err := s.storeImplementation.PostAutosaveFind(ctx, postID)
+	traceEnd(span, err)
+	return autosave, err
+}
+
+// PostAutosaveRestore traces StoreInterface.PostAutosaveRestore.
+func (s *tracingStore) PostAutosaveRestore(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostAutosaveRestore", s.autosaveTableName)
+	err := s.storeImplementation.PostAutosaveRestore(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostAutosaveDiscard traces StoreInterface.PostAutosaveDiscard.
+func (s *tracingStore) PostAutosaveDiscard(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostAutosaveDiscard", s.autosaveTableName)
+	err := s.storeImplementation.PostAutosaveDiscard(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostLock traces StoreInterface.PostLock.
+func (s *tracingStore) PostLock(ctx context.Context, postID string, owner string, ttl time.Duration) error {
+	ctx, span := s.traceStart(ctx, "PostLock", s.lockTableName)
+	err := s.storeImplementation.PostLock(ctx, postID, owner, ttl)
+	traceEnd(span, err)
+	return err
+}
+
+// PostUnlock traces StoreInterface.PostUnlock.
+func (s *tracingStore) PostUnlock(ctx context.Context, postID string, owner string) error {
+	ctx, span := s.traceStart(ctx, "PostUnlock", s.lockTableName)
+	err := s.storeImplementation.PostUnlock(ctx, postID, owner)
+	traceEnd(span, err)
+	return err
+}
+
+// PostLockStatus traces StoreInterface.PostLockStatus.
+func (s *tracingStore) PostLockStatus(ctx context.Context, postID string) (*Lock, error) {
+	ctx, span := s.traceStart(ctx, "PostLockStatus", s.lockTableName)
+	lock, err := s.storeImplementation.PostLockStatus(ctx, postID)
+	traceEnd(span, err)
+	return lock, err
+}
+
+// PostTrashPurge traces StoreInterface.PostTrashPurge.
+func (s *tracingStore) PostTrashPurge(ctx context.Context) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostTrashPurge", s.postTableName)
+	purged, err := s.storeImplementation.PostTrashPurge(ctx)
+	span.SetAttributes(attribute.Int(attributeRowCount, purged))
+	traceEnd(span, err)
+	return purged, err
+}
+
+// PostExpireDue traces StoreInterface.PostExpireDue.
+func (s *tracingStore) PostExpireDue(ctx context.Context) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostExpireDue", s.postTableName)
+	expired, err := s.storeImplementation.PostExpireDue(ctx)
+	span.SetAttributes(attribute.Int(attributeRowCount, expired))
+	traceEnd(span, err)
+	return expired, err
+}
+
+// PostPurgeSoftDeletedBefore traces StoreInterface.PostPurgeSoftDeletedBefore.
+func (s *tracingStore) PostPurgeSoftDeletedBefore(ctx context.Context, cutoff string) (int, error) {
+	ctx, span := s.traceStart(ctx, "PostPurgeSoftDeletedBefore", s.postTableName)
+	purged, err := s.storeImplementation.PostPurgeSoftDeletedBefore(ctx, cutoff)
+	span.SetAttributes(attribute.Int(attributeRowCount, purged))
+	traceEnd(span, err)
+	return purged, err
+}
+
+// PostCountSoftDeletedBefore traces StoreInterface.PostCountSoftDeletedBefore.
+func (s *tracingStore) PostCountSoftDeletedBefore(ctx context.Context, cutoff string) (int64, error) {
+	ctx, span := s.traceStart(ctx, "PostCountSoftDeletedBefore", s.postTableName)
+	count, err := s.storeImplementation.PostCountSoftDeletedBefore(ctx, cutoff)
+	traceEnd(span, err)
+	return count, err
+}
+
+// PostIncrementViewCount traces StoreInterface.PostIncrementViewCount.
+func (s *tracingStore) PostIncrementViewCount(ctx context.Context, postID string) error {
+	ctx, span := s.traceStart(ctx, "PostIncrementViewCount", s.postTableName)
+	err := s.storeImplementation.PostIncrementViewCount(ctx, postID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostRecordView traces StoreInterface.PostRecordView.
+func (s *tracingStore) PostRecordView(ctx context.Context, postID string, day time.Time) error {
+	ctx, span := s.traceStart(ctx, "PostRecordView", s.viewsTableName)
+	err := s.storeImplementation.PostRecordView(ctx, postID, day)
+	traceEnd(span, err)
+	return err
+}
+
+// PostViewsByDay traces StoreInterface.PostViewsByDay.
+func (s *tracingStore) PostViewsByDay(ctx context.Context, postID string, from time.Time, to time.Time) ([]PostViewsDay, error) {
+	ctx, span := s.traceStart(ctx, "PostViewsByDay", s.viewsTableName)
+	list, err := s.storeImplementation.PostViewsByDay(ctx, postID, from, to)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostViewsTop traces StoreInterface.PostViewsTop.
+func (s *tracingStore) PostViewsTop(ctx context.Context, from time.Time, to time.Time, limit int) ([]PostViewsTotal, error) {
+	ctx, span := s.traceStart(ctx, "PostViewsTop", s.viewsTableName)
+	list, err := s.storeImplementation.PostViewsTop(ctx, from, to, limit)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostListPopular traces StoreInterface.PostListPopular.
+func (s *tracingStore) PostListPopular(ctx context.Context, since time.Time, limit int) ([]PostInterface, error) {
+	ctx, span := s.traceStart(ctx, "PostListPopular", s.postTableName)
+	list, err := s.storeImplementation.PostListPopular(ctx, since, limit)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostReactionIncrement traces StoreInterface.PostReactionIncrement.
+func (s *tracingStore) PostReactionIncrement(ctx context.Context, postID string, reaction string) error {
+	ctx, span := s.traceStart(ctx, "PostReactionIncrement", s.reactionsTableName)
+	err := s.storeImplementation.PostReactionIncrement(ctx, postID, reaction)
+	traceEnd(span, err)
+	return err
+}
+
+// PostReactionsSummary traces StoreInterface.PostReactionsSummary.
+func (s *tracingStore) PostReactionsSummary(ctx context.Context, postID string) (map[string]int64, error) {
+	ctx, span := s.traceStart(ctx, "PostReactionsSummary", s.reactionsTableName)
+	summary, err := s.storeImplementation.PostReactionsSummary(ctx, postID)
+	traceEnd(span, err)
+	return summary, err
+}
+
+// PostVariantCreate traces StoreInterface.PostVariantCreate.
+func (s *tracingStore) PostVariantCreate(ctx context.Context, variant *Variant) error {
+	ctx, span := s.traceStart(ctx, "PostVariantCreate", s.variantsTableName)
+	err := s.storeImplementation.PostVariantCreate(ctx, variant)
+	traceEnd(span, err)
+	return err
+}
+
+// PostVariantList traces StoreInterface.PostVariantList.
+func (s *tracingStore) PostVariantList(ctx context.Context, postID string) ([]Variant, error) {
+	ctx, span := s.traceStart(ctx, "PostVariantList", s.variantsTableName)
+	list, err := s.storeImplementation.PostVariantList(ctx, postID)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// PostVariantPick traces StoreInterface.PostVariantPick.
+func (s *tracingStore) PostVariantPick(ctx context.Context, postID string) (*Variant, error) {
+	ctx, span := s.traceStart(ctx, "PostVariantPick", s.variantsTableName)
+	variant, err := s.storeImplementation.PostVariantPick(ctx, postID)
+	traceEnd(span, err)
+	return variant, err
+}
+
+// PostVariantDelete traces StoreInterface.PostVariantDelete.
+func (s *tracingStore) PostVariantDelete(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "PostVariantDelete", s.variantsTableName)
+	err := s.storeImplementation.PostVariantDelete(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// PostVariantRecordImpression traces StoreInterface.PostVariantRecordImpression.
+func (s *tracingStore) PostVariantRecordImpression(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "PostVariantRecordImpression", s.variantsTableName)
+	err := s.storeImplementation.PostVariantRecordImpression(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// PostVariantRecordClick traces StoreInterface.PostVariantRecordClick.
+func (s *tracingStore) PostVariantRecordClick(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "PostVariantRecordClick", s.variantsTableName)
+	err := s.storeImplementation.PostVariantRecordClick(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// AuthorCreate traces StoreInterface.AuthorCreate.
+func (s *tracingStore) AuthorCreate(ctx context.Context, author *Author) error {
+	ctx, span := s.traceStart(ctx, "AuthorCreate", s.authorsTableName)
+	err := s.storeImplementation.AuthorCreate(ctx, author)
+	traceEnd(span, err)
+	return err
+}
+
+// AuthorUpdate traces StoreInterface.AuthorUpdate.
+func (s *tracingStore) AuthorUpdate(ctx context.Context, author *Author) error {
+	ctx, span := s.traceStart(ctx, "AuthorUpdate", s.authorsTableName)
+	err := s.storeImplementation.AuthorUpdate(ctx, author)
+	traceEnd(span, err)
+	return err
+}
+
+// AuthorDelete traces StoreInterface.AuthorDelete.
+func (s *tracingStore) AuthorDelete(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "AuthorDelete", s.authorsTableName)
+	err := s.storeImplementation.AuthorDelete(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// AuthorFindByID traces StoreInterface.AuthorFindByID.
+func (s *tracingStore) AuthorFindByID(ctx context.Context, id string) (*Author, error) {
+	ctx, span := s.traceStart(ctx, "AuthorFindByID", s.authorsTableName)
+	author, err := s.storeImplementation.AuthorFindByID(ctx, id)
+	traceEnd(span, err)
+	return author, err
+}
+
+// AuthorList traces StoreInterface.AuthorList.
+func (s *tracingStore) AuthorList(ctx context.Context, options AuthorQueryOptions) ([]Author, error) {
+	ctx, span := s.traceStart(ctx, "AuthorList", s.authorsTableName)
+	list, err := s.storeImplementation.AuthorList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// MenuCreate traces StoreInterface.MenuCreate.
+func (s *tracingStore) MenuCreate(ctx context.Context, menu *Menu) error {
+	ctx, span := s.traceStart(ctx, "MenuCreate", s.menusTableName)
+	err := s.storeImplementation.MenuCreate(ctx, menu)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuUpdate traces StoreInterface.MenuUpdate.
+func (s *tracingStore) MenuUpdate(ctx context.Context, menu *Menu) error {
+	ctx, span := s.traceStart(ctx, "MenuUpdate", s.menusTableName)
+	err := s.storeImplementation.MenuUpdate(ctx, menu)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuDelete traces StoreInterface.MenuDelete.
+func (s *tracingStore) MenuDelete(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "MenuDelete", s.menusTableName)
+	err := s.storeImplementation.MenuDelete(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuFindByID traces StoreInterface.MenuFindByID.
+func (s *tracingStore) MenuFindByID(ctx context.Context, id string) (*Menu, error) {
+	ctx, span := s.traceStart(ctx, "MenuFindByID", s.menusTableName)
+	menu, err := s.storeImplementation.MenuFindByID(ctx, id)
+	traceEnd(span, err)
+	return menu, err
+}
+
+// MenuFindByName traces StoreInterface.MenuFindByName.
+func (s *tracingStore) MenuFindByName(ctx context.Context, name string) (*Menu, error) {
+	ctx, span := s.traceStart(ctx, "MenuFindByName", s.menusTableName)
+	menu, err := s.storeImplementation.MenuFindByName(ctx, name)
+	traceEnd(span, err)
+	return menu, err
+}
+
+// MenuList traces StoreInterface.MenuList.
+func (s *tracingStore) MenuList(ctx context.Context, options MenuQueryOptions) ([]Menu, error) {
+	ctx, span := s.traceStart(ctx, "MenuList", s.menusTableName)
+	list, err := s.storeImplementation.MenuList(ctx, options)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(list)))
+	traceEnd(span, err)
+	return list, err
+}
+
+// MenuItemCreate traces StoreInterface.MenuItemCreate.
+func (s *tracingStore) MenuItemCreate(ctx context.Context, item *MenuItem) error {
+	ctx, span := s.traceStart(ctx, "MenuItemCreate", s.menuItemsTableName)
+	err := s.storeImplementation.MenuItemCreate(ctx, item)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuItemUpdate traces StoreInterface.MenuItemUpdate.
+func (s *tracingStore) MenuItemUpdate(ctx context.Context, item *MenuItem) error {
+	ctx, span := s.traceStart(ctx, "MenuItemUpdate", s.menuItemsTableName)
+	err := s.storeImplementation.MenuItemUpdate(ctx, item)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuItemDelete traces StoreInterface.MenuItemDelete.
+func (s *tracingStore) MenuItemDelete(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "MenuItemDelete", s.menuItemsTableName)
+	err := s.storeImplementation.MenuItemDelete(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// MenuItemFindByID traces StoreInterface.MenuItemFindByID.
+func (s *tracingStore) MenuItemFindByID(ctx context.Context, id string) (*MenuItem, error) {
+	ctx, span := s.traceStart(ctx, "MenuItemFindByID", s.menuItemsTableName)
+	item, err := s.storeImplementation.MenuItemFindByID(ctx, id)
+	traceEnd(span, err)
+	return item, err
+}
+
+// MenuItemList traces StoreInterface.MenuItemList.
+func (s *tracingStore) MenuItemList(ctx context.Context, menuID string) ([]MenuItem, error) {
+	ctx, span := s.traceStart(ctx, "MenuItemList", s.menuItemsTableName)
+	items, err := s.storeImplementation.MenuItemList(ctx, menuID)
+	span.SetAttributes(attribute.Int(attributeRowCount, len(items)))
+	traceEnd(span, err)
+	return items, err
+}
+
+// MenuTree traces StoreInterface.MenuTree.
+func (s *tracingStore) MenuTree(ctx context.Context, menuID string) ([]MenuItemNode, error) {
+	ctx, span := s.traceStart(ctx, "MenuTree", s.menuItemsTableName)
+	tree, err := s.storeImplementation.MenuTree(ctx, menuID)
+	traceEnd(span, err)
+	return tree, err
+}
+
+// PostSubmitForReview traces StoreInterface.PostSubmitForReview.
+func (s *tracingStore) PostSubmitForReview(ctx context.Context, id string) error {
+	ctx, span := s.traceStart(ctx, "PostSubmitForReview", s.postTableName)
+	err := s.storeImplementation.PostSubmitForReview(ctx, id)
+	traceEnd(span, err)
+	return err
+}
+
+// PostApprove traces StoreInterface.PostApprove.
+func (s *tracingStore) PostApprove(ctx context.Context, id string, reviewerID string) error {
+	ctx, span := s.traceStart(ctx, "PostApprove", s.postTableName)
+	err := s.storeImplementation.PostApprove(ctx, id, reviewerID)
+	traceEnd(span, err)
+	return err
+}
+
+// PostReject traces StoreInterface.PostReject.
+func (s *tracingStore) PostReject(ctx context.Context, id string, reason string) error {
+	ctx, span := s.traceStart(ctx, "PostReject", s.postTableName)
+	err := s.storeImplementation.PostReject(ctx, id, reason)
+	traceEnd(span, err)
+	return err
+}
+
+// EraseAuthorData traces StoreInterface.EraseAuthorData.
+func (s *tracingStore) EraseAuthorData(ctx context.Context, authorID string) error {
+	ctx, span := s.traceStart(ctx, "EraseAuthorData", s.postTableName)
+	err := s.storeImplementation.EraseAuthorData(ctx, authorID)
+	traceEnd(span, err)
+	return err
+}
@@ -0,0 +1,93 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStoreTracingRecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts_tracing",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TracerProvider:     provider,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Traced post")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatal("expected 1 post, found:", len(list))
+	}
+
+	if err := store.PostUpdate(ctx, nil); err == nil {
+		t.Fatal("expected error for nil post")
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	for _, name := range []string{"blogstore.MigrateUp", "blogstore.PostCreate", "blogstore.PostList", "blogstore.PostUpdate"} {
+		if _, ok := spans[name]; !ok {
+			t.Fatal("expected span to be recorded:", name)
+		}
+	}
+
+	listSpan := spans["blogstore.PostList"]
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range listSpan.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	if attrs[attributeTable].AsString() != "blog_posts_tracing" {
+		t.Fatal("expected table attribute blog_posts_tracing, found:", attrs[attributeTable].AsString())
+	}
+
+	if attrs[attributeRowCount].AsInt64() != 1 {
+		t.Fatal("expected row count attribute 1, found:", attrs[attributeRowCount].AsInt64())
+	}
+
+	if spans["blogstore.PostUpdate"].Status().Code != codes.Error {
+		t.Fatal("expected PostUpdate span to have error status")
+	}
+}
+
+func TestStoreTracingDisabledByDefault(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		DB:            initDB(),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, ok := store.(*storeImplementation); !ok {
+		t.Fatal("expected the plain store when no TracerProvider is set")
+	}
+
+	if _, ok := store.EnableDebug(true).(*storeImplementation); !ok {
+		t.Fatal("expected EnableDebug to return the plain store")
+	}
+}
@@ -0,0 +1,51 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+)
+
+// PostTranslations returns the other language versions of the post, the
+// posts sharing its translation group, ordered by locale. Returns an empty
+// list if the post is not part of a translation group.
+func (store *storeImplementation) PostTranslations(ctx context.Context, postID string) ([]PostInterface, error) {
+	if ctx == nil {
+		return []PostInterface{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if postID == "" {
+		return []PostInterface{}, errors.New("post id is empty")
+	}
+
+	post, err := store.PostFindByID(ctx, postID)
+	if err != nil {
+		return []PostInterface{}, err
+	}
+	if post == nil {
+		return []PostInterface{}, errors.New("post not found")
+	}
+	if post.GetTranslationGroupID() == "" {
+		return []PostInterface{}, nil
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{
+		TranslationGroupID: post.GetTranslationGroupID(),
+		OrderBy:            COLUMN_LOCALE,
+		SortOrder:          SORT_ORDER_ASC,
+	})
+	if err != nil {
+		return []PostInterface{}, err
+	}
+
+	translations := make([]PostInterface, 0, len(list))
+	for _, translation := range list {
+		if translation.GetID() != post.GetID() {
+			translations = append(translations, translation)
+		}
+	}
+
+	return translations, nil
+}
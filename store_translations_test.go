@@ -0,0 +1,67 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStorePostTranslations(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	original := NewPost().SetTitle("Hello").SetLocale("en")
+	original.SetTranslationGroupID(original.GetID())
+	german := NewPost().SetTitle("Hallo").SetLocale("de").SetTranslationGroupID(original.GetID())
+	spanish := NewPost().SetTitle("Hola").SetLocale("es").SetTranslationGroupID(original.GetID())
+	single := NewPost().SetTitle("English only").SetLocale("en")
+
+	for _, post := range []PostInterface{original, german, spanish, single} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	translations, err := store.PostTranslations(ctx, original.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(translations) != 2 || translations[0].GetID() != german.GetID() || translations[1].GetID() != spanish.GetID() {
+		t.Fatalf("PostTranslations() = %v, want the German and Spanish versions", translations)
+	}
+	if translations[0].GetLocale() != "de" || translations[0].GetTranslationGroupID() != original.GetID() {
+		t.Fatalf("translation locale = %q, group = %q", translations[0].GetLocale(), translations[0].GetTranslationGroupID())
+	}
+
+	translations, err = store.PostTranslations(ctx, german.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(translations) != 2 || translations[0].GetID() != original.GetID() {
+		t.Fatalf("PostTranslations(german) = %v, want the English and Spanish versions", translations)
+	}
+
+	translations, err = store.PostTranslations(ctx, single.GetID())
+	if err != nil || len(translations) != 0 {
+		t.Fatalf("PostTranslations(single) = %v, %v, want none", translations, err)
+	}
+
+	if _, err := store.PostTranslations(ctx, "missing"); err == nil {
+		t.Fatal("expected error for missing post")
+	}
+
+	english, err := store.PostList(ctx, PostQueryOptions{Locale: "en"})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(english) != 2 {
+		t.Fatalf("PostList(Locale: en) returned %d posts, want 2", len(english))
+	}
+}
@@ -0,0 +1,202 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// PostTrashPurge permanently deletes the posts that have been in the trash
+// for longer than NewStoreOptions.TrashRetentionDays, counted from when they
+// were moved to the trash, and returns how many were deleted. Run it periodically, e.g. with
+// the trash purge job of StartWorkers.
+func (store *storeImplementation) PostTrashPurge(ctx context.Context) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if store.trashRetention <= 0 {
+		return 0, errors.New("trash retention is not configured")
+	}
+
+	return store.postTrashPurgeOlderThan(ctx, store.trashRetention)
+}
+
+// postTrashPurgeOlderThan deletes the posts in the trash since before the retention.
+func (store *storeImplementation) postTrashPurgeOlderThan(ctx context.Context, retention time.Duration) (int, error) {
+	cutoff := carbon.CreateFromStdTime(store.now().StdTime().Add(-retention)).ToDateTimeString(carbon.UTC)
+
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{
+		Status:            POST_STATUS_TRASH,
+		TrashedAtLessThan: cutoff,
+		WithoutContent:    true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, post := range posts {
+		if err := store.PostDeleteByID(ctx, post.GetID()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// postPurgeBatchSize caps the posts deleted by one statement of
+// PostPurgeSoftDeletedBefore, keeping the IN clause within driver limits.
+const postPurgeBatchSize = 500
+
+// PostPurgeSoftDeletedBefore permanently deletes the posts soft deleted
+// before the cutoff, a UTC datetime such as "2024-01-31 00:00:00", and
+// returns how many were deleted. Use it to enforce a retention policy for
+// deleted content. The posts are deleted as by PostDeleteByIDs, in batches.
+// Posts moved to the archive table are not affected.
+func (store *storeImplementation) PostPurgeSoftDeletedBefore(ctx context.Context, cutoff string) (int, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	ids, err := store.postSoftDeletedBeforeIDs(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for start := 0; start < len(ids); start += postPurgeBatchSize {
+		deleted, err := store.PostDeleteByIDs(ctx, ids[start:min(start+postPurgeBatchSize, len(ids))])
+		purged += deleted
+		if err != nil {
+			return purged, err
+		}
+	}
+
+	return purged, nil
+}
+
+// PostCountSoftDeletedBefore returns how many posts were soft deleted before
+// the cutoff, i.e. how many PostPurgeSoftDeletedBefore would delete.
+func (store *storeImplementation) PostCountSoftDeletedBefore(ctx context.Context, cutoff string) (int64, error) {
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	where, args, err := store.postSoftDeletedBeforeCondition(cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = store.queryRowContext(ctx, db, "PostCountSoftDeletedBefore", "SELECT COUNT(*) FROM "+store.postTableName+" WHERE "+where, args, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// postSoftDeletedBeforeIDs returns the IDs of the posts soft deleted before
+// the cutoff.
+func (store *storeImplementation) postSoftDeletedBeforeIDs(ctx context.Context, cutoff string) ([]string, error) {
+	where, args, err := store.postSoftDeletedBeforeCondition(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostPurgeSoftDeletedBefore", "SELECT "+COLUMN_ID+" FROM "+store.postTableName+" WHERE "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// postSoftDeletedBeforeCondition returns the condition matching the posts
+// soft deleted before the cutoff. Posts scheduled to be soft deleted in the
+// future are not matched.
+func (store *storeImplementation) postSoftDeletedBeforeCondition(cutoff string) (string, []any, error) {
+	if strings.TrimSpace(cutoff) == "" {
+		return "", nil, errors.New("cutoff is empty")
+	}
+
+	cutoffTime := carbon.Parse(cutoff, carbon.UTC)
+	if cutoffTime.Error != nil || cutoffTime.IsInvalid() {
+		return "", nil, errors.New("cutoff is not a valid datetime: " + cutoff)
+	}
+
+	where := COLUMN_SOFT_DELETED_AT + " <= ? AND " + COLUMN_SOFT_DELETED_AT + " < ?"
+	return where, []any{store.now().StdTime(), cutoffTime.StdTime()}, nil
+}
+
+// PostUntrash moves a post out of the trash, back to the status recorded by
+// PostTrash in the META_KEY_TRASHED_FROM_STATUS meta. Posts trashed otherwise,
+// e.g. with PostStatusUpdateByIDs, or whose previous status is no longer
+// registered, become drafts. Returns an error if the post is not in the trash.
+func (store *storeImplementation) PostUntrash(ctx context.Context, post PostInterface) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if post == nil {
+		return errors.New("post is nil")
+	}
+	if post.GetStatus() != POST_STATUS_TRASH {
+		return errors.New("blogstore: post is not in the trash")
+	}
+
+	status := post.GetMeta(META_KEY_TRASHED_FROM_STATUS)
+	if status == "" || status == POST_STATUS_TRASH || store.postStatusValidate(status) != nil {
+		status = POST_STATUS_DRAFT
+	}
+
+	metas, err := post.GetMetas()
+	if err != nil {
+		return err
+	}
+	delete(metas, META_KEY_TRASHED_FROM_STATUS)
+	if err := post.SetMetas(metas); err != nil {
+		return err
+	}
+
+	post.SetStatus(status)
+
+	return store.PostUpdate(contextWithAction(ctx, ACTION_UNTRASH), post)
+}
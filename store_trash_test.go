@@ -0,0 +1,296 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStorePostTrashPurge(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TrashRetentionDays: 30,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	old := NewPost().SetTitle("Old")
+	kept := NewPost().SetTitle("Kept")
+	recent := NewPost().SetTitle("Recent")
+	for _, post := range []PostInterface{old, kept, recent} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.PostTrash(ctx, old); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	now = now.Add(20 * 24 * time.Hour)
+	if err := store.PostTrash(ctx, recent); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	now = now.Add(11 * 24 * time.Hour)
+
+	due, err := store.PostCount(ctx, PostQueryOptions{
+		Status:            POST_STATUS_TRASH,
+		TrashedAtLessThan: now.Add(-30 * 24 * time.Hour).Format(time.DateTime),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if due != 1 {
+		t.Fatalf("PostCount(TrashedAtLessThan) = %d, want 1", due)
+	}
+
+	purged, err := store.PostTrashPurge(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if purged != 1 {
+		t.Fatalf("PostTrashPurge() = %d, want 1", purged)
+	}
+
+	if found, _ := store.PostFindByID(ctx, old.GetID()); found != nil {
+		t.Fatal("expected the post trashed 31 days ago to be deleted")
+	}
+	for _, post := range []PostInterface{kept, recent} {
+		if found, _ := store.PostFindByID(ctx, post.GetID()); found == nil {
+			t.Fatalf("expected post %q to be kept", post.GetTitle())
+		}
+	}
+}
+
+func TestStorePostTrashPurgeCountsFromTrashing(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		TrashRetentionDays: 30,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	edited := NewPost().SetTitle("Edited in the trash")
+	bulk := NewPost().SetTitle("Trashed in bulk")
+	retrashed := NewPost().SetTitle("Trashed again")
+	for _, post := range []PostInterface{edited, bulk, retrashed} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.PostTrash(ctx, edited); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostStatusUpdateByIDs(ctx, []string{bulk.GetID()}, POST_STATUS_TRASH); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostTrash(ctx, retrashed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// edits to a trashed post do not restart its retention
+	now = now.Add(20 * 24 * time.Hour)
+	if err := edited.SetMeta("note", "edited"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUpdate(ctx, edited); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostStatusUpdateByIDs(ctx, []string{bulk.GetID()}, POST_STATUS_TRASH); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// leaving the trash does
+	if err := store.PostUntrash(ctx, retrashed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostTrash(ctx, retrashed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	now = now.Add(11 * 24 * time.Hour)
+
+	purged, err := store.PostTrashPurge(ctx)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if purged != 2 {
+		t.Fatalf("PostTrashPurge() = %d, want 2", purged)
+	}
+
+	for _, post := range []PostInterface{edited, bulk} {
+		if found, _ := store.PostFindByID(ctx, post.GetID()); found != nil {
+			t.Fatalf("expected post %q trashed 31 days ago to be deleted", post.GetTitle())
+		}
+	}
+	if found, _ := store.PostFindByID(ctx, retrashed.GetID()); found == nil {
+		t.Fatal("expected the post trashed again 11 days ago to be kept")
+	}
+}
+
+func TestStorePostTrashPurgeRequiresRetention(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.PostTrashPurge(context.Background()); err == nil {
+		t.Fatal("expected error when TrashRetentionDays is not set")
+	}
+}
+
+func TestStorePostPurgeSoftDeletedBefore(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	old := NewPost().SetTitle("Old")
+	recent := NewPost().SetTitle("Recent")
+	kept := NewPost().SetTitle("Kept")
+	for _, post := range []PostInterface{old, recent, kept} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.PostSoftDelete(ctx, old); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	now = now.Add(20 * 24 * time.Hour)
+	if err := store.PostSoftDelete(ctx, recent); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	now = now.Add(24 * time.Hour)
+
+	cutoff := "2026-03-10 00:00:00"
+
+	count, err := store.PostCountSoftDeletedBefore(ctx, cutoff)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("PostCountSoftDeletedBefore() = %d, want 1", count)
+	}
+
+	purged, err := store.PostPurgeSoftDeletedBefore(ctx, cutoff)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if purged != 1 {
+		t.Fatalf("PostPurgeSoftDeletedBefore() = %d, want 1", purged)
+	}
+
+	remaining, err := store.PostCount(ctx, PostQueryOptions{WithDeleted: true})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if remaining != 2 {
+		t.Fatalf("remaining posts = %d, want 2", remaining)
+	}
+
+	if _, err := store.PostPurgeSoftDeletedBefore(ctx, "not a date"); err == nil {
+		t.Fatal("expected an error for an invalid cutoff")
+	}
+	if _, err := store.PostCountSoftDeletedBefore(ctx, ""); err == nil {
+		t.Fatal("expected an error for an empty cutoff")
+	}
+}
+
+func TestStorePostUntrash(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Published").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostUntrash(ctx, post); err == nil {
+		t.Fatal("expected an error for a post outside the trash")
+	}
+
+	if err := store.PostTrash(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	trashed, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUntrash(ctx, trashed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	untrashed, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if untrashed.GetStatus() != POST_STATUS_PUBLISHED {
+		t.Fatalf("status = %q, want the status before trashing", untrashed.GetStatus())
+	}
+	if untrashed.GetMeta(META_KEY_TRASHED_FROM_STATUS) != "" {
+		t.Fatal("expected the previous status meta to be cleared")
+	}
+}
+
+func TestStorePostUntrashFallsBackToDraft(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Bulk trashed").SetStatus(POST_STATUS_PUBLISHED)
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostStatusUpdateByIDs(ctx, []string{post.GetID()}, POST_STATUS_TRASH); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	trashed, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostUntrash(ctx, trashed); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if trashed.GetStatus() != POST_STATUS_DRAFT {
+		t.Fatalf("status = %q, want draft without a recorded status", trashed.GetStatus())
+	}
+}
@@ -0,0 +1,217 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// VariantsEnabled returns true if posts can have title and summary variants.
+func (store *storeImplementation) VariantsEnabled() bool {
+	return store.variantsEnabled
+}
+
+// GetVariantsTableName returns the variants table name
+func (store *storeImplementation) GetVariantsTableName() string {
+	return store.variantsTableName
+}
+
+// PostVariantCreate adds a title and summary variant to a post. The ID and
+// creation time are set on the variant, and its counters start at zero.
+// A weight of zero defaults to 1.
+func (store *storeImplementation) PostVariantCreate(ctx context.Context, variant *Variant) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.variantsEnabled {
+		return errors.New("variants are not enabled")
+	}
+	if variant == nil {
+		return errors.New("variant is nil")
+	}
+	if variant.PostID == "" {
+		return errors.New("post id is empty")
+	}
+	if strings.TrimSpace(variant.Title) == "" && strings.TrimSpace(variant.Summary) == "" {
+		return errors.New("variant title and summary are empty")
+	}
+	if variant.Weight < 0 {
+		return errors.New("variant weight is negative")
+	}
+
+	list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: variant.PostID, WithoutContent: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errors.New("post not found")
+	}
+
+	if err := store.authorize(ctx, ACTION_UPDATE, list[0]); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	if variant.Weight == 0 {
+		variant.Weight = 1
+	}
+	variant.ID = GenerateShortID()
+	variant.Impressions = 0
+	variant.Clicks = 0
+	variant.CreatedAt = store.now().StdTime()
+
+	_, err = store.execContext(ctx, db, "PostVariantCreate", "INSERT INTO "+store.variantsTableName+" (id, post_id, title, summary, weight, impressions, clicks, created_at) VALUES (?, ?, ?, ?, ?, 0, 0, ?)",
+		variant.ID, variant.PostID, variant.Title, variant.Summary, variant.Weight, variant.CreatedAt)
+	return err
+}
+
+// PostVariantList returns the variants of a post, oldest first.
+func (store *storeImplementation) PostVariantList(ctx context.Context, postID string) ([]Variant, error) {
+	if ctx == nil {
+		return []Variant{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.variantsEnabled {
+		return []Variant{}, errors.New("variants are not enabled")
+	}
+	if postID == "" {
+		return []Variant{}, errors.New("post id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return []Variant{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostVariantList", "SELECT id, post_id, title, summary, weight, impressions, clicks, created_at FROM "+store.variantsTableName+
+		" WHERE "+COLUMN_POST_ID+" = ? ORDER BY "+COLUMN_CREATED_AT+" ASC, "+COLUMN_ID+" ASC", postID)
+	if err != nil {
+		return []Variant{}, err
+	}
+	defer rows.Close()
+
+	list := []Variant{}
+	for rows.Next() {
+		var variant Variant
+		if err := rows.Scan(&variant.ID, &variant.PostID, &variant.Title, &variant.Summary, &variant.Weight, &variant.Impressions, &variant.Clicks, &variant.CreatedAt); err != nil {
+			return []Variant{}, err
+		}
+		list = append(list, variant)
+	}
+
+	return list, rows.Err()
+}
+
+// PostVariantPick picks one of the variants of a post in proportion to the
+// weights. Returns nil if the post has no variants, in which case its own
+// title and summary are shown. The pick is not counted; record it with
+// PostVariantRecordImpression once the variant is shown.
+func (store *storeImplementation) PostVariantPick(ctx context.Context, postID string) (*Variant, error) {
+	variants, err := store.PostVariantList(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return VariantPick(variants, rand.Float64()), nil
+}
+
+// PostVariantDelete removes a variant with its counters.
+func (store *storeImplementation) PostVariantDelete(ctx context.Context, id string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.variantsEnabled {
+		return errors.New("variants are not enabled")
+	}
+	if id == "" {
+		return errors.New("variant id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostVariantDelete", "DELETE FROM "+store.variantsTableName+" WHERE "+COLUMN_ID+" = ?", id)
+	return err
+}
+
+// PostVariantRecordImpression counts one showing of a variant.
+func (store *storeImplementation) PostVariantRecordImpression(ctx context.Context, id string) error {
+	return store.variantIncrement(ctx, "PostVariantRecordImpression", id, COLUMN_IMPRESSIONS)
+}
+
+// PostVariantRecordClick counts one click on a variant.
+func (store *storeImplementation) PostVariantRecordClick(ctx context.Context, id string) error {
+	return store.variantIncrement(ctx, "PostVariantRecordClick", id, COLUMN_CLICKS)
+}
+
+// variantIncrement adds one to a counter column of a variant.
+func (store *storeImplementation) variantIncrement(ctx context.Context, operation string, id string, column string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.variantsEnabled {
+		return errors.New("variants are not enabled")
+	}
+	if id == "" {
+		return errors.New("variant id is empty")
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	result, err := store.execContext(ctx, db, operation, "UPDATE "+store.variantsTableName+" SET "+column+" = "+column+" + 1 WHERE "+COLUMN_ID+" = ?", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("variant not found")
+	}
+
+	return nil
+}
+
+// variantsTableBlueprint defines the variants table, holding the title and
+// summary variants of the posts with their counters.
+func variantsTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_ID, 40)
+	table.Primary(COLUMN_ID)
+	table.String(COLUMN_POST_ID, 40)
+	table.String(COLUMN_TITLE, 255)
+	table.Text(COLUMN_SUMMARY)
+	table.Integer(COLUMN_WEIGHT).Default(1)
+	table.BigInteger(COLUMN_IMPRESSIONS).Default(0)
+	table.BigInteger(COLUMN_CLICKS).Default(0)
+	table.DateTime(COLUMN_CREATED_AT)
+	table.Index(COLUMN_POST_ID)
+}
@@ -0,0 +1,98 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+)
+
+func initVariantsStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		VariantsEnabled:    true,
+		VariantsTableName:  "blog_post_variants",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestStoreVariantsTableNameRequired(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName:   "blog_posts",
+		VariantsEnabled: true,
+		DB:              initDB(),
+	})
+	if err == nil || err.Error() != "blog store: VariantsTableName is required" {
+		t.Fatalf("expected VariantsTableName is required, got %v", err)
+	}
+}
+
+func TestStorePostVariants(t *testing.T) {
+	store := initVariantsStore(t)
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Original headline")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if picked, err := store.PostVariantPick(ctx, post.GetID()); err != nil || picked != nil {
+		t.Fatalf("PostVariantPick() without variants = %+v, %v, want nil", picked, err)
+	}
+
+	bold := &Variant{PostID: post.GetID(), Title: "Bold headline"}
+	if err := store.PostVariantCreate(ctx, bold); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if bold.ID == "" || bold.Weight != 1 {
+		t.Fatalf("created variant = %+v, want an ID and weight 1", bold)
+	}
+
+	if err := store.PostVariantCreate(ctx, &Variant{PostID: post.GetID()}); err == nil {
+		t.Fatal("expected an error for a variant without title and summary")
+	}
+	if err := store.PostVariantCreate(ctx, &Variant{PostID: "missing", Title: "x"}); err == nil {
+		t.Fatal("expected an error for a missing post")
+	}
+
+	for range 3 {
+		if err := store.PostVariantRecordImpression(ctx, bold.ID); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	if err := store.PostVariantRecordClick(ctx, bold.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostVariantRecordClick(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for a missing variant")
+	}
+
+	list, err := store.PostVariantList(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 || list[0].Impressions != 3 || list[0].Clicks != 1 {
+		t.Fatalf("PostVariantList() = %+v, want one variant with 3 impressions and 1 click", list)
+	}
+
+	picked, err := store.PostVariantPick(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if picked == nil || picked.ID != bold.ID {
+		t.Fatalf("PostVariantPick() = %+v, want the only variant", picked)
+	}
+
+	if err := store.PostVariantDelete(ctx, bold.ID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if list, _ := store.PostVariantList(ctx, post.GetID()); len(list) != 0 {
+		t.Fatalf("PostVariantList() after delete = %+v, want none", list)
+	}
+}
@@ -9,8 +9,12 @@ import (
 
 	contractsorm "github.com/dracory/neat/contracts/database/orm"
 	"github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
 )
 
+// versioningLabelMaxLength is the longest label the versioning table holds.
+const versioningLabelMaxLength = 100
+
 // versioningMarshalToInterface is an internal interface for entities that can serialize to versioning content.
 type versioningMarshalToInterface interface {
 	MarshalToVersioning() (string, error)
@@ -94,7 +98,9 @@ func (store *storeImplementation) versioningCreateIfChanged(ctx context.Context,
 	return store.VersioningCreate(ctx, NewVersioning().
 		SetEntityID(entityID).
 		SetEntityType(entityType).
-		SetContent(content))
+		SetContent(content).
+		SetChangedBy(ActorFromContext(ctx)).
+		SetChangeNote(ChangeNoteFromContext(ctx)))
 }
 
 // versioningTrackEntity tracks an entity by creating a version entry if changed.
@@ -109,9 +115,120 @@ func (store *storeImplementation) versioningTrackEntity(ctx context.Context, ent
 		return err
 	}
 
+	if store.versioningAsync != nil {
+		return store.versioningAsync.enqueue(ctx, versioningAsyncJob{
+			entityType: entityType,
+			entityID:   entityID,
+			content:    content,
+			changedBy:  ActorFromContext(ctx),
+			changeNote: ChangeNoteFromContext(ctx),
+		})
+	}
+
 	return store.versioningCreateIfChanged(ctx, entityType, entityID, content)
 }
 
+// versioningDeleteSnapshot records a final version of each post about to be
+// permanently deleted, marked with META_KEY_DELETED_AT, unless versioning is
+// disabled or VersioningSkipDeleteSnapshot is set. The posts already loaded
+// are reused; when loaded is nil, the posts with the IDs are loaded.
+func (store *storeImplementation) versioningDeleteSnapshot(ctx context.Context, ids []string, loaded []PostInterface) error {
+	if !store.versioningEnabled || store.versioningSkipDeleteSnapshot {
+		return nil
+	}
+
+	posts := loaded
+	if posts == nil {
+		list, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{IDIn: ids, WithDeleted: true})
+		if err != nil {
+			return err
+		}
+		posts = list
+	}
+
+	deletedAt := store.now().ToDateTimeString(carbon.UTC)
+	for _, post := range posts {
+		snapshot := NewPostFromExistingData(post.GetData())
+		if err := snapshot.SetMeta(META_KEY_DELETED_AT, deletedAt); err != nil {
+			return err
+		}
+		if err := store.versioningTrackEntity(ctx, VERSIONING_TYPE_POST, post.GetID(), snapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PostRevertToVersion restores a post to the content of one of its versions
+// and saves it. The version recorded by the revert notes the version it was
+// reverted to in its change note, ahead of any note set on the context with
+// ContextWithChangeNote, so it tells the revert apart from an edit. A post that was permanently deleted
+// is created again from the version, e.g. from the snapshot recorded before
+// the deletion.
+func (store *storeImplementation) PostRevertToVersion(ctx context.Context, postID string, versionID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.versioningEnabled {
+		return errors.New("versioning is not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+	if versionID == "" {
+		return errors.New("version id is empty")
+	}
+
+	version, err := store.VersioningFindByID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+	if version == nil || version.EntityType() != VERSIONING_TYPE_POST || version.EntityID() != postID {
+		return errors.New("version not found for post")
+	}
+
+	existing, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{ID: postID, WithDeleted: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+
+	post := NewPost()
+	if len(existing) > 0 {
+		post = existing[0]
+	}
+
+	if err := post.UnmarshalFromVersioning(version.Content()); err != nil {
+		return err
+	}
+
+	metas, err := post.GetMetas()
+	if err != nil {
+		return err
+	}
+	delete(metas, META_KEY_DELETED_AT)
+	if err := post.SetMetas(metas); err != nil {
+		return err
+	}
+
+	note := "Reverted to version " + versionID
+	if contextNote := ChangeNoteFromContext(ctx); contextNote != "" {
+		note += ": " + contextNote
+	}
+	ctx = ContextWithChangeNote(ctx, note)
+
+	if len(existing) == 0 {
+		post.SetID(postID)
+		return store.PostCreate(ctx, post)
+	}
+
+	return store.PostUpdate(ctx, post)
+}
+
 // VersioningCreate creates a new version entry in the versioning store.
 func (store *storeImplementation) VersioningCreate(ctx context.Context, version VersioningInterface) error {
 	if store.versioningTableName == "" {
@@ -120,6 +237,10 @@ func (store *storeImplementation) VersioningCreate(ctx context.Context, version
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if version == nil {
 		return errors.New("versioning is nil")
 	}
@@ -133,22 +254,30 @@ func (store *storeImplementation) VersioningCreate(ctx context.Context, version
 		return errors.New("versioning entity id is empty")
 	}
 	if version.GetCreatedAt() == "" {
-		version.SetCreatedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+		version.SetCreatedAt(store.now().ToDateTimeString(carbon.UTC))
 	}
 	if version.GetSoftDeletedAt() == "" {
 		version.SetSoftDeletedAt(MAX_DATETIME)
 	}
 
+	content, err := store.encryptVersioningContent(ctx, version.Content())
+	if err != nil {
+		return err
+	}
+
 	row := map[string]any{
 		COLUMN_ID:              version.ID(),
 		COLUMN_ENTITY_TYPE:     version.EntityType(),
 		COLUMN_ENTITY_ID:       version.EntityID(),
-		COLUMN_CONTENT:         version.Content(),
+		COLUMN_CONTENT:         content,
+		COLUMN_CHANGED_BY:      version.ChangedBy(),
+		COLUMN_CHANGE_NOTE:     version.ChangeNote(),
+		COLUMN_LABEL:           version.Label(),
 		COLUMN_CREATED_AT:      version.GetCreatedAtCarbon().StdTime(),
 		COLUMN_SOFT_DELETED_AT: version.GetSoftDeletedAtCarbon().StdTime(),
 	}
 
-	return store.db.Query().Table(store.versioningTableName).Create(row)
+	return store.query(ctx).Table(store.versioningTableName).Create(row)
 }
 
 // VersioningDelete permanently removes a version entry from the versioning store.
@@ -159,6 +288,10 @@ func (store *storeImplementation) VersioningDelete(ctx context.Context, version
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if version == nil {
 		return errors.New("versioning is nil")
 	}
@@ -174,11 +307,15 @@ func (store *storeImplementation) VersioningDeleteByID(ctx context.Context, id s
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if id == "" {
 		return errors.New("versioning id is empty")
 	}
 
-	_, err := store.db.Query().
+	_, err := store.query(ctx).
 		Table(store.versioningTableName).
 		Where(COLUMN_ID+" = ?", id).
 		Delete()
@@ -187,6 +324,9 @@ func (store *storeImplementation) VersioningDeleteByID(ctx context.Context, id s
 
 // VersioningFindByID retrieves a version entry by its ID.
 func (store *storeImplementation) VersioningFindByID(ctx context.Context, versioningID string) (VersioningInterface, error) {
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if store.versioningTableName == "" {
 		return nil, nil
 	}
@@ -213,16 +353,31 @@ func (store *storeImplementation) VersioningList(ctx context.Context, query Vers
 		return nil, errors.New("ctx is nil")
 	}
 
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if query != nil {
+		if err := query.Validate(); err != nil {
+			return []VersioningInterface{}, err
+		}
+	}
+
 	type versioningRow struct {
 		ID            string    `db:"id"`
 		EntityType    string    `db:"entity_type"`
 		EntityID      string    `db:"entity_id"`
 		Content       string    `db:"content"`
+		ChangedBy     string    `db:"changed_by"`
+		ChangeNote    string    `db:"change_note"`
+		Label         string    `db:"label"`
 		CreatedAt     time.Time `db:"created_at"`
 		SoftDeletedAt time.Time `db:"soft_deleted_at"`
 	}
 
-	q := store.buildVersioningQuery(query)
+	q, err := store.buildVersioningQuery(ctx, query)
+	if err != nil {
+		return []VersioningInterface{}, err
+	}
 	q = q.Table(store.versioningTableName)
 
 	if len(query.Columns()) > 0 {
@@ -230,16 +385,30 @@ func (store *storeImplementation) VersioningList(ctx context.Context, query Vers
 	}
 
 	var rows []versioningRow
-	if err := q.Get(&rows); err != nil {
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Get(&[]versioningRow{}) })
+	store.explainQuery(ctx, nil, "VersioningList", sqlFn)
+
+	start := time.Now()
+	err = q.Get(&rows)
+	store.logSlowQuery(ctx, "VersioningList", start, sqlFn)
+	if err != nil {
 		return []VersioningInterface{}, err
 	}
 
 	list := make([]VersioningInterface, 0, len(rows))
 	for _, r := range rows {
+		content, err := store.decryptField(ctx, encryptedVersioningContent, r.Content)
+		if err != nil {
+			return []VersioningInterface{}, err
+		}
+
 		v := &versioningImplementation{
 			EntityTypeField: r.EntityType,
 			EntityIDField:   r.EntityID,
-			ContentField:    r.Content,
+			ContentField:    content,
+			ChangedByField:  r.ChangedBy,
+			ChangeNoteField: r.ChangeNote,
+			LabelField:      r.Label,
 			CreatedAt:       r.CreatedAt,
 		}
 		v.ShortID.ID = r.ID
@@ -258,11 +427,15 @@ func (store *storeImplementation) VersioningSoftDelete(ctx context.Context, vers
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if versioning == nil {
 		return errors.New("versioning is nil")
 	}
 
-	versioning.SetSoftDeletedAt(carbon.Now(carbon.UTC).ToDateTimeString(carbon.UTC))
+	versioning.SetSoftDeletedAt(store.now().ToDateTimeString(carbon.UTC))
 
 	return store.VersioningUpdate(ctx, versioning)
 }
@@ -275,6 +448,10 @@ func (store *storeImplementation) VersioningSoftDeleteByID(ctx context.Context,
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if id == "" {
 		return errors.New("versioning id is empty")
 	}
@@ -298,6 +475,10 @@ func (store *storeImplementation) VersioningUpdate(ctx context.Context, version
 	if ctx == nil {
 		return errors.New("ctx is nil")
 	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
 	if version == nil {
 		return errors.New("versioning is nil")
 	}
@@ -306,17 +487,135 @@ func (store *storeImplementation) VersioningUpdate(ctx context.Context, version
 		COLUMN_SOFT_DELETED_AT: version.GetSoftDeletedAtCarbon().StdTime(),
 	}
 
-	_, err := store.db.Query().Table(store.versioningTableName).Where(COLUMN_ID+" = ?", version.ID()).Update(row)
+	_, err := store.query(ctx).Table(store.versioningTableName).Where(COLUMN_ID+" = ?", version.ID()).Update(row)
+	return err
+}
+
+// VersioningCount returns the number of version entries matching the query,
+// e.g. to paginate the history of a post. Limit, offset and ordering are
+// ignored, so the count is always the total.
+func (store *storeImplementation) VersioningCount(ctx context.Context, query VersioningQueryInterface) (int64, error) {
+	if store.versioningTableName == "" {
+		return 0, nil
+	}
+	if ctx == nil {
+		return 0, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if query != nil {
+		if err := query.Validate(); err != nil {
+			return 0, err
+		}
+	}
+
+	q := store.versioningFilterQuery(ctx, query).Table(store.versioningTableName)
+
+	var count int64
+	sqlFn := rawSQL(func() string { return q.ToRawSql().Count() })
+	store.explainQuery(ctx, nil, "VersioningCount", sqlFn)
+
+	start := time.Now()
+	err := q.Count(&count)
+	store.logSlowQuery(ctx, "VersioningCount", start, sqlFn)
+	return count, err
+}
+
+// VersioningLabel tags a version with a label so editors can bookmark it,
+// e.g. "v1.0 launch copy". An empty label removes the tag.
+func (store *storeImplementation) VersioningLabel(ctx context.Context, versionID string, label string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.versioningEnabled {
+		return errors.New("versioning is not enabled")
+	}
+	if versionID == "" {
+		return errors.New("versioning id is empty")
+	}
+
+	label = strings.TrimSpace(label)
+	if len(label) > versioningLabelMaxLength {
+		return errors.New("version label is too long")
+	}
+
+	version, err := store.VersioningFindByID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		return errors.New("versioning not found")
+	}
+
+	_, err = store.query(ctx).
+		Table(store.versioningTableName).
+		Where(COLUMN_ID+" = ?", versionID).
+		Update(map[string]any{COLUMN_LABEL: label})
 	return err
 }
 
 // buildVersioningQuery builds a neat query from the versioning query interface.
-func (store *storeImplementation) buildVersioningQuery(options VersioningQueryInterface) contractsorm.Query {
+// The entries are ordered by OrderBy, newest first by default, then by ID, so
+// pages taken with Offset and Limit neither overlap nor skip entries created
+// in the same second. Returns an OrderError if OrderBy or SortOrder is not
+// allowed.
+func (store *storeImplementation) buildVersioningQuery(ctx context.Context, options VersioningQueryInterface) (contractsorm.Query, error) {
+	q := store.versioningFilterQuery(ctx, options)
+
+	if options == nil {
+		return q, nil
+	}
+
+	if options.HasLimit() && options.Limit() > 0 {
+		q = q.Limit(options.Limit())
+	}
+
+	if options.HasOffset() && options.Offset() > 0 {
+		q = q.Offset(int(options.Offset()))
+	}
+
+	orderBy := COLUMN_CREATED_AT
+	sortOrder := SORT_ORDER_DESC
+	if options.HasOrderBy() && options.OrderBy() != "" {
+		if err := validateOrderBy(options.OrderBy(), versioningTableColumns); err != nil {
+			return nil, err
+		}
+		orderBy = options.OrderBy()
+		sortOrder = ""
+		if options.HasSortOrder() {
+			sortOrder = options.SortOrder()
+		}
+	}
+
+	order, err := normalizeSortOrder(sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range lo.Uniq([]string{orderBy, COLUMN_ID}) {
+		if order == SORT_ORDER_ASC {
+			q = q.OrderBy(column)
+		} else {
+			q = q.OrderByDesc(column)
+		}
+	}
+
+	return q, nil
+}
+
+// versioningFilterQuery builds a neat query selecting the version entries
+// matching the filters of the query, without pagination or ordering.
+func (store *storeImplementation) versioningFilterQuery(ctx context.Context, options VersioningQueryInterface) contractsorm.Query {
 	// Use Model() to enable neat's automatic soft delete handling via SoftDeletesMaxDate
 	// Then override the table name since versioningImplementation doesn't implement TableName()
 	// Use Select("*") because versioningImplementation wraps timestamps in named struct fields
 	// (CreatedAt) which neat's column extractor skips
-	q := store.db.Query().Model(&versioningImplementation{}).Select("*")
+	q := store.query(ctx).Model(&versioningImplementation{}).Select("*")
 
 	if options == nil {
 		return q
@@ -334,27 +633,15 @@ func (store *storeImplementation) buildVersioningQuery(options VersioningQueryIn
 		q = q.Where(COLUMN_ENTITY_ID+" = ?", options.EntityID())
 	}
 
-	if options.HasLimit() && options.Limit() > 0 {
-		q = q.Limit(options.Limit())
-	}
-
-	if options.HasOffset() && options.Offset() > 0 {
-		q = q.Offset(int(options.Offset()))
-	}
-
-	if options.HasOrderBy() && options.OrderBy() != "" {
-		if options.HasSortOrder() && strings.ToLower(options.SortOrder()) == "asc" {
-			q = q.OrderBy(options.OrderBy())
-		} else {
-			q = q.OrderByDesc(options.OrderBy())
-		}
+	if options.HasLabel() && options.Label() != "" {
+		q = q.Where(COLUMN_LABEL+" = ?", options.Label())
 	}
 
 	// Active records have soft_deleted_at > NOW (soft-deleted have soft_deleted_at <= NOW)
 	if options.HasSoftDeletedIncluded() && options.SoftDeletedIncluded() {
 		q = q.WithSoftDeleted()
 	} else {
-		q = q.Where(COLUMN_SOFT_DELETED_AT+" > ?", carbon.Now(carbon.UTC).StdTime())
+		q = q.Where(COLUMN_SOFT_DELETED_AT+" > ?", store.now().StdTime())
 	}
 
 	return q
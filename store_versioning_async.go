@@ -0,0 +1,109 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// versioningAsyncDefaultQueueSize is the queue size used when VersioningQueueSize is not set.
+const versioningAsyncDefaultQueueSize = 1000
+
+// versioningAsyncJob is a version entry waiting to be written.
+// The content is captured when the job is queued, so later changes to the
+// entity do not leak into the version.
+type versioningAsyncJob struct {
+	entityType string
+	entityID   string
+	content    string
+	changedBy  string
+	changeNote string
+}
+
+// versioningAsyncWriter writes version entries in the background using a
+// bounded queue and a single worker, which keeps versions of the same entity
+// in the order they were queued.
+type versioningAsyncWriter struct {
+	store *storeImplementation
+	queue chan versioningAsyncJob
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// newVersioningAsyncWriter creates the writer and starts its worker.
+func newVersioningAsyncWriter(store *storeImplementation, queueSize int) *versioningAsyncWriter {
+	if queueSize <= 0 {
+		queueSize = versioningAsyncDefaultQueueSize
+	}
+
+	w := &versioningAsyncWriter{
+		store: store,
+		queue: make(chan versioningAsyncJob, queueSize),
+		done:  make(chan struct{}),
+	}
+
+	go w.run()
+
+	return w
+}
+
+// enqueue adds a job to the queue. It blocks while the queue is full,
+// until there is room or the context is done.
+func (w *versioningAsyncWriter) enqueue(ctx context.Context, job versioningAsyncJob) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return errors.New("blogstore: store is closed")
+	}
+
+	select {
+	case w.queue <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes queued jobs until the queue is closed and drained.
+// Write errors are logged, as there is no caller left to return them to.
+func (w *versioningAsyncWriter) run() {
+	defer close(w.done)
+
+	for job := range w.queue {
+		ctx, cancel := w.store.contextWithTimeout(ContextWithChangeNote(ContextWithActor(context.Background(), job.changedBy), job.changeNote))
+		if err := w.store.versioningCreateIfChanged(ctx, job.entityType, job.entityID, job.content); err != nil {
+			w.store.getLogger().ErrorContext(ctx, "blogstore: async version write failed",
+				"entity_type", job.entityType,
+				"entity_id", job.entityID,
+				"error", err)
+		}
+		cancel()
+	}
+}
+
+// close stops accepting jobs and waits until all queued jobs are written.
+func (w *versioningAsyncWriter) close() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		<-w.done
+		return
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+}
+
+// Close flushes pending asynchronous version writes and stops the background
+// worker. It is safe to call more than once. The database is not closed.
+func (store *storeImplementation) Close() error {
+	if store.versioningAsync != nil {
+		store.versioningAsync.close()
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package blogstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestStoreVersioningAsyncFlushOnClose(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_posts_version",
+		VersioningEnabled:   true,
+		VersioningAsync:     true,
+		VersioningQueueSize: 2,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+		// a single connection keeps the in-memory database shared with the worker
+		MaxOpenConns: 1,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Version 0")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for i := 1; i <= 5; i++ {
+		post.SetTitle(fmt.Sprintf("Version %d", i))
+		if err := store.PostUpdate(ContextWithActor(ctx, fmt.Sprintf("editor-%d", i)), post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(versions) != 6 {
+		t.Fatal("expected 6 versions after Close, found:", len(versions))
+	}
+
+	changedBy := map[string]bool{}
+	for _, version := range versions {
+		changedBy[version.ChangedBy()] = true
+	}
+	if !changedBy["editor-5"] {
+		t.Fatal("expected the async versions to keep the actor, found:", changedBy)
+	}
+
+	post.SetTitle("After close")
+	if err := store.PostUpdate(ctx, post); err == nil {
+		t.Fatal("expected error when versioning after Close")
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal("expected Close to be idempotent, got:", err)
+	}
+}
+
+func TestStoreCloseWithoutAsyncVersioning(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostCreate(context.Background(), NewPost().SetTitle("Post")); err != nil {
+		t.Fatal("expected store to keep working without async versioning, got:", err)
+	}
+}
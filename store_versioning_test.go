@@ -3,6 +3,7 @@ package blogstore
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -957,3 +958,439 @@ func TestVersioningList_EmptyResult(t *testing.T) {
 		t.Errorf("expected empty list, got %d items", len(list))
 	}
 }
+
+func TestStorePostRevertToVersion(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_versioning",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Original").SetContent("First draft")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("VersioningList() = %d versions, %v, want 1", len(versions), err)
+	}
+	originalID := versions[0].ID()
+
+	post.SetTitle("Edited").SetContent("Second draft")
+	if err := store.PostUpdate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostRevertToVersion(ctx, post.GetID(), originalID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	reverted, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if reverted.GetTitle() != "Original" || reverted.GetContent() != "First draft" {
+		t.Fatalf("reverted post = %q, %q, want the original title and content", reverted.GetTitle(), reverted.GetContent())
+	}
+	metas, err := reverted.GetMetas()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for key, value := range metas {
+		if value == originalID {
+			t.Fatalf("expected the revert not to be recorded on the post, got meta %q", key)
+		}
+	}
+
+	versions, err = store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("VersioningList() = %d versions, want 3 with the revert", len(versions))
+	}
+	reverts := 0
+	for _, version := range versions {
+		if version.ChangeNote() == "Reverted to version "+originalID {
+			reverts++
+		}
+	}
+	if reverts != 1 {
+		t.Fatalf("versions noting the revert = %d, want 1", reverts)
+	}
+
+	// an edit after the revert is not marked as a revert
+	reverted.SetTitle("Edited again")
+	if err := store.PostUpdate(ctx, reverted); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err = store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 4 {
+		t.Fatalf("VersioningList() = %d versions, want 4 with the edit", len(versions))
+	}
+	for _, version := range versions {
+		if !strings.Contains(version.Content(), "Edited again") {
+			continue
+		}
+		if version.ChangeNote() != "" {
+			t.Fatalf("edit change note = %q, want none", version.ChangeNote())
+		}
+		if strings.Contains(version.Content(), originalID) {
+			t.Fatal("expected the edit version not to reference the reverted version")
+		}
+	}
+
+	if err := store.PostRevertToVersion(ctx, post.GetID(), "missing"); err == nil {
+		t.Fatal("expected error for missing version")
+	}
+	other := NewPost().SetTitle("Other")
+	if err := store.PostCreate(ctx, other); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostRevertToVersion(ctx, other.GetID(), originalID); err == nil {
+		t.Fatal("expected error for a version of another post")
+	}
+}
+
+func TestStoreVersioningChangeMetadata(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_versioning",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("First")
+	if err := store.PostCreate(ContextWithActor(ctx, "author-1"), post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	post.SetTitle("Second")
+	editCtx := ContextWithChangeNote(ContextWithActor(ctx, "editor-1"), "Fix the title")
+	if err := store.PostUpdate(editCtx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().
+		SetEntityType(VERSIONING_TYPE_POST).
+		SetEntityID(post.GetID()).
+		SetOrderBy(COLUMN_CREATED_AT).
+		SetSortOrder(SORT_ORDER_ASC))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("VersioningList() = %d versions, want 2", len(versions))
+	}
+
+	byActor := map[string]VersioningInterface{}
+	for _, version := range versions {
+		byActor[version.ChangedBy()] = version
+	}
+	if v := byActor["author-1"]; v == nil || v.ChangeNote() != "" {
+		t.Fatalf("expected a version by author-1 without a note, got %v", byActor)
+	}
+	if v := byActor["editor-1"]; v == nil || v.ChangeNote() != "Fix the title" {
+		t.Fatalf("expected a version by editor-1 noting the fix, got %v", byActor)
+	}
+}
+
+func TestStoreVersioningChangeColumnsMigration(t *testing.T) {
+	db := initDB()
+
+	// a versioning table created before the changed by, change note and label
+	// columns existed
+	_, err := db.Exec(`CREATE TABLE blog_versioning (
+		id VARCHAR(21) PRIMARY KEY,
+		entity_type VARCHAR(40),
+		entity_id VARCHAR(40),
+		content TEXT,
+		created_at DATETIME,
+		soft_deleted_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_versioning",
+		VersioningEnabled:   true,
+		DB:                  db,
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := ContextWithActor(context.Background(), "editor-1")
+
+	post := NewPost().SetTitle("Upgraded")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 1 || versions[0].ChangedBy() != "editor-1" {
+		t.Fatalf("expected one version by editor-1, got %d", len(versions))
+	}
+}
+
+func TestStoreVersioningCountAndPaging(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_versioning",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Revision 0")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	// versions saved within the same second share their created at time
+	for i := 1; i < 25; i++ {
+		post.SetTitle("Revision " + strconv.Itoa(i))
+		if err := store.PostUpdate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	query := func() VersioningQueryInterface {
+		return NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(post.GetID())
+	}
+
+	count, err := store.VersioningCount(ctx, query().SetLimit(10).SetOffset(20))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 25 {
+		t.Fatalf("VersioningCount() = %d, want 25", count)
+	}
+
+	seen := map[string]bool{}
+	for offset := int64(0); offset < count; offset += 10 {
+		page, err := store.VersioningList(ctx, query().SetLimit(10).SetOffset(offset))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		for _, version := range page {
+			if seen[version.ID()] {
+				t.Fatalf("version %s returned on more than one page", version.ID())
+			}
+			seen[version.ID()] = true
+		}
+	}
+	if len(seen) != 25 {
+		t.Fatalf("pages returned %d versions, want 25", len(seen))
+	}
+
+	if _, err := store.VersioningList(ctx, query().SetOffset(-1)); err == nil {
+		t.Fatal("expected error for a negative offset")
+	}
+}
+
+func TestStorePostDeleteVersionSnapshot(t *testing.T) {
+	newStore := func(skip bool) StoreInterface {
+		store, err := NewStore(NewStoreOptions{
+			PostTableName:                "blog_posts",
+			VersioningTableName:          "blog_versioning",
+			VersioningEnabled:            true,
+			VersioningSkipDeleteSnapshot: skip,
+			DB:                           initDB(),
+			AutomigrateEnabled:           true,
+		})
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		return store
+	}
+
+	ctx := context.Background()
+
+	store := newStore(false)
+
+	post := NewPost().SetTitle("Deleted by accident").SetContent("Precious content")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	bulk := NewPost().SetTitle("Deleted in bulk")
+	if err := store.PostCreate(ctx, bulk); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if err := store.PostDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := store.PostDeleteByIDs(ctx, []string{bulk.GetID()}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for _, deleted := range []PostInterface{post, bulk} {
+		count, err := store.VersioningCount(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(deleted.GetID()))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if count != 2 {
+			t.Fatalf("versions of %q = %d, want 2 with the delete snapshot", deleted.GetTitle(), count)
+		}
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	var snapshotID string
+	for _, version := range versions {
+		snapshot := NewPost()
+		if err := snapshot.UnmarshalFromVersioning(version.Content()); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if snapshot.GetMeta(META_KEY_DELETED_AT) != "" {
+			snapshotID = version.ID()
+		}
+	}
+	if snapshotID == "" {
+		t.Fatal("expected a version marked with META_KEY_DELETED_AT")
+	}
+
+	if err := store.PostRevertToVersion(ctx, post.GetID(), snapshotID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	recovered, err := store.PostFindByID(ctx, post.GetID())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if recovered == nil || recovered.GetContent() != "Precious content" {
+		t.Fatalf("recovered post = %v, want the deleted content", recovered)
+	}
+	if recovered.GetMeta(META_KEY_DELETED_AT) != "" {
+		t.Fatal("expected the recovered post not to be marked deleted")
+	}
+
+	skipping := newStore(true)
+	post = NewPost().SetTitle("Gone")
+	if err := skipping.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := skipping.PostDeleteByID(ctx, post.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	count, err := skipping.VersioningCount(ctx, NewVersioningQuery().SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count != 1 {
+		t.Fatalf("versions with VersioningSkipDeleteSnapshot = %d, want 1", count)
+	}
+}
+
+func TestStoreVersioningLabel(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningTableName: "blog_versioning",
+		VersioningEnabled:   true,
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Launch copy")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, title := range []string{"Draft 2", "Draft 3"} {
+		post.SetTitle(title)
+		if err := store.PostUpdate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	query := NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(post.GetID())
+	versions, err := store.VersioningList(ctx, query)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(versions))
+	}
+	launch := versions[len(versions)-1]
+
+	if err := store.VersioningLabel(ctx, launch.ID(), "  v1.0 launch copy "); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	labeled, err := store.VersioningList(ctx, NewVersioningQuery().SetLabel("v1.0 launch copy"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(labeled) != 1 || labeled[0].ID() != launch.ID() || labeled[0].Label() != "v1.0 launch copy" {
+		t.Fatalf("expected the launch version labeled, got %d versions", len(labeled))
+	}
+
+	if err := store.VersioningLabel(ctx, launch.ID(), strings.Repeat("x", 101)); err == nil {
+		t.Fatal("expected error for a too long label")
+	}
+	if err := store.VersioningLabel(ctx, "missing", "x"); err == nil {
+		t.Fatal("expected error for a missing version")
+	}
+	if _, err := store.VersioningList(ctx, NewVersioningQuery().SetLabel("")); err == nil {
+		t.Fatal("expected error for an empty label filter")
+	}
+
+	// labeled versions survive pruning
+	if _, err := store.(*storeImplementation).workerPruneVersions(ctx, 1); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	versions, err = store.VersioningList(ctx, query)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 || versions[1].ID() != launch.ID() {
+		t.Fatalf("expected the newest and the labeled version after pruning, got %d", len(versions))
+	}
+
+	if err := store.VersioningLabel(ctx, launch.ID(), ""); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if count, _ := store.VersioningCount(ctx, NewVersioningQuery().SetLabel("v1.0 launch copy")); count != 0 {
+		t.Fatalf("expected the label removed, got %d labeled versions", count)
+	}
+}
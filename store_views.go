@@ -0,0 +1,289 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	contractsschema "github.com/dracory/neat/contracts/database/schema"
+)
+
+// ViewsEnabled returns true if post views are counted in the views table.
+func (store *storeImplementation) ViewsEnabled() bool {
+	return store.viewsEnabled
+}
+
+// GetViewsTableName returns the views table name
+func (store *storeImplementation) GetViewsTableName() string {
+	return store.viewsTableName
+}
+
+// PostRecordView counts a view of the post on the calendar day of the given
+// time, in its location. Views are aggregated into one row per post and day.
+func (store *storeImplementation) PostRecordView(ctx context.Context, postID string, day time.Time) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.viewsEnabled {
+		return errors.New("views are not enabled")
+	}
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	if err := store.authorizePost(ctx, ACTION_VIEW, postID); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	increment := func() (bool, error) {
+		result, err := store.execContext(ctx, db, "PostRecordView", "UPDATE "+store.viewsTableName+" SET "+COLUMN_VIEWS+" = "+COLUMN_VIEWS+" + 1 WHERE "+COLUMN_POST_ID+" = ? AND "+COLUMN_DAY+" = ?",
+			postID, day.Format(time.DateOnly))
+		if err != nil {
+			return false, err
+		}
+		affected, err := result.RowsAffected()
+		return affected > 0, err
+	}
+
+	if counted, err := increment(); counted || err != nil {
+		return err
+	}
+
+	_, err = store.execContext(ctx, db, "PostRecordView", "INSERT INTO "+store.viewsTableName+" (post_id, day, views) VALUES (?, ?, 1)",
+		postID, day.Format(time.DateOnly))
+	if err == nil {
+		return nil
+	}
+
+	// A concurrent view inserted the row first
+	if counted, retryErr := increment(); counted || retryErr != nil {
+		return retryErr
+	}
+	return err
+}
+
+// PostIncrementViewCount adds one to the total views of the post, kept in
+// the views column of the post table, with a single atomic UPDATE. It works
+// without ViewsEnabled; use PostRecordView as well for views per day.
+func (store *storeImplementation) PostIncrementViewCount(ctx context.Context, postID string) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if postID == "" {
+		return errors.New("post id is empty")
+	}
+
+	if err := store.authorizePost(ctx, ACTION_VIEW, NormalizeID(postID)); err != nil {
+		return err
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+
+	result, err := store.execContext(ctx, db, "PostIncrementViewCount", "UPDATE "+store.postTableName+" SET "+COLUMN_VIEWS+" = "+COLUMN_VIEWS+" + 1 WHERE "+COLUMN_ID+" = ?",
+		NormalizeID(postID))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("post not found")
+	}
+
+	return nil
+}
+
+// PostViewsByDay returns the views of the post on each day from the first to
+// the last given day, inclusive, oldest first. Days without views are left
+// out. An empty post ID sums the views of all posts.
+func (store *storeImplementation) PostViewsByDay(ctx context.Context, postID string, from time.Time, to time.Time) ([]PostViewsDay, error) {
+	if ctx == nil {
+		return []PostViewsDay{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.viewsEnabled {
+		return []PostViewsDay{}, errors.New("views are not enabled")
+	}
+
+	where := COLUMN_DAY + " >= ? AND " + COLUMN_DAY + " <= ?"
+	args := []any{from.Format(time.DateOnly), to.Format(time.DateOnly)}
+	if postID != "" {
+		where += " AND " + COLUMN_POST_ID + " = ?"
+		args = append(args, postID)
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return []PostViewsDay{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostViewsByDay", "SELECT "+COLUMN_DAY+", SUM("+COLUMN_VIEWS+") FROM "+store.viewsTableName+
+		" WHERE "+where+" GROUP BY "+COLUMN_DAY+" ORDER BY "+COLUMN_DAY+" ASC", args...)
+	if err != nil {
+		return []PostViewsDay{}, err
+	}
+	defer rows.Close()
+
+	list := []PostViewsDay{}
+	for rows.Next() {
+		var entry PostViewsDay
+		if err := rows.Scan(&entry.Day, &entry.Views); err != nil {
+			return []PostViewsDay{}, err
+		}
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// PostViewsTop returns the posts with the most views from the first to the
+// last given day, inclusive, most viewed first. A limit of zero returns all.
+func (store *storeImplementation) PostViewsTop(ctx context.Context, from time.Time, to time.Time, limit int) ([]PostViewsTotal, error) {
+	if ctx == nil {
+		return []PostViewsTotal{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.viewsEnabled {
+		return []PostViewsTotal{}, errors.New("views are not enabled")
+	}
+
+	query := "SELECT " + COLUMN_POST_ID + ", SUM(" + COLUMN_VIEWS + ") AS total FROM " + store.viewsTableName +
+		" WHERE " + COLUMN_DAY + " >= ? AND " + COLUMN_DAY + " <= ?" +
+		" GROUP BY " + COLUMN_POST_ID + " ORDER BY total DESC, " + COLUMN_POST_ID + " ASC"
+	if limit > 0 {
+		query += " LIMIT " + strconv.Itoa(limit)
+	}
+
+	db, err := store.db.DB()
+	if err != nil {
+		return []PostViewsTotal{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostViewsTop", query, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return []PostViewsTotal{}, err
+	}
+	defer rows.Close()
+
+	list := []PostViewsTotal{}
+	for rows.Next() {
+		var entry PostViewsTotal
+		if err := rows.Scan(&entry.PostID, &entry.Views); err != nil {
+			return []PostViewsTotal{}, err
+		}
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// PostListPopular returns the published posts with the most views since the
+// calendar day of the given time, most viewed first. The ranking, status and
+// soft delete filters run in a single aggregate query; the ranked posts are
+// then loaded in one batch.
+func (store *storeImplementation) PostListPopular(ctx context.Context, since time.Time, limit int) ([]PostInterface, error) {
+	if ctx == nil {
+		return []PostInterface{}, errors.New("ctx is nil")
+	}
+
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	if !store.viewsEnabled {
+		return []PostInterface{}, errors.New("views are not enabled")
+	}
+	if limit <= 0 {
+		return []PostInterface{}, errors.New("limit must be positive")
+	}
+
+	if err := store.authorize(ctx, ACTION_LIST, nil); err != nil {
+		return []PostInterface{}, err
+	}
+
+	query := "SELECT p." + COLUMN_ID + " FROM " + store.postTableName + " p" +
+		" INNER JOIN " + store.viewsTableName + " v ON v." + COLUMN_POST_ID + " = p." + COLUMN_ID +
+		" WHERE v." + COLUMN_DAY + " >= ? AND p." + COLUMN_STATUS + " = ? AND p." + COLUMN_SOFT_DELETED_AT + " > ?" +
+		" GROUP BY p." + COLUMN_ID +
+		" ORDER BY SUM(v." + COLUMN_VIEWS + ") DESC, p." + COLUMN_ID + " ASC" +
+		" LIMIT " + strconv.Itoa(limit)
+
+	db, err := store.db.DB()
+	if err != nil {
+		return []PostInterface{}, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "PostListPopular", query, since.Format(time.DateOnly), POST_STATUS_PUBLISHED, store.now().StdTime())
+	if err != nil {
+		return []PostInterface{}, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return []PostInterface{}, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return []PostInterface{}, err
+	}
+	if len(ids) == 0 {
+		return []PostInterface{}, nil
+	}
+
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{IDIn: ids})
+	if err != nil {
+		return []PostInterface{}, err
+	}
+
+	byID := map[string]PostInterface{}
+	for _, post := range posts {
+		byID[post.GetID()] = post
+	}
+
+	list := make([]PostInterface, 0, len(ids))
+	for _, id := range ids {
+		if post, ok := byID[id]; ok {
+			list = append(list, post)
+		}
+	}
+
+	return list, nil
+}
+
+// viewsTableBlueprint defines the views table, holding one row per post and day.
+func viewsTableBlueprint(table contractsschema.Blueprint) {
+	table.String(COLUMN_POST_ID, 40)
+	table.String(COLUMN_DAY, 10)
+	table.Primary(COLUMN_POST_ID, COLUMN_DAY)
+	table.BigInteger(COLUMN_VIEWS).Default(0)
+	table.Index(COLUMN_DAY)
+}
@@ -0,0 +1,210 @@
+package blogstore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func initViewsStore(t *testing.T) StoreInterface {
+	t.Helper()
+
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		ViewsEnabled:       true,
+		ViewsTableName:     "blog_post_views",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return store
+}
+
+func TestNewStoreViewsRequireTableName(t *testing.T) {
+	_, err := NewStore(NewStoreOptions{
+		PostTableName: "blog_posts",
+		ViewsEnabled:  true,
+		DB:            initDB(),
+	})
+	if err == nil {
+		t.Fatal("expected error for missing ViewsTableName")
+	}
+}
+
+func TestStorePostRecordView(t *testing.T) {
+	store := initViewsStore(t)
+	ctx := context.Background()
+
+	day1 := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	day3 := day2.Add(24 * time.Hour)
+
+	views := []struct {
+		postID string
+		day    time.Time
+		count  int
+	}{
+		{"post-a", day1, 3},
+		{"post-a", day2, 1},
+		{"post-b", day2, 5},
+		{"post-b", day3, 2},
+		{"post-c", day3, 1},
+	}
+	for _, v := range views {
+		for range v.count {
+			if err := store.PostRecordView(ctx, v.postID, v.day); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+
+	byDay, err := store.PostViewsByDay(ctx, "post-a", day1, day3)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want := []PostViewsDay{{Day: "2026-05-01", Views: 3}, {Day: "2026-05-02", Views: 1}}
+	if !reflect.DeepEqual(byDay, want) {
+		t.Fatalf("PostViewsByDay() = %+v, want %+v", byDay, want)
+	}
+
+	allPosts, err := store.PostViewsByDay(ctx, "", day2, day3)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want = []PostViewsDay{{Day: "2026-05-02", Views: 6}, {Day: "2026-05-03", Views: 3}}
+	if !reflect.DeepEqual(allPosts, want) {
+		t.Fatalf("PostViewsByDay() of all posts = %+v, want %+v", allPosts, want)
+	}
+
+	top, err := store.PostViewsTop(ctx, day1, day3, 2)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	wantTop := []PostViewsTotal{{PostID: "post-b", Views: 7}, {PostID: "post-a", Views: 4}}
+	if !reflect.DeepEqual(top, wantTop) {
+		t.Fatalf("PostViewsTop() = %+v, want %+v", top, wantTop)
+	}
+
+	top, err = store.PostViewsTop(ctx, day3, day3, 0)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	wantTop = []PostViewsTotal{{PostID: "post-b", Views: 2}, {PostID: "post-c", Views: 1}}
+	if !reflect.DeepEqual(top, wantTop) {
+		t.Fatalf("PostViewsTop() on day 3 = %+v, want %+v", top, wantTop)
+	}
+}
+
+func TestStorePostListPopular(t *testing.T) {
+	store := initViewsStore(t)
+	ctx := context.Background()
+
+	published := map[string]PostInterface{}
+	for _, title := range []string{"Hot", "Warm", "Cold"} {
+		post := NewPost().SetTitle(title).SetStatus(POST_STATUS_PUBLISHED)
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		published[title] = post
+	}
+	draft := NewPost().SetTitle("Draft").SetStatus(POST_STATUS_DRAFT)
+	if err := store.PostCreate(ctx, draft); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	lastWeek := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	thisWeek := lastWeek.Add(7 * 24 * time.Hour)
+
+	record := func(post PostInterface, day time.Time, count int) {
+		for range count {
+			if err := store.PostRecordView(ctx, post.GetID(), day); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+	}
+	record(published["Hot"], thisWeek, 5)
+	record(published["Warm"], thisWeek, 2)
+	record(published["Cold"], lastWeek, 50)
+	record(draft, thisWeek, 100)
+
+	popular, err := store.PostListPopular(ctx, thisWeek, 10)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	titles := []string{}
+	for _, post := range popular {
+		titles = append(titles, post.GetTitle())
+	}
+	if !reflect.DeepEqual(titles, []string{"Hot", "Warm"}) {
+		t.Fatalf("PostListPopular() = %v, want [Hot Warm]", titles)
+	}
+
+	popular, err = store.PostListPopular(ctx, lastWeek, 1)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(popular) != 1 || popular[0].GetTitle() != "Cold" {
+		t.Fatalf("PostListPopular() with limit 1 = %v, want Cold", popular)
+	}
+}
+
+func TestStorePostIncrementViewCount(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	popular := NewPost().SetTitle("Popular")
+	quiet := NewPost().SetTitle("Quiet")
+	for _, post := range []PostInterface{popular, quiet} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	for range 3 {
+		if err := store.PostIncrementViewCount(ctx, popular.GetID()); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	if err := store.PostIncrementViewCount(ctx, quiet.GetID()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := store.PostIncrementViewCount(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown post")
+	}
+
+	// saving a post does not overwrite the views counted meanwhile
+	if err := store.PostUpdate(ctx, popular.SetTitle("Popular, edited")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{OrderBy: COLUMN_VIEWS, SortOrder: SORT_ORDER_DESC})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 2 || list[0].GetID() != popular.GetID() {
+		t.Fatal("expected the most viewed post first")
+	}
+	if list[0].GetViewCount() != 3 || list[1].GetViewCount() != 1 {
+		t.Fatalf("view counts = %d and %d, want 3 and 1", list[0].GetViewCount(), list[1].GetViewCount())
+	}
+
+	list, err = store.PostList(ctx, PostQueryOptions{ViewsGreaterThan: 1})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 || list[0].GetID() != popular.GetID() {
+		t.Fatalf("got %d posts with more than one view, want only the popular post", len(list))
+	}
+}
@@ -0,0 +1,304 @@
+package blogstore
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/dracory/neat"
+)
+
+// Maintenance jobs run by StartWorkers, the keys of Workers.Metrics.
+const WORKER_JOB_PUBLISH = "publish"
+const WORKER_JOB_TRASH_PURGE = "trash_purge"
+const WORKER_JOB_VERSION_PRUNE = "version_prune"
+const WORKER_JOB_STATS_REFRESH = "stats_refresh"
+const WORKER_JOB_EXPIRE = "expire"
+
+// WORKER_ACTOR is the actor the maintenance jobs act as, so the audit log and
+// the authorizer can tell them apart from users.
+const WORKER_ACTOR = "blogstore:worker"
+
+// workerDefaultTrashRetention is used when WorkerOptions.TrashRetention is not set.
+const workerDefaultTrashRetention = 30 * 24 * time.Hour
+
+// workerDefaultVersionsToKeep is used when WorkerOptions.VersionsToKeep is not set.
+const workerDefaultVersionsToKeep = 50
+
+// WorkerOptions configures the maintenance jobs run by StartWorkers.
+// A job runs only when its interval is set.
+type WorkerOptions struct {
+	// PublishInterval is how often the posts in PublishStatus whose
+	// published_at has passed are published.
+	PublishInterval time.Duration
+	// PublishStatus is the status of the posts waiting for publication,
+	// e.g. a "scheduled" status registered with NewStoreOptions.PostStatuses.
+	// Required with PublishInterval.
+	PublishStatus string
+
+	// TrashPurgeInterval is how often the posts in the trash for longer than
+	// TrashRetention are deleted permanently.
+	TrashPurgeInterval time.Duration
+	// TrashRetention is how long posts stay in the trash, counted from their
+	// last update. Defaults to NewStoreOptions.TrashRetentionDays, or 30 days.
+	TrashRetention time.Duration
+
+	// VersionPruneInterval is how often the versions of each post or term
+	// beyond the newest VersionsToKeep are deleted, except labeled ones.
+	// Requires versioning.
+	VersionPruneInterval time.Duration
+	// VersionsToKeep is the number of versions kept per post or term.
+	// Defaults to 50.
+	VersionsToKeep int
+
+	// StatsRefreshInterval is how often the numbers returned by Stats are recomputed.
+	StatsRefreshInterval time.Duration
+
+	// ExpireInterval is how often the published posts whose expires_at has
+	// passed are unpublished.
+	ExpireInterval time.Duration
+}
+
+// WorkerMetrics describes the runs of a maintenance job.
+type WorkerMetrics struct {
+	// Runs is the number of completed runs, failed or not.
+	Runs int64
+	// Failures is the number of runs that returned an error.
+	Failures int64
+	// Processed is the number of posts or versions handled over all runs.
+	Processed int64
+	// LastRunAt is when the last run started.
+	LastRunAt time.Time
+	// LastDuration is how long the last run took.
+	LastDuration time.Duration
+	// LastError is the error of the last run, empty if it succeeded.
+	LastError string
+}
+
+// Workers runs the maintenance jobs started by StartWorkers.
+type Workers struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	metrics map[string]WorkerMetrics
+}
+
+// workerJob is a maintenance job returning the number of items it handled.
+type workerJob func(ctx context.Context) (int, error)
+
+// StartWorkers starts the maintenance jobs configured in the options, each
+// in its own goroutine, running first after one interval. They run until
+// the context is done or Stop is called. Job errors are logged and counted
+// in the metrics, they do not stop the workers.
+func (store *storeImplementation) StartWorkers(ctx context.Context, options WorkerOptions) (*Workers, error) {
+	if ctx == nil {
+		return nil, errors.New("ctx is nil")
+	}
+
+	if options.PublishInterval > 0 && options.PublishStatus == "" {
+		return nil, errors.New("blogstore: PublishStatus is required")
+	}
+	if options.VersionPruneInterval > 0 && !store.versioningEnabled {
+		return nil, errors.New("blogstore: version pruning requires versioning")
+	}
+	if options.TrashRetention <= 0 {
+		options.TrashRetention = store.trashRetention
+	}
+	if options.TrashRetention <= 0 {
+		options.TrashRetention = workerDefaultTrashRetention
+	}
+	if options.VersionsToKeep <= 0 {
+		options.VersionsToKeep = workerDefaultVersionsToKeep
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	workers := &Workers{cancel: cancel, metrics: map[string]WorkerMetrics{}}
+
+	workers.start(ctx, store, WORKER_JOB_PUBLISH, options.PublishInterval, func(ctx context.Context) (int, error) {
+		return store.workerPublishDue(ctx, options.PublishStatus)
+	})
+	workers.start(ctx, store, WORKER_JOB_TRASH_PURGE, options.TrashPurgeInterval, func(ctx context.Context) (int, error) {
+		return store.postTrashPurgeOlderThan(ctx, options.TrashRetention)
+	})
+	workers.start(ctx, store, WORKER_JOB_VERSION_PRUNE, options.VersionPruneInterval, func(ctx context.Context) (int, error) {
+		return store.workerPruneVersions(ctx, options.VersionsToKeep)
+	})
+	workers.start(ctx, store, WORKER_JOB_STATS_REFRESH, options.StatsRefreshInterval, func(ctx context.Context) (int, error) {
+		_, err := store.StatsRefresh(ctx)
+		return 0, err
+	})
+	workers.start(ctx, store, WORKER_JOB_EXPIRE, options.ExpireInterval, store.PostExpireDue)
+
+	return workers, nil
+}
+
+// Stop stops the workers, waiting for the jobs running at the time to finish.
+// It is safe to call more than once.
+func (w *Workers) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+// Metrics returns the metrics of the started jobs, by WORKER_JOB_* name.
+func (w *Workers) Metrics() map[string]WorkerMetrics {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return maps.Clone(w.metrics)
+}
+
+// start runs the job every interval until the context is done.
+// A job without an interval is not started.
+func (w *Workers) start(ctx context.Context, store *storeImplementation, name string, interval time.Duration, job workerJob) {
+	if interval <= 0 {
+		return
+	}
+
+	w.mu.Lock()
+	w.metrics[name] = WorkerMetrics{}
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.run(ctx, store, name, job)
+			}
+		}
+	}()
+}
+
+// run runs the job once and records its metrics. A run started before the
+// workers are stopped is allowed to finish.
+func (w *Workers) run(ctx context.Context, store *storeImplementation, name string, job workerJob) {
+	ctx = ContextWithActor(context.WithoutCancel(ctx), WORKER_ACTOR)
+	ctx, cancel := store.contextWithTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	processed, err := job(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		store.getLogger().ErrorContext(ctx, "blogstore: worker job failed", "job", name, "error", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	metrics := w.metrics[name]
+	metrics.Runs++
+	metrics.Processed += int64(processed)
+	metrics.LastRunAt = start
+	metrics.LastDuration = duration
+	metrics.LastError = ""
+	if err != nil {
+		metrics.Failures++
+		metrics.LastError = err.Error()
+	}
+	w.metrics[name] = metrics
+}
+
+// workerPublishBatchSize caps the posts published by one run of the publish
+// job. The posts left over are published by the next runs.
+const workerPublishBatchSize = 100
+
+// workerPublishDue publishes the posts in the given status whose
+// published_at has passed, oldest first and at most workerPublishBatchSize.
+// Posts without a publication date are never published.
+func (store *storeImplementation) workerPublishDue(ctx context.Context, status string) (int, error) {
+	posts, err := store.postListFromTable(ctx, store.postTableName, PostQueryOptions{
+		Status:                 status,
+		PublishedAtGreaterThan: neat.NullDateTime,
+		PublishedBeforeNow:     true,
+		WithoutContent:         true,
+		OrderBy:                COLUMN_PUBLISHED_AT,
+		SortOrder:              SORT_ORDER_ASC,
+		Limit:                  workerPublishBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, post := range posts {
+		if err := store.PostLoadContent(ctx, post); err != nil {
+			return published, err
+		}
+
+		post.SetStatus(POST_STATUS_PUBLISHED)
+		if err := store.PostUpdate(ctx, post); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// workerPruneVersions deletes the versions of each entity, e.g. a post or a
+// term, beyond the newest keep. Labeled versions are bookmarks and are kept.
+func (store *storeImplementation) workerPruneVersions(ctx context.Context, keep int) (int, error) {
+	db, err := store.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := store.queryContext(ctx, db, "workerPruneVersions", "SELECT "+COLUMN_ENTITY_TYPE+", "+COLUMN_ENTITY_ID+" FROM "+store.versioningTableName+
+		" GROUP BY "+COLUMN_ENTITY_TYPE+", "+COLUMN_ENTITY_ID+" HAVING COUNT(*) > ?", keep)
+	if err != nil {
+		return 0, err
+	}
+
+	type entity struct{ entityType, entityID string }
+	entities := []entity{}
+	for rows.Next() {
+		var e entity
+		if err := rows.Scan(&e.entityType, &e.entityID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		entities = append(entities, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, e := range entities {
+		versions, err := store.VersioningList(ctx, NewVersioningQuery().
+			SetEntityType(e.entityType).
+			SetEntityID(e.entityID).
+			SetColumns([]string{COLUMN_ID, COLUMN_LABEL, COLUMN_CREATED_AT}).
+			SetOrderBy(COLUMN_CREATED_AT).
+			SetSortOrder(SORT_ORDER_DESC).
+			SetSoftDeletedIncluded(true))
+		if err != nil {
+			return pruned, err
+		}
+
+		for _, version := range versions[min(keep, len(versions)):] {
+			if version.Label() != "" {
+				continue
+			}
+			if err := store.VersioningDeleteByID(ctx, version.ID()); err != nil {
+				return pruned, err
+			}
+			pruned++
+		}
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,212 @@
+package blogstore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// waitForRuns polls the metrics until the job completed a run.
+func waitForRuns(t *testing.T, workers *Workers, job string) WorkerMetrics {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if metrics := workers.Metrics()[job]; metrics.Runs > 0 {
+			return metrics
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("job %s did not run", job)
+	return WorkerMetrics{}
+}
+
+func TestStoreStartWorkersValidatesOptions(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := store.StartWorkers(context.Background(), WorkerOptions{PublishInterval: time.Second}); err == nil {
+		t.Fatal("expected error when PublishStatus is missing")
+	}
+	if _, err := store.StartWorkers(context.Background(), WorkerOptions{VersionPruneInterval: time.Second}); err == nil {
+		t.Fatal("expected error when pruning versions without versioning")
+	}
+}
+
+func TestStoreWorkersPublishAndPurge(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		PostStatuses:       []string{"scheduled"},
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	due := NewPost().SetTitle("Due").SetStatus("scheduled").SetPublishedAt("2026-01-10 11:00:00")
+	later := NewPost().SetTitle("Later").SetStatus("scheduled").SetPublishedAt("2026-01-11 11:00:00")
+	oldTrash := NewPost().SetTitle("Old trash").SetStatus(POST_STATUS_TRASH)
+	for _, post := range []PostInterface{due, later, oldTrash} {
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	now = now.Add(40 * 24 * time.Hour)
+	recentTrash := NewPost().SetTitle("Recent trash").SetStatus(POST_STATUS_TRASH)
+	if err := store.PostCreate(ctx, recentTrash); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	workers, err := store.StartWorkers(ctx, WorkerOptions{
+		PublishInterval:    10 * time.Millisecond,
+		PublishStatus:      "scheduled",
+		TrashPurgeInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	publish := waitForRuns(t, workers, WORKER_JOB_PUBLISH)
+	purge := waitForRuns(t, workers, WORKER_JOB_TRASH_PURGE)
+	workers.Stop()
+
+	if publish.Failures != 0 || purge.Failures != 0 {
+		t.Fatalf("unexpected failures: %+v, %+v", publish, purge)
+	}
+	if publish.Processed != 2 {
+		t.Fatalf("published %d posts, want both past due ones", publish.Processed)
+	}
+	if purge.Processed != 1 {
+		t.Fatalf("purged %d posts, want the old trash only", purge.Processed)
+	}
+
+	if stored, _ := store.PostFindByID(ctx, due.GetID()); stored.GetStatus() != POST_STATUS_PUBLISHED {
+		t.Fatalf("status = %q, want the due post published", stored.GetStatus())
+	}
+	if stored, _ := store.PostFindByID(ctx, oldTrash.GetID()); stored != nil {
+		t.Fatal("expected the old trash to be purged")
+	}
+	if stored, _ := store.PostFindByID(ctx, recentTrash.GetID()); stored == nil {
+		t.Fatal("expected the recent trash to be kept")
+	}
+
+	if _, ok := workers.Metrics()[WORKER_JOB_VERSION_PRUNE]; ok {
+		t.Fatal("expected jobs without an interval not to be started")
+	}
+}
+
+func TestStoreWorkersPruneVersions(t *testing.T) {
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:       "blog_posts",
+		VersioningEnabled:   true,
+		VersioningTableName: "blog_versions",
+		DB:                  initDB(),
+		AutomigrateEnabled:  true,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	post := NewPost().SetTitle("Edit 0")
+	if err := store.PostCreate(ctx, post); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, title := range []string{"Edit 1", "Edit 2", "Edit 3", "Edit 4"} {
+		post.SetTitle(title)
+		if err := store.PostUpdate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	workers, err := store.StartWorkers(ctx, WorkerOptions{
+		VersionPruneInterval: 10 * time.Millisecond,
+		VersionsToKeep:       2,
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	metrics := waitForRuns(t, workers, WORKER_JOB_VERSION_PRUNE)
+	workers.Stop()
+	workers.Stop()
+
+	if metrics.Processed != 3 || metrics.LastError != "" {
+		t.Fatalf("metrics = %+v, want 3 versions pruned", metrics)
+	}
+
+	versions, err := store.VersioningList(ctx, NewVersioningQuery().SetEntityType(VERSIONING_TYPE_POST).SetEntityID(post.GetID()))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d versions, want 2 kept", len(versions))
+	}
+}
+
+func TestStoreWorkerPublishDueBatches(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	store, err := NewStore(NewStoreOptions{
+		PostTableName:      "blog_posts",
+		DB:                 initDB(),
+		AutomigrateEnabled: true,
+		PostStatuses:       []string{"scheduled"},
+		Now:                func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < workerPublishBatchSize+1; i++ {
+		post := NewPost().SetTitle("Due").SetStatus("scheduled").SetContent("Kept content").SetPublishedAt("2026-01-10 11:00:00")
+		if err := store.PostCreate(ctx, post); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	undated := NewPost().SetTitle("Undated").SetStatus("scheduled").SetPublishedAt(NULL_DATETIME)
+	if err := store.PostCreate(ctx, undated); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	impl := store.(*storeImplementation)
+
+	published, err := impl.workerPublishDue(ctx, "scheduled")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if published != workerPublishBatchSize {
+		t.Fatalf("published %d posts, want a batch of %d", published, workerPublishBatchSize)
+	}
+
+	published, err = impl.workerPublishDue(ctx, "scheduled")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if published != 1 {
+		t.Fatalf("published %d posts on the next run, want the one left over", published)
+	}
+
+	list, err := store.PostList(ctx, PostQueryOptions{Status: POST_STATUS_PUBLISHED, Limit: 1})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(list) != 1 || list[0].GetContent() != "Kept content" {
+		t.Fatal("expected the published posts to keep their content, got:", list)
+	}
+
+	if stored, _ := store.PostFindByID(ctx, undated.GetID()); stored.GetStatus() != "scheduled" {
+		t.Fatalf("status = %q, want the undated post left scheduled", stored.GetStatus())
+	}
+}
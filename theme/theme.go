@@ -0,0 +1,151 @@
+// Package theme defines how the HTML renderers of blogstore, such as the
+// admin UI, are styled: a Theme provides the templates, the static assets and
+// the template functions. Applications restyle a renderer by passing their own
+// theme, or by overriding a few files of the default one with Overlay.
+package theme
+
+import (
+	"errors"
+	"html/template"
+	"io/fs"
+	"maps"
+)
+
+// Theme provides the files and functions a renderer draws its pages with.
+type Theme interface {
+	// Templates returns the html/template files, at the root of the FS.
+	Templates() fs.FS
+	// Assets returns the static files (stylesheets, scripts, images) served
+	// by the renderer, at the root of the FS.
+	Assets() fs.FS
+	// Funcs returns the functions available to the templates.
+	Funcs() template.FuncMap
+}
+
+// New creates a theme from the given files and functions.
+// A nil FS has no files, a nil FuncMap no functions.
+func New(templates fs.FS, assets fs.FS, funcs template.FuncMap) Theme {
+	return &staticTheme{templates: templates, assets: assets, funcs: funcs}
+}
+
+// Overlay creates a theme using the files and functions of the override
+// first, and those of the base for everything the override does not provide.
+// This allows replacing a single template or stylesheet of a theme.
+func Overlay(base Theme, templates fs.FS, assets fs.FS, funcs template.FuncMap) Theme {
+	merged := template.FuncMap{}
+	maps.Copy(merged, base.Funcs())
+	maps.Copy(merged, funcs)
+
+	return &staticTheme{
+		templates: overlayFS{top: templates, bottom: base.Templates()},
+		assets:    overlayFS{top: assets, bottom: base.Assets()},
+		funcs:     merged,
+	}
+}
+
+// Parse parses all templates of the theme (files ending in .html), with the
+// theme functions available, into a template set addressed by file name.
+func Parse(t Theme) (*template.Template, error) {
+	templates := template.New("").Funcs(t.Funcs())
+
+	names, err := fs.Glob(t.Templates(), "*.html")
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("theme: no templates found")
+	}
+
+	for _, name := range names {
+		content, err := fs.ReadFile(t.Templates(), name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := templates.New(name).Parse(string(content)); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+type staticTheme struct {
+	templates fs.FS
+	assets    fs.FS
+	funcs     template.FuncMap
+}
+
+func (t *staticTheme) Templates() fs.FS {
+	if t.templates == nil {
+		return emptyFS{}
+	}
+	return t.templates
+}
+
+func (t *staticTheme) Assets() fs.FS {
+	if t.assets == nil {
+		return emptyFS{}
+	}
+	return t.assets
+}
+
+func (t *staticTheme) Funcs() template.FuncMap {
+	if t.funcs == nil {
+		return template.FuncMap{}
+	}
+	return t.funcs
+}
+
+// overlayFS opens files from top, falling back to bottom.
+// Globbing lists the files of both.
+type overlayFS struct {
+	top    fs.FS
+	bottom fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if o.top != nil {
+		file, err := o.top.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return o.bottom.Open(name)
+}
+
+func (o overlayFS) Glob(pattern string) ([]string, error) {
+	names, err := fs.Glob(o.bottom, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if o.top == nil {
+		return names, nil
+	}
+
+	topNames, err := fs.Glob(o.top, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		seen[name] = true
+	}
+	for _, name := range topNames {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// emptyFS has no files.
+type emptyFS struct{}
+
+func (emptyFS) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
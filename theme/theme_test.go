@@ -0,0 +1,54 @@
+package theme_test
+
+import (
+	"html/template"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dracory/blogstore/theme"
+)
+
+func TestOverlay(t *testing.T) {
+	base := theme.New(fstest.MapFS{
+		"page.html":   {Data: []byte(`{{define "page.html"}}{{template "title" .}}: {{shout .}}{{end}}`)},
+		"blocks.html": {Data: []byte(`{{define "title"}}Base{{end}}`)},
+	}, fstest.MapFS{
+		"site.css": {Data: []byte("body{}")},
+		"logo.svg": {Data: []byte("<svg/>")},
+	}, template.FuncMap{"shout": strings.ToUpper})
+
+	overlay := theme.Overlay(base, fstest.MapFS{
+		"blocks.html": {Data: []byte(`{{define "title"}}Custom{{end}}`)},
+	}, fstest.MapFS{
+		"site.css": {Data: []byte("body{color:red}")},
+	}, nil)
+
+	templates, err := theme.Parse(overlay)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	var out strings.Builder
+	if err := templates.ExecuteTemplate(&out, "page.html", "hi"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if out.String() != "Custom: HI" {
+		t.Fatalf("rendered %q, want %q", out.String(), "Custom: HI")
+	}
+
+	css, err := fs.ReadFile(overlay.Assets(), "site.css")
+	if err != nil || string(css) != "body{color:red}" {
+		t.Fatalf("site.css = %q, %v, want the overriding file", css, err)
+	}
+	if _, err := fs.ReadFile(overlay.Assets(), "logo.svg"); err != nil {
+		t.Fatal("expected base assets to remain available:", err)
+	}
+}
+
+func TestParseRequiresTemplates(t *testing.T) {
+	if _, err := theme.Parse(theme.New(nil, nil, nil)); err == nil {
+		t.Fatal("expected error for a theme without templates")
+	}
+}
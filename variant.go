@@ -0,0 +1,61 @@
+package blogstore
+
+import "time"
+
+// Variant is an alternative title and summary of a post, shown to a share
+// of the readers in proportion to its weight so headlines can be compared
+// by their click-through rate.
+type Variant struct {
+	ID          string
+	PostID      string
+	Title       string
+	Summary     string
+	Weight      int
+	Impressions int64
+	Clicks      int64
+	CreatedAt   time.Time
+}
+
+// ClickThroughRate returns the clicks per impression of the variant, or zero
+// before its first impression.
+func (variant Variant) ClickThroughRate() float64 {
+	if variant.Impressions == 0 {
+		return 0
+	}
+	return float64(variant.Clicks) / float64(variant.Impressions)
+}
+
+// VariantPick picks a variant in proportion to the weights, using a roll in
+// [0, 1) such as rand.Float64(). Variants with a weight of zero or less are
+// never picked. Returns nil if no variant can be picked.
+func VariantPick(variants []Variant, roll float64) *Variant {
+	total := 0
+	for _, variant := range variants {
+		if variant.Weight > 0 {
+			total += variant.Weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	target := roll * float64(total)
+	cumulative := 0
+	for i := range variants {
+		if variants[i].Weight <= 0 {
+			continue
+		}
+		cumulative += variants[i].Weight
+		if target < float64(cumulative) {
+			return &variants[i]
+		}
+	}
+
+	// A roll of 1 or more falls on the last weighted variant
+	for i := len(variants) - 1; i >= 0; i-- {
+		if variants[i].Weight > 0 {
+			return &variants[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package blogstore
+
+import "testing"
+
+func TestVariantPick(t *testing.T) {
+	variants := []Variant{
+		{ID: "a", Weight: 1},
+		{ID: "off", Weight: 0},
+		{ID: "b", Weight: 3},
+	}
+
+	cases := []struct {
+		roll float64
+		want string
+	}{
+		{0, "a"},
+		{0.24, "a"},
+		{0.25, "b"},
+		{0.99, "b"},
+		{1, "b"},
+	}
+	for _, c := range cases {
+		picked := VariantPick(variants, c.roll)
+		if picked == nil || picked.ID != c.want {
+			t.Fatalf("VariantPick(%v) = %+v, want %s", c.roll, picked, c.want)
+		}
+	}
+
+	if picked := VariantPick([]Variant{{ID: "off", Weight: 0}}, 0.5); picked != nil {
+		t.Fatalf("VariantPick() without weights = %+v, want nil", picked)
+	}
+}
+
+func TestVariantClickThroughRate(t *testing.T) {
+	if rate := (Variant{}).ClickThroughRate(); rate != 0 {
+		t.Fatalf("ClickThroughRate() without impressions = %v, want 0", rate)
+	}
+	if rate := (Variant{Impressions: 8, Clicks: 2}).ClickThroughRate(); rate != 0.25 {
+		t.Fatalf("ClickThroughRate() = %v, want 0.25", rate)
+	}
+}
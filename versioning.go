@@ -24,6 +24,21 @@ type VersioningInterface interface {
 	Content() string
 	SetContent(content string) VersioningInterface
 
+	// ChangedBy returns the ID of the actor who made the change, set with
+	// ContextWithActor.
+	ChangedBy() string
+	SetChangedBy(changedBy string) VersioningInterface
+
+	// ChangeNote returns the note on why the change was made, set with
+	// ContextWithChangeNote.
+	ChangeNote() string
+	SetChangeNote(changeNote string) VersioningInterface
+
+	// Label returns the label bookmarking the version, set with
+	// VersioningLabel, e.g. "v1.0 launch copy".
+	Label() string
+	SetLabel(label string) VersioningInterface
+
 	GetCreatedAt() string
 	GetCreatedAtCarbon() *carbon.Carbon
 	SetCreatedAt(createdAt string) VersioningInterface
@@ -56,6 +71,10 @@ type VersioningQueryInterface interface {
 	EntityType() string
 	SetEntityType(entityType string) VersioningQueryInterface
 
+	HasLabel() bool
+	Label() string
+	SetLabel(label string) VersioningQueryInterface
+
 	HasOffset() bool
 	Offset() int64
 	SetOffset(offset int64) VersioningQueryInterface
@@ -98,6 +117,9 @@ func NewVersioningFromExistingData(data map[string]string) VersioningInterface {
 	o.SetEntityType(data[COLUMN_ENTITY_TYPE])
 	o.SetEntityID(data[COLUMN_ENTITY_ID])
 	o.SetContent(data[COLUMN_CONTENT])
+	o.SetChangedBy(data[COLUMN_CHANGED_BY])
+	o.SetChangeNote(data[COLUMN_CHANGE_NOTE])
+	o.SetLabel(data[COLUMN_LABEL])
 	if v, ok := data[COLUMN_CREATED_AT]; ok {
 		o.SetCreatedAt(v)
 	}
@@ -115,6 +137,9 @@ type versioningImplementation struct {
 	EntityTypeField string    `db:"entity_type"`
 	EntityIDField   string    `db:"entity_id"`
 	ContentField    string    `db:"content"`
+	ChangedByField  string    `db:"changed_by"`
+	ChangeNoteField string    `db:"change_note"`
+	LabelField      string    `db:"label"`
 	CreatedAt       time.Time `db:"created_at"`
 }
 
@@ -169,6 +194,39 @@ func (o *versioningImplementation) SetContent(content string) VersioningInterfac
 	return o
 }
 
+// ChangedBy returns the ID of the actor who made the change.
+func (o *versioningImplementation) ChangedBy() string {
+	return o.ChangedByField
+}
+
+// SetChangedBy sets the ID of the actor who made the change.
+func (o *versioningImplementation) SetChangedBy(changedBy string) VersioningInterface {
+	o.ChangedByField = changedBy
+	return o
+}
+
+// ChangeNote returns the note on why the change was made.
+func (o *versioningImplementation) ChangeNote() string {
+	return o.ChangeNoteField
+}
+
+// SetChangeNote sets the note on why the change was made.
+func (o *versioningImplementation) SetChangeNote(changeNote string) VersioningInterface {
+	o.ChangeNoteField = changeNote
+	return o
+}
+
+// Label returns the label bookmarking the version.
+func (o *versioningImplementation) Label() string {
+	return o.LabelField
+}
+
+// SetLabel sets the label bookmarking the version.
+func (o *versioningImplementation) SetLabel(label string) VersioningInterface {
+	o.LabelField = label
+	return o
+}
+
 // GetCreatedAt returns the created at time of the version.
 func (o *versioningImplementation) GetCreatedAt() string {
 	if o.CreatedAt.IsZero() {
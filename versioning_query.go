@@ -31,6 +31,10 @@ func (q *versioningQueryImplementation) Validate() error {
 		return errors.New("version query. id cannot be empty")
 	}
 
+	if q.HasLabel() && q.Label() == "" {
+		return errors.New("version query. label cannot be empty")
+	}
+
 	if q.HasLimit() && q.Limit() < 0 {
 		return errors.New("version query. limit cannot be negative")
 	}
@@ -141,6 +145,26 @@ func (q *versioningQueryImplementation) SetID(id string) VersioningQueryInterfac
 	return q
 }
 
+// HasLabel returns true if label is set.
+func (q *versioningQueryImplementation) HasLabel() bool {
+	return q.hasProperty("label")
+}
+
+// Label returns the label.
+func (q *versioningQueryImplementation) Label() string {
+	if !q.hasProperty("label") {
+		return ""
+	}
+
+	return q.properties["label"].(string)
+}
+
+// SetLabel sets the label.
+func (q *versioningQueryImplementation) SetLabel(label string) VersioningQueryInterface {
+	q.properties["label"] = label
+	return q
+}
+
 // HasLimit returns true if limit is set.
 func (q *versioningQueryImplementation) HasLimit() bool {
 	return q.hasProperty("limit")
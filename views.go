@@ -0,0 +1,13 @@
+package blogstore
+
+// PostViewsDay is the number of views on a day, formatted as "2006-01-02".
+type PostViewsDay struct {
+	Day   string
+	Views int64
+}
+
+// PostViewsTotal is the number of views of a post over a range of days.
+type PostViewsTotal struct {
+	PostID string
+	Views  int64
+}